@@ -2,12 +2,50 @@ import '@wangeditor/editor/dist/css/style.css' // 引入 css
 import React, { useState, useEffect } from 'react'
 import { Editor, Toolbar } from '@wangeditor/editor-for-react'
 import { IDomEditor, IEditorConfig, IToolbarConfig } from '@wangeditor/editor'
+import axios, { Result } from '@/axios/axios'
 
 interface Props {
     html: any,
     setHtmlFn: any
 }
 
+interface UploadVO {
+    attachment: { id: number, url: string },
+    upload_url: string,
+}
+
+type InsertFn = (url: string, alt: string, href: string) => void
+
+// 先申请上传地址，再直接上传到对象存储，最后确认上传完成
+async function uploadImage(file: File, insertFn: InsertFn) {
+    const res = await axios.post<Result<UploadVO>>("/attachments/upload", {
+        filename: file.name,
+        content_type: file.type,
+        size: file.size,
+    })
+    if (res.data?.code != 0) {
+        alert(res.data?.msg || "申请上传失败")
+        return
+    }
+    const {attachment, upload_url} = res.data.data
+    // 预签名的地址不能带上我们自己的 Authorization 头部
+    const put = await fetch(upload_url, {
+        method: "PUT",
+        headers: {"Content-Type": file.type},
+        body: file,
+    })
+    if (!put.ok) {
+        alert("上传失败")
+        return
+    }
+    const done = await axios.post<Result<any>>("/attachments/complete", {id: attachment.id})
+    if (done.data?.code != 0) {
+        alert(done.data?.msg || "上传失败")
+        return
+    }
+    insertFn(attachment.url, file.name, attachment.url)
+}
+
 function WangEditor(props: Props) {
     // editor 实例
     const [editor, setEditor] = useState<IDomEditor | null>(null)   // TS 语法
@@ -32,6 +70,11 @@ function WangEditor(props: Props) {
     const editorConfig: Partial<IEditorConfig> = {    // TS 语法
         // const editorConfig = {                         // JS 语法
         placeholder: '请输入内容...',
+        MENU_CONF: {
+            uploadImage: {
+                customUpload: uploadImage,
+            },
+        },
     }
 
     // 及时销毁 editor ，重要！
@@ -19,13 +19,47 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ArticleService_Save_FullMethodName       = "/art.v1.ArticleService/Save"
-	ArticleService_WithDraw_FullMethodName   = "/art.v1.ArticleService/WithDraw"
-	ArticleService_Publish_FullMethodName    = "/art.v1.ArticleService/Publish"
-	ArticleService_List_FullMethodName       = "/art.v1.ArticleService/List"
-	ArticleService_ListPub_FullMethodName    = "/art.v1.ArticleService/ListPub"
-	ArticleService_GetById_FullMethodName    = "/art.v1.ArticleService/GetById"
-	ArticleService_GetPubById_FullMethodName = "/art.v1.ArticleService/GetPubById"
+	ArticleService_Save_FullMethodName                  = "/art.v1.ArticleService/Save"
+	ArticleService_WithDraw_FullMethodName              = "/art.v1.ArticleService/WithDraw"
+	ArticleService_Publish_FullMethodName               = "/art.v1.ArticleService/Publish"
+	ArticleService_List_FullMethodName                  = "/art.v1.ArticleService/List"
+	ArticleService_ListPub_FullMethodName               = "/art.v1.ArticleService/ListPub"
+	ArticleService_ListPubByTag_FullMethodName          = "/art.v1.ArticleService/ListPubByTag"
+	ArticleService_ListPubByAuthor_FullMethodName       = "/art.v1.ArticleService/ListPubByAuthor"
+	ArticleService_GetById_FullMethodName               = "/art.v1.ArticleService/GetById"
+	ArticleService_GetPubById_FullMethodName            = "/art.v1.ArticleService/GetPubById"
+	ArticleService_ListRevisions_FullMethodName         = "/art.v1.ArticleService/ListRevisions"
+	ArticleService_GetRevision_FullMethodName           = "/art.v1.ArticleService/GetRevision"
+	ArticleService_DiffRevisions_FullMethodName         = "/art.v1.ArticleService/DiffRevisions"
+	ArticleService_RestoreRevision_FullMethodName       = "/art.v1.ArticleService/RestoreRevision"
+	ArticleService_SchedulePublish_FullMethodName       = "/art.v1.ArticleService/SchedulePublish"
+	ArticleService_CancelSchedule_FullMethodName        = "/art.v1.ArticleService/CancelSchedule"
+	ArticleService_Delete_FullMethodName                = "/art.v1.ArticleService/Delete"
+	ArticleService_ListTrash_FullMethodName             = "/art.v1.ArticleService/ListTrash"
+	ArticleService_Restore_FullMethodName               = "/art.v1.ArticleService/Restore"
+	ArticleService_Purge_FullMethodName                 = "/art.v1.ArticleService/Purge"
+	ArticleService_PurgeExpired_FullMethodName          = "/art.v1.ArticleService/PurgeExpired"
+	ArticleService_CreateSeries_FullMethodName          = "/art.v1.ArticleService/CreateSeries"
+	ArticleService_ReorderSeries_FullMethodName         = "/art.v1.ArticleService/ReorderSeries"
+	ArticleService_ListSeries_FullMethodName            = "/art.v1.ArticleService/ListSeries"
+	ArticleService_GetSeriesNav_FullMethodName          = "/art.v1.ArticleService/GetSeriesNav"
+	ArticleService_CreateUpload_FullMethodName          = "/art.v1.ArticleService/CreateUpload"
+	ArticleService_CompleteUpload_FullMethodName        = "/art.v1.ArticleService/CompleteUpload"
+	ArticleService_ListAttachments_FullMethodName       = "/art.v1.ArticleService/ListAttachments"
+	ArticleService_DeleteAttachment_FullMethodName      = "/art.v1.ArticleService/DeleteAttachment"
+	ArticleService_GCAttachments_FullMethodName         = "/art.v1.ArticleService/GCAttachments"
+	ArticleService_ListPendingReview_FullMethodName     = "/art.v1.ArticleService/ListPendingReview"
+	ArticleService_Approve_FullMethodName               = "/art.v1.ArticleService/Approve"
+	ArticleService_Reject_FullMethodName                = "/art.v1.ArticleService/Reject"
+	ArticleService_ListModerationLogs_FullMethodName    = "/art.v1.ArticleService/ListModerationLogs"
+	ArticleService_FindSimilar_FullMethodName           = "/art.v1.ArticleService/FindSimilar"
+	ArticleService_ListFlaggedDuplicates_FullMethodName = "/art.v1.ArticleService/ListFlaggedDuplicates"
+	ArticleService_StartExport_FullMethodName           = "/art.v1.ArticleService/StartExport"
+	ArticleService_GetExport_FullMethodName             = "/art.v1.ArticleService/GetExport"
+	ArticleService_ListExports_FullMethodName           = "/art.v1.ArticleService/ListExports"
+	ArticleService_StartImport_FullMethodName           = "/art.v1.ArticleService/StartImport"
+	ArticleService_GetImport_FullMethodName             = "/art.v1.ArticleService/GetImport"
+	ArticleService_ListImports_FullMethodName           = "/art.v1.ArticleService/ListImports"
 )
 
 // ArticleServiceClient is the client API for ArticleService service.
@@ -37,8 +71,55 @@ type ArticleServiceClient interface {
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	ListPub(ctx context.Context, in *ListPubRequest, opts ...grpc.CallOption) (*ListPubResponse, error)
+	ListPubByTag(ctx context.Context, in *ListPubByTagRequest, opts ...grpc.CallOption) (*ListPubByTagResponse, error)
+	// ListPubByAuthor 查线上库里面某个作者已发表的文章，不包括撤回了的
+	ListPubByAuthor(ctx context.Context, in *ListPubByAuthorRequest, opts ...grpc.CallOption) (*ListPubByAuthorResponse, error)
 	GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*GetByIdResponse, error)
 	GetPubById(ctx context.Context, in *GetPubByIdRequest, opts ...grpc.CallOption) (*GetPubByIdResponse, error)
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*GetRevisionResponse, error)
+	DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error)
+	RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error)
+	SchedulePublish(ctx context.Context, in *SchedulePublishRequest, opts ...grpc.CallOption) (*SchedulePublishResponse, error)
+	CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*CancelScheduleResponse, error)
+	// 回收站，文章不存在或者状态不对会返回 NOT_FOUND
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error)
+	// PurgeExpired 给定时任务用的，彻底删除过期的文章
+	PurgeExpired(ctx context.Context, in *PurgeExpiredRequest, opts ...grpc.CallOption) (*PurgeExpiredResponse, error)
+	// 系列，文章或者系列不对会返回 INVALID_ARGUMENT，系列不存在会返回 NOT_FOUND
+	CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error)
+	ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*ReorderSeriesResponse, error)
+	ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error)
+	// GetSeriesNav 文章不在任何系列里面的时候 series 为空
+	GetSeriesNav(ctx context.Context, in *GetSeriesNavRequest, opts ...grpc.CallOption) (*GetSeriesNavResponse, error)
+	// 附件，类型和大小不对会返回 INVALID_ARGUMENT，空间不足会返回 RESOURCE_EXHAUSTED
+	CreateUpload(ctx context.Context, in *CreateUploadRequest, opts ...grpc.CallOption) (*CreateUploadResponse, error)
+	// CompleteUpload 还没有上传或者上传的内容不对会返回 FAILED_PRECONDITION
+	CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*CompleteUploadResponse, error)
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error)
+	// GCAttachments 给定时任务用的，清理没有被引用的附件
+	GCAttachments(ctx context.Context, in *GCAttachmentsRequest, opts ...grpc.CallOption) (*GCAttachmentsResponse, error)
+	// 审核，文章不处于待审核状态会返回 FAILED_PRECONDITION，审核期间作者修改过会返回 ABORTED
+	ListPendingReview(ctx context.Context, in *ListPendingReviewRequest, opts ...grpc.CallOption) (*ListPendingReviewResponse, error)
+	Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*ApproveResponse, error)
+	// Reject 没有填原因会返回 INVALID_ARGUMENT
+	Reject(ctx context.Context, in *RejectRequest, opts ...grpc.CallOption) (*RejectResponse, error)
+	ListModerationLogs(ctx context.Context, in *ListModerationLogsRequest, opts ...grpc.CallOption) (*ListModerationLogsResponse, error)
+	// 查重，文章没有发表过会返回 NOT_FOUND
+	FindSimilar(ctx context.Context, in *FindSimilarRequest, opts ...grpc.CallOption) (*FindSimilarResponse, error)
+	ListFlaggedDuplicates(ctx context.Context, in *ListFlaggedDuplicatesRequest, opts ...grpc.CallOption) (*ListFlaggedDuplicatesResponse, error)
+	// 导出，上一次导出还没有完成会返回 FAILED_PRECONDITION
+	StartExport(ctx context.Context, in *StartExportRequest, opts ...grpc.CallOption) (*StartExportResponse, error)
+	GetExport(ctx context.Context, in *GetExportRequest, opts ...grpc.CallOption) (*GetExportResponse, error)
+	ListExports(ctx context.Context, in *ListExportsRequest, opts ...grpc.CallOption) (*ListExportsResponse, error)
+	// 导入，压缩包非法会返回 INVALID_ARGUMENT，上一次导入还没有完成会返回 FAILED_PRECONDITION
+	StartImport(ctx context.Context, in *StartImportRequest, opts ...grpc.CallOption) (*StartImportResponse, error)
+	GetImport(ctx context.Context, in *GetImportRequest, opts ...grpc.CallOption) (*GetImportResponse, error)
+	ListImports(ctx context.Context, in *ListImportsRequest, opts ...grpc.CallOption) (*ListImportsResponse, error)
 }
 
 type articleServiceClient struct {
@@ -99,6 +180,26 @@ func (c *articleServiceClient) ListPub(ctx context.Context, in *ListPubRequest,
 	return out, nil
 }
 
+func (c *articleServiceClient) ListPubByTag(ctx context.Context, in *ListPubByTagRequest, opts ...grpc.CallOption) (*ListPubByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPubByTagResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListPubByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListPubByAuthor(ctx context.Context, in *ListPubByAuthorRequest, opts ...grpc.CallOption) (*ListPubByAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPubByAuthorResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListPubByAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *articleServiceClient) GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*GetByIdResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetByIdResponse)
@@ -106,204 +207,1285 @@ func (c *articleServiceClient) GetById(ctx context.Context, in *GetByIdRequest,
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *articleServiceClient) GetPubById(ctx context.Context, in *GetPubByIdRequest, opts ...grpc.CallOption) (*GetPubByIdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPubByIdResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetPubById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*GetRevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRevisionResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffRevisionsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_DiffRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreRevisionResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RestoreRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) SchedulePublish(ctx context.Context, in *SchedulePublishRequest, opts ...grpc.CallOption) (*SchedulePublishResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SchedulePublishResponse)
+	err := c.cc.Invoke(ctx, ArticleService_SchedulePublish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*CancelScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduleResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CancelSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Purge(ctx context.Context, in *PurgeRequest, opts ...grpc.CallOption) (*PurgeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Purge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) PurgeExpired(ctx context.Context, in *PurgeExpiredRequest, opts ...grpc.CallOption) (*PurgeExpiredResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeExpiredResponse)
+	err := c.cc.Invoke(ctx, ArticleService_PurgeExpired_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CreateSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*ReorderSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ReorderSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetSeriesNav(ctx context.Context, in *GetSeriesNavRequest, opts ...grpc.CallOption) (*GetSeriesNavResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSeriesNavResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetSeriesNav_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CreateUpload(ctx context.Context, in *CreateUploadRequest, opts ...grpc.CallOption) (*CreateUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateUploadResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CreateUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*CompleteUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteUploadResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CompleteUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAttachmentResponse)
+	err := c.cc.Invoke(ctx, ArticleService_DeleteAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GCAttachments(ctx context.Context, in *GCAttachmentsRequest, opts ...grpc.CallOption) (*GCAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GCAttachmentsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GCAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListPendingReview(ctx context.Context, in *ListPendingReviewRequest, opts ...grpc.CallOption) (*ListPendingReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingReviewResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListPendingReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*ApproveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Approve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Reject(ctx context.Context, in *RejectRequest, opts ...grpc.CallOption) (*RejectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Reject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListModerationLogs(ctx context.Context, in *ListModerationLogsRequest, opts ...grpc.CallOption) (*ListModerationLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModerationLogsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListModerationLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) FindSimilar(ctx context.Context, in *FindSimilarRequest, opts ...grpc.CallOption) (*FindSimilarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSimilarResponse)
+	err := c.cc.Invoke(ctx, ArticleService_FindSimilar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListFlaggedDuplicates(ctx context.Context, in *ListFlaggedDuplicatesRequest, opts ...grpc.CallOption) (*ListFlaggedDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFlaggedDuplicatesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListFlaggedDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) StartExport(ctx context.Context, in *StartExportRequest, opts ...grpc.CallOption) (*StartExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartExportResponse)
+	err := c.cc.Invoke(ctx, ArticleService_StartExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetExport(ctx context.Context, in *GetExportRequest, opts ...grpc.CallOption) (*GetExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExportResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListExports(ctx context.Context, in *ListExportsRequest, opts ...grpc.CallOption) (*ListExportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExportsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListExports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) StartImport(ctx context.Context, in *StartImportRequest, opts ...grpc.CallOption) (*StartImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartImportResponse)
+	err := c.cc.Invoke(ctx, ArticleService_StartImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetImport(ctx context.Context, in *GetImportRequest, opts ...grpc.CallOption) (*GetImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImportResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListImports(ctx context.Context, in *ListImportsRequest, opts ...grpc.CallOption) (*ListImportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListImportsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListImports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArticleServiceServer is the server API for ArticleService service.
+// All implementations must embed UnimplementedArticleServiceServer
+// for forward compatibility.
+type ArticleServiceServer interface {
+	Save(context.Context, *SaveRequest) (*SaveResponse, error)
+	WithDraw(context.Context, *WithDrawRequest) (*WithDrawResponse, error)
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error)
+	ListPubByTag(context.Context, *ListPubByTagRequest) (*ListPubByTagResponse, error)
+	// ListPubByAuthor 查线上库里面某个作者已发表的文章，不包括撤回了的
+	ListPubByAuthor(context.Context, *ListPubByAuthorRequest) (*ListPubByAuthorResponse, error)
+	GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error)
+	GetPubById(context.Context, *GetPubByIdRequest) (*GetPubByIdResponse, error)
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	GetRevision(context.Context, *GetRevisionRequest) (*GetRevisionResponse, error)
+	DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error)
+	RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error)
+	SchedulePublish(context.Context, *SchedulePublishRequest) (*SchedulePublishResponse, error)
+	CancelSchedule(context.Context, *CancelScheduleRequest) (*CancelScheduleResponse, error)
+	// 回收站，文章不存在或者状态不对会返回 NOT_FOUND
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	Purge(context.Context, *PurgeRequest) (*PurgeResponse, error)
+	// PurgeExpired 给定时任务用的，彻底删除过期的文章
+	PurgeExpired(context.Context, *PurgeExpiredRequest) (*PurgeExpiredResponse, error)
+	// 系列，文章或者系列不对会返回 INVALID_ARGUMENT，系列不存在会返回 NOT_FOUND
+	CreateSeries(context.Context, *CreateSeriesRequest) (*CreateSeriesResponse, error)
+	ReorderSeries(context.Context, *ReorderSeriesRequest) (*ReorderSeriesResponse, error)
+	ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error)
+	// GetSeriesNav 文章不在任何系列里面的时候 series 为空
+	GetSeriesNav(context.Context, *GetSeriesNavRequest) (*GetSeriesNavResponse, error)
+	// 附件，类型和大小不对会返回 INVALID_ARGUMENT，空间不足会返回 RESOURCE_EXHAUSTED
+	CreateUpload(context.Context, *CreateUploadRequest) (*CreateUploadResponse, error)
+	// CompleteUpload 还没有上传或者上传的内容不对会返回 FAILED_PRECONDITION
+	CompleteUpload(context.Context, *CompleteUploadRequest) (*CompleteUploadResponse, error)
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error)
+	// GCAttachments 给定时任务用的，清理没有被引用的附件
+	GCAttachments(context.Context, *GCAttachmentsRequest) (*GCAttachmentsResponse, error)
+	// 审核，文章不处于待审核状态会返回 FAILED_PRECONDITION，审核期间作者修改过会返回 ABORTED
+	ListPendingReview(context.Context, *ListPendingReviewRequest) (*ListPendingReviewResponse, error)
+	Approve(context.Context, *ApproveRequest) (*ApproveResponse, error)
+	// Reject 没有填原因会返回 INVALID_ARGUMENT
+	Reject(context.Context, *RejectRequest) (*RejectResponse, error)
+	ListModerationLogs(context.Context, *ListModerationLogsRequest) (*ListModerationLogsResponse, error)
+	// 查重，文章没有发表过会返回 NOT_FOUND
+	FindSimilar(context.Context, *FindSimilarRequest) (*FindSimilarResponse, error)
+	ListFlaggedDuplicates(context.Context, *ListFlaggedDuplicatesRequest) (*ListFlaggedDuplicatesResponse, error)
+	// 导出，上一次导出还没有完成会返回 FAILED_PRECONDITION
+	StartExport(context.Context, *StartExportRequest) (*StartExportResponse, error)
+	GetExport(context.Context, *GetExportRequest) (*GetExportResponse, error)
+	ListExports(context.Context, *ListExportsRequest) (*ListExportsResponse, error)
+	// 导入，压缩包非法会返回 INVALID_ARGUMENT，上一次导入还没有完成会返回 FAILED_PRECONDITION
+	StartImport(context.Context, *StartImportRequest) (*StartImportResponse, error)
+	GetImport(context.Context, *GetImportRequest) (*GetImportResponse, error)
+	ListImports(context.Context, *ListImportsRequest) (*ListImportsResponse, error)
+	mustEmbedUnimplementedArticleServiceServer()
+}
+
+// UnimplementedArticleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedArticleServiceServer struct{}
+
+func (UnimplementedArticleServiceServer) Save(context.Context, *SaveRequest) (*SaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
+}
+func (UnimplementedArticleServiceServer) WithDraw(context.Context, *WithDrawRequest) (*WithDrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithDraw not implemented")
+}
+func (UnimplementedArticleServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedArticleServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedArticleServiceServer) ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPub not implemented")
+}
+func (UnimplementedArticleServiceServer) ListPubByTag(context.Context, *ListPubByTagRequest) (*ListPubByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPubByTag not implemented")
+}
+func (UnimplementedArticleServiceServer) ListPubByAuthor(context.Context, *ListPubByAuthorRequest) (*ListPubByAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPubByAuthor not implemented")
+}
+func (UnimplementedArticleServiceServer) GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetById not implemented")
+}
+func (UnimplementedArticleServiceServer) GetPubById(context.Context, *GetPubByIdRequest) (*GetPubByIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPubById not implemented")
+}
+func (UnimplementedArticleServiceServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedArticleServiceServer) GetRevision(context.Context, *GetRevisionRequest) (*GetRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedArticleServiceServer) DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRevisions not implemented")
+}
+func (UnimplementedArticleServiceServer) RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedArticleServiceServer) SchedulePublish(context.Context, *SchedulePublishRequest) (*SchedulePublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePublish not implemented")
+}
+func (UnimplementedArticleServiceServer) CancelSchedule(context.Context, *CancelScheduleRequest) (*CancelScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
+}
+func (UnimplementedArticleServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedArticleServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedArticleServiceServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedArticleServiceServer) Purge(context.Context, *PurgeRequest) (*PurgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+func (UnimplementedArticleServiceServer) PurgeExpired(context.Context, *PurgeExpiredRequest) (*PurgeExpiredResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeExpired not implemented")
+}
+func (UnimplementedArticleServiceServer) CreateSeries(context.Context, *CreateSeriesRequest) (*CreateSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) ReorderSeries(context.Context, *ReorderSeriesRequest) (*ReorderSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) GetSeriesNav(context.Context, *GetSeriesNavRequest) (*GetSeriesNavResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeriesNav not implemented")
+}
+func (UnimplementedArticleServiceServer) CreateUpload(context.Context, *CreateUploadRequest) (*CreateUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpload not implemented")
+}
+func (UnimplementedArticleServiceServer) CompleteUpload(context.Context, *CompleteUploadRequest) (*CompleteUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUpload not implemented")
+}
+func (UnimplementedArticleServiceServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedArticleServiceServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (UnimplementedArticleServiceServer) GCAttachments(context.Context, *GCAttachmentsRequest) (*GCAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GCAttachments not implemented")
+}
+func (UnimplementedArticleServiceServer) ListPendingReview(context.Context, *ListPendingReviewRequest) (*ListPendingReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingReview not implemented")
+}
+func (UnimplementedArticleServiceServer) Approve(context.Context, *ApproveRequest) (*ApproveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
+}
+func (UnimplementedArticleServiceServer) Reject(context.Context, *RejectRequest) (*RejectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reject not implemented")
+}
+func (UnimplementedArticleServiceServer) ListModerationLogs(context.Context, *ListModerationLogsRequest) (*ListModerationLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModerationLogs not implemented")
+}
+func (UnimplementedArticleServiceServer) FindSimilar(context.Context, *FindSimilarRequest) (*FindSimilarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSimilar not implemented")
+}
+func (UnimplementedArticleServiceServer) ListFlaggedDuplicates(context.Context, *ListFlaggedDuplicatesRequest) (*ListFlaggedDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFlaggedDuplicates not implemented")
+}
+func (UnimplementedArticleServiceServer) StartExport(context.Context, *StartExportRequest) (*StartExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartExport not implemented")
+}
+func (UnimplementedArticleServiceServer) GetExport(context.Context, *GetExportRequest) (*GetExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExport not implemented")
+}
+func (UnimplementedArticleServiceServer) ListExports(context.Context, *ListExportsRequest) (*ListExportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExports not implemented")
+}
+func (UnimplementedArticleServiceServer) StartImport(context.Context, *StartImportRequest) (*StartImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartImport not implemented")
+}
+func (UnimplementedArticleServiceServer) GetImport(context.Context, *GetImportRequest) (*GetImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImport not implemented")
+}
+func (UnimplementedArticleServiceServer) ListImports(context.Context, *ListImportsRequest) (*ListImportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImports not implemented")
+}
+func (UnimplementedArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {}
+func (UnimplementedArticleServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeArticleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArticleServiceServer will
+// result in compilation errors.
+type UnsafeArticleServiceServer interface {
+	mustEmbedUnimplementedArticleServiceServer()
+}
+
+func RegisterArticleServiceServer(s grpc.ServiceRegistrar, srv ArticleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedArticleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ArticleService_ServiceDesc, srv)
+}
+
+func _ArticleService_Save_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Save(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Save_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Save(ctx, req.(*SaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_WithDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithDrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).WithDraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_WithDraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).WithDraw(ctx, req.(*WithDrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListPub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListPub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListPub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListPub(ctx, req.(*ListPubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListPubByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPubByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListPubByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListPubByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListPubByTag(ctx, req.(*ListPubByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListPubByAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPubByAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListPubByAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListPubByAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListPubByAuthor(ctx, req.(*ListPubByAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetById(ctx, req.(*GetByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetPubById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPubByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetPubById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetPubById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetPubById(ctx, req.(*GetPubByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetRevision(ctx, req.(*GetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_DiffRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).DiffRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_DiffRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).DiffRevisions(ctx, req.(*DiffRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RestoreRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RestoreRevision(ctx, req.(*RestoreRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_SchedulePublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).SchedulePublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_SchedulePublish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).SchedulePublish(ctx, req.(*SchedulePublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CancelSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CancelSchedule(ctx, req.(*CancelScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Purge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Purge(ctx, req.(*PurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_PurgeExpired_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeExpiredRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).PurgeExpired(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_PurgeExpired_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).PurgeExpired(ctx, req.(*PurgeExpiredRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CreateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CreateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CreateSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CreateSeries(ctx, req.(*CreateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ReorderSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ReorderSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ReorderSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ReorderSeries(ctx, req.(*ReorderSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListSeries(ctx, req.(*ListSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetSeriesNav_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesNavRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetSeriesNav(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetSeriesNav_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetSeriesNav(ctx, req.(*GetSeriesNavRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CreateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CreateUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CreateUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CreateUpload(ctx, req.(*CreateUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CompleteUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CompleteUpload(ctx, req.(*CompleteUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *articleServiceClient) GetPubById(ctx context.Context, in *GetPubByIdRequest, opts ...grpc.CallOption) (*GetPubByIdResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetPubByIdResponse)
-	err := c.cc.Invoke(ctx, ArticleService_GetPubById_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ArticleService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// ArticleServiceServer is the server API for ArticleService service.
-// All implementations must embed UnimplementedArticleServiceServer
-// for forward compatibility.
-type ArticleServiceServer interface {
-	Save(context.Context, *SaveRequest) (*SaveResponse, error)
-	WithDraw(context.Context, *WithDrawRequest) (*WithDrawResponse, error)
-	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
-	List(context.Context, *ListRequest) (*ListResponse, error)
-	ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error)
-	GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error)
-	GetPubById(context.Context, *GetPubByIdRequest) (*GetPubByIdResponse, error)
-	mustEmbedUnimplementedArticleServiceServer()
+func _ArticleService_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_DeleteAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).DeleteAttachment(ctx, req.(*DeleteAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedArticleServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedArticleServiceServer struct{}
-
-func (UnimplementedArticleServiceServer) Save(context.Context, *SaveRequest) (*SaveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
-}
-func (UnimplementedArticleServiceServer) WithDraw(context.Context, *WithDrawRequest) (*WithDrawResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WithDraw not implemented")
-}
-func (UnimplementedArticleServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
-}
-func (UnimplementedArticleServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+func _ArticleService_GCAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GCAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GCAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GCAttachments(ctx, req.(*GCAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedArticleServiceServer) ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListPub not implemented")
+
+func _ArticleService_ListPendingReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListPendingReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListPendingReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListPendingReview(ctx, req.(*ListPendingReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedArticleServiceServer) GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetById not implemented")
+
+func _ArticleService_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Approve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Approve(ctx, req.(*ApproveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedArticleServiceServer) GetPubById(context.Context, *GetPubByIdRequest) (*GetPubByIdResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPubById not implemented")
+
+func _ArticleService_Reject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Reject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Reject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Reject(ctx, req.(*RejectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {}
-func (UnimplementedArticleServiceServer) testEmbeddedByValue()                        {}
 
-// UnsafeArticleServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ArticleServiceServer will
-// result in compilation errors.
-type UnsafeArticleServiceServer interface {
-	mustEmbedUnimplementedArticleServiceServer()
+func _ArticleService_ListModerationLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModerationLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListModerationLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListModerationLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListModerationLogs(ctx, req.(*ListModerationLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterArticleServiceServer(s grpc.ServiceRegistrar, srv ArticleServiceServer) {
-	// If the following call pancis, it indicates UnimplementedArticleServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ArticleService_FindSimilar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ArticleService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).FindSimilar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_FindSimilar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).FindSimilar(ctx, req.(*FindSimilarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ArticleService_Save_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SaveRequest)
+func _ArticleService_ListFlaggedDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFlaggedDuplicatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArticleServiceServer).Save(ctx, in)
+		return srv.(ArticleServiceServer).ListFlaggedDuplicates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArticleService_Save_FullMethodName,
+		FullMethod: ArticleService_ListFlaggedDuplicates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticleServiceServer).Save(ctx, req.(*SaveRequest))
+		return srv.(ArticleServiceServer).ListFlaggedDuplicates(ctx, req.(*ListFlaggedDuplicatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArticleService_WithDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WithDrawRequest)
+func _ArticleService_StartExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartExportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArticleServiceServer).WithDraw(ctx, in)
+		return srv.(ArticleServiceServer).StartExport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArticleService_WithDraw_FullMethodName,
+		FullMethod: ArticleService_StartExport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticleServiceServer).WithDraw(ctx, req.(*WithDrawRequest))
+		return srv.(ArticleServiceServer).StartExport(ctx, req.(*StartExportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArticleService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PublishRequest)
+func _ArticleService_GetExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArticleServiceServer).Publish(ctx, in)
+		return srv.(ArticleServiceServer).GetExport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArticleService_Publish_FullMethodName,
+		FullMethod: ArticleService_GetExport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticleServiceServer).Publish(ctx, req.(*PublishRequest))
+		return srv.(ArticleServiceServer).GetExport(ctx, req.(*GetExportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArticleService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRequest)
+func _ArticleService_ListExports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExportsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArticleServiceServer).List(ctx, in)
+		return srv.(ArticleServiceServer).ListExports(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArticleService_List_FullMethodName,
+		FullMethod: ArticleService_ListExports_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticleServiceServer).List(ctx, req.(*ListRequest))
+		return srv.(ArticleServiceServer).ListExports(ctx, req.(*ListExportsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArticleService_ListPub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPubRequest)
+func _ArticleService_StartImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartImportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArticleServiceServer).ListPub(ctx, in)
+		return srv.(ArticleServiceServer).StartImport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArticleService_ListPub_FullMethodName,
+		FullMethod: ArticleService_StartImport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticleServiceServer).ListPub(ctx, req.(*ListPubRequest))
+		return srv.(ArticleServiceServer).StartImport(ctx, req.(*StartImportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArticleService_GetById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetByIdRequest)
+func _ArticleService_GetImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArticleServiceServer).GetById(ctx, in)
+		return srv.(ArticleServiceServer).GetImport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArticleService_GetById_FullMethodName,
+		FullMethod: ArticleService_GetImport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticleServiceServer).GetById(ctx, req.(*GetByIdRequest))
+		return srv.(ArticleServiceServer).GetImport(ctx, req.(*GetImportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArticleService_GetPubById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPubByIdRequest)
+func _ArticleService_ListImports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImportsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArticleServiceServer).GetPubById(ctx, in)
+		return srv.(ArticleServiceServer).ListImports(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ArticleService_GetPubById_FullMethodName,
+		FullMethod: ArticleService_ListImports_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArticleServiceServer).GetPubById(ctx, req.(*GetPubByIdRequest))
+		return srv.(ArticleServiceServer).ListImports(ctx, req.(*ListImportsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -335,6 +1517,14 @@ var ArticleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListPub",
 			Handler:    _ArticleService_ListPub_Handler,
 		},
+		{
+			MethodName: "ListPubByTag",
+			Handler:    _ArticleService_ListPubByTag_Handler,
+		},
+		{
+			MethodName: "ListPubByAuthor",
+			Handler:    _ArticleService_ListPubByAuthor_Handler,
+		},
 		{
 			MethodName: "GetById",
 			Handler:    _ArticleService_GetById_Handler,
@@ -343,6 +1533,134 @@ var ArticleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPubById",
 			Handler:    _ArticleService_GetPubById_Handler,
 		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _ArticleService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _ArticleService_GetRevision_Handler,
+		},
+		{
+			MethodName: "DiffRevisions",
+			Handler:    _ArticleService_DiffRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _ArticleService_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "SchedulePublish",
+			Handler:    _ArticleService_SchedulePublish_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _ArticleService_CancelSchedule_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ArticleService_Delete_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _ArticleService_ListTrash_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _ArticleService_Restore_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _ArticleService_Purge_Handler,
+		},
+		{
+			MethodName: "PurgeExpired",
+			Handler:    _ArticleService_PurgeExpired_Handler,
+		},
+		{
+			MethodName: "CreateSeries",
+			Handler:    _ArticleService_CreateSeries_Handler,
+		},
+		{
+			MethodName: "ReorderSeries",
+			Handler:    _ArticleService_ReorderSeries_Handler,
+		},
+		{
+			MethodName: "ListSeries",
+			Handler:    _ArticleService_ListSeries_Handler,
+		},
+		{
+			MethodName: "GetSeriesNav",
+			Handler:    _ArticleService_GetSeriesNav_Handler,
+		},
+		{
+			MethodName: "CreateUpload",
+			Handler:    _ArticleService_CreateUpload_Handler,
+		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _ArticleService_CompleteUpload_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _ArticleService_ListAttachments_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _ArticleService_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "GCAttachments",
+			Handler:    _ArticleService_GCAttachments_Handler,
+		},
+		{
+			MethodName: "ListPendingReview",
+			Handler:    _ArticleService_ListPendingReview_Handler,
+		},
+		{
+			MethodName: "Approve",
+			Handler:    _ArticleService_Approve_Handler,
+		},
+		{
+			MethodName: "Reject",
+			Handler:    _ArticleService_Reject_Handler,
+		},
+		{
+			MethodName: "ListModerationLogs",
+			Handler:    _ArticleService_ListModerationLogs_Handler,
+		},
+		{
+			MethodName: "FindSimilar",
+			Handler:    _ArticleService_FindSimilar_Handler,
+		},
+		{
+			MethodName: "ListFlaggedDuplicates",
+			Handler:    _ArticleService_ListFlaggedDuplicates_Handler,
+		},
+		{
+			MethodName: "StartExport",
+			Handler:    _ArticleService_StartExport_Handler,
+		},
+		{
+			MethodName: "GetExport",
+			Handler:    _ArticleService_GetExport_Handler,
+		},
+		{
+			MethodName: "ListExports",
+			Handler:    _ArticleService_ListExports_Handler,
+		},
+		{
+			MethodName: "StartImport",
+			Handler:    _ArticleService_StartImport_Handler,
+		},
+		{
+			MethodName: "GetImport",
+			Handler:    _ArticleService_GetImport_Handler,
+		},
+		{
+			MethodName: "ListImports",
+			Handler:    _ArticleService_ListImports_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "art/v1/art.proto",
@@ -0,0 +1,1547 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./article_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./article_grpc.pb.go -package=artv1mocks -destination=./mocks/article_grpc.pb.mock.go ArticleServiceClient
+//
+
+// Package artv1mocks is a generated GoMock package.
+package artv1mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockArticleServiceClient is a mock of ArticleServiceClient interface.
+type MockArticleServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleServiceClientMockRecorder
+}
+
+// MockArticleServiceClientMockRecorder is the mock recorder for MockArticleServiceClient.
+type MockArticleServiceClientMockRecorder struct {
+	mock *MockArticleServiceClient
+}
+
+// NewMockArticleServiceClient creates a new mock instance.
+func NewMockArticleServiceClient(ctrl *gomock.Controller) *MockArticleServiceClient {
+	mock := &MockArticleServiceClient{ctrl: ctrl}
+	mock.recorder = &MockArticleServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleServiceClient) EXPECT() *MockArticleServiceClientMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockArticleServiceClient) Approve(ctx context.Context, in *artv1.ApproveRequest, opts ...grpc.CallOption) (*artv1.ApproveResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Approve", varargs...)
+	ret0, _ := ret[0].(*artv1.ApproveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockArticleServiceClientMockRecorder) Approve(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockArticleServiceClient)(nil).Approve), varargs...)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleServiceClient) CancelSchedule(ctx context.Context, in *artv1.CancelScheduleRequest, opts ...grpc.CallOption) (*artv1.CancelScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelSchedule", varargs...)
+	ret0, _ := ret[0].(*artv1.CancelScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleServiceClientMockRecorder) CancelSchedule(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleServiceClient)(nil).CancelSchedule), varargs...)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockArticleServiceClient) CompleteUpload(ctx context.Context, in *artv1.CompleteUploadRequest, opts ...grpc.CallOption) (*artv1.CompleteUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompleteUpload", varargs...)
+	ret0, _ := ret[0].(*artv1.CompleteUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockArticleServiceClientMockRecorder) CompleteUpload(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockArticleServiceClient)(nil).CompleteUpload), varargs...)
+}
+
+// CreateSeries mocks base method.
+func (m *MockArticleServiceClient) CreateSeries(ctx context.Context, in *artv1.CreateSeriesRequest, opts ...grpc.CallOption) (*artv1.CreateSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSeries", varargs...)
+	ret0, _ := ret[0].(*artv1.CreateSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockArticleServiceClientMockRecorder) CreateSeries(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockArticleServiceClient)(nil).CreateSeries), varargs...)
+}
+
+// CreateUpload mocks base method.
+func (m *MockArticleServiceClient) CreateUpload(ctx context.Context, in *artv1.CreateUploadRequest, opts ...grpc.CallOption) (*artv1.CreateUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateUpload", varargs...)
+	ret0, _ := ret[0].(*artv1.CreateUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockArticleServiceClientMockRecorder) CreateUpload(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockArticleServiceClient)(nil).CreateUpload), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockArticleServiceClient) Delete(ctx context.Context, in *artv1.DeleteRequest, opts ...grpc.CallOption) (*artv1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(*artv1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleServiceClientMockRecorder) Delete(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleServiceClient)(nil).Delete), varargs...)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockArticleServiceClient) DeleteAttachment(ctx context.Context, in *artv1.DeleteAttachmentRequest, opts ...grpc.CallOption) (*artv1.DeleteAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAttachment", varargs...)
+	ret0, _ := ret[0].(*artv1.DeleteAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockArticleServiceClientMockRecorder) DeleteAttachment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockArticleServiceClient)(nil).DeleteAttachment), varargs...)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleServiceClient) DiffRevisions(ctx context.Context, in *artv1.DiffRevisionsRequest, opts ...grpc.CallOption) (*artv1.DiffRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DiffRevisions", varargs...)
+	ret0, _ := ret[0].(*artv1.DiffRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceClientMockRecorder) DiffRevisions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleServiceClient)(nil).DiffRevisions), varargs...)
+}
+
+// FindSimilar mocks base method.
+func (m *MockArticleServiceClient) FindSimilar(ctx context.Context, in *artv1.FindSimilarRequest, opts ...grpc.CallOption) (*artv1.FindSimilarResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindSimilar", varargs...)
+	ret0, _ := ret[0].(*artv1.FindSimilarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilar indicates an expected call of FindSimilar.
+func (mr *MockArticleServiceClientMockRecorder) FindSimilar(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilar", reflect.TypeOf((*MockArticleServiceClient)(nil).FindSimilar), varargs...)
+}
+
+// GCAttachments mocks base method.
+func (m *MockArticleServiceClient) GCAttachments(ctx context.Context, in *artv1.GCAttachmentsRequest, opts ...grpc.CallOption) (*artv1.GCAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GCAttachments", varargs...)
+	ret0, _ := ret[0].(*artv1.GCAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GCAttachments indicates an expected call of GCAttachments.
+func (mr *MockArticleServiceClientMockRecorder) GCAttachments(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GCAttachments", reflect.TypeOf((*MockArticleServiceClient)(nil).GCAttachments), varargs...)
+}
+
+// GetById mocks base method.
+func (m *MockArticleServiceClient) GetById(ctx context.Context, in *artv1.GetByIdRequest, opts ...grpc.CallOption) (*artv1.GetByIdResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetById", varargs...)
+	ret0, _ := ret[0].(*artv1.GetByIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleServiceClientMockRecorder) GetById(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleServiceClient)(nil).GetById), varargs...)
+}
+
+// GetExport mocks base method.
+func (m *MockArticleServiceClient) GetExport(ctx context.Context, in *artv1.GetExportRequest, opts ...grpc.CallOption) (*artv1.GetExportResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetExport", varargs...)
+	ret0, _ := ret[0].(*artv1.GetExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockArticleServiceClientMockRecorder) GetExport(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockArticleServiceClient)(nil).GetExport), varargs...)
+}
+
+// GetImport mocks base method.
+func (m *MockArticleServiceClient) GetImport(ctx context.Context, in *artv1.GetImportRequest, opts ...grpc.CallOption) (*artv1.GetImportResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetImport", varargs...)
+	ret0, _ := ret[0].(*artv1.GetImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockArticleServiceClientMockRecorder) GetImport(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockArticleServiceClient)(nil).GetImport), varargs...)
+}
+
+// GetPubById mocks base method.
+func (m *MockArticleServiceClient) GetPubById(ctx context.Context, in *artv1.GetPubByIdRequest, opts ...grpc.CallOption) (*artv1.GetPubByIdResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPubById", varargs...)
+	ret0, _ := ret[0].(*artv1.GetPubByIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubById indicates an expected call of GetPubById.
+func (mr *MockArticleServiceClientMockRecorder) GetPubById(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleServiceClient)(nil).GetPubById), varargs...)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleServiceClient) GetRevision(ctx context.Context, in *artv1.GetRevisionRequest, opts ...grpc.CallOption) (*artv1.GetRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRevision", varargs...)
+	ret0, _ := ret[0].(*artv1.GetRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleServiceClientMockRecorder) GetRevision(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleServiceClient)(nil).GetRevision), varargs...)
+}
+
+// GetSeriesNav mocks base method.
+func (m *MockArticleServiceClient) GetSeriesNav(ctx context.Context, in *artv1.GetSeriesNavRequest, opts ...grpc.CallOption) (*artv1.GetSeriesNavResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSeriesNav", varargs...)
+	ret0, _ := ret[0].(*artv1.GetSeriesNavResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesNav indicates an expected call of GetSeriesNav.
+func (mr *MockArticleServiceClientMockRecorder) GetSeriesNav(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesNav", reflect.TypeOf((*MockArticleServiceClient)(nil).GetSeriesNav), varargs...)
+}
+
+// List mocks base method.
+func (m *MockArticleServiceClient) List(ctx context.Context, in *artv1.ListRequest, opts ...grpc.CallOption) (*artv1.ListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(*artv1.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleServiceClientMockRecorder) List(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleServiceClient)(nil).List), varargs...)
+}
+
+// ListAttachments mocks base method.
+func (m *MockArticleServiceClient) ListAttachments(ctx context.Context, in *artv1.ListAttachmentsRequest, opts ...grpc.CallOption) (*artv1.ListAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAttachments", varargs...)
+	ret0, _ := ret[0].(*artv1.ListAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockArticleServiceClientMockRecorder) ListAttachments(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockArticleServiceClient)(nil).ListAttachments), varargs...)
+}
+
+// ListExports mocks base method.
+func (m *MockArticleServiceClient) ListExports(ctx context.Context, in *artv1.ListExportsRequest, opts ...grpc.CallOption) (*artv1.ListExportsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListExports", varargs...)
+	ret0, _ := ret[0].(*artv1.ListExportsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockArticleServiceClientMockRecorder) ListExports(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockArticleServiceClient)(nil).ListExports), varargs...)
+}
+
+// ListFlaggedDuplicates mocks base method.
+func (m *MockArticleServiceClient) ListFlaggedDuplicates(ctx context.Context, in *artv1.ListFlaggedDuplicatesRequest, opts ...grpc.CallOption) (*artv1.ListFlaggedDuplicatesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFlaggedDuplicates", varargs...)
+	ret0, _ := ret[0].(*artv1.ListFlaggedDuplicatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlaggedDuplicates indicates an expected call of ListFlaggedDuplicates.
+func (mr *MockArticleServiceClientMockRecorder) ListFlaggedDuplicates(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlaggedDuplicates", reflect.TypeOf((*MockArticleServiceClient)(nil).ListFlaggedDuplicates), varargs...)
+}
+
+// ListImports mocks base method.
+func (m *MockArticleServiceClient) ListImports(ctx context.Context, in *artv1.ListImportsRequest, opts ...grpc.CallOption) (*artv1.ListImportsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListImports", varargs...)
+	ret0, _ := ret[0].(*artv1.ListImportsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImports indicates an expected call of ListImports.
+func (mr *MockArticleServiceClientMockRecorder) ListImports(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImports", reflect.TypeOf((*MockArticleServiceClient)(nil).ListImports), varargs...)
+}
+
+// ListModerationLogs mocks base method.
+func (m *MockArticleServiceClient) ListModerationLogs(ctx context.Context, in *artv1.ListModerationLogsRequest, opts ...grpc.CallOption) (*artv1.ListModerationLogsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListModerationLogs", varargs...)
+	ret0, _ := ret[0].(*artv1.ListModerationLogsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationLogs indicates an expected call of ListModerationLogs.
+func (mr *MockArticleServiceClientMockRecorder) ListModerationLogs(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationLogs", reflect.TypeOf((*MockArticleServiceClient)(nil).ListModerationLogs), varargs...)
+}
+
+// ListPendingReview mocks base method.
+func (m *MockArticleServiceClient) ListPendingReview(ctx context.Context, in *artv1.ListPendingReviewRequest, opts ...grpc.CallOption) (*artv1.ListPendingReviewResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPendingReview", varargs...)
+	ret0, _ := ret[0].(*artv1.ListPendingReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingReview indicates an expected call of ListPendingReview.
+func (mr *MockArticleServiceClientMockRecorder) ListPendingReview(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingReview", reflect.TypeOf((*MockArticleServiceClient)(nil).ListPendingReview), varargs...)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleServiceClient) ListPub(ctx context.Context, in *artv1.ListPubRequest, opts ...grpc.CallOption) (*artv1.ListPubResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPub", varargs...)
+	ret0, _ := ret[0].(*artv1.ListPubResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleServiceClientMockRecorder) ListPub(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleServiceClient)(nil).ListPub), varargs...)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockArticleServiceClient) ListPubByAuthor(ctx context.Context, in *artv1.ListPubByAuthorRequest, opts ...grpc.CallOption) (*artv1.ListPubByAuthorResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPubByAuthor", varargs...)
+	ret0, _ := ret[0].(*artv1.ListPubByAuthorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleServiceClientMockRecorder) ListPubByAuthor(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleServiceClient)(nil).ListPubByAuthor), varargs...)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleServiceClient) ListPubByTag(ctx context.Context, in *artv1.ListPubByTagRequest, opts ...grpc.CallOption) (*artv1.ListPubByTagResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPubByTag", varargs...)
+	ret0, _ := ret[0].(*artv1.ListPubByTagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceClientMockRecorder) ListPubByTag(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleServiceClient)(nil).ListPubByTag), varargs...)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleServiceClient) ListRevisions(ctx context.Context, in *artv1.ListRevisionsRequest, opts ...grpc.CallOption) (*artv1.ListRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRevisions", varargs...)
+	ret0, _ := ret[0].(*artv1.ListRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceClientMockRecorder) ListRevisions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleServiceClient)(nil).ListRevisions), varargs...)
+}
+
+// ListSeries mocks base method.
+func (m *MockArticleServiceClient) ListSeries(ctx context.Context, in *artv1.ListSeriesRequest, opts ...grpc.CallOption) (*artv1.ListSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSeries", varargs...)
+	ret0, _ := ret[0].(*artv1.ListSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockArticleServiceClientMockRecorder) ListSeries(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockArticleServiceClient)(nil).ListSeries), varargs...)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleServiceClient) ListTrash(ctx context.Context, in *artv1.ListTrashRequest, opts ...grpc.CallOption) (*artv1.ListTrashResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrash", varargs...)
+	ret0, _ := ret[0].(*artv1.ListTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleServiceClientMockRecorder) ListTrash(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleServiceClient)(nil).ListTrash), varargs...)
+}
+
+// Publish mocks base method.
+func (m *MockArticleServiceClient) Publish(ctx context.Context, in *artv1.PublishRequest, opts ...grpc.CallOption) (*artv1.PublishResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(*artv1.PublishResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockArticleServiceClientMockRecorder) Publish(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleServiceClient)(nil).Publish), varargs...)
+}
+
+// Purge mocks base method.
+func (m *MockArticleServiceClient) Purge(ctx context.Context, in *artv1.PurgeRequest, opts ...grpc.CallOption) (*artv1.PurgeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Purge", varargs...)
+	ret0, _ := ret[0].(*artv1.PurgeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleServiceClientMockRecorder) Purge(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleServiceClient)(nil).Purge), varargs...)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockArticleServiceClient) PurgeExpired(ctx context.Context, in *artv1.PurgeExpiredRequest, opts ...grpc.CallOption) (*artv1.PurgeExpiredResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PurgeExpired", varargs...)
+	ret0, _ := ret[0].(*artv1.PurgeExpiredResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockArticleServiceClientMockRecorder) PurgeExpired(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockArticleServiceClient)(nil).PurgeExpired), varargs...)
+}
+
+// Reject mocks base method.
+func (m *MockArticleServiceClient) Reject(ctx context.Context, in *artv1.RejectRequest, opts ...grpc.CallOption) (*artv1.RejectResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Reject", varargs...)
+	ret0, _ := ret[0].(*artv1.RejectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockArticleServiceClientMockRecorder) Reject(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockArticleServiceClient)(nil).Reject), varargs...)
+}
+
+// ReorderSeries mocks base method.
+func (m *MockArticleServiceClient) ReorderSeries(ctx context.Context, in *artv1.ReorderSeriesRequest, opts ...grpc.CallOption) (*artv1.ReorderSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReorderSeries", varargs...)
+	ret0, _ := ret[0].(*artv1.ReorderSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderSeries indicates an expected call of ReorderSeries.
+func (mr *MockArticleServiceClientMockRecorder) ReorderSeries(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSeries", reflect.TypeOf((*MockArticleServiceClient)(nil).ReorderSeries), varargs...)
+}
+
+// Restore mocks base method.
+func (m *MockArticleServiceClient) Restore(ctx context.Context, in *artv1.RestoreRequest, opts ...grpc.CallOption) (*artv1.RestoreResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(*artv1.RestoreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleServiceClientMockRecorder) Restore(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleServiceClient)(nil).Restore), varargs...)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticleServiceClient) RestoreRevision(ctx context.Context, in *artv1.RestoreRevisionRequest, opts ...grpc.CallOption) (*artv1.RestoreRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreRevision", varargs...)
+	ret0, _ := ret[0].(*artv1.RestoreRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceClientMockRecorder) RestoreRevision(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleServiceClient)(nil).RestoreRevision), varargs...)
+}
+
+// Save mocks base method.
+func (m *MockArticleServiceClient) Save(ctx context.Context, in *artv1.SaveRequest, opts ...grpc.CallOption) (*artv1.SaveResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Save", varargs...)
+	ret0, _ := ret[0].(*artv1.SaveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockArticleServiceClientMockRecorder) Save(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleServiceClient)(nil).Save), varargs...)
+}
+
+// SchedulePublish mocks base method.
+func (m *MockArticleServiceClient) SchedulePublish(ctx context.Context, in *artv1.SchedulePublishRequest, opts ...grpc.CallOption) (*artv1.SchedulePublishResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SchedulePublish", varargs...)
+	ret0, _ := ret[0].(*artv1.SchedulePublishResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePublish indicates an expected call of SchedulePublish.
+func (mr *MockArticleServiceClientMockRecorder) SchedulePublish(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePublish", reflect.TypeOf((*MockArticleServiceClient)(nil).SchedulePublish), varargs...)
+}
+
+// StartExport mocks base method.
+func (m *MockArticleServiceClient) StartExport(ctx context.Context, in *artv1.StartExportRequest, opts ...grpc.CallOption) (*artv1.StartExportResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StartExport", varargs...)
+	ret0, _ := ret[0].(*artv1.StartExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartExport indicates an expected call of StartExport.
+func (mr *MockArticleServiceClientMockRecorder) StartExport(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExport", reflect.TypeOf((*MockArticleServiceClient)(nil).StartExport), varargs...)
+}
+
+// StartImport mocks base method.
+func (m *MockArticleServiceClient) StartImport(ctx context.Context, in *artv1.StartImportRequest, opts ...grpc.CallOption) (*artv1.StartImportResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StartImport", varargs...)
+	ret0, _ := ret[0].(*artv1.StartImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockArticleServiceClientMockRecorder) StartImport(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockArticleServiceClient)(nil).StartImport), varargs...)
+}
+
+// WithDraw mocks base method.
+func (m *MockArticleServiceClient) WithDraw(ctx context.Context, in *artv1.WithDrawRequest, opts ...grpc.CallOption) (*artv1.WithDrawResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithDraw", varargs...)
+	ret0, _ := ret[0].(*artv1.WithDrawResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithDraw indicates an expected call of WithDraw.
+func (mr *MockArticleServiceClientMockRecorder) WithDraw(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithDraw", reflect.TypeOf((*MockArticleServiceClient)(nil).WithDraw), varargs...)
+}
+
+// MockArticleServiceServer is a mock of ArticleServiceServer interface.
+type MockArticleServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleServiceServerMockRecorder
+}
+
+// MockArticleServiceServerMockRecorder is the mock recorder for MockArticleServiceServer.
+type MockArticleServiceServerMockRecorder struct {
+	mock *MockArticleServiceServer
+}
+
+// NewMockArticleServiceServer creates a new mock instance.
+func NewMockArticleServiceServer(ctrl *gomock.Controller) *MockArticleServiceServer {
+	mock := &MockArticleServiceServer{ctrl: ctrl}
+	mock.recorder = &MockArticleServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleServiceServer) EXPECT() *MockArticleServiceServerMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockArticleServiceServer) Approve(arg0 context.Context, arg1 *artv1.ApproveRequest) (*artv1.ApproveResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ApproveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockArticleServiceServerMockRecorder) Approve(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockArticleServiceServer)(nil).Approve), arg0, arg1)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleServiceServer) CancelSchedule(arg0 context.Context, arg1 *artv1.CancelScheduleRequest) (*artv1.CancelScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.CancelScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleServiceServerMockRecorder) CancelSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleServiceServer)(nil).CancelSchedule), arg0, arg1)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockArticleServiceServer) CompleteUpload(arg0 context.Context, arg1 *artv1.CompleteUploadRequest) (*artv1.CompleteUploadResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.CompleteUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockArticleServiceServerMockRecorder) CompleteUpload(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockArticleServiceServer)(nil).CompleteUpload), arg0, arg1)
+}
+
+// CreateSeries mocks base method.
+func (m *MockArticleServiceServer) CreateSeries(arg0 context.Context, arg1 *artv1.CreateSeriesRequest) (*artv1.CreateSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.CreateSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockArticleServiceServerMockRecorder) CreateSeries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockArticleServiceServer)(nil).CreateSeries), arg0, arg1)
+}
+
+// CreateUpload mocks base method.
+func (m *MockArticleServiceServer) CreateUpload(arg0 context.Context, arg1 *artv1.CreateUploadRequest) (*artv1.CreateUploadResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.CreateUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockArticleServiceServerMockRecorder) CreateUpload(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockArticleServiceServer)(nil).CreateUpload), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockArticleServiceServer) Delete(arg0 context.Context, arg1 *artv1.DeleteRequest) (*artv1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleServiceServerMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleServiceServer)(nil).Delete), arg0, arg1)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockArticleServiceServer) DeleteAttachment(arg0 context.Context, arg1 *artv1.DeleteAttachmentRequest) (*artv1.DeleteAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.DeleteAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockArticleServiceServerMockRecorder) DeleteAttachment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockArticleServiceServer)(nil).DeleteAttachment), arg0, arg1)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleServiceServer) DiffRevisions(arg0 context.Context, arg1 *artv1.DiffRevisionsRequest) (*artv1.DiffRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.DiffRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceServerMockRecorder) DiffRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleServiceServer)(nil).DiffRevisions), arg0, arg1)
+}
+
+// FindSimilar mocks base method.
+func (m *MockArticleServiceServer) FindSimilar(arg0 context.Context, arg1 *artv1.FindSimilarRequest) (*artv1.FindSimilarResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilar", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.FindSimilarResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilar indicates an expected call of FindSimilar.
+func (mr *MockArticleServiceServerMockRecorder) FindSimilar(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilar", reflect.TypeOf((*MockArticleServiceServer)(nil).FindSimilar), arg0, arg1)
+}
+
+// GCAttachments mocks base method.
+func (m *MockArticleServiceServer) GCAttachments(arg0 context.Context, arg1 *artv1.GCAttachmentsRequest) (*artv1.GCAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GCAttachments", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.GCAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GCAttachments indicates an expected call of GCAttachments.
+func (mr *MockArticleServiceServerMockRecorder) GCAttachments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GCAttachments", reflect.TypeOf((*MockArticleServiceServer)(nil).GCAttachments), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockArticleServiceServer) GetById(arg0 context.Context, arg1 *artv1.GetByIdRequest) (*artv1.GetByIdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.GetByIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleServiceServerMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleServiceServer)(nil).GetById), arg0, arg1)
+}
+
+// GetExport mocks base method.
+func (m *MockArticleServiceServer) GetExport(arg0 context.Context, arg1 *artv1.GetExportRequest) (*artv1.GetExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.GetExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockArticleServiceServerMockRecorder) GetExport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockArticleServiceServer)(nil).GetExport), arg0, arg1)
+}
+
+// GetImport mocks base method.
+func (m *MockArticleServiceServer) GetImport(arg0 context.Context, arg1 *artv1.GetImportRequest) (*artv1.GetImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.GetImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockArticleServiceServerMockRecorder) GetImport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockArticleServiceServer)(nil).GetImport), arg0, arg1)
+}
+
+// GetPubById mocks base method.
+func (m *MockArticleServiceServer) GetPubById(arg0 context.Context, arg1 *artv1.GetPubByIdRequest) (*artv1.GetPubByIdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubById", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.GetPubByIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubById indicates an expected call of GetPubById.
+func (mr *MockArticleServiceServerMockRecorder) GetPubById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubById", reflect.TypeOf((*MockArticleServiceServer)(nil).GetPubById), arg0, arg1)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleServiceServer) GetRevision(arg0 context.Context, arg1 *artv1.GetRevisionRequest) (*artv1.GetRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.GetRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleServiceServerMockRecorder) GetRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleServiceServer)(nil).GetRevision), arg0, arg1)
+}
+
+// GetSeriesNav mocks base method.
+func (m *MockArticleServiceServer) GetSeriesNav(arg0 context.Context, arg1 *artv1.GetSeriesNavRequest) (*artv1.GetSeriesNavResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesNav", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.GetSeriesNavResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesNav indicates an expected call of GetSeriesNav.
+func (mr *MockArticleServiceServerMockRecorder) GetSeriesNav(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesNav", reflect.TypeOf((*MockArticleServiceServer)(nil).GetSeriesNav), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockArticleServiceServer) List(arg0 context.Context, arg1 *artv1.ListRequest) (*artv1.ListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleServiceServerMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleServiceServer)(nil).List), arg0, arg1)
+}
+
+// ListAttachments mocks base method.
+func (m *MockArticleServiceServer) ListAttachments(arg0 context.Context, arg1 *artv1.ListAttachmentsRequest) (*artv1.ListAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockArticleServiceServerMockRecorder) ListAttachments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockArticleServiceServer)(nil).ListAttachments), arg0, arg1)
+}
+
+// ListExports mocks base method.
+func (m *MockArticleServiceServer) ListExports(arg0 context.Context, arg1 *artv1.ListExportsRequest) (*artv1.ListExportsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExports", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListExportsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockArticleServiceServerMockRecorder) ListExports(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockArticleServiceServer)(nil).ListExports), arg0, arg1)
+}
+
+// ListFlaggedDuplicates mocks base method.
+func (m *MockArticleServiceServer) ListFlaggedDuplicates(arg0 context.Context, arg1 *artv1.ListFlaggedDuplicatesRequest) (*artv1.ListFlaggedDuplicatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlaggedDuplicates", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListFlaggedDuplicatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlaggedDuplicates indicates an expected call of ListFlaggedDuplicates.
+func (mr *MockArticleServiceServerMockRecorder) ListFlaggedDuplicates(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlaggedDuplicates", reflect.TypeOf((*MockArticleServiceServer)(nil).ListFlaggedDuplicates), arg0, arg1)
+}
+
+// ListImports mocks base method.
+func (m *MockArticleServiceServer) ListImports(arg0 context.Context, arg1 *artv1.ListImportsRequest) (*artv1.ListImportsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImports", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListImportsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImports indicates an expected call of ListImports.
+func (mr *MockArticleServiceServerMockRecorder) ListImports(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImports", reflect.TypeOf((*MockArticleServiceServer)(nil).ListImports), arg0, arg1)
+}
+
+// ListModerationLogs mocks base method.
+func (m *MockArticleServiceServer) ListModerationLogs(arg0 context.Context, arg1 *artv1.ListModerationLogsRequest) (*artv1.ListModerationLogsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationLogs", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListModerationLogsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationLogs indicates an expected call of ListModerationLogs.
+func (mr *MockArticleServiceServerMockRecorder) ListModerationLogs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationLogs", reflect.TypeOf((*MockArticleServiceServer)(nil).ListModerationLogs), arg0, arg1)
+}
+
+// ListPendingReview mocks base method.
+func (m *MockArticleServiceServer) ListPendingReview(arg0 context.Context, arg1 *artv1.ListPendingReviewRequest) (*artv1.ListPendingReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingReview", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListPendingReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingReview indicates an expected call of ListPendingReview.
+func (mr *MockArticleServiceServerMockRecorder) ListPendingReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingReview", reflect.TypeOf((*MockArticleServiceServer)(nil).ListPendingReview), arg0, arg1)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleServiceServer) ListPub(arg0 context.Context, arg1 *artv1.ListPubRequest) (*artv1.ListPubResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListPubResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleServiceServerMockRecorder) ListPub(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleServiceServer)(nil).ListPub), arg0, arg1)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockArticleServiceServer) ListPubByAuthor(arg0 context.Context, arg1 *artv1.ListPubByAuthorRequest) (*artv1.ListPubByAuthorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListPubByAuthorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleServiceServerMockRecorder) ListPubByAuthor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleServiceServer)(nil).ListPubByAuthor), arg0, arg1)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleServiceServer) ListPubByTag(arg0 context.Context, arg1 *artv1.ListPubByTagRequest) (*artv1.ListPubByTagResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListPubByTagResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceServerMockRecorder) ListPubByTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleServiceServer)(nil).ListPubByTag), arg0, arg1)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleServiceServer) ListRevisions(arg0 context.Context, arg1 *artv1.ListRevisionsRequest) (*artv1.ListRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceServerMockRecorder) ListRevisions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleServiceServer)(nil).ListRevisions), arg0, arg1)
+}
+
+// ListSeries mocks base method.
+func (m *MockArticleServiceServer) ListSeries(arg0 context.Context, arg1 *artv1.ListSeriesRequest) (*artv1.ListSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockArticleServiceServerMockRecorder) ListSeries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockArticleServiceServer)(nil).ListSeries), arg0, arg1)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleServiceServer) ListTrash(arg0 context.Context, arg1 *artv1.ListTrashRequest) (*artv1.ListTrashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ListTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleServiceServerMockRecorder) ListTrash(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleServiceServer)(nil).ListTrash), arg0, arg1)
+}
+
+// Publish mocks base method.
+func (m *MockArticleServiceServer) Publish(arg0 context.Context, arg1 *artv1.PublishRequest) (*artv1.PublishResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.PublishResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockArticleServiceServerMockRecorder) Publish(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleServiceServer)(nil).Publish), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockArticleServiceServer) Purge(arg0 context.Context, arg1 *artv1.PurgeRequest) (*artv1.PurgeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.PurgeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleServiceServerMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleServiceServer)(nil).Purge), arg0, arg1)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockArticleServiceServer) PurgeExpired(arg0 context.Context, arg1 *artv1.PurgeExpiredRequest) (*artv1.PurgeExpiredResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.PurgeExpiredResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockArticleServiceServerMockRecorder) PurgeExpired(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockArticleServiceServer)(nil).PurgeExpired), arg0, arg1)
+}
+
+// Reject mocks base method.
+func (m *MockArticleServiceServer) Reject(arg0 context.Context, arg1 *artv1.RejectRequest) (*artv1.RejectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.RejectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockArticleServiceServerMockRecorder) Reject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockArticleServiceServer)(nil).Reject), arg0, arg1)
+}
+
+// ReorderSeries mocks base method.
+func (m *MockArticleServiceServer) ReorderSeries(arg0 context.Context, arg1 *artv1.ReorderSeriesRequest) (*artv1.ReorderSeriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderSeries", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.ReorderSeriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderSeries indicates an expected call of ReorderSeries.
+func (mr *MockArticleServiceServerMockRecorder) ReorderSeries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSeries", reflect.TypeOf((*MockArticleServiceServer)(nil).ReorderSeries), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockArticleServiceServer) Restore(arg0 context.Context, arg1 *artv1.RestoreRequest) (*artv1.RestoreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.RestoreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleServiceServerMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleServiceServer)(nil).Restore), arg0, arg1)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticleServiceServer) RestoreRevision(arg0 context.Context, arg1 *artv1.RestoreRevisionRequest) (*artv1.RestoreRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.RestoreRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceServerMockRecorder) RestoreRevision(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleServiceServer)(nil).RestoreRevision), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockArticleServiceServer) Save(arg0 context.Context, arg1 *artv1.SaveRequest) (*artv1.SaveResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.SaveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockArticleServiceServerMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleServiceServer)(nil).Save), arg0, arg1)
+}
+
+// SchedulePublish mocks base method.
+func (m *MockArticleServiceServer) SchedulePublish(arg0 context.Context, arg1 *artv1.SchedulePublishRequest) (*artv1.SchedulePublishResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePublish", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.SchedulePublishResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePublish indicates an expected call of SchedulePublish.
+func (mr *MockArticleServiceServerMockRecorder) SchedulePublish(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePublish", reflect.TypeOf((*MockArticleServiceServer)(nil).SchedulePublish), arg0, arg1)
+}
+
+// StartExport mocks base method.
+func (m *MockArticleServiceServer) StartExport(arg0 context.Context, arg1 *artv1.StartExportRequest) (*artv1.StartExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartExport", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.StartExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartExport indicates an expected call of StartExport.
+func (mr *MockArticleServiceServerMockRecorder) StartExport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExport", reflect.TypeOf((*MockArticleServiceServer)(nil).StartExport), arg0, arg1)
+}
+
+// StartImport mocks base method.
+func (m *MockArticleServiceServer) StartImport(arg0 context.Context, arg1 *artv1.StartImportRequest) (*artv1.StartImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.StartImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockArticleServiceServerMockRecorder) StartImport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockArticleServiceServer)(nil).StartImport), arg0, arg1)
+}
+
+// WithDraw mocks base method.
+func (m *MockArticleServiceServer) WithDraw(arg0 context.Context, arg1 *artv1.WithDrawRequest) (*artv1.WithDrawResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithDraw", arg0, arg1)
+	ret0, _ := ret[0].(*artv1.WithDrawResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithDraw indicates an expected call of WithDraw.
+func (mr *MockArticleServiceServerMockRecorder) WithDraw(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithDraw", reflect.TypeOf((*MockArticleServiceServer)(nil).WithDraw), arg0, arg1)
+}
+
+// mustEmbedUnimplementedArticleServiceServer mocks base method.
+func (m *MockArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedArticleServiceServer")
+}
+
+// mustEmbedUnimplementedArticleServiceServer indicates an expected call of mustEmbedUnimplementedArticleServiceServer.
+func (mr *MockArticleServiceServerMockRecorder) mustEmbedUnimplementedArticleServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedArticleServiceServer", reflect.TypeOf((*MockArticleServiceServer)(nil).mustEmbedUnimplementedArticleServiceServer))
+}
+
+// MockUnsafeArticleServiceServer is a mock of UnsafeArticleServiceServer interface.
+type MockUnsafeArticleServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeArticleServiceServerMockRecorder
+}
+
+// MockUnsafeArticleServiceServerMockRecorder is the mock recorder for MockUnsafeArticleServiceServer.
+type MockUnsafeArticleServiceServerMockRecorder struct {
+	mock *MockUnsafeArticleServiceServer
+}
+
+// NewMockUnsafeArticleServiceServer creates a new mock instance.
+func NewMockUnsafeArticleServiceServer(ctrl *gomock.Controller) *MockUnsafeArticleServiceServer {
+	mock := &MockUnsafeArticleServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeArticleServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeArticleServiceServer) EXPECT() *MockUnsafeArticleServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedArticleServiceServer mocks base method.
+func (m *MockUnsafeArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedArticleServiceServer")
+}
+
+// mustEmbedUnimplementedArticleServiceServer indicates an expected call of mustEmbedUnimplementedArticleServiceServer.
+func (mr *MockUnsafeArticleServiceServerMockRecorder) mustEmbedUnimplementedArticleServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedArticleServiceServer", reflect.TypeOf((*MockUnsafeArticleServiceServer)(nil).mustEmbedUnimplementedArticleServiceServer))
+}
@@ -11,9 +11,9 @@ package intrv1mocks
 
 import (
 	context "context"
-	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
 	reflect "reflect"
 
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
 	gomock "go.uber.org/mock/gomock"
 	grpc "google.golang.org/grpc"
 )
@@ -81,6 +81,26 @@ func (mr *MockInteractiveServiceClientMockRecorder) Collect(ctx, in any, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Collect), varargs...)
 }
 
+// Delete mocks base method.
+func (m *MockInteractiveServiceClient) Delete(ctx context.Context, in *intrv1.DeleteRequest, opts ...grpc.CallOption) (*intrv1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(*intrv1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractiveServiceClientMockRecorder) Delete(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Delete), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveServiceClient) Get(ctx context.Context, in *intrv1.GetRequest, opts ...grpc.CallOption) (*intrv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -121,6 +141,26 @@ func (mr *MockInteractiveServiceClientMockRecorder) GetByIds(ctx, in any, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveServiceClient)(nil).GetByIds), varargs...)
 }
 
+// GetStats mocks base method.
+func (m *MockInteractiveServiceClient) GetStats(ctx context.Context, in *intrv1.GetStatsRequest, opts ...grpc.CallOption) (*intrv1.GetStatsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetStats", varargs...)
+	ret0, _ := ret[0].(*intrv1.GetStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockInteractiveServiceClientMockRecorder) GetStats(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockInteractiveServiceClient)(nil).GetStats), varargs...)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveServiceClient) IncrReadCnt(ctx context.Context, in *intrv1.IncrReadCntRequest, opts ...grpc.CallOption) (*intrv1.IncrReadCntResponse, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +201,46 @@ func (mr *MockInteractiveServiceClientMockRecorder) Like(ctx, in any, opts ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveServiceClient)(nil).Like), varargs...)
 }
 
+// ListStats mocks base method.
+func (m *MockInteractiveServiceClient) ListStats(ctx context.Context, in *intrv1.ListStatsRequest, opts ...grpc.CallOption) (*intrv1.ListStatsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListStats", varargs...)
+	ret0, _ := ret[0].(*intrv1.ListStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStats indicates an expected call of ListStats.
+func (mr *MockInteractiveServiceClientMockRecorder) ListStats(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStats", reflect.TypeOf((*MockInteractiveServiceClient)(nil).ListStats), varargs...)
+}
+
+// TopStats mocks base method.
+func (m *MockInteractiveServiceClient) TopStats(ctx context.Context, in *intrv1.TopStatsRequest, opts ...grpc.CallOption) (*intrv1.TopStatsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TopStats", varargs...)
+	ret0, _ := ret[0].(*intrv1.TopStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopStats indicates an expected call of TopStats.
+func (mr *MockInteractiveServiceClientMockRecorder) TopStats(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopStats", reflect.TypeOf((*MockInteractiveServiceClient)(nil).TopStats), varargs...)
+}
+
 // MockInteractiveServiceServer is a mock of InteractiveServiceServer interface.
 type MockInteractiveServiceServer struct {
 	ctrl     *gomock.Controller
@@ -214,6 +294,21 @@ func (mr *MockInteractiveServiceServerMockRecorder) Collect(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Collect), arg0, arg1)
 }
 
+// Delete mocks base method.
+func (m *MockInteractiveServiceServer) Delete(arg0 context.Context, arg1 *intrv1.DeleteRequest) (*intrv1.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractiveServiceServerMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Delete), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveServiceServer) Get(arg0 context.Context, arg1 *intrv1.GetRequest) (*intrv1.GetResponse, error) {
 	m.ctrl.T.Helper()
@@ -244,6 +339,21 @@ func (mr *MockInteractiveServiceServerMockRecorder) GetByIds(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveServiceServer)(nil).GetByIds), arg0, arg1)
 }
 
+// GetStats mocks base method.
+func (m *MockInteractiveServiceServer) GetStats(arg0 context.Context, arg1 *intrv1.GetStatsRequest) (*intrv1.GetStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.GetStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockInteractiveServiceServerMockRecorder) GetStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockInteractiveServiceServer)(nil).GetStats), arg0, arg1)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveServiceServer) IncrReadCnt(arg0 context.Context, arg1 *intrv1.IncrReadCntRequest) (*intrv1.IncrReadCntResponse, error) {
 	m.ctrl.T.Helper()
@@ -274,6 +384,36 @@ func (mr *MockInteractiveServiceServerMockRecorder) Like(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveServiceServer)(nil).Like), arg0, arg1)
 }
 
+// ListStats mocks base method.
+func (m *MockInteractiveServiceServer) ListStats(arg0 context.Context, arg1 *intrv1.ListStatsRequest) (*intrv1.ListStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStats", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.ListStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStats indicates an expected call of ListStats.
+func (mr *MockInteractiveServiceServerMockRecorder) ListStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStats", reflect.TypeOf((*MockInteractiveServiceServer)(nil).ListStats), arg0, arg1)
+}
+
+// TopStats mocks base method.
+func (m *MockInteractiveServiceServer) TopStats(arg0 context.Context, arg1 *intrv1.TopStatsRequest) (*intrv1.TopStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopStats", arg0, arg1)
+	ret0, _ := ret[0].(*intrv1.TopStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopStats indicates an expected call of TopStats.
+func (mr *MockInteractiveServiceServerMockRecorder) TopStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopStats", reflect.TypeOf((*MockInteractiveServiceServer)(nil).TopStats), arg0, arg1)
+}
+
 // mustEmbedUnimplementedInteractiveServiceServer mocks base method.
 func (m *MockInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {
 	m.ctrl.T.Helper()
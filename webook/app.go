@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/TengFeiyang01/webook/webook/interactive/events"
+	"github.com/TengFeiyang01/webook/webook/internal/job"
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron/v3"
 )
@@ -10,4 +11,6 @@ type App struct {
 	Server    *gin.Engine
 	Consumers []events.Consumer
 	cron      *cron.Cron
+	// scheduler 基于 MySQL 的分布式任务调度，比如定时发表
+	scheduler *job.Schedule
 }
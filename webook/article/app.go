@@ -1,9 +1,19 @@
 package main
 
 import (
+	"github.com/TengFeiyang01/webook/webook/article/events"
+	"github.com/TengFeiyang01/webook/webook/article/ioc"
+	"github.com/TengFeiyang01/webook/webook/article/service"
 	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
 )
 
 type App struct {
 	server *grpcx.Server
+	relay  *events.OutboxRelay
+	// exporter 在后台执行导出任务
+	exporter *service.Exporter
+	// importer 在后台执行导入任务
+	importer *service.Importer
+	// blobServers 附件和导出用本地存储的时候才有
+	blobServers ioc.LocalBlobServers
 }
@@ -10,6 +10,12 @@ const (
 	ArticleStatusUnPublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusScheduled 定时发表，到了 PublishAt 才会真的发表
+	ArticleStatusScheduled
+	// ArticleStatusPendingReview 开启了审核之后，发表的文章要先等审核通过
+	ArticleStatusPendingReview
+	// ArticleStatusRejected 审核没有通过，作者修改之后可以重新发表
+	ArticleStatusRejected
 )
 
 // Article 可以同时表达线上库和制作库的概念吗？
@@ -20,17 +26,56 @@ type Article struct {
 	Content string        `json:"content" json:"content,omitempty"`
 	Author  Author        `json:"author" json:"author"`
 	Status  ArticleStatus `json:"status" json:"status"`
-	Ctime   time.Time     `json:"ctime,omitempty"`
-	Utime   time.Time     `json:"utime,omitempty"`
+	// PublishAt 定时发表的时间，只有 ArticleStatusScheduled 的时候才有意义
+	PublishAt time.Time `json:"publish_at,omitempty"`
+	// Tags 标签，编辑的时候整体覆盖
+	Tags []string `json:"tags,omitempty"`
+	// Version 制作库的版本号，用于乐观锁，0 表示不检查
+	Version int64     `json:"version,omitempty"`
+	Ctime   time.Time `json:"ctime,omitempty"`
+	Utime   time.Time `json:"utime,omitempty"`
+	// DeletedAt 移进回收站的时间，零值表示没有删除
+	DeletedAt time.Time `json:"deleted_at,omitempty"`
+	// Rendered Content 按照 Markdown 渲染之后的结果，不落库，只跟着文章一起进缓存
+	Rendered RenderedContent `json:"rendered,omitempty"`
+	// RevisionKind 不落库，保存的时候不是 RevisionKindUnknown 就在同一个事务里面记一个历史版本
+	RevisionKind RevisionKind `json:"-"`
 }
 
+// RenderedContent 是从 Content 派生出来的，Content 变了就要重新渲染
+type RenderedContent struct {
+	// HTML 已经清洗过，可以直接展示
+	HTML string `json:"html,omitempty"`
+	// Abstract 纯文本的摘要
+	Abstract    string        `json:"abstract,omitempty"`
+	ReadingTime time.Duration `json:"reading_time,omitempty"`
+}
+
+// NextVersion 保存成功之后的版本号
+// 新建的文章是 1，不检查版本号的更新不知道最新的版本号是多少，返回 0
+func (a Article) NextVersion() int64 {
+	if a.Id == 0 {
+		return 1
+	}
+	if a.Version == 0 {
+		return 0
+	}
+	return a.Version + 1
+}
+
+// MaxAbstractLength 摘要的最大长度，按字符算
+const MaxAbstractLength = 100
+
 func (a Article) Abstract() string {
-	// 摘要我们取前几句。
+	if a.Rendered.Abstract != "" {
+		return a.Rendered.Abstract
+	}
+	// 没有渲染过就退化成直接截取原文，注意要按字符截取
 	cs := []rune(a.Content)
-	if len(cs) < 100 {
+	if len(cs) <= MaxAbstractLength {
 		return a.Content
 	}
-	return a.Content[:100]
+	return string(cs[:MaxAbstractLength])
 }
 
 func (s ArticleStatus) ToUint8() uint8 {
@@ -49,6 +94,12 @@ func (s ArticleStatus) String() string {
 		return "Published"
 	case ArticleStatusPrivate:
 		return "Private"
+	case ArticleStatusScheduled:
+		return "Scheduled"
+	case ArticleStatusPendingReview:
+		return "PendingReview"
+	case ArticleStatusRejected:
+		return "Rejected"
 	default:
 		return "Unknown"
 	}
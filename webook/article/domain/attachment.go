@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+const (
+	// MaxAttachmentSize 单个附件最大 10MB
+	MaxAttachmentSize = 10 << 20
+	// AttachmentQuota 每个作者所有附件加起来最多 1GB，还没有上传完成的也算
+	AttachmentQuota = 1 << 30
+)
+
+// AttachmentTypes 允许上传的类型和对应的扩展名
+// 不允许 SVG，里面可以带脚本
+var AttachmentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type AttachmentStatus uint8
+
+const (
+	AttachmentStatusUnknown AttachmentStatus = iota
+	// AttachmentStatusPending 已经生成了上传 URL，客户端还没有确认上传完成
+	AttachmentStatusPending
+	AttachmentStatusUploaded
+)
+
+func (s AttachmentStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+// Attachment 文章里面的图片等附件，客户端拿着预签名的 URL 直接上传到对象存储
+// 没有被任何历史版本引用的附件会被定时任务清理掉
+type Attachment struct {
+	Id     int64  `json:"id"`
+	Author Author `json:"author"`
+	// Key 对象存储里面的 key
+	Key string `json:"key"`
+	// Filename 用户上传的时候的文件名，只用来展示
+	Filename    string           `json:"filename"`
+	ContentType string           `json:"content_type"`
+	Size        int64            `json:"size"`
+	Status      AttachmentStatus `json:"status"`
+	// URL 访问地址，插入到文章里面的就是这个
+	URL   string    `json:"url"`
+	Ctime time.Time `json:"ctime"`
+	Utime time.Time `json:"utime"`
+}
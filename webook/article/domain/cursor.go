@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("非法的游标")
+
+// Cursor 按照 (Utime, Id) 倒序翻页的游标，指向上一页的最后一条
+// 对外暴露的时候是编码之后的字符串，调用方不应该解析它
+type Cursor struct {
+	Utime time.Time
+	Id    int64
+}
+
+// NewCursor 用文章构造游标，下一页从这篇文章之后开始
+func NewCursor(art Article) Cursor {
+	return Cursor{Utime: art.Utime, Id: art.Id}
+}
+
+func (c Cursor) IsZero() bool {
+	return c.Utime.IsZero() && c.Id == 0
+}
+
+// Encode 零值编码成空字符串，表示第一页或者没有下一页
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := fmt.Sprintf("%d:%d", c.Utime.UnixMilli(), c.Id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor 是 Encode 的逆过程，空字符串解析成零值
+func ParseCursor(s string) (Cursor, error) {
+	if s == "" {
+		return Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var utime, id int64
+	n, err := fmt.Sscanf(string(raw), "%d:%d", &utime, &id)
+	if err != nil || n != 2 || utime <= 0 || id <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{Utime: time.UnixMilli(utime), Id: id}, nil
+}
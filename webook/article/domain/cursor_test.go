@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cursor  Cursor
+		wantStr bool
+	}{
+		{
+			name:   "零值",
+			cursor: Cursor{},
+		},
+		{
+			name:    "正常游标",
+			cursor:  Cursor{Utime: time.UnixMilli(1700000000123), Id: 123},
+			wantStr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := tc.cursor.Encode()
+			assert.Equal(t, tc.wantStr, s != "")
+			c, err := ParseCursor(s)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.cursor.Id, c.Id)
+			assert.Equal(t, tc.cursor.Utime.UnixMilli(), c.Utime.UnixMilli())
+			assert.Equal(t, tc.cursor.IsZero(), c.IsZero())
+		})
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	testCases := []string{
+		"不是base64",
+		base64.RawURLEncoding.EncodeToString([]byte("abc")),
+		base64.RawURLEncoding.EncodeToString([]byte("123")),
+		base64.RawURLEncoding.EncodeToString([]byte("-1:2")),
+		base64.RawURLEncoding.EncodeToString([]byte("1:0")),
+	}
+	for _, s := range testCases {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseCursor(s)
+			assert.Equal(t, ErrInvalidCursor, err)
+		})
+	}
+}
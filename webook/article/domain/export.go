@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+type ExportStatus uint8
+
+const (
+	ExportStatusUnknown ExportStatus = iota
+	// ExportStatusPending 等待后台任务处理
+	ExportStatusPending
+	ExportStatusRunning
+	ExportStatusDone
+	ExportStatusFailed
+)
+
+func (s ExportStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+// Finished 成功或者失败了，作者可以再次发起导出
+func (s ExportStatus) Finished() bool {
+	return s == ExportStatusDone || s == ExportStatusFailed
+}
+
+// Export 把作者所有的文章（草稿和已发表的，不包括回收站）打包成 Markdown 压缩包
+// 在后台异步执行，作者通过 Total 和 Exported 查看进度
+type Export struct {
+	Id     int64
+	Author Author
+	Status ExportStatus
+	// Total 开始执行的时候才知道
+	Total    int
+	Exported int
+	// Size 压缩包的大小，字节数
+	Size int64
+	// Key 压缩包在对象存储里面的 key
+	Key string
+	// URL 有有效期的下载地址，只有成功了才有
+	URL string
+	// Error 失败的原因
+	Error string
+	// Version 每次被后台任务抢占加一，执行的时候用来判断任务还是不是自己的
+	Version int64
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// Filename 下载的时候保存的文件名
+func (e Export) Filename() string {
+	return "webook-" + e.Ctime.Format("20060102-150405") + ".zip"
+}
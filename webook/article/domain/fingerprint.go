@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Fingerprint 已发表文章的 SimHash 指纹，标题和内容一起计算
+type Fingerprint struct {
+	ArticleId int64
+	AuthorId  int64
+	Simhash   uint64
+	// Ctime 第一次发表的时间，重新发表不会变
+	Ctime time.Time
+}
+
+// SimilarArticle 指纹相近的文章，Distance 是海明距离，越小越像
+type SimilarArticle struct {
+	Id       int64
+	AuthorId int64
+	Distance int
+	// Ctime 第一次发表的时间，比较早的那篇一般是原文
+	Ctime time.Time
+}
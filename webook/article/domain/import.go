@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+const (
+	// MaxImportSize 上传的压缩包最大 20MB
+	MaxImportSize = 20 << 20
+	// MaxImportFiles 一次最多导入多少篇
+	MaxImportFiles = 200
+	// MaxImportFileSize 解压之后单篇最大 1MB
+	MaxImportFileSize = 1 << 20
+)
+
+// ImportStatus 和 ExportStatus 的取值一样
+type ImportStatus uint8
+
+const (
+	ImportStatusUnknown ImportStatus = iota
+	// ImportStatusPending 等待后台任务处理
+	ImportStatusPending
+	ImportStatusRunning
+	ImportStatusDone
+	ImportStatusFailed
+)
+
+func (s ImportStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+// Import 把 Markdown 压缩包里面的文章逐篇保存成草稿
+// 在后台异步执行，作者通过 Total 和 Imported 查看进度
+type Import struct {
+	Id     int64
+	Author Author
+	Status ImportStatus
+	// Total 开始执行的时候才知道
+	Total    int
+	Imported int
+	// Key 压缩包在对象存储里面的 key，导入完成之后会删掉
+	Key string
+	// Results 每个文件的结果，某一篇失败了不影响其它的
+	Results []ImportResult
+	// Error 整个任务失败的原因，比如压缩包读不出来
+	Error string
+	// Version 每次被后台任务抢占加一，执行的时候用来判断任务还是不是自己的
+	Version int64
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// ImportResult 一个文件的导入结果，Id 和 Error 只会有一个
+type ImportResult struct {
+	Filename string
+	Title    string
+	// Id 新建的草稿
+	Id    int64
+	Error string
+}
@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+type ModerationAction uint8
+
+const (
+	ModerationActionUnknown ModerationAction = iota
+	// ModerationActionSubmit 作者发表，进入审核队列
+	ModerationActionSubmit
+	// ModerationActionAutoApprove 自动预审全部通过，直接上线
+	ModerationActionAutoApprove
+	// ModerationActionAutoReject 自动预审没有通过
+	ModerationActionAutoReject
+	ModerationActionApprove
+	ModerationActionReject
+	// ModerationActionFlagDuplicate 发表之后发现和别的文章几乎一样，提醒审核人看一下
+	// 文章不会下线，状态也不会变
+	ModerationActionFlagDuplicate
+)
+
+func (a ModerationAction) ToUint8() uint8 {
+	return uint8(a)
+}
+
+func (a ModerationAction) String() string {
+	switch a {
+	case ModerationActionSubmit:
+		return "Submit"
+	case ModerationActionAutoApprove:
+		return "AutoApprove"
+	case ModerationActionAutoReject:
+		return "AutoReject"
+	case ModerationActionApprove:
+		return "Approve"
+	case ModerationActionReject:
+		return "Reject"
+	case ModerationActionFlagDuplicate:
+		return "FlagDuplicate"
+	default:
+		return "Unknown"
+	}
+}
+
+// ModerationLog 审核过程中的每一次状态变化，用于审计，只增不改
+type ModerationLog struct {
+	Id        int64
+	ArticleId int64
+	AuthorId  int64
+	// Reviewer 审核人，0 表示是系统自动处理的
+	Reviewer int64
+	Action   ModerationAction
+	// Status 这一次操作之后文章的状态
+	Status ArticleStatus
+	// Reason 拒绝的原因，或者预审给出的提示
+	Reason string
+	Ctime  time.Time
+}
+
+// ModerationDecision 按照严重程度排序，多个预审的结果取最严重的那个
+type ModerationDecision uint8
+
+const (
+	ModerationDecisionPass ModerationDecision = iota
+	// ModerationDecisionReview 拿不准，交给人工审核
+	ModerationDecisionReview
+	ModerationDecisionReject
+)
+
+// ModerationVerdict 自动预审的结果
+type ModerationVerdict struct {
+	Decision ModerationDecision
+	Reason   string
+}
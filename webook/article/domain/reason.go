@@ -0,0 +1,10 @@
+package domain
+
+// gRPC 的 InvalidArgument 太宽泛了，拒绝请求的具体原因放在错误详情里面，
+// 调用方根据原因给用户不同的提示
+const (
+	// ReasonSensitiveContent 标题或者内容里面有直接拒绝的违禁词
+	ReasonSensitiveContent = "SENSITIVE_CONTENT"
+	// ReasonTooManyTags 标签数量超过了 MaxTagsPerArticle
+	ReasonTooManyTags = "TOO_MANY_TAGS"
+)
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+type RevisionKind uint8
+
+const (
+	RevisionKindUnknown RevisionKind = iota
+	// RevisionKindSave 保存草稿
+	RevisionKindSave
+	// RevisionKindPublish 发表
+	RevisionKindPublish
+	// RevisionKindRestore 从历史版本恢复
+	RevisionKindRestore
+)
+
+func (k RevisionKind) ToUint8() uint8 {
+	return uint8(k)
+}
+
+func (k RevisionKind) String() string {
+	switch k {
+	case RevisionKindSave:
+		return "Save"
+	case RevisionKindPublish:
+		return "Publish"
+	case RevisionKindRestore:
+		return "Restore"
+	default:
+		return "Unknown"
+	}
+}
+
+// ArticleRevision 文章的一个历史版本，一旦写入就不会再修改
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	AuthorId  int64
+	// Version 同一篇文章内部递增的版本号，从 1 开始
+	Version int64
+	Title   string
+	Content string
+	Kind    RevisionKind
+	Ctime   time.Time
+}
+
+// RevisionDiff 两个版本之间的差异
+type RevisionDiff struct {
+	From  ArticleRevision
+	To    ArticleRevision
+	Lines []DiffLine
+}
+
+type DiffOp uint8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpInsert
+	DiffOpDelete
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
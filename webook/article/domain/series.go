@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// MaxArticlesPerSeries 一个系列最多可以包含多少篇文章
+const MaxArticlesPerSeries = 200
+
+// Series 系列，作者把多篇文章按照顺序组织起来，比如分成多篇的教程
+// 一篇文章最多只能属于一个系列
+type Series struct {
+	Id          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Author      Author `json:"author"`
+	// ArticleIds 按照阅读顺序排列
+	ArticleIds []int64   `json:"article_ids"`
+	Ctime      time.Time `json:"ctime"`
+	Utime      time.Time `json:"utime"`
+}
+
+// Position 文章在系列中的位置，不在系列里面返回 -1
+func (s Series) Position(artId int64) int {
+	for i, id := range s.ArticleIds {
+		if id == artId {
+			return i
+		}
+	}
+	return -1
+}
+
+// SeriesNav 文章详情页上的系列导航
+// 只会导航到已经发表的文章，没有上一篇或者下一篇的话对应的字段是零值
+type SeriesNav struct {
+	Series Series
+	Prev   Article
+	Next   Article
+}
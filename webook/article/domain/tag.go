@@ -0,0 +1,32 @@
+package domain
+
+import "strings"
+
+const (
+	// MaxTagsPerArticle 一篇文章最多可以打多少个标签
+	MaxTagsPerArticle = 10
+	// MaxTagLength 单个标签的最大长度，按字符算
+	MaxTagLength = 32
+)
+
+// NormalizeTags 去掉首尾空白、空标签和重复标签，保持原有的顺序
+// 超长的标签会被截断
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if rs := []rune(t); len(rs) > MaxTagLength {
+			t = strings.TrimSpace(string(rs[:MaxTagLength]))
+		}
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		res = append(res, t)
+	}
+	return res
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	testCases := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "没有标签",
+			want: []string{},
+		},
+		{
+			name: "去掉空白和空标签",
+			tags: []string{" Go ", "", "  ", "MySQL"},
+			want: []string{"Go", "MySQL"},
+		},
+		{
+			name: "去重并且保持顺序",
+			tags: []string{"Redis", "Go", "Redis", " Go"},
+			want: []string{"Redis", "Go"},
+		},
+		{
+			name: "超长的标签按字符截断",
+			tags: []string{strings.Repeat("标", MaxTagLength+5)},
+			want: []string{strings.Repeat("标", MaxTagLength)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, NormalizeTags(tc.tags))
+		})
+	}
+}
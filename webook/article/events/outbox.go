@@ -0,0 +1,180 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+)
+
+// OutboxProducer 阅读事件（包括批量的）先写 outbox，由 OutboxRelay 投递
+// Kafka 不可用的时候事件也不会丢，其它事件直接发
+type OutboxProducer struct {
+	Producer
+	dao dao.OutboxDAO
+}
+
+func NewOutboxProducer(pc sarama.SyncProducer, d dao.OutboxDAO) Producer {
+	return &OutboxProducer{
+		Producer: NewKafkaProducer(pc),
+		dao:      d,
+	}
+}
+
+func (o *OutboxProducer) ProduceReadEvent(ctx context.Context, event ReadEvent) error {
+	return o.insert(ctx, dao.TopicReadArticle, event)
+}
+
+func (o *OutboxProducer) ProduceReadEventV1(ctx context.Context, event ReadEventV1) error {
+	return o.insert(ctx, dao.TopicReadArticle, event)
+}
+
+func (o *OutboxProducer) insert(ctx context.Context, topic string, event any) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return o.dao.Insert(ctx, dao.OutboxMessage{
+		Topic:   topic,
+		Payload: data,
+	})
+}
+
+const (
+	relayResultSent  = "sent"
+	relayResultRetry = "retry"
+	relayResultDead  = "dead"
+)
+
+// OutboxRelay 把 outbox 里面的消息投递到 Kafka
+// 消息先认领再投递，多个实例一起跑也不会拿到同一批消息
+// 投递成功但是删除失败的话，租约到期之后会重复投递，所以是至少一次，消费者要做幂等
+type OutboxRelay struct {
+	dao      dao.OutboxDAO
+	producer sarama.SyncProducer
+	l        logger.LoggerV1
+
+	batchSize int
+	// lease 认领的消息多久之内要投递完，超过了别的实例可以重新认领
+	lease time.Duration
+	// interval 没有消息的时候隔多久再查一次
+	interval   time.Duration
+	maxRetries int
+	// 第 n 次重试等待 baseBackoff * 2^(n-1)，最多等待 maxBackoff
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	counter *prometheus.CounterVec
+}
+
+func NewOutboxRelay(d dao.OutboxDAO, producer sarama.SyncProducer, l logger.LoggerV1) *OutboxRelay {
+	r := newOutboxRelay(d, producer, l)
+	prometheus.MustRegister(r.counter)
+	return r
+}
+
+func newOutboxRelay(d dao.OutboxDAO, producer sarama.SyncProducer, l logger.LoggerV1) *OutboxRelay {
+	return &OutboxRelay{
+		dao:         d,
+		producer:    producer,
+		l:           l,
+		batchSize:   100,
+		lease:       time.Minute,
+		interval:    time.Second,
+		maxRetries:  10,
+		baseBackoff: time.Second,
+		maxBackoff:  time.Minute * 10,
+		counter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ytf",
+			Subsystem: "webook",
+			Name:      "article_outbox_relay",
+			Help:      "统计 outbox 消息的投递结果",
+		}, []string{"topic", "result"}),
+	}
+}
+
+// Start 一直运行到 ctx 被取消
+func (r *OutboxRelay) Start(ctx context.Context) {
+	for {
+		cnt, err := r.relay(ctx)
+		if err != nil {
+			r.l.Error("查询 outbox 失败", logger.Error(err))
+		}
+		if cnt >= r.batchSize {
+			// 还有积压，马上处理下一批
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// relay 投递一批，返回这一批的数量
+func (r *OutboxRelay) relay(ctx context.Context) (int, error) {
+	msgs, err := r.dao.Claim(ctx, time.Now().UnixMilli(), r.lease, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range msgs {
+		if ctx.Err() != nil {
+			return len(msgs), ctx.Err()
+		}
+		r.deliver(ctx, msg)
+	}
+	return len(msgs), nil
+}
+
+func (r *OutboxRelay) deliver(ctx context.Context, msg dao.OutboxMessage) {
+	pm := &sarama.ProducerMessage{
+		Topic: msg.Topic,
+		Value: sarama.ByteEncoder(msg.Payload),
+	}
+	if msg.Key != "" {
+		pm.Key = sarama.StringEncoder(msg.Key)
+	}
+	_, _, err := r.producer.SendMessage(pm)
+	if err == nil {
+		r.counter.WithLabelValues(msg.Topic, relayResultSent).Inc()
+		if err = r.dao.Delete(ctx, msg.Id); err != nil {
+			// 下一轮会重复投递
+			r.l.Error("删除已经投递的 outbox 消息失败",
+				logger.Int64("id", msg.Id), logger.Error(err))
+		}
+		return
+	}
+	retries := msg.Retries + 1
+	r.l.Error("投递 outbox 消息失败",
+		logger.Int64("id", msg.Id),
+		logger.String("topic", msg.Topic),
+		logger.Int64("retries", int64(retries)),
+		logger.Error(err))
+	if retries >= r.maxRetries {
+		r.counter.WithLabelValues(msg.Topic, relayResultDead).Inc()
+		err = r.dao.MarkDead(ctx, msg.Id, retries)
+	} else {
+		r.counter.WithLabelValues(msg.Topic, relayResultRetry).Inc()
+		next := time.Now().Add(r.backoff(retries)).UnixMilli()
+		err = r.dao.Retry(ctx, msg.Id, retries, next)
+	}
+	if err != nil {
+		r.l.Error("更新 outbox 消息失败", logger.Int64("id", msg.Id), logger.Error(err))
+	}
+}
+
+func (r *OutboxRelay) backoff(retries int) time.Duration {
+	d := r.baseBackoff
+	for i := 1; i < retries && d < r.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.maxBackoff {
+		d = r.maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,118 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	saramamocks "github.com/IBM/sarama/mocks"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	daomocks "github.com/TengFeiyang01/webook/webook/article/repository/dao/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller, p *saramamocks.SyncProducer) dao.OutboxDAO
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "投递成功就删除",
+			mock: func(ctrl *gomock.Controller, p *saramamocks.SyncProducer) dao.OutboxDAO {
+				d := daomocks.NewMockOutboxDAO(ctrl)
+				d.EXPECT().Claim(gomock.Any(), gomock.Any(), time.Minute, 100).
+					Return([]dao.OutboxMessage{
+						{Id: 1, Topic: dao.TopicArticlePublished, Key: "12", Payload: []byte(`{"id":12}`)},
+						{Id: 2, Topic: dao.TopicReadArticle, Payload: []byte(`{"Uid":1,"Aid":12}`)},
+					}, nil)
+				p.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+					key, _ := msg.Key.Encode()
+					assert.Equal(t, "12", string(key))
+					return nil
+				})
+				p.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+					assert.Nil(t, msg.Key)
+					return nil
+				})
+				d.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+				d.EXPECT().Delete(gomock.Any(), int64(2)).Return(nil)
+				return d
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "投递失败等待重试",
+			mock: func(ctrl *gomock.Controller, p *saramamocks.SyncProducer) dao.OutboxDAO {
+				d := daomocks.NewMockOutboxDAO(ctrl)
+				d.EXPECT().Claim(gomock.Any(), gomock.Any(), time.Minute, 100).
+					Return([]dao.OutboxMessage{
+						{Id: 1, Topic: dao.TopicArticleWithdrawn, Key: "12", Retries: 2},
+					}, nil)
+				p.ExpectSendMessageAndFail(errors.New("kafka 挂了"))
+				now := time.Now()
+				d.EXPECT().Retry(gomock.Any(), int64(1), 3, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id int64, retries int, next int64) error {
+						// 第三次重试等待 4 秒
+						assert.InDelta(t, now.Add(time.Second*4).UnixMilli(), next, 1000)
+						return nil
+					})
+				return d
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "重试次数用完",
+			mock: func(ctrl *gomock.Controller, p *saramamocks.SyncProducer) dao.OutboxDAO {
+				d := daomocks.NewMockOutboxDAO(ctrl)
+				d.EXPECT().Claim(gomock.Any(), gomock.Any(), time.Minute, 100).
+					Return([]dao.OutboxMessage{
+						{Id: 1, Topic: dao.TopicArticleWithdrawn, Retries: 9},
+					}, nil)
+				p.ExpectSendMessageAndFail(errors.New("kafka 挂了"))
+				d.EXPECT().MarkDead(gomock.Any(), int64(1), 10).Return(nil)
+				return d
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller, p *saramamocks.SyncProducer) dao.OutboxDAO {
+				d := daomocks.NewMockOutboxDAO(ctrl)
+				d.EXPECT().Claim(gomock.Any(), gomock.Any(), time.Minute, 100).
+					Return(nil, errors.New("db 错误"))
+				return d
+			},
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			p := saramamocks.NewSyncProducer(t, nil)
+			defer func() {
+				require.NoError(t, p.Close())
+			}()
+			r := newOutboxRelay(tc.mock(ctrl, p), p, logger.NewNopLogger())
+			cnt, err := r.relay(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	r := newOutboxRelay(nil, nil, logger.NewNopLogger())
+	assert.Equal(t, time.Second, r.backoff(1))
+	assert.Equal(t, time.Second*2, r.backoff(2))
+	assert.Equal(t, time.Second*256, r.backoff(9))
+	assert.Equal(t, time.Minute*10, r.backoff(20))
+}
@@ -15,9 +15,18 @@ type KafkaProducer struct {
 	producer sarama.SyncProducer
 }
 
+// ProduceReadEventV1 批量的阅读事件和 ReadEvent 共用一个 topic
+// 消费者在兼容期内两种格式都能处理
 func (k *KafkaProducer) ProduceReadEventV1(ctx context.Context, event ReadEventV1) error {
-	//TODO implement me
-	panic("implement me")
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: "read_article",
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
 }
 
 // ProduceReadEvent 如果你有很复杂的重试逻辑, 就用装饰器
@@ -44,6 +53,7 @@ type ReadEvent struct {
 	Aid int64
 }
 
+// ReadEventV1 批量的阅读事件，Uid 和 Aid 一一对应
 type ReadEventV1 struct {
 	Uid []int64
 	Aid []int64
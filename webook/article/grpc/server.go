@@ -1,12 +1,16 @@
 package grpc
 
 import (
+	"errors"
 	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
 	"github.com/TengFeiyang01/webook/webook/article/service"
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
 	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"time"
 )
 
@@ -24,8 +28,18 @@ func (a *ArticleServiceServer) Register(server *grpc.Server) {
 }
 
 func (a *ArticleServiceServer) Save(ctx context.Context, request *artv1.SaveRequest) (*artv1.SaveResponse, error) {
-	id, err := a.svc.Save(ctx, a.toPB(request.GetArt()))
-	return &artv1.SaveResponse{Id: id}, err
+	art := a.toPB(request.GetArt())
+	id, err := a.svc.Save(ctx, art)
+	if errors.Is(err, service.ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, service.ErrSensitiveContent) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonSensitiveContent, err.Error())
+	}
+	if errors.Is(err, service.ErrTooManyTags) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonTooManyTags, err.Error())
+	}
+	return &artv1.SaveResponse{Id: id, Version: art.NextVersion()}, err
 }
 
 func (a *ArticleServiceServer) WithDraw(ctx context.Context, request *artv1.WithDrawRequest) (*artv1.WithDrawResponse, error) {
@@ -35,39 +49,261 @@ func (a *ArticleServiceServer) WithDraw(ctx context.Context, request *artv1.With
 
 func (a *ArticleServiceServer) Publish(ctx context.Context, request *artv1.PublishRequest) (*artv1.PublishResponse, error) {
 	id, err := a.svc.Publish(ctx, a.toPB(request.GetArt()))
+	if errors.Is(err, service.ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, service.ErrSensitiveContent) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonSensitiveContent, err.Error())
+	}
+	if errors.Is(err, service.ErrTooManyTags) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonTooManyTags, err.Error())
+	}
 	return &artv1.PublishResponse{Id: id}, err
 }
 
 func (a *ArticleServiceServer) List(ctx context.Context, request *artv1.ListRequest) (*artv1.ListResponse, error) {
-	arts, err := a.svc.List(ctx, request.GetId(), int(request.GetOffset()), int(request.GetLimit()))
+	var (
+		arts []domain.Article
+		next string
+		err  error
+	)
+	// 老的调用方还在用 offset 翻页
+	if request.GetCursor() == "" && request.GetOffset() > 0 {
+		arts, err = a.svc.List(ctx, request.GetId(), int(request.GetOffset()), int(request.GetLimit()))
+	} else {
+		arts, next, err = a.svc.ListByCursor(ctx, request.GetId(), request.GetCursor(), int(request.GetLimit()))
+	}
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	return &artv1.ListResponse{
 		Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
 			return a.toDTO(arts[idx])
 		}),
+		NextCursor: next,
 	}, err
 }
 
 func (a *ArticleServiceServer) ListPub(ctx context.Context, request *artv1.ListPubRequest) (*artv1.ListPubResponse, error) {
-	arts, err := a.svc.ListPub(ctx, time.UnixMilli(request.GetTimestamp()), int(request.GetOffset()), int(request.GetLimit()))
+	var (
+		arts  []domain.Article
+		next  string
+		err   error
+		start = time.UnixMilli(request.GetTimestamp())
+	)
+	if request.GetCursor() == "" && request.GetOffset() > 0 {
+		arts, err = a.svc.ListPub(ctx, start, int(request.GetOffset()), int(request.GetLimit()))
+	} else {
+		arts, next, err = a.svc.ListPubByCursor(ctx, start, request.GetCursor(), int(request.GetLimit()))
+	}
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	return &artv1.ListPubResponse{
 		Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
 			return a.toDTO(arts[idx])
 		}),
+		NextCursor: next,
 	}, err
 }
 
+func (a *ArticleServiceServer) ListPubByTag(ctx context.Context, request *artv1.ListPubByTagRequest) (*artv1.ListPubByTagResponse, error) {
+	arts, err := a.svc.ListPubByTag(ctx, request.GetTag(), time.UnixMilli(request.GetTimestamp()), int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListPubByTagResponse{
+		Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+			return a.toDTO(arts[idx])
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) ListPubByAuthor(ctx context.Context, request *artv1.ListPubByAuthorRequest) (*artv1.ListPubByAuthorResponse, error) {
+	arts, err := a.svc.ListPubByAuthor(ctx, request.GetAuthorId(), time.UnixMilli(request.GetTimestamp()),
+		int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListPubByAuthorResponse{Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+		return a.toDTO(src)
+	})}, err
+}
+
 func (a *ArticleServiceServer) GetById(ctx context.Context, request *artv1.GetByIdRequest) (*artv1.GetByIdResponse, error) {
 	art, err := a.svc.GetById(ctx, request.GetId())
-	return &artv1.GetByIdResponse{Art: a.toDTO(art)}, err
+	// 文章不存在的时候返回 NOT_FOUND，评论服务要靠它区分文章不存在和系统错误
+	return &artv1.GetByIdResponse{Art: a.toDTO(art)}, a.trashErr(err)
 }
 
 func (a *ArticleServiceServer) GetPubById(ctx context.Context, request *artv1.GetPubByIdRequest) (*artv1.GetPubByIdResponse, error) {
+	if request.GetSkipRead() {
+		art, err := a.svc.LookupPublished(ctx, request.GetId())
+		return &artv1.GetPubByIdResponse{Art: a.toDTO(art)}, a.trashErr(err)
+	}
 	art, err := a.svc.GetPublishedById(ctx, request.GetId(), request.GetUid())
 	return &artv1.GetPubByIdResponse{Art: a.toDTO(art)}, err
 }
 
+func (a *ArticleServiceServer) ListRevisions(ctx context.Context, request *artv1.ListRevisionsRequest) (*artv1.ListRevisionsResponse, error) {
+	revs, err := a.svc.ListRevisions(ctx, request.GetUid(), request.GetArticleId(),
+		int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListRevisionsResponse{
+		Revisions: slice.Map(revs, func(idx int, src domain.ArticleRevision) *artv1.ArticleRevision {
+			return a.toRevisionDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) GetRevision(ctx context.Context, request *artv1.GetRevisionRequest) (*artv1.GetRevisionResponse, error) {
+	rev, err := a.svc.GetRevision(ctx, request.GetUid(), request.GetId())
+	return &artv1.GetRevisionResponse{Revision: a.toRevisionDTO(rev)}, err
+}
+
+func (a *ArticleServiceServer) DiffRevisions(ctx context.Context, request *artv1.DiffRevisionsRequest) (*artv1.DiffRevisionsResponse, error) {
+	res, err := a.svc.DiffRevisions(ctx, request.GetUid(), request.GetFrom(), request.GetTo())
+	return &artv1.DiffRevisionsResponse{
+		From: a.toRevisionDTO(res.From),
+		To:   a.toRevisionDTO(res.To),
+		Lines: slice.Map(res.Lines, func(idx int, src domain.DiffLine) *artv1.DiffLine {
+			return &artv1.DiffLine{Op: artv1.DiffOp(src.Op), Text: src.Text}
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) RestoreRevision(ctx context.Context, request *artv1.RestoreRevisionRequest) (*artv1.RestoreRevisionResponse, error) {
+	id, err := a.svc.RestoreRevision(ctx, request.GetUid(), request.GetId())
+	return &artv1.RestoreRevisionResponse{Id: id}, err
+}
+
+func (a *ArticleServiceServer) SchedulePublish(ctx context.Context, request *artv1.SchedulePublishRequest) (*artv1.SchedulePublishResponse, error) {
+	id, err := a.svc.SchedulePublish(ctx, a.toPB(request.GetArt()))
+	if errors.Is(err, service.ErrSensitiveContent) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonSensitiveContent, err.Error())
+	}
+	if errors.Is(err, service.ErrTooManyTags) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonTooManyTags, err.Error())
+	}
+	return &artv1.SchedulePublishResponse{Id: id}, err
+}
+
+func (a *ArticleServiceServer) CancelSchedule(ctx context.Context, request *artv1.CancelScheduleRequest) (*artv1.CancelScheduleResponse, error) {
+	err := a.svc.CancelSchedule(ctx, request.GetUid(), request.GetId())
+	return &artv1.CancelScheduleResponse{}, err
+}
+
+func (a *ArticleServiceServer) Delete(ctx context.Context, request *artv1.DeleteRequest) (*artv1.DeleteResponse, error) {
+	err := a.svc.Delete(ctx, request.GetUid(), request.GetId())
+	return &artv1.DeleteResponse{}, a.trashErr(err)
+}
+
+func (a *ArticleServiceServer) ListTrash(ctx context.Context, request *artv1.ListTrashRequest) (*artv1.ListTrashResponse, error) {
+	arts, err := a.svc.ListTrash(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListTrashResponse{
+		Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+			return a.toDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) Restore(ctx context.Context, request *artv1.RestoreRequest) (*artv1.RestoreResponse, error) {
+	err := a.svc.Restore(ctx, request.GetUid(), request.GetId())
+	return &artv1.RestoreResponse{}, a.trashErr(err)
+}
+
+func (a *ArticleServiceServer) Purge(ctx context.Context, request *artv1.PurgeRequest) (*artv1.PurgeResponse, error) {
+	err := a.svc.Purge(ctx, request.GetUid(), request.GetId())
+	return &artv1.PurgeResponse{}, a.trashErr(err)
+}
+
+func (a *ArticleServiceServer) PurgeExpired(ctx context.Context, request *artv1.PurgeExpiredRequest) (*artv1.PurgeExpiredResponse, error) {
+	ids, err := a.svc.PurgeExpired(ctx, time.UnixMilli(request.GetBefore()), int(request.GetLimit()))
+	return &artv1.PurgeExpiredResponse{Ids: ids}, err
+}
+
+func (a *ArticleServiceServer) trashErr(err error) error {
+	if errors.Is(err, service.ErrArticleNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceServer) CreateSeries(ctx context.Context, request *artv1.CreateSeriesRequest) (*artv1.CreateSeriesResponse, error) {
+	s := request.GetSeries()
+	id, err := a.svc.CreateSeries(ctx, domain.Series{
+		Title:       s.GetTitle(),
+		Description: s.GetDescription(),
+		Author:      domain.Author{Id: s.GetAuthorId()},
+		ArticleIds:  s.GetArticleIds(),
+	})
+	return &artv1.CreateSeriesResponse{Id: id}, a.seriesErr(err)
+}
+
+func (a *ArticleServiceServer) ReorderSeries(ctx context.Context, request *artv1.ReorderSeriesRequest) (*artv1.ReorderSeriesResponse, error) {
+	err := a.svc.ReorderSeries(ctx, request.GetUid(), request.GetId(), request.GetArticleIds())
+	return &artv1.ReorderSeriesResponse{}, a.seriesErr(err)
+}
+
+func (a *ArticleServiceServer) ListSeries(ctx context.Context, request *artv1.ListSeriesRequest) (*artv1.ListSeriesResponse, error) {
+	res, err := a.svc.ListSeries(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListSeriesResponse{
+		Series: slice.Map(res, func(idx int, src domain.Series) *artv1.Series {
+			return a.toSeriesDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) GetSeriesNav(ctx context.Context, request *artv1.GetSeriesNavRequest) (*artv1.GetSeriesNavResponse, error) {
+	nav, err := a.svc.GetSeriesNav(ctx, request.GetArtId())
+	if errors.Is(err, service.ErrSeriesNotFound) {
+		return &artv1.GetSeriesNavResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &artv1.GetSeriesNavResponse{Series: a.toSeriesDTO(nav.Series)}
+	if nav.Prev.Id > 0 {
+		res.Prev = &artv1.Article{Id: nav.Prev.Id, Title: nav.Prev.Title}
+	}
+	if nav.Next.Id > 0 {
+		res.Next = &artv1.Article{Id: nav.Next.Id, Title: nav.Next.Title}
+	}
+	return res, nil
+}
+
+func (a *ArticleServiceServer) seriesErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrSeriesNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidSeries),
+		errors.Is(err, service.ErrInvalidSeriesArticles),
+		errors.Is(err, service.ErrArticleInOtherSeries):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceServer) toSeriesDTO(s domain.Series) *artv1.Series {
+	return &artv1.Series{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		AuthorId:    s.Author.Id,
+		ArticleIds:  s.ArticleIds,
+		Ctime:       s.Ctime.UnixMilli(),
+		Utime:       s.Utime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceServer) toRevisionDTO(rev domain.ArticleRevision) *artv1.ArticleRevision {
+	return &artv1.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		AuthorId:  rev.AuthorId,
+		Version:   rev.Version,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Kind:      artv1.RevisionKind(rev.Kind),
+		Ctime:     rev.Ctime.UnixMilli(),
+	}
+}
+
 func (a *ArticleServiceServer) toDTO(art domain.Article) *artv1.Article {
-	return &artv1.Article{
+	res := &artv1.Article{
 		Id:      art.Id,
 		Title:   art.Title,
 		Content: art.Content,
@@ -76,13 +312,25 @@ func (a *ArticleServiceServer) toDTO(art domain.Article) *artv1.Article {
 			Id:   art.Author.Id,
 			Name: art.Author.Name,
 		},
-		Ctime: art.Ctime.UnixMilli(),
-		Utime: art.Utime.UnixMilli(),
+		Tags:           art.Tags,
+		Html:           art.Rendered.HTML,
+		Abstract:       art.Abstract(),
+		ReadingMinutes: int32(art.Rendered.ReadingTime / time.Minute),
+		Version:        art.Version,
+		Ctime:          art.Ctime.UnixMilli(),
+		Utime:          art.Utime.UnixMilli(),
+	}
+	if !art.PublishAt.IsZero() {
+		res.PublishAt = art.PublishAt.UnixMilli()
+	}
+	if !art.DeletedAt.IsZero() {
+		res.DeletedAt = art.DeletedAt.UnixMilli()
 	}
+	return res
 }
 
 func (a *ArticleServiceServer) toPB(art *artv1.Article) domain.Article {
-	return domain.Article{
+	res := domain.Article{
 		Id:      art.Id,
 		Title:   art.Title,
 		Content: art.Content,
@@ -90,8 +338,285 @@ func (a *ArticleServiceServer) toPB(art *artv1.Article) domain.Article {
 			Id:   art.Author.Id,
 			Name: art.Author.Name,
 		},
-		Status: domain.ArticleStatus(uint8(art.Status)),
-		Ctime:  time.UnixMilli(art.Ctime),
-		Utime:  time.UnixMilli(art.Utime),
+		Status:  domain.ArticleStatus(uint8(art.Status)),
+		Tags:    art.Tags,
+		Version: art.Version,
+		Ctime:   time.UnixMilli(art.Ctime),
+		Utime:   time.UnixMilli(art.Utime),
+	}
+	if art.PublishAt > 0 {
+		res.PublishAt = time.UnixMilli(art.PublishAt)
+	}
+	switch {
+	case art.KeepTags:
+		res.Tags = nil
+	case res.Tags == nil:
+		// 没传标签就是清空
+		res.Tags = []string{}
+	}
+	return res
+}
+
+func (a *ArticleServiceServer) CreateUpload(ctx context.Context, request *artv1.CreateUploadRequest) (*artv1.CreateUploadResponse, error) {
+	att, url, err := a.svc.CreateUpload(ctx, domain.Attachment{
+		Author:      domain.Author{Id: request.GetUid()},
+		Filename:    request.GetFilename(),
+		ContentType: request.GetContentType(),
+		Size:        request.GetSize(),
+	})
+	if err != nil {
+		return nil, a.attachmentErr(err)
+	}
+	return &artv1.CreateUploadResponse{
+		Attachment: a.toAttachmentDTO(att),
+		UploadUrl:  url,
+	}, nil
+}
+
+func (a *ArticleServiceServer) CompleteUpload(ctx context.Context, request *artv1.CompleteUploadRequest) (*artv1.CompleteUploadResponse, error) {
+	att, err := a.svc.CompleteUpload(ctx, request.GetUid(), request.GetId())
+	if err != nil {
+		return nil, a.attachmentErr(err)
+	}
+	return &artv1.CompleteUploadResponse{Attachment: a.toAttachmentDTO(att)}, nil
+}
+
+func (a *ArticleServiceServer) ListAttachments(ctx context.Context, request *artv1.ListAttachmentsRequest) (*artv1.ListAttachmentsResponse, error) {
+	res, err := a.svc.ListAttachments(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListAttachmentsResponse{
+		Attachments: slice.Map(res, func(idx int, src domain.Attachment) *artv1.Attachment {
+			return a.toAttachmentDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) DeleteAttachment(ctx context.Context, request *artv1.DeleteAttachmentRequest) (*artv1.DeleteAttachmentResponse, error) {
+	err := a.svc.DeleteAttachment(ctx, request.GetUid(), request.GetId())
+	return &artv1.DeleteAttachmentResponse{}, a.attachmentErr(err)
+}
+
+func (a *ArticleServiceServer) GCAttachments(ctx context.Context, request *artv1.GCAttachmentsRequest) (*artv1.GCAttachmentsResponse, error) {
+	next, deleted, err := a.svc.GCAttachments(ctx, time.UnixMilli(request.GetBefore()), request.GetStartId(), int(request.GetLimit()))
+	return &artv1.GCAttachmentsResponse{NextId: next, Deleted: int32(deleted)}, err
+}
+
+func (a *ArticleServiceServer) attachmentErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed),
+		errors.Is(err, service.ErrAttachmentTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrAttachmentQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrAttachmentNotUploaded):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceServer) toAttachmentDTO(att domain.Attachment) *artv1.Attachment {
+	return &artv1.Attachment{
+		Id:          att.Id,
+		AuthorId:    att.Author.Id,
+		Filename:    att.Filename,
+		ContentType: att.ContentType,
+		Size:        att.Size,
+		Status:      uint32(att.Status),
+		Url:         att.URL,
+		Ctime:       att.Ctime.UnixMilli(),
+		Utime:       att.Utime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceServer) ListPendingReview(ctx context.Context, request *artv1.ListPendingReviewRequest) (*artv1.ListPendingReviewResponse, error) {
+	arts, err := a.svc.ListPendingReview(ctx, int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListPendingReviewResponse{
+		Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+			return a.toDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) Approve(ctx context.Context, request *artv1.ApproveRequest) (*artv1.ApproveResponse, error) {
+	err := a.svc.Approve(ctx, request.GetReviewer(), request.GetId(), request.GetVersion())
+	return &artv1.ApproveResponse{}, a.moderationErr(err)
+}
+
+func (a *ArticleServiceServer) Reject(ctx context.Context, request *artv1.RejectRequest) (*artv1.RejectResponse, error) {
+	err := a.svc.Reject(ctx, request.GetReviewer(), request.GetId(), request.GetVersion(), request.GetReason())
+	return &artv1.RejectResponse{}, a.moderationErr(err)
+}
+
+func (a *ArticleServiceServer) ListModerationLogs(ctx context.Context, request *artv1.ListModerationLogsRequest) (*artv1.ListModerationLogsResponse, error) {
+	logs, err := a.svc.ListModerationLogs(ctx, request.GetArtId(), int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListModerationLogsResponse{
+		Logs: slice.Map(logs, func(idx int, src domain.ModerationLog) *artv1.ModerationLog {
+			return a.toModerationLogDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) moderationErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrArticleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotPendingReview):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrEmptyRejectReason):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceServer) toModerationLogDTO(log domain.ModerationLog) *artv1.ModerationLog {
+	return &artv1.ModerationLog{
+		Id:       log.Id,
+		ArtId:    log.ArticleId,
+		AuthorId: log.AuthorId,
+		Reviewer: log.Reviewer,
+		Action:   uint32(log.Action),
+		Status:   uint32(log.Status),
+		Reason:   log.Reason,
+		Ctime:    log.Ctime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceServer) FindSimilar(ctx context.Context, request *artv1.FindSimilarRequest) (*artv1.FindSimilarResponse, error) {
+	arts, err := a.svc.FindSimilar(ctx, request.GetId(), int(request.GetLimit()))
+	if err != nil {
+		if errors.Is(err, service.ErrFingerprintNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &artv1.FindSimilarResponse{
+		Arts: slice.Map(arts, func(idx int, src domain.SimilarArticle) *artv1.SimilarArticle {
+			return &artv1.SimilarArticle{
+				Id:       src.Id,
+				AuthorId: src.AuthorId,
+				Distance: int32(src.Distance),
+				Ctime:    src.Ctime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (a *ArticleServiceServer) ListFlaggedDuplicates(ctx context.Context, request *artv1.ListFlaggedDuplicatesRequest) (*artv1.ListFlaggedDuplicatesResponse, error) {
+	logs, err := a.svc.ListFlaggedDuplicates(ctx, int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListFlaggedDuplicatesResponse{
+		Logs: slice.Map(logs, func(idx int, src domain.ModerationLog) *artv1.ModerationLog {
+			return a.toModerationLogDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) StartExport(ctx context.Context, request *artv1.StartExportRequest) (*artv1.StartExportResponse, error) {
+	e, err := a.svc.StartExport(ctx, request.GetUid())
+	if err != nil {
+		return nil, a.exportErr(err)
+	}
+	return &artv1.StartExportResponse{Export: a.toExportDTO(e)}, nil
+}
+
+func (a *ArticleServiceServer) GetExport(ctx context.Context, request *artv1.GetExportRequest) (*artv1.GetExportResponse, error) {
+	e, err := a.svc.GetExport(ctx, request.GetUid(), request.GetId())
+	if err != nil {
+		return nil, a.exportErr(err)
+	}
+	return &artv1.GetExportResponse{Export: a.toExportDTO(e)}, nil
+}
+
+func (a *ArticleServiceServer) ListExports(ctx context.Context, request *artv1.ListExportsRequest) (*artv1.ListExportsResponse, error) {
+	res, err := a.svc.ListExports(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListExportsResponse{
+		Exports: slice.Map(res, func(idx int, src domain.Export) *artv1.Export {
+			return a.toExportDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) exportErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrExportNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrExportInProgress):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceServer) toExportDTO(e domain.Export) *artv1.Export {
+	return &artv1.Export{
+		Id:          e.Id,
+		AuthorId:    e.Author.Id,
+		Status:      uint32(e.Status),
+		Total:       int32(e.Total),
+		Exported:    int32(e.Exported),
+		Size:        e.Size,
+		DownloadUrl: e.URL,
+		Error:       e.Error,
+		Ctime:       e.Ctime.UnixMilli(),
+		Utime:       e.Utime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceServer) StartImport(ctx context.Context, request *artv1.StartImportRequest) (*artv1.StartImportResponse, error) {
+	i, err := a.svc.StartImport(ctx, request.GetUid(), request.GetData())
+	if err != nil {
+		return nil, a.importErr(err)
+	}
+	return &artv1.StartImportResponse{Import: a.toImportDTO(i)}, nil
+}
+
+func (a *ArticleServiceServer) GetImport(ctx context.Context, request *artv1.GetImportRequest) (*artv1.GetImportResponse, error) {
+	i, err := a.svc.GetImport(ctx, request.GetUid(), request.GetId())
+	if err != nil {
+		return nil, a.importErr(err)
+	}
+	return &artv1.GetImportResponse{Import: a.toImportDTO(i)}, nil
+}
+
+func (a *ArticleServiceServer) ListImports(ctx context.Context, request *artv1.ListImportsRequest) (*artv1.ListImportsResponse, error) {
+	res, err := a.svc.ListImports(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	return &artv1.ListImportsResponse{
+		Imports: slice.Map(res, func(idx int, src domain.Import) *artv1.Import {
+			return a.toImportDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceServer) importErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidArchive):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrImportNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrImportInProgress):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceServer) toImportDTO(i domain.Import) *artv1.Import {
+	return &artv1.Import{
+		Id:       i.Id,
+		AuthorId: i.Author.Id,
+		Status:   uint32(i.Status),
+		Total:    int32(i.Total),
+		Imported: int32(i.Imported),
+		Results: slice.Map(i.Results, func(idx int, src domain.ImportResult) *artv1.ImportResult {
+			return &artv1.ImportResult{
+				Filename: src.Filename,
+				Title:    src.Title,
+				Id:       src.Id,
+				Error:    src.Error,
+			}
+		}),
+		Error: i.Error,
+		Ctime: i.Ctime.UnixMilli(),
+		Utime: i.Utime.UnixMilli(),
 	}
 }
@@ -0,0 +1,45 @@
+package startup
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+)
+
+// InitAttachmentRepository 测试里面附件放在临时目录，不需要对象存储
+func InitAttachmentRepository(d dao.AttachmentDAO) repository.AttachmentRepository {
+	local, err := blobstore.NewLocalStore(filepath.Join(os.TempDir(), "webook-attachments"))
+	if err != nil {
+		panic(err)
+	}
+	p, err := blobstore.NewLocalPresigner(local, "http://localhost:8088/attachments", []byte("test"))
+	if err != nil {
+		panic(err)
+	}
+	return repository.NewAttachmentRepository(d, p, "http://localhost:8088/attachments")
+}
+
+// InitExportRepository 和附件一样放在临时目录
+func InitExportRepository(d dao.ExportDAO) repository.ExportRepository {
+	local, err := blobstore.NewLocalStore(filepath.Join(os.TempDir(), "webook-exports"))
+	if err != nil {
+		panic(err)
+	}
+	p, err := blobstore.NewPrivateLocalPresigner(local, "http://localhost:8089/exports", []byte("test"))
+	if err != nil {
+		panic(err)
+	}
+	return repository.NewExportRepository(d, p)
+}
+
+// InitImportRepository 和导出放在同一个临时目录
+func InitImportRepository(d dao.ImportDAO) repository.ImportRepository {
+	local, err := blobstore.NewLocalStore(filepath.Join(os.TempDir(), "webook-exports"))
+	if err != nil {
+		panic(err)
+	}
+	return repository.NewImportRepository(d, local)
+}
@@ -0,0 +1,16 @@
+package startup
+
+import (
+	"github.com/TengFeiyang01/webook/webook/article/service"
+	"github.com/TengFeiyang01/webook/webook/pkg/sensitive"
+)
+
+// InitModeration 测试里面默认不开启审核，需要的时候自己构造 service
+func InitModeration() service.Moderation {
+	return service.Moderation{}
+}
+
+// InitSensitiveFilter 测试里面词库是空的，什么都不会命中
+func InitSensitiveFilter() *sensitive.Filter {
+	return sensitive.NewFilter(nil, sensitive.DefaultPolicy())
+}
@@ -29,13 +29,33 @@ var userSvcProviderSet = wire.NewSet(
 
 var articlSvcProvider = wire.NewSet(
 	repository.NewCachedArticleRepository,
+	repository.NewArticleRevisionRepository,
+	repository.NewCachedArticleTagRepository,
+	repository.NewCachedSeriesRepository,
 	dao.NewGORMArticleDAO,
+	dao.NewGORMArticleRevisionDAO,
+	dao.NewGORMTagDAO,
+	dao.NewGORMSeriesDAO,
+	dao.NewGORMOutboxDAO,
+	dao.NewGORMAttachmentDAO,
+	dao.NewGORMModerationDAO,
+	dao.NewGORMExportDAO,
+	dao.NewGORMImportDAO,
+	dao.NewGORMFingerprintDAO,
+	InitAttachmentRepository,
+	InitExportRepository,
+	InitImportRepository,
+	repository.NewModerationRepository,
+	repository.NewFingerprintRepository,
+	InitModeration,
+	InitSensitiveFilter,
 	service.NewArticleService,
-	events.NewKafkaProducer,
+	events.NewOutboxProducer,
 	intrv1.NewInteractiveServiceClient,
-	cache.NewArticleCache)
+	cache.NewArticleCache,
+	cache.NewSeriesCache)
 
 func InitArticleHandler() service.ArticleService {
 	wire.Build(articlSvcProvider, thirdPartySet, userSvcProviderSet)
-	return service.NewArticleService(nil, nil, nil)
+	return service.NewArticleService(nil, nil, nil, nil, nil, nil, nil, nil, nil, service.Moderation{}, nil, nil, nil)
 }
@@ -7,7 +7,6 @@
 package startup
 
 import (
-	"github.com/google/wire"
 	"github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
 	"github.com/TengFeiyang01/webook/webook/article/events"
 	"github.com/TengFeiyang01/webook/webook/article/repository"
@@ -19,6 +18,7 @@ import (
 	dao2 "github.com/TengFeiyang01/webook/webook/internal/repository/dao"
 	service2 "github.com/TengFeiyang01/webook/webook/internal/service"
 	"github.com/TengFeiyang01/webook/webook/ioc"
+	"github.com/google/wire"
 )
 
 // Injectors from wire.go:
@@ -31,10 +31,30 @@ func InitArticleHandler() service.ArticleService {
 	cmdable := InitRedis()
 	articleCache := cache.NewArticleCache(cmdable)
 	articleRepository := repository.NewCachedArticleRepository(articleDAO, loggerV1, userDAO, articleCache)
+	articleRevisionDAO := dao.NewGORMArticleRevisionDAO(gormDB)
+	articleRevisionRepository := repository.NewArticleRevisionRepository(articleRevisionDAO)
+	tagDAO := dao.NewGORMTagDAO(gormDB)
+	articleTagRepository := repository.NewCachedArticleTagRepository(tagDAO, articleCache, loggerV1)
+	seriesDAO := dao.NewGORMSeriesDAO(gormDB)
+	seriesCache := cache.NewSeriesCache(cmdable)
+	seriesRepository := repository.NewCachedSeriesRepository(seriesDAO, seriesCache, loggerV1)
+	attachmentDAO := dao.NewGORMAttachmentDAO(gormDB)
+	attachmentRepository := InitAttachmentRepository(attachmentDAO)
+	moderationDAO := dao.NewGORMModerationDAO(gormDB)
+	moderationRepository := repository.NewModerationRepository(moderationDAO, articleCache, loggerV1)
+	exportDAO := dao.NewGORMExportDAO(gormDB)
+	exportRepository := InitExportRepository(exportDAO)
+	importDAO := dao.NewGORMImportDAO(gormDB)
+	importRepository := InitImportRepository(importDAO)
+	fingerprintDAO := dao.NewGORMFingerprintDAO(gormDB)
+	fingerprintRepository := repository.NewFingerprintRepository(fingerprintDAO)
+	moderation := InitModeration()
+	filter := InitSensitiveFilter()
 	client := InitKafka()
 	syncProducer := ioc.NewSyncProducer(client)
-	producer := events.NewKafkaProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer, loggerV1)
+	outboxDAO := dao.NewGORMOutboxDAO(gormDB)
+	producer := events.NewOutboxProducer(syncProducer, outboxDAO)
+	articleService := service.NewArticleService(articleRepository, articleRevisionRepository, articleTagRepository, seriesRepository, attachmentRepository, moderationRepository, exportRepository, importRepository, fingerprintRepository, moderation, filter, producer, loggerV1)
 	return articleService
 }
 
@@ -46,4 +66,8 @@ var thirdPartySet = wire.NewSet(
 
 var userSvcProviderSet = wire.NewSet(dao2.NewUserDAO, repository2.NewUserRepository, service2.NewUserService, cache2.NewRedisUserCache)
 
-var articlSvcProvider = wire.NewSet(repository.NewCachedArticleRepository, dao.NewGORMArticleDAO, service.NewArticleService, events.NewKafkaProducer, intrv1.NewInteractiveServiceClient, cache.NewArticleCache)
+var articlSvcProvider = wire.NewSet(repository.NewCachedArticleRepository, repository.NewArticleRevisionRepository, repository.NewCachedArticleTagRepository, repository.NewCachedSeriesRepository, dao.NewGORMArticleDAO, dao.NewGORMArticleRevisionDAO, dao.NewGORMTagDAO, dao.NewGORMSeriesDAO, dao.NewGORMOutboxDAO, dao.NewGORMAttachmentDAO, dao.NewGORMModerationDAO, dao.NewGORMExportDAO, dao.NewGORMImportDAO, dao.NewGORMFingerprintDAO, InitAttachmentRepository,
+	InitExportRepository,
+	InitImportRepository, repository.NewModerationRepository, repository.NewFingerprintRepository, InitModeration,
+	InitSensitiveFilter, service.NewArticleService, events.NewOutboxProducer, intrv1.NewInteractiveServiceClient, cache.NewArticleCache, cache.NewSeriesCache,
+)
@@ -0,0 +1,64 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/spf13/viper"
+)
+
+type attachmentConfig struct {
+	// Type 可选 local 和 s3
+	Type string `yaml:"type"`
+	// BaseURL 插入到文章里面的地址的前缀，一般是 CDN 的地址
+	BaseURL string `yaml:"baseURL"`
+	Local   struct {
+		Root string `yaml:"root"`
+		// Secret 本地签名用的密钥
+		Secret string `yaml:"secret"`
+	} `yaml:"local"`
+	S3 struct {
+		Bucket   string `yaml:"bucket"`
+		Region   string `yaml:"region"`
+		Endpoint string `yaml:"endpoint"`
+	} `yaml:"s3"`
+}
+
+func loadAttachmentConfig() attachmentConfig {
+	cfg := attachmentConfig{Type: "local", BaseURL: "http://localhost:8088/attachments"}
+	cfg.Local.Root = "./data/attachments"
+	err := viper.UnmarshalKey("attachment", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+func InitAttachmentRepository(d dao.AttachmentDAO) repository.AttachmentRepository {
+	cfg := loadAttachmentConfig()
+	var store blobstore.Presigner
+	switch cfg.Type {
+	case "local":
+		store = initLocalPresigner(cfg)
+	case "s3":
+		store = initS3Store(cfg.S3.Bucket, cfg.S3.Region, cfg.S3.Endpoint)
+	default:
+		panic(fmt.Errorf("未知的附件存储类型 %s", cfg.Type))
+	}
+	return repository.NewAttachmentRepository(d, store, cfg.BaseURL)
+}
+
+// initLocalPresigner 附件是公开的，下载不需要签名
+func initLocalPresigner(cfg attachmentConfig) *blobstore.LocalPresigner {
+	local, err := blobstore.NewLocalStore(cfg.Local.Root)
+	if err != nil {
+		panic(err)
+	}
+	p, err := blobstore.NewLocalPresigner(local, cfg.BaseURL, []byte(cfg.Local.Secret))
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
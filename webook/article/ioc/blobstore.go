@@ -0,0 +1,82 @@
+package ioc
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type blobStoreConfig struct {
+	// Type 为空的话文章内容都存在数据库里面，可选 local 和 s3
+	Type string `yaml:"type"`
+	// Threshold 线上库的内容超过这个字节数才放到对象存储
+	Threshold int `yaml:"threshold"`
+	Local     struct {
+		Root string `yaml:"root"`
+	} `yaml:"local"`
+	S3 struct {
+		Bucket   string `yaml:"bucket"`
+		Region   string `yaml:"region"`
+		Endpoint string `yaml:"endpoint"`
+	} `yaml:"s3"`
+}
+
+// initGORMArticleDAO 根据配置决定线上库的内容要不要放到对象存储里面
+func initGORMArticleDAO(db *gorm.DB) dao.ArticleDAO {
+	cfg := blobStoreConfig{Threshold: 64 * 1024}
+	cfg.Local.Root = "./data/blob"
+	err := viper.UnmarshalKey("blobstore", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.Type == "" {
+		return dao.NewGORMArticleDAO(db)
+	}
+	return dao.NewArticleBlobDAO(db, initBlobStore(cfg), cfg.Threshold)
+}
+
+func initBlobStore(cfg blobStoreConfig) blobstore.Store {
+	switch cfg.Type {
+	case "local":
+		store, err := blobstore.NewLocalStore(cfg.Local.Root)
+		if err != nil {
+			panic(err)
+		}
+		return store
+	case "s3":
+		return initS3Store(cfg.S3.Bucket, cfg.S3.Region, cfg.S3.Endpoint)
+	default:
+		panic(fmt.Errorf("未知的 blobstore 类型 %s", cfg.Type))
+	}
+}
+
+func initS3Store(bucket, region, endpoint string) *blobstore.S3Store {
+	// 密钥不要写在配置文件里面
+	id, ok := os.LookupEnv("COS_APP_ID")
+	if !ok {
+		panic("没有找到环境变量 COS_APP_ID ")
+	}
+	key, ok := os.LookupEnv("COS_APP_SECRET")
+	if !ok {
+		panic("没有找到环境变量 COS_APP_SECRET")
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials(id, key, ""),
+		Region:      aws.String(region),
+		Endpoint:    aws.String(endpoint),
+		// 强制使用 /bucket/key 的形态
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return blobstore.NewS3Store(s3.New(sess), bucket)
+}
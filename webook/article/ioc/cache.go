@@ -0,0 +1,29 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/repository/cache"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// InitArticleCache 线上库的文章走本地缓存 + Redis 两级缓存
+func InitArticleCache(client redis.Cmdable) cache.ArticleCache {
+	type Config struct {
+		// Size 本地最多缓存多少篇文章
+		Size int `yaml:"size"`
+		// Expiration 本地缓存的过期时间，也是修改之后别的实例最多多久能看到
+		Expiration time.Duration `yaml:"expiration"`
+	}
+	cfg := Config{Size: 10000, Expiration: time.Second * 10}
+	if err := viper.UnmarshalKey("articleCache", &cfg); err != nil {
+		panic(err)
+	}
+	local, err := lru.New(cfg.Size)
+	if err != nil {
+		panic(err)
+	}
+	return cache.NewMultiLevelArticleCache(cache.NewArticleCache(client), local, cfg.Expiration)
+}
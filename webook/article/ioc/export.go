@@ -0,0 +1,69 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/spf13/viper"
+)
+
+// InitExportRepository 和附件分开存放，附件是公开的，导出的压缩包不是
+func InitExportRepository(d dao.ExportDAO) repository.ExportRepository {
+	return repository.NewExportRepository(d, initExportStore())
+}
+
+// InitImportRepository 上传的压缩包也放在导出的私有存储里面，用完就删
+func InitImportRepository(d dao.ImportDAO) repository.ImportRepository {
+	return repository.NewImportRepository(d, initExportStore())
+}
+
+func initExportStore() blobstore.Presigner {
+	cfg := loadExportConfig()
+	switch cfg.Type {
+	case "local":
+		return initPrivateLocalPresigner(cfg)
+	case "s3":
+		return initS3Store(cfg.S3.Bucket, cfg.S3.Region, cfg.S3.Endpoint)
+	default:
+		panic(fmt.Errorf("未知的导出存储类型 %s", cfg.Type))
+	}
+}
+
+type exportConfig struct {
+	// Type 可选 local 和 s3
+	Type    string `yaml:"type"`
+	BaseURL string `yaml:"baseURL"`
+	Local   struct {
+		Root   string `yaml:"root"`
+		Secret string `yaml:"secret"`
+	} `yaml:"local"`
+	S3 struct {
+		Bucket   string `yaml:"bucket"`
+		Region   string `yaml:"region"`
+		Endpoint string `yaml:"endpoint"`
+	} `yaml:"s3"`
+}
+
+func loadExportConfig() exportConfig {
+	cfg := exportConfig{Type: "local", BaseURL: "http://localhost:8089/exports"}
+	cfg.Local.Root = "./data/exports"
+	err := viper.UnmarshalKey("export", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+func initPrivateLocalPresigner(cfg exportConfig) *blobstore.LocalPresigner {
+	local, err := blobstore.NewLocalStore(cfg.Local.Root)
+	if err != nil {
+		panic(err)
+	}
+	p, err := blobstore.NewPrivateLocalPresigner(local, cfg.BaseURL, []byte(cfg.Local.Secret))
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
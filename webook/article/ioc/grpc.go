@@ -3,6 +3,7 @@ package ioc
 import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"github.com/TengFeiyang01/webook/webook/article/domain"
 	grpc2 "github.com/TengFeiyang01/webook/webook/article/grpc"
 	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
 )
@@ -17,7 +18,8 @@ func NewGRPCxServer(artServer *grpc2.ArticleServiceServer) *grpcx.Server {
 		panic(err)
 	}
 
-	server := grpc.NewServer()
+	// 导入的压缩包放在请求里面，默认 4MB 不够
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(domain.MaxImportSize + 1<<20))
 	artServer.Register(server)
 
 	return &grpcx.Server{
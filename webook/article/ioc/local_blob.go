@@ -0,0 +1,31 @@
+package ioc
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// LocalBlobServers 本地开发的时候没有对象存储，直接在 baseURL 上起 HTTP 服务来上传和下载
+// 用 s3 的时候没有对应的服务。只有文章服务会启动它们。
+// BFF 里面的本地文章服务也会给附件签名，所以 BFF 要配置和文章服务一样的 attachment，不然签出来的地址校验不过；
+// 导出和导入只在文章服务里面处理，BFF 不需要 export 的配置
+type LocalBlobServers []*http.Server
+
+func InitLocalBlobServers() LocalBlobServers {
+	var res LocalBlobServers
+	if cfg := loadAttachmentConfig(); cfg.Type == "local" {
+		res = append(res, newLocalBlobServer(cfg.BaseURL, initLocalPresigner(cfg)))
+	}
+	if cfg := loadExportConfig(); cfg.Type == "local" {
+		res = append(res, newLocalBlobServer(cfg.BaseURL, initPrivateLocalPresigner(cfg)))
+	}
+	return res
+}
+
+func newLocalBlobServer(baseURL string, h http.Handler) *http.Server {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		panic(err)
+	}
+	return &http.Server{Addr: u.Host, Handler: h}
+}
@@ -0,0 +1,35 @@
+package ioc
+
+import (
+	"github.com/TengFeiyang01/webook/webook/article/service"
+	"github.com/spf13/viper"
+)
+
+// InitModeration 默认不开启审核，发表之后直接上线
+func InitModeration() service.Moderation {
+	type KeywordConfig struct {
+		// Reject 出现了就直接拒绝
+		Reject []string `yaml:"reject"`
+		// Review 出现了就交给人工审核
+		Review []string `yaml:"review"`
+	}
+	type Config struct {
+		Enabled     bool          `yaml:"enabled"`
+		AutoApprove bool          `yaml:"autoApprove"`
+		Keywords    KeywordConfig `yaml:"keywords"`
+		// DuplicateDistance SimHash 的海明距离，0 表示不检查重复，最大是 3
+		DuplicateDistance int `yaml:"duplicateDistance"`
+	}
+	cfg := Config{DuplicateDistance: 3}
+	if err := viper.UnmarshalKey("moderation", &cfg); err != nil {
+		panic(err)
+	}
+	return service.Moderation{
+		Enabled:           cfg.Enabled,
+		AutoApprove:       cfg.AutoApprove,
+		DuplicateDistance: cfg.DuplicateDistance,
+		Checkers: []service.ModerationChecker{
+			service.NewKeywordChecker(cfg.Keywords.Reject, cfg.Keywords.Review),
+		},
+	}
+}
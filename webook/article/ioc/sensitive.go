@@ -0,0 +1,59 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/sensitive"
+	"github.com/spf13/viper"
+)
+
+// InitSensitiveFilter 词库文件修改之后会自动重新加载，处理方式要重启才生效
+// 没有配置词库文件的话什么都不会命中
+func InitSensitiveFilter(l logger.LoggerV1) *sensitive.Filter {
+	type Config struct {
+		// File 词库文件，格式见 sensitive.LoadWords
+		File string `yaml:"file"`
+		// Interval 检查词库文件有没有修改的间隔
+		Interval time.Duration `yaml:"interval"`
+		// Levels 每个级别的处理方式，可选 pass、mask、review 和 block
+		Levels map[string]string `yaml:"levels"`
+		// Categories 单独配置某个分类的处理方式，优先于 Levels
+		Categories map[string]string `yaml:"categories"`
+	}
+	cfg := Config{Interval: time.Second * 10}
+	if err := viper.UnmarshalKey("sensitive", &cfg); err != nil {
+		panic(err)
+	}
+	policy := sensitive.DefaultPolicy()
+	for lv, act := range cfg.Levels {
+		level, err := sensitive.ParseLevel(lv)
+		if err != nil {
+			panic(err)
+		}
+		policy.Levels[level] = mustParseAction(act)
+	}
+	policy.Categories = make(map[string]sensitive.Action, len(cfg.Categories))
+	for cat, act := range cfg.Categories {
+		policy.Categories[cat] = mustParseAction(act)
+	}
+	if cfg.File == "" {
+		return sensitive.NewFilter(nil, policy)
+	}
+	words, err := sensitive.LoadFile(cfg.File)
+	if err != nil {
+		panic(err)
+	}
+	f := sensitive.NewFilter(words, policy)
+	go sensitive.WatchFile(context.Background(), f, cfg.File, cfg.Interval, l)
+	return f
+}
+
+func mustParseAction(s string) sensitive.Action {
+	act, err := sensitive.ParseAction(s)
+	if err != nil {
+		panic(err)
+	}
+	return act
+}
@@ -0,0 +1,37 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/TengFeiyang01/webook/webook/article/events"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/article/service"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/sensitive"
+	"github.com/spf13/viper"
+)
+
+// InitArticleService 阅读事件可以一条条发 ReadEvent，也可以攒批之后发 ReadEventV1
+// 切换到 batch 之前要先升级消费者，兼容期内消费者两种格式都能处理
+func InitArticleService(repo repository.ArticleRepository, revRepo repository.ArticleRevisionRepository,
+	tagRepo repository.ArticleTagRepository, seriesRepo repository.SeriesRepository,
+	attachRepo repository.AttachmentRepository, modRepo repository.ModerationRepository,
+	exportRepo repository.ExportRepository, importRepo repository.ImportRepository,
+	fpRepo repository.FingerprintRepository, moderation service.Moderation, filter *sensitive.Filter, producer events.Producer, l logger.LoggerV1) service.ArticleService {
+	type Config struct {
+		// Mode 可选 single 和 batch
+		Mode string `yaml:"mode"`
+	}
+	cfg := Config{Mode: "single"}
+	if err := viper.UnmarshalKey("readEvent", &cfg); err != nil {
+		panic(err)
+	}
+	switch cfg.Mode {
+	case "", "single":
+		return service.NewArticleService(repo, revRepo, tagRepo, seriesRepo, attachRepo, modRepo, exportRepo, importRepo, fpRepo, moderation, filter, producer, l)
+	case "batch":
+		return service.NewArticleServiceV2(repo, revRepo, tagRepo, seriesRepo, attachRepo, modRepo, exportRepo, importRepo, fpRepo, moderation, filter, producer, l)
+	default:
+		panic(fmt.Errorf("未知的 readEvent 模式 %s", cfg.Mode))
+	}
+}
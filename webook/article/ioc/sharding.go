@@ -0,0 +1,128 @@
+package ioc
+
+import (
+	"errors"
+
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/idgen"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/spf13/viper"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	glogger "gorm.io/gorm/logger"
+)
+
+// ArticleShards 文章的分库，没有开启分库的时候 DBs 是空的
+// 文章、标签、系列、审核、历史版本和 outbox 跟着作者在同一个库，保存的时候还是单库事务
+// 附件、导出和导入任务还是在 InitDB 的库里面
+type ArticleShards struct {
+	DBs []*gorm.DB
+	// Gen 所有分库的 DAO 共用一个，同一个节点生成的 ID 才不会重复
+	Gen *idgen.Generator
+}
+
+type shardingConfig struct {
+	// DSNs 为空表示不分库，下标就是分库编号，上线之后不能调整顺序
+	DSNs []string `yaml:"dsns"`
+	// NodeId 生成文章 ID 的节点，多个实例要配置成不一样的
+	// 开启分库的时候必须配置，没有默认值，不然所有实例都用同一个节点，生成的 ID 会重复
+	NodeId int64 `yaml:"nodeId"`
+}
+
+func loadShardingConfig() shardingConfig {
+	var cfg shardingConfig
+	if err := viper.UnmarshalKey("sharding", &cfg); err != nil {
+		panic(err)
+	}
+	if len(cfg.DSNs) > 0 && cfg.NodeId <= 0 {
+		panic(errors.New("开启分库的时候必须给每个实例配置不一样的 sharding.nodeId"))
+	}
+	return cfg
+}
+
+func InitArticleShards(l logger.LoggerV1) ArticleShards {
+	cfg := loadShardingConfig()
+	if len(cfg.DSNs) == 0 {
+		return ArticleShards{}
+	}
+	gen, err := idgen.NewGenerator(cfg.NodeId)
+	if err != nil {
+		panic(err)
+	}
+	dbs := make([]*gorm.DB, 0, len(cfg.DSNs))
+	for _, dsn := range cfg.DSNs {
+		db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+			Logger: glogger.New(gormLoggerFunc(l.Debug), glogger.Config{}),
+		})
+		if err != nil {
+			panic(err)
+		}
+		if err = dao.InitTables(db); err != nil {
+			panic(err)
+		}
+		dbs = append(dbs, db)
+	}
+	return ArticleShards{DBs: dbs, Gen: gen}
+}
+
+func initShardingArticleDAO(shards ArticleShards) dao.ArticleDAO {
+	d, err := dao.NewShardingArticleDAO(shards.DBs, shards.Gen)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// InitOutboxDAO 分库之后文章的事件写在文章所在的库，OutboxRelay 要查所有的库
+func InitOutboxDAO(db *gorm.DB, shards ArticleShards) dao.OutboxDAO {
+	if len(shards.DBs) == 0 {
+		return dao.NewGORMOutboxDAO(db)
+	}
+	return dao.NewShardingOutboxDAO(shards.DBs)
+}
+
+func InitTagDAO(db *gorm.DB, shards ArticleShards) dao.TagDAO {
+	if len(shards.DBs) == 0 {
+		return dao.NewGORMTagDAO(db)
+	}
+	return dao.NewShardingTagDAO(shards.DBs)
+}
+
+func InitSeriesDAO(db *gorm.DB, shards ArticleShards) dao.SeriesDAO {
+	if len(shards.DBs) == 0 {
+		return dao.NewGORMSeriesDAO(db)
+	}
+	return dao.NewShardingSeriesDAO(shards.DBs, shards.Gen)
+}
+
+func InitModerationDAO(db *gorm.DB, shards ArticleShards) dao.ModerationDAO {
+	if len(shards.DBs) == 0 {
+		return dao.NewGORMModerationDAO(db)
+	}
+	return dao.NewShardingModerationDAO(shards.DBs, shards.Gen)
+}
+
+func InitArticleRevisionDAO(db *gorm.DB, shards ArticleShards) dao.ArticleRevisionDAO {
+	if len(shards.DBs) == 0 {
+		return dao.NewGORMArticleRevisionDAO(db)
+	}
+	return dao.NewShardingArticleRevisionDAO(shards.DBs, shards.Gen)
+}
+
+// InitAttachmentDAO 附件在 InitDB 的库里面，引用跟着历史版本在分库里面
+func InitAttachmentDAO(db *gorm.DB, shards ArticleShards) dao.AttachmentDAO {
+	d := dao.NewGORMAttachmentDAO(db)
+	if len(shards.DBs) == 0 {
+		return d
+	}
+	return dao.NewShardingAttachmentDAO(d, shards.DBs)
+}
+
+// InitExportDAO 导出任务在 InitDB 的库里面，统计文章数量要去作者所在的库
+func InitExportDAO(db *gorm.DB, shards ArticleShards) dao.ExportDAO {
+	d := dao.NewGORMExportDAO(db)
+	if len(shards.DBs) == 0 {
+		return d
+	}
+	return dao.NewShardingExportDAO(d, shards.DBs)
+}
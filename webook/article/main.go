@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/pflag"
@@ -27,6 +28,18 @@ func initViperV1() {
 func main() {
 	initViperV1()
 	app := InitAPP()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// outbox 只在文章服务里面投递，BFF 里面的本地文章服务只负责写
+	go app.relay.Start(ctx)
+	go app.exporter.Start(ctx)
+	go app.importer.Start(ctx)
+	for _, srv := range app.blobServers {
+		go func() {
+			err := srv.ListenAndServe()
+			log.Println(srv.Addr, err)
+		}()
+	}
 	err := app.server.Serve()
 	log.Println(err)
 }
@@ -2,16 +2,29 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
+	"strconv"
 	"time"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
 	"github.com/TengFeiyang01/webook/webook/article/repository/cache"
 	dao2 "github.com/TengFeiyang01/webook/webook/article/repository/dao"
 	"github.com/TengFeiyang01/webook/webook/internal/repository/dao"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/markdown"
 )
 
+var (
+	// ErrVersionConflict 保存的时候版本号对不上
+	ErrVersionConflict = dao2.ErrVersionConflict
+	ErrArticleNotFound = dao2.ErrArticleNotFound
+)
+
+// firstPageSize 作者列表只缓存第一页，每页这么多条
+const firstPageSize = 100
+
 type ArticleRepository interface {
 	Create(ctx context.Context, art domain.Article) (int64, error)
 	Update(ctx context.Context, art domain.Article) error
@@ -24,6 +37,23 @@ type ArticleRepository interface {
 	GetByID(ctx context.Context, id int64) (domain.Article, error)
 	GetPublishedById(ctx context.Context, id int64) (domain.Article, error)
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+	// ListPubByTag 和 ListPub 一样，只要带着这个标签的已发表文章
+	ListPubByTag(ctx context.Context, tag string, start time.Time, offset int, limit int) ([]domain.Article, error)
+	// ListPubByAuthor 和 ListPub 一样，只要这个作者已发表的文章
+	ListPubByAuthor(ctx context.Context, author int64, start time.Time, offset int, limit int) ([]domain.Article, error)
+	// GetPubTitles 批量查询已发表文章的标题，不查缓存也不渲染，查不到的直接跳过
+	GetPubTitles(ctx context.Context, ids []int64) ([]domain.Article, error)
+	// ListByCursor 按照 (utime, id) 倒序翻页，cursor 为零值表示第一页
+	ListByCursor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error)
+	// ListPubByCursor 只取排在 cursor 之后的，第一页可以用 Cursor{Utime: start}
+	ListPubByCursor(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error)
+
+	Delete(ctx context.Context, id int64, author int64) error
+	ListTrash(ctx context.Context, author int64, offset int, limit int) ([]domain.Article, error)
+	Restore(ctx context.Context, id int64, author int64) error
+	Purge(ctx context.Context, id int64, author int64) error
+	// ListExpiredTrash 删除时间早于 before 的文章
+	ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
 }
 
 type CachedArticleRepository struct {
@@ -43,6 +73,10 @@ type CachedArticleRepository struct {
 	db *gorm.DB
 
 	cache cache.ArticleCache
+	// pubGroup 线上库的文章回源的时候合并请求
+	pubGroup singleflight.Group
+
+	renderer *markdown.Renderer
 }
 
 func (c *CachedArticleRepository) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
@@ -50,14 +84,93 @@ func (c *CachedArticleRepository) ListPub(ctx context.Context, start time.Time,
 	if err != nil {
 		return nil, err
 	}
+	data := slice.Map(res, func(idx int, src dao2.Article) domain.Article {
+		return c.toDomain(src)
+	})
+	// 订阅源和首页都要用纯文本的摘要，不能直接截 Markdown 原文
+	c.renderSummaries(data)
+	return data, nil
+}
+
+func (c *CachedArticleRepository) ListPubByTag(ctx context.Context, tag string, start time.Time, offset int, limit int) ([]domain.Article, error) {
+	res, err := c.dao.ListPubByTag(ctx, tag, domain.ArticleStatusPublished.ToUint8(), start, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	data := slice.Map(res, func(idx int, src dao2.Article) domain.Article {
+		return c.toDomain(src)
+	})
+	c.renderSummaries(data)
+	return data, nil
+}
+
+func (c *CachedArticleRepository) ListPubByAuthor(ctx context.Context, author int64, start time.Time, offset int, limit int) ([]domain.Article, error) {
+	res, err := c.dao.ListPubByAuthor(ctx, author, domain.ArticleStatusPublished.ToUint8(), start, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	data := slice.Map(res, func(idx int, src dao2.Article) domain.Article {
+		return c.toDomain(src)
+	})
+	c.renderSummaries(data)
+	return data, nil
+}
+
+func (c *CachedArticleRepository) GetPubTitles(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	res, err := c.dao.GetPubTitles(ctx, ids, domain.ArticleStatusPublished.ToUint8())
+	if err != nil {
+		return nil, err
+	}
 	return slice.Map(res, func(idx int, src dao2.Article) domain.Article {
 		return c.toDomain(src)
 	}), nil
 }
 
+func (c *CachedArticleRepository) ListPubByCursor(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	res, err := c.dao.ListPubCursor(ctx, cursor.Utime.UnixMilli(), cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	data := slice.Map(res, func(idx int, src dao2.Article) domain.Article {
+		return c.toDomain(src)
+	})
+	c.renderSummaries(data)
+	return data, nil
+}
+
+// GetPublishedById 先查缓存，缓存没有再查数据库
+// 同一篇文章同时只有一个请求回源，热门文章缓存过期的时候不会把数据库打垮
 func (c *CachedArticleRepository) GetPublishedById(ctx context.Context, id int64) (domain.Article, error) {
+	res, err := c.cache.GetPub(ctx, id)
+	switch {
+	case err == nil:
+		return res, nil
+	case errors.Is(err, cache.ErrArticleNotExist):
+		return domain.Article{}, ErrArticleNotFound
+	case !errors.Is(err, cache.ErrKeyNotExist):
+		// Redis 出问题了也回源，有 singleflight 兜底
+		c.l.Error("查询线上库文章缓存失败", logger.Int64("aid", id), logger.Error(err))
+	}
+	val, err, _ := c.pubGroup.Do(strconv.FormatInt(id, 10), func() (any, error) {
+		// 不要因为第一个请求被取消了，导致一起等待的请求全部失败
+		return c.loadPublished(context.WithoutCancel(ctx), id)
+	})
+	if err != nil {
+		return domain.Article{}, err
+	}
+	return val.(domain.Article), nil
+}
+
+func (c *CachedArticleRepository) loadPublished(ctx context.Context, id int64) (domain.Article, error) {
 	// 读取线上库数据，如果你的 Content 被放过去了 OSS 上，就需要前端去读
 	art, err := c.dao.GetPubById(ctx, id)
+	if errors.Is(err, dao2.ErrArticleNotFound) {
+		er := c.cache.SetPubNotExist(ctx, id)
+		if er != nil {
+			c.l.Error("缓存文章不存在失败", logger.Int64("aid", id), logger.Error(er))
+		}
+		return domain.Article{}, err
+	}
 	if err != nil {
 		return domain.Article{}, err
 	}
@@ -68,6 +181,8 @@ func (c *CachedArticleRepository) GetPublishedById(ctx context.Context, id int64
 	}
 	res := c.toDomain(art)
 	res.Author.Name = usr.NickName
+	// 渲染的结果跟着文章一起缓存
+	c.render(&res)
 
 	err = c.cache.SetPub(ctx, id, res)
 	if err != nil {
@@ -82,13 +197,16 @@ func (c *CachedArticleRepository) GetByID(ctx context.Context, id int64) (domain
 	if err != nil {
 		return domain.Article{}, err
 	}
-	return c.toDomain(data), nil
+	res := c.toDomain(data)
+	c.render(&res)
+	return res, nil
 }
 
 func (c *CachedArticleRepository) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
 	// 你在这个地方，集成记得复杂的缓存方案
 	// 缓存第一页
-	if offset == 0 && limit == 100 {
+	firstPage := offset == 0 && limit == firstPageSize
+	if firstPage {
 		data, err := c.cache.GetFirstPage(ctx, uid)
 		if err == nil {
 			go func() {
@@ -104,14 +222,54 @@ func (c *CachedArticleRepository) List(ctx context.Context, uid int64, offset in
 	data := slice.Map[dao2.Article, domain.Article](res, func(idx int, src dao2.Article) domain.Article {
 		return c.toDomain(src)
 	})
+	c.renderSummaries(data)
 	// 回写缓存的时候，是 Set 还是 Del
 	// 如果觉得不太可能有很高并发，你就直接 Set
 	// 否则就 Del
-	go func() {
-		_ = c.cache.SetFirstPage(ctx, uid, data)
-		c.l.Error("回写缓存失败", logger.Error(err))
-		c.preCache(ctx, data)
-	}()
+	if firstPage {
+		go func() {
+			er := c.cache.SetFirstPage(ctx, uid, data)
+			if er != nil {
+				c.l.Error("回写缓存失败", logger.Error(er))
+			}
+			c.preCache(ctx, data)
+		}()
+	}
+	return data, nil
+}
+
+func (c *CachedArticleRepository) ListByCursor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	// 第一页和 List 共用一份缓存
+	if cursor.IsZero() && limit == firstPageSize {
+		data, err := c.cache.GetFirstPage(ctx, uid)
+		if err == nil {
+			go func() {
+				c.preCache(ctx, data)
+			}()
+			return data, nil
+		}
+	}
+	var utime int64
+	if !cursor.IsZero() {
+		utime = cursor.Utime.UnixMilli()
+	}
+	res, err := c.dao.GetByAuthorCursor(ctx, uid, utime, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	data := slice.Map[dao2.Article, domain.Article](res, func(idx int, src dao2.Article) domain.Article {
+		return c.toDomain(src)
+	})
+	c.renderSummaries(data)
+	if cursor.IsZero() && limit == firstPageSize {
+		go func() {
+			er := c.cache.SetFirstPage(ctx, uid, data)
+			if er != nil {
+				c.l.Error("回写缓存失败", logger.Error(er))
+			}
+			c.preCache(ctx, data)
+		}()
+	}
 	return data, nil
 }
 
@@ -123,14 +281,58 @@ func (c *CachedArticleRepository) toDomain(art dao2.Article) domain.Article {
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
-		Status: domain.ArticleStatus(art.Status),
-		Ctime:  time.UnixMilli(art.Ctime),
-		Utime:  time.UnixMilli(art.Utime),
+		Status:    domain.ArticleStatus(art.Status),
+		PublishAt: c.toTime(art.PublishAt),
+		Version:   art.Version,
+		Ctime:     time.UnixMilli(art.Ctime),
+		Utime:     time.UnixMilli(art.Utime),
+		DeletedAt: c.toTime(art.DeletedAt),
+	}
+}
+
+// render 渲染失败不影响读取文章，摘要会退化成直接截取原文
+func (c *CachedArticleRepository) render(art *domain.Article) {
+	doc, err := c.renderer.Render(art.Content)
+	if err != nil {
+		c.l.Error("渲染文章失败", logger.Int64("aid", art.Id), logger.Error(err))
+		return
+	}
+	art.Rendered = domain.RenderedContent{
+		HTML:        doc.HTML,
+		Abstract:    markdown.Abstract(doc.Text, domain.MaxAbstractLength),
+		ReadingTime: doc.ReadingTime,
+	}
+}
+
+// renderSummaries 列表页用不到 HTML，只保留摘要和阅读时间
+func (c *CachedArticleRepository) renderSummaries(arts []domain.Article) {
+	for i := range arts {
+		c.render(&arts[i])
+		arts[i].Rendered.HTML = ""
+	}
+}
+
+// toTime 0 代表没有设置，转成 time 的零值
+func (c *CachedArticleRepository) toTime(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+func (c *CachedArticleRepository) toMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
 	}
+	return t.UnixMilli()
 }
 
 func (c *CachedArticleRepository) SyncStatus(ctx context.Context, id int64, author int64, status domain.ArticleStatus) error {
-	return c.dao.SyncStatus(ctx, id, author, status.ToUint8())
+	err := c.dao.SyncStatus(ctx, id, author, status.ToUint8())
+	if err == nil {
+		c.evict(ctx, id, author)
+	}
+	return err
 }
 
 func (c *CachedArticleRepository) Sync(ctx context.Context, art domain.Article) (int64, error) {
@@ -138,14 +340,14 @@ func (c *CachedArticleRepository) Sync(ctx context.Context, art domain.Article)
 	if err == nil {
 		author := art.Author.Id
 		// 清空缓存
-		err := c.cache.DelFirstPage(ctx, art.Id)
+		err := c.cache.DelFirstPage(ctx, author)
 		if err != nil {
 			c.l.Error("删除缓存失败", logger.Int64("author", author), logger.Int64("artId", art.Id), logger.Error(err))
 		}
-		// 提前缓存
-		err = c.cache.SetPub(ctx, art.Id, art)
+		// 这里的 art 没有作者名字和渲染结果，不能直接放进缓存，删掉等读的时候再加载
+		err = c.cache.Del(ctx, id)
 		if err != nil {
-			c.l.Warn("缓存失败", logger.Int64("author", author), logger.Int64("artId", art.Id), logger.Error(err))
+			c.l.Warn("删除缓存失败", logger.Int64("author", author), logger.Int64("artId", id), logger.Error(err))
 		}
 	}
 	return id, err
@@ -212,7 +414,12 @@ func (c *CachedArticleRepository) Update(ctx context.Context, art domain.Article
 		return err
 	}
 	author := art.Author.Id
-	err = c.cache.DelFirstPage(ctx, art.Id)
+	if art.Tags != nil {
+		// 标签是制作库和线上库共用的，缓存里面的文章带着旧的标签
+		c.evict(ctx, art.Id, author)
+		return nil
+	}
+	err = c.cache.DelFirstPage(ctx, author)
 	if err != nil {
 		c.l.Error("删除缓存失败",
 			logger.Int64("author", author), logger.Error(err))
@@ -223,30 +430,100 @@ func (c *CachedArticleRepository) Update(ctx context.Context, art domain.Article
 func (c *CachedArticleRepository) Create(ctx context.Context, art domain.Article) (int64, error) {
 
 	id, err := c.dao.Insert(ctx, dao2.Article{
-		Title:    art.Title,
-		Content:  art.Content,
-		AuthorId: art.Author.Id,
-		Status:   art.Status.ToUint8(),
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.Author.Id,
+		Status:    art.Status.ToUint8(),
+		PublishAt: c.toMilli(art.PublishAt),
+		Tags:      art.Tags,
+
+		RevisionKind: art.RevisionKind.ToUint8(),
 	})
 	if err != nil {
 		return 0, err
 	}
 	author := art.Author.Id
 	// 清空缓存
-	err = c.cache.DelFirstPage(ctx, art.Id)
+	err = c.cache.DelFirstPage(ctx, author)
 	if err != nil {
 		c.l.Error("删除缓存失败", logger.Int64("author", author), logger.Error(err))
 	}
 	return id, nil
 }
 
+func (c *CachedArticleRepository) Delete(ctx context.Context, id int64, author int64) error {
+	err := c.dao.Delete(ctx, id, author)
+	if err != nil {
+		return err
+	}
+	c.evict(ctx, id, author)
+	return nil
+}
+
+func (c *CachedArticleRepository) ListTrash(ctx context.Context, author int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListTrash(ctx, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := slice.Map[dao2.Article, domain.Article](arts, func(idx int, src dao2.Article) domain.Article {
+		return c.toDomain(src)
+	})
+	c.renderSummaries(res)
+	return res, nil
+}
+
+func (c *CachedArticleRepository) Restore(ctx context.Context, id int64, author int64) error {
+	err := c.dao.Restore(ctx, id, author)
+	if err != nil {
+		return err
+	}
+	c.evict(ctx, id, author)
+	return nil
+}
+
+func (c *CachedArticleRepository) Purge(ctx context.Context, id int64, author int64) error {
+	err := c.dao.Purge(ctx, id, author)
+	if err != nil {
+		return err
+	}
+	c.evict(ctx, id, author)
+	return nil
+}
+
+func (c *CachedArticleRepository) ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListExpiredTrash(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao2.Article, domain.Article](arts, func(idx int, src dao2.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+// evict 文章进出回收站之后，作者的第一页和文章本身的缓存都不能用了
+func (c *CachedArticleRepository) evict(ctx context.Context, id int64, author int64) {
+	err := c.cache.DelFirstPage(ctx, author)
+	if err != nil {
+		c.l.Error("删除缓存失败", logger.Int64("author", author), logger.Error(err))
+	}
+	err = c.cache.Del(ctx, id)
+	if err != nil {
+		c.l.Error("删除缓存失败", logger.Int64("artId", id), logger.Error(err))
+	}
+}
+
 func (c *CachedArticleRepository) toEntity(art domain.Article) dao2.Article {
 	return dao2.Article{
-		Id:       art.Id,
-		Title:    art.Title,
-		Content:  art.Content,
-		AuthorId: art.Author.Id,
-		Status:   art.Status.ToUint8(),
+		Id:        art.Id,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.Author.Id,
+		Status:    art.Status.ToUint8(),
+		PublishAt: c.toMilli(art.PublishAt),
+		Version:   art.Version,
+		Tags:      art.Tags,
+
+		RevisionKind: art.RevisionKind.ToUint8(),
 	}
 }
 
@@ -262,9 +539,10 @@ func (c *CachedArticleRepository) preCache(ctx context.Context, arts []domain.Ar
 
 func NewCachedArticleRepository(dao dao2.ArticleDAO, l logger.LoggerV1, usrDAO dao.UserDAO, cache cache.ArticleCache) ArticleRepository {
 	return &CachedArticleRepository{
-		dao:     dao,
-		l:       l,
-		userDAO: usrDAO,
-		cache:   cache,
+		dao:      dao,
+		l:        l,
+		userDAO:  usrDAO,
+		cache:    cache,
+		renderer: markdown.NewRenderer(),
 	}
 }
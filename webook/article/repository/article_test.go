@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/cache"
+	cachemocks "github.com/TengFeiyang01/webook/webook/article/repository/cache/mocks"
+	dao2 "github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	daomocks "github.com/TengFeiyang01/webook/webook/article/repository/dao/mocks"
+	"github.com/TengFeiyang01/webook/webook/internal/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// userDAO 只用到了 FindById
+type userDAO struct {
+	dao.UserDAO
+}
+
+func (u userDAO) FindById(ctx context.Context, id int64) (dao.User, error) {
+	return dao.User{ID: id, NickName: "作者"}, nil
+}
+
+func TestCachedArticleRepository_GetPublishedById(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao2.ArticleDAO, cache.ArticleCache)
+
+		wantArt domain.Article
+		wantErr error
+	}{
+		{
+			name: "缓存命中",
+			mock: func(ctrl *gomock.Controller) (dao2.ArticleDAO, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{Id: 1}, nil)
+				return daomocks.NewMockArticleDAO(ctrl), c
+			},
+			wantArt: domain.Article{Id: 1},
+		},
+		{
+			name: "缓存过不存在，不查数据库",
+			mock: func(ctrl *gomock.Controller) (dao2.ArticleDAO, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, cache.ErrArticleNotExist)
+				return daomocks.NewMockArticleDAO(ctrl), c
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "数据库没有，缓存不存在",
+			mock: func(ctrl *gomock.Controller) (dao2.ArticleDAO, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, cache.ErrKeyNotExist)
+				c.EXPECT().SetPubNotExist(gomock.Any(), int64(1)).Return(nil)
+				d := daomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(dao2.Article{}, dao2.ErrArticleNotFound)
+				return d, c
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "Redis 出错也回源",
+			mock: func(ctrl *gomock.Controller) (dao2.ArticleDAO, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, errors.New("redis 错误"))
+				c.EXPECT().SetPub(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+				d := daomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(dao2.Article{Id: 1, AuthorId: 123, Ctime: 100, Utime: 100}, nil)
+				return d, c
+			},
+			wantArt: domain.Article{Id: 1, Author: domain.Author{Id: 123, Name: "作者"},
+				Ctime: time.UnixMilli(100), Utime: time.UnixMilli(100)},
+		},
+		{
+			name: "数据库出错，不缓存",
+			mock: func(ctrl *gomock.Controller) (dao2.ArticleDAO, cache.ArticleCache) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, cache.ErrKeyNotExist)
+				d := daomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(dao2.Article{}, errors.New("db 错误"))
+				return d, c
+			},
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewCachedArticleRepository(d, logger.NewNopLogger(), userDAO{}, c)
+			art, err := repo.GetPublishedById(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			if err == nil {
+				// 渲染结果不关心
+				art.Rendered = domain.RenderedContent{}
+			}
+			assert.Equal(t, tc.wantArt, art)
+		})
+	}
+}
+
+func TestCachedArticleRepository_GetPublishedById_Singleflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	const n = 10
+	c := cachemocks.NewMockArticleCache(ctrl)
+	c.EXPECT().GetPub(gomock.Any(), int64(1)).
+		Return(domain.Article{}, cache.ErrKeyNotExist).Times(n)
+	c.EXPECT().SetPub(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+
+	var arrived sync.WaitGroup
+	arrived.Add(n)
+	d := daomocks.NewMockArticleDAO(ctrl)
+	// 只回源一次
+	d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+		DoAndReturn(func(ctx context.Context, id int64) (dao2.Article, error) {
+			// 等所有请求都查过缓存，再慢一点返回，让它们都排上队
+			arrived.Wait()
+			time.Sleep(time.Millisecond * 50)
+			return dao2.Article{Id: 1, AuthorId: 123}, nil
+		})
+	repo := NewCachedArticleRepository(d, logger.NewNopLogger(), userDAO{}, c)
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithCancel(context.Background())
+			if i == 0 {
+				// 第一个请求被取消了，别的请求不受影响
+				cancel()
+			} else {
+				defer cancel()
+			}
+			arrived.Done()
+			art, err := repo.GetPublishedById(ctx, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "作者", art.Author.Name)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCachedArticleRepository_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	content := "# 标题\n\n" + strings.Repeat("很长的正文", 100)
+	d := daomocks.NewMockArticleDAO(ctrl)
+	d.EXPECT().GetByAuthor(gomock.Any(), int64(123), 0, 100).
+		Return([]dao2.Article{{Id: 1, Title: "标题", Content: content, AuthorId: 123}}, nil)
+	c := cachemocks.NewMockArticleCache(ctrl)
+	c.EXPECT().GetFirstPage(gomock.Any(), int64(123)).Return(nil, cache.ErrKeyNotExist)
+	done := make(chan struct{})
+	// 第一页原样缓存，命中缓存的时候拿到的也是完整的内容
+	c.EXPECT().SetFirstPage(gomock.Any(), int64(123), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, author int64, arts []domain.Article) error {
+			assert.Equal(t, content, arts[0].Content)
+			return nil
+		})
+	c.EXPECT().Set(gomock.Any(), int64(1), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id int64, art domain.Article) error {
+			close(done)
+			return nil
+		})
+	repo := NewCachedArticleRepository(d, logger.NewNopLogger(), userDAO{}, c)
+
+	arts, err := repo.List(context.Background(), 123, 0, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, content, arts[0].Content)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("没有回写缓存")
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+var (
+	ErrAttachmentNotFound = dao.ErrAttachmentNotFound
+	// ErrAttachmentObjectNotFound 对象存储里面没有，客户端还没有上传
+	ErrAttachmentObjectNotFound = blobstore.ErrNotFound
+)
+
+type AttachmentRepository interface {
+	// Create 返回的附件带上了 Id 和 URL
+	Create(ctx context.Context, a domain.Attachment) (domain.Attachment, error)
+	GetById(ctx context.Context, id int64) (domain.Attachment, error)
+	// PresignUpload 客户端拿着这个 URL 直接上传
+	PresignUpload(ctx context.Context, a domain.Attachment, expiration time.Duration) (string, error)
+	// Stat 对象存储里面实际的大小和类型，还没有上传返回 ErrAttachmentObjectNotFound
+	Stat(ctx context.Context, key string) (blobstore.ObjectInfo, error)
+	MarkUploaded(ctx context.Context, id int64, size int64, contentType string) error
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Attachment, error)
+	// UsedBytes 作者已经用掉的空间
+	UsedBytes(ctx context.Context, author int64) (int64, error)
+	// Delete 先删除对象，再删除元数据，删除对象失败的话下次还能重试
+	Delete(ctx context.Context, a domain.Attachment) error
+	// ListBefore 按照 id 升序列出 id 大于 startId 并且在 before 之前创建的附件
+	ListBefore(ctx context.Context, before time.Time, startId int64, limit int) ([]domain.Attachment, error)
+	// Referenced 附件有没有被任何一个历史版本引用
+	Referenced(ctx context.Context, a domain.Attachment) (bool, error)
+}
+
+// attachmentRepository 附件只有作者自己会访问，所以没有引入缓存
+type attachmentRepository struct {
+	dao   dao.AttachmentDAO
+	store blobstore.Presigner
+	// baseURL 对外访问的地址，一般是 CDN 的地址
+	baseURL string
+}
+
+func NewAttachmentRepository(dao dao.AttachmentDAO, store blobstore.Presigner, baseURL string) AttachmentRepository {
+	return &attachmentRepository{dao: dao, store: store, baseURL: baseURL}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, a domain.Attachment) (domain.Attachment, error) {
+	id, err := r.dao.Insert(ctx, dao.Attachment{
+		AuthorId:    a.Author.Id,
+		Key:         a.Key,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Status:      a.Status.ToUint8(),
+	})
+	a.Id = id
+	a.URL = r.url(a.Key)
+	return a, err
+}
+
+func (r *attachmentRepository) GetById(ctx context.Context, id int64) (domain.Attachment, error) {
+	a, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return r.toDomain(a), nil
+}
+
+func (r *attachmentRepository) PresignUpload(ctx context.Context, a domain.Attachment, expiration time.Duration) (string, error) {
+	return r.store.PresignPut(ctx, a.Key, a.ContentType, a.Size, expiration)
+}
+
+func (r *attachmentRepository) Stat(ctx context.Context, key string) (blobstore.ObjectInfo, error) {
+	return r.store.Stat(ctx, key)
+}
+
+func (r *attachmentRepository) MarkUploaded(ctx context.Context, id int64, size int64, contentType string) error {
+	return r.dao.MarkUploaded(ctx, id, size, contentType)
+}
+
+func (r *attachmentRepository) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Attachment, error) {
+	res, err := r.dao.ListByAuthor(ctx, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Attachment) domain.Attachment {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *attachmentRepository) UsedBytes(ctx context.Context, author int64) (int64, error) {
+	return r.dao.SumSize(ctx, author)
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, a domain.Attachment) error {
+	err := r.store.Delete(ctx, a.Key)
+	if err != nil {
+		return err
+	}
+	return r.dao.Delete(ctx, a.Id)
+}
+
+func (r *attachmentRepository) ListBefore(ctx context.Context, before time.Time, startId int64, limit int) ([]domain.Attachment, error) {
+	res, err := r.dao.ListBefore(ctx, before.UnixMilli(), startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Attachment) domain.Attachment {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *attachmentRepository) Referenced(ctx context.Context, a domain.Attachment) (bool, error) {
+	return r.dao.Referenced(ctx, a.Key)
+}
+
+func (r *attachmentRepository) url(key string) string {
+	return r.baseURL + "/" + key
+}
+
+func (r *attachmentRepository) toDomain(a dao.Attachment) domain.Attachment {
+	return domain.Attachment{
+		Id: a.Id,
+		Author: domain.Author{
+			Id: a.AuthorId,
+		},
+		Key:         a.Key,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Status:      domain.AttachmentStatus(a.Status),
+		URL:         r.url(a.Key),
+		Ctime:       time.UnixMilli(a.Ctime),
+		Utime:       time.UnixMilli(a.Utime),
+	}
+}
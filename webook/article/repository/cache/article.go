@@ -2,13 +2,35 @@ package cache
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/net/context"
+	"math/rand"
 	"time"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
 )
 
+var (
+	// ErrKeyNotExist 缓存里面没有，需要回源
+	ErrKeyNotExist = redis.Nil
+	// ErrArticleNotExist 缓存过文章不存在，不需要回源
+	ErrArticleNotExist = errors.New("缓存的文章不存在")
+)
+
+const (
+	// pubExpiration 线上库更新的时候会删除缓存，所以可以缓存久一点
+	pubExpiration = time.Minute * 10
+	// pubExpirationJitter 随机加一点过期时间，避免同一批文章一起过期
+	pubExpirationJitter = time.Minute * 2
+	// notExistExpiration 不存在的文章缓存短一点，刚发表的文章不会等太久
+	notExistExpiration = time.Minute
+	// notExistValue 和正常的 JSON 区分开
+	notExistValue = "-"
+	// maxFirstPageBytes 第一页超过这个大小就不缓存了
+	maxFirstPageBytes = 1024 * 1024
+)
+
 type ArticleCache interface {
 	GetFirstPage(ctx context.Context, author int64) ([]domain.Article, error)
 	SetFirstPage(ctx context.Context, author int64, arts []domain.Article) error
@@ -18,7 +40,12 @@ type ArticleCache interface {
 	Get(ctx context.Context, id int64) (domain.Article, error)
 
 	SetPub(ctx context.Context, id int64, article domain.Article) error
+	// GetPub 缓存里面没有返回 ErrKeyNotExist，缓存过文章不存在返回 ErrArticleNotExist
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
+	// SetPubNotExist 缓存文章不存在，避免不存在的 id 一直打到数据库上
+	SetPubNotExist(ctx context.Context, id int64) error
+	// Del 删除文章本身的缓存，制作库和线上库的都删掉
+	Del(ctx context.Context, id int64) error
 }
 
 type RedisArticleCache struct {
@@ -41,14 +68,17 @@ func (r *RedisArticleCache) GetFirstPage(ctx context.Context, author int64) ([]d
 	return arts, err
 }
 
+// SetFirstPage 原样缓存，命中缓存和查数据库拿到的数据要一样
+// 预加载和导出都要用完整的内容，所以不能只缓存摘要；太大的就不缓存了
 func (r *RedisArticleCache) SetFirstPage(ctx context.Context, author int64, arts []domain.Article) error {
-	for i := 0; i < len(arts); i++ {
-		arts[i].Content = arts[i].Abstract()
-	}
 	data, err := json.Marshal(arts)
 	if err != nil {
 		return err
 	}
+	if len(data) > maxFirstPageBytes {
+		// 删掉旧的，不然会读到过期的第一页
+		return r.DelFirstPage(ctx, author)
+	}
 	return r.client.Set(ctx, r.firstPageKey(author), data, time.Minute*10).Err()
 }
 
@@ -60,6 +90,10 @@ func (r *RedisArticleCache) key(uid int64) string {
 	return fmt.Sprintf("art:%d", uid)
 }
 
+func (r *RedisArticleCache) pubKey(id int64) string {
+	return fmt.Sprintf("art:pub:%d", id)
+}
+
 func (r *RedisArticleCache) DelFirstPage(ctx context.Context, author int64) error {
 	return r.client.Del(ctx, r.firstPageKey(author)).Err()
 }
@@ -88,15 +122,32 @@ func (r *RedisArticleCache) SetPub(ctx context.Context, id int64, article domain
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, r.key(id), data, time.Minute).Err()
+	expiration := pubExpiration + time.Duration(rand.Int63n(int64(pubExpirationJitter)))
+	return r.client.Set(ctx, r.pubKey(id), data, expiration).Err()
 }
 
 func (r *RedisArticleCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
-	bs, err := r.client.Get(ctx, r.key(id)).Result()
+	bs, err := r.client.Get(ctx, r.pubKey(id)).Result()
 	if err != nil {
 		return domain.Article{}, err
 	}
+	if bs == notExistValue {
+		return domain.Article{}, ErrArticleNotExist
+	}
 	var art domain.Article
 	err = json.Unmarshal([]byte(bs), &art)
 	return art, err
 }
+
+func (r *RedisArticleCache) SetPubNotExist(ctx context.Context, id int64) error {
+	return r.client.Set(ctx, r.pubKey(id), notExistValue, notExistExpiration).Err()
+}
+
+// Del 分开删，集群模式下两个 key 可能不在一个槽上
+func (r *RedisArticleCache) Del(ctx context.Context, id int64) error {
+	err := r.client.Del(ctx, r.key(id)).Err()
+	if err != nil {
+		return err
+	}
+	return r.client.Del(ctx, r.pubKey(id)).Err()
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MultiLevelArticleCache 线上库的文章先查本地的 LRU，再查 Redis
+// 其它方法直接交给 Redis
+// 本地缓存没办法通知别的实例删除，所以过期时间要短，修改之后最多这么久就能看到
+type MultiLevelArticleCache struct {
+	ArticleCache
+	local *lru.Cache
+	// localExpiration 本地缓存的过期时间
+	localExpiration time.Duration
+	counter         *prometheus.CounterVec
+}
+
+func NewMultiLevelArticleCache(redisCache ArticleCache, local *lru.Cache, localExpiration time.Duration) ArticleCache {
+	c := newMultiLevelArticleCache(redisCache, local, localExpiration)
+	prometheus.MustRegister(c.counter)
+	return c
+}
+
+func newMultiLevelArticleCache(redisCache ArticleCache, local *lru.Cache, localExpiration time.Duration) *MultiLevelArticleCache {
+	return &MultiLevelArticleCache{
+		ArticleCache:    redisCache,
+		local:           local,
+		localExpiration: localExpiration,
+		counter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "ytf",
+			Subsystem: "webook",
+			Name:      "article_pub_cache",
+			Help:      "统计线上库文章缓存的命中情况",
+		}, []string{"level", "result"}),
+	}
+}
+
+const (
+	cacheLevelLocal = "local"
+	cacheLevelRedis = "redis"
+	cacheResultHit  = "hit"
+	cacheResultMiss = "miss"
+)
+
+// localItem notExist 为 true 的时候缓存的是文章不存在
+type localItem struct {
+	art      domain.Article
+	notExist bool
+	expire   time.Time
+}
+
+func (m *MultiLevelArticleCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
+	if val, ok := m.local.Get(id); ok {
+		item := val.(localItem)
+		if time.Now().Before(item.expire) {
+			m.counter.WithLabelValues(cacheLevelLocal, cacheResultHit).Inc()
+			if item.notExist {
+				return domain.Article{}, ErrArticleNotExist
+			}
+			return item.art, nil
+		}
+		m.local.Remove(id)
+	}
+	m.counter.WithLabelValues(cacheLevelLocal, cacheResultMiss).Inc()
+
+	art, err := m.ArticleCache.GetPub(ctx, id)
+	switch err {
+	case nil:
+		m.counter.WithLabelValues(cacheLevelRedis, cacheResultHit).Inc()
+		m.setLocal(id, localItem{art: art})
+	case ErrArticleNotExist:
+		m.counter.WithLabelValues(cacheLevelRedis, cacheResultHit).Inc()
+		m.setLocal(id, localItem{notExist: true})
+	default:
+		m.counter.WithLabelValues(cacheLevelRedis, cacheResultMiss).Inc()
+	}
+	return art, err
+}
+
+func (m *MultiLevelArticleCache) SetPub(ctx context.Context, id int64, art domain.Article) error {
+	m.setLocal(id, localItem{art: art})
+	return m.ArticleCache.SetPub(ctx, id, art)
+}
+
+func (m *MultiLevelArticleCache) SetPubNotExist(ctx context.Context, id int64) error {
+	m.setLocal(id, localItem{notExist: true})
+	return m.ArticleCache.SetPubNotExist(ctx, id)
+}
+
+func (m *MultiLevelArticleCache) Del(ctx context.Context, id int64) error {
+	m.local.Remove(id)
+	return m.ArticleCache.Del(ctx, id)
+}
+
+func (m *MultiLevelArticleCache) setLocal(id int64, item localItem) {
+	item.expire = time.Now().Add(m.localExpiration)
+	m.local.Add(id, item)
+}
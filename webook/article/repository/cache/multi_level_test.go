@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	cachemocks "github.com/TengFeiyang01/webook/webook/article/repository/cache/mocks"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMultiLevelArticleCache_GetPub(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) ArticleCache
+		// 调用几次 GetPub
+		times int
+
+		wantArt      domain.Article
+		wantErr      error
+		wantLocalHit float64
+		wantRedisHit float64
+	}{
+		{
+			name: "Redis 命中之后放进本地缓存",
+			mock: func(ctrl *gomock.Controller) ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Title: "标题"}, nil)
+				return c
+			},
+			times:        3,
+			wantArt:      domain.Article{Id: 1, Title: "标题"},
+			wantLocalHit: 2,
+			wantRedisHit: 1,
+		},
+		{
+			name: "不存在的文章也放进本地缓存",
+			mock: func(ctrl *gomock.Controller) ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, ErrArticleNotExist)
+				return c
+			},
+			times:        2,
+			wantErr:      ErrArticleNotExist,
+			wantLocalHit: 1,
+			wantRedisHit: 1,
+		},
+		{
+			name: "Redis 没有就不放本地缓存",
+			mock: func(ctrl *gomock.Controller) ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, ErrKeyNotExist).Times(2)
+				return c
+			},
+			times:   2,
+			wantErr: ErrKeyNotExist,
+		},
+		{
+			name: "Redis 出错",
+			mock: func(ctrl *gomock.Controller) ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).
+					Return(domain.Article{}, errors.New("redis 错误"))
+				return c
+			},
+			times:   1,
+			wantErr: errors.New("redis 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			local, err := lru.New(10)
+			require.NoError(t, err)
+			c := newMultiLevelArticleCache(tc.mock(ctrl), local, time.Minute)
+			var art domain.Article
+			for i := 0; i < tc.times; i++ {
+				art, err = c.GetPub(context.Background(), 1)
+			}
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, art)
+			assert.Equal(t, tc.wantLocalHit,
+				testutil.ToFloat64(c.counter.WithLabelValues(cacheLevelLocal, cacheResultHit)))
+			assert.Equal(t, tc.wantRedisHit,
+				testutil.ToFloat64(c.counter.WithLabelValues(cacheLevelRedis, cacheResultHit)))
+		})
+	}
+}
+
+func TestMultiLevelArticleCache_Expire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	redisCache := cachemocks.NewMockArticleCache(ctrl)
+	local, err := lru.New(10)
+	require.NoError(t, err)
+	c := newMultiLevelArticleCache(redisCache, local, time.Millisecond*10)
+	ctx := context.Background()
+
+	redisCache.EXPECT().SetPub(gomock.Any(), int64(1), domain.Article{Id: 1}).Return(nil).Times(2)
+	require.NoError(t, c.SetPub(ctx, 1, domain.Article{Id: 1}))
+	art, err := c.GetPub(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), art.Id)
+
+	// 本地缓存过期之后查 Redis
+	time.Sleep(time.Millisecond * 20)
+	redisCache.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, ErrKeyNotExist)
+	_, err = c.GetPub(ctx, 1)
+	assert.Equal(t, ErrKeyNotExist, err)
+
+	// 删除的时候两级都要删
+	require.NoError(t, c.SetPub(ctx, 1, domain.Article{Id: 1}))
+	redisCache.EXPECT().Del(gomock.Any(), int64(1)).Return(nil)
+	require.NoError(t, c.Del(ctx, 1))
+	_, ok := local.Get(int64(1))
+	assert.False(t, ok)
+}
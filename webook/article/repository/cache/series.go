@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// SeriesCache 缓存系列本身和文章的顺序，详情页的导航每次都要用到
+type SeriesCache interface {
+	Get(ctx context.Context, id int64) (domain.Series, error)
+	Set(ctx context.Context, s domain.Series) error
+	Del(ctx context.Context, id int64) error
+}
+
+type RedisSeriesCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewSeriesCache(client redis.Cmdable) SeriesCache {
+	return &RedisSeriesCache{
+		client:     client,
+		expiration: time.Minute * 10,
+	}
+}
+
+func (r *RedisSeriesCache) Get(ctx context.Context, id int64) (domain.Series, error) {
+	bs, err := r.client.Get(ctx, r.key(id)).Bytes()
+	if err != nil {
+		return domain.Series{}, err
+	}
+	var s domain.Series
+	err = json.Unmarshal(bs, &s)
+	return s, err
+}
+
+func (r *RedisSeriesCache) Set(ctx context.Context, s domain.Series) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.key(s.Id), data, r.expiration).Err()
+}
+
+func (r *RedisSeriesCache) Del(ctx context.Context, id int64) error {
+	return r.client.Del(ctx, r.key(id)).Err()
+}
+
+func (r *RedisSeriesCache) key(id int64) string {
+	return fmt.Sprintf("series:%d", id)
+}
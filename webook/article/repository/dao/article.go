@@ -2,51 +2,152 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/ecodeclub/ekit/slice"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
 )
 
+var (
+	// ErrVersionConflict 文章已经被别人更新过了，调用方拿着的是旧版本
+	ErrVersionConflict = errors.New("文章版本冲突")
+	// ErrArticleNotFound 文章不存在、不属于该作者，或者不处于要求的删除状态
+	ErrArticleNotFound = gorm.ErrRecordNotFound
+)
+
 type ArticleDAO interface {
 	Insert(ctx context.Context, art Article) (int64, error)
+	// UpdateById 每次更新版本号都会加一
+	// art.Version 大于 0 的时候要求数据库里面的版本号和它一致，否则返回 ErrVersionConflict
+	// 等于 0 的时候不检查版本号，兼容老的调用方
 	UpdateById(ctx context.Context, art Article) error
 	Sync(ctx context.Context, art Article) (int64, error)
 	Upsert(ctx context.Context, art PublishedArticleV1) error
+	// SyncStatus 同时修改制作库和线上库的状态，文章不属于 author 的时候返回错误
 	SyncStatus(ctx context.Context, id int64, author int64, status uint8) error
 	GetByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Article, error)
+	// GetById 查询制作库，文章不存在返回 ErrArticleNotFound
 	GetById(ctx context.Context, id int64) (Article, error)
+	// GetPubById 查询线上库，文章没有发表过返回 ErrArticleNotFound
 	GetPubById(ctx context.Context, id int64) (Article, error)
+	// ListPub 查询线上库里面 start 之前更新过的文章，按照 utime 倒序
 	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error)
+	// GetByAuthorCursor 按照 (utime, id) 倒序翻页，只取排在 (utime, id) 之后的
+	// utime 为 0 表示第一页
+	GetByAuthorCursor(ctx context.Context, author int64, utime int64, id int64, limit int) ([]Article, error)
+	// ListPubCursor 和 GetByAuthorCursor 一样，第一页传 start 和 0 就可以
+	ListPubCursor(ctx context.Context, utime int64, id int64, limit int) ([]Article, error)
+	// ListPubByTag 和 ListPub 一样查询线上库，只要带着这个标签、处于 status 状态的文章
+	ListPubByTag(ctx context.Context, tag string, status uint8, start time.Time, offset int, limit int) ([]Article, error)
+	// ListPubByAuthor 和 ListPub 一样查询线上库，只要这个作者处于 status 状态的文章
+	ListPubByAuthor(ctx context.Context, author int64, status uint8, start time.Time, offset int, limit int) ([]Article, error)
+	// GetPubTitles 批量查询线上库，只返回 id、标题、作者和状态，不加载内容
+	// 查不到或者不处于 status 状态的 ID 直接跳过，返回的顺序和 ids 无关
+	GetPubTitles(ctx context.Context, ids []int64, status uint8) ([]Article, error)
+
+	// Delete 把文章移进回收站，制作库和线上库一起软删除
+	// 上面的查询都不会返回回收站里面的文章
+	Delete(ctx context.Context, id int64, author int64) error
+	// ListTrash 按照删除时间倒序
+	ListTrash(ctx context.Context, author int64, offset int, limit int) ([]Article, error)
+	Restore(ctx context.Context, id int64, author int64) error
+	// Purge 彻底删除，只能删除已经在回收站里面的文章
+	Purge(ctx context.Context, id int64, author int64) error
+	// ListExpiredTrash 找出删除时间早于 before 的文章，给定时清理用
+	ListExpiredTrash(ctx context.Context, before int64, limit int) ([]Article, error)
 }
 
 type GORMArticleDAO struct {
 	db *gorm.DB
+	// nextId 为空的时候用数据库的自增主键，分库之后由 ShardingArticleDAO 设置
+	nextId func(author int64) int64
 }
 
 func (dao *GORMArticleDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
 	var res []Article
-	err := dao.db.WithContext(ctx).Where("utime < ?", start.UnixMilli()).
+	err := dao.db.WithContext(ctx).Model(&PublishedArticleV1{}).
+		Where("utime < ? AND deleted_at = 0", start.UnixMilli()).
 		Order("utime desc").Offset(offset).Limit(limit).Find(&res).Error
 	return res, err
 }
 
+func (dao *GORMArticleDAO) ListPubCursor(ctx context.Context, utime int64, id int64, limit int) ([]Article, error) {
+	var res []Article
+	err := dao.db.WithContext(ctx).Model(&PublishedArticleV1{}).
+		Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id).
+		Where("deleted_at = 0").
+		Order("utime DESC, id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) ListPubByTag(ctx context.Context, tag string, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	var res []PublishedArticleV1
+	err := pubByTag(dao.db.WithContext(ctx).Model(&PublishedArticleV1{}), "published_article_v1",
+		tag, status, start, offset, limit).Find(&res).Error
+	return slice.Map(res, func(idx int, src PublishedArticleV1) Article {
+		return src.Article
+	}), err
+}
+
+func (dao *GORMArticleDAO) ListPubByAuthor(ctx context.Context, author int64, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	var res []PublishedArticleV1
+	err := pubByAuthor(dao.db.WithContext(ctx).Model(&PublishedArticleV1{}),
+		author, status, start, offset, limit).Find(&res).Error
+	return slice.Map(res, func(idx int, src PublishedArticleV1) Article {
+		return src.Article
+	}), err
+}
+
+func pubByAuthor(db *gorm.DB, author int64, status uint8, start time.Time, offset int, limit int) *gorm.DB {
+	return db.Where("author_id = ? AND status = ? AND utime < ? AND deleted_at = 0",
+		author, status, start.UnixMilli()).
+		Order("utime DESC").
+		Offset(offset).Limit(limit)
+}
+
+func (dao *GORMArticleDAO) GetPubTitles(ctx context.Context, ids []int64, status uint8) ([]Article, error) {
+	var res []PublishedArticleV1
+	err := pubTitles(dao.db.WithContext(ctx).Model(&PublishedArticleV1{}), ids, status).Find(&res).Error
+	return slice.Map(res, func(idx int, src PublishedArticleV1) Article {
+		return src.Article
+	}), err
+}
+
+func pubTitles(db *gorm.DB, ids []int64, status uint8) *gorm.DB {
+	return db.Select("id", "title", "author_id", "status").
+		Where("id IN ? AND status = ? AND deleted_at = 0", ids, status)
+}
+
+// pubByTag 线上库和标签表 JOIN，table 是线上库的表名
+func pubByTag(db *gorm.DB, table string, tag string, status uint8, start time.Time, offset int, limit int) *gorm.DB {
+	return db.Select(table+".*").
+		Joins("JOIN article_tags ON article_tags.article_id = "+table+".id").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("tags.name = ?", tag).
+		Where(table+".status = ? AND "+table+".utime < ? AND "+table+".deleted_at = 0",
+			status, start.UnixMilli()).
+		Order(table + ".utime DESC").
+		Offset(offset).Limit(limit)
+}
+
 func (dao *GORMArticleDAO) GetPubById(ctx context.Context, id int64) (Article, error) {
-	var art Article
-	err := dao.db.WithContext(ctx).First(&art, "id = ?", id).Error
-	return art, err
+	var art PublishedArticleV1
+	err := dao.db.WithContext(ctx).First(&art, "id = ? AND deleted_at = 0", id).Error
+	return art.Article, err
 }
 
 func (dao *GORMArticleDAO) GetById(ctx context.Context, id int64) (Article, error) {
 	var art Article
-	err := dao.db.WithContext(ctx).Where("id=?", id).First(&art).Error
+	err := dao.db.WithContext(ctx).Where("id=? AND deleted_at = 0", id).First(&art).Error
 	return art, err
 }
 
 func (dao *GORMArticleDAO) GetByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Article, error) {
 	var arts []Article
 	err := dao.db.WithContext(ctx).Model(&Article{}).
-		Where("author_id = ?", author).
+		Where("author_id = ? AND deleted_at = 0", author).
 		Offset(offset).
 		Limit(limit).
 		// 升序老徐. utime ASC
@@ -56,6 +157,108 @@ func (dao *GORMArticleDAO) GetByAuthor(ctx context.Context, author int64, offset
 	return arts, err
 }
 
+func (dao *GORMArticleDAO) GetByAuthorCursor(ctx context.Context, author int64, utime int64, id int64, limit int) ([]Article, error) {
+	var arts []Article
+	db := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? AND deleted_at = 0", author)
+	if utime > 0 {
+		// 不能只用 utime，同一毫秒更新的文章会被跳过或者重复
+		db = db.Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id)
+	}
+	err := db.Order("utime DESC, id DESC").Limit(limit).Find(&arts).Error
+	return arts, err
+}
+
+func (dao *GORMArticleDAO) Delete(ctx context.Context, id int64, author int64) error {
+	return dao.setDeletedAt(ctx, id, author, time.Now().UnixMilli(), &PublishedArticleV1{})
+}
+
+func (dao *GORMArticleDAO) Restore(ctx context.Context, id int64, author int64) error {
+	return dao.setDeletedAt(ctx, id, author, 0, &PublishedArticleV1{})
+}
+
+// setDeletedAt deletedAt 为 0 是恢复，否则是删除
+// pub 是线上库的表，不同的实现线上库不一样
+func (dao *GORMArticleDAO) setDeletedAt(ctx context.Context, id int64, author int64, deletedAt int64, pub any) error {
+	cond := "deleted_at = 0"
+	if deletedAt == 0 {
+		cond = "deleted_at > 0"
+	}
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ?", id, author).Where(cond).
+			Update("deleted_at", deletedAt)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		// 线上库可能没有，也就是从来没有发表过
+		res = tx.Model(pub).Where("id = ?", id).
+			Update("deleted_at", deletedAt)
+		if res.Error != nil {
+			return res.Error
+		}
+		published := res.RowsAffected > 0
+		if !published && deletedAt > 0 {
+			// 没有发表过的文章下游不知道，删除的时候不用通知
+			return nil
+		}
+		// 对于下游来说，回收站里的文章和撤回了的一样，都是不可见的
+		// 恢复之后按照原来的状态再通知一次，已经发表的文章要重新可见
+		var art Article
+		err := tx.Where("id = ?", id).First(&art).Error
+		if err != nil {
+			return err
+		}
+		status := art.Status
+		if deletedAt > 0 {
+			status = articleStatusPrivate
+		}
+		// 没有发表过的文章只有定时发表的要通知，恢复之后要重新安排任务
+		if !published && status != articleStatusScheduled {
+			return nil
+		}
+		return insertArticleEvent(tx, ArticleEvent{Id: id, AuthorId: author, Status: status,
+			Version: art.Version, PublishAt: art.PublishAt, Utime: time.Now().UnixMilli()})
+	})
+}
+
+func (dao *GORMArticleDAO) ListTrash(ctx context.Context, author int64, offset int, limit int) ([]Article, error) {
+	var res []Article
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ? AND deleted_at > 0", author).
+		Order("deleted_at DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMArticleDAO) Purge(ctx context.Context, id int64, author int64) error {
+	return dao.purge(ctx, id, author, &PublishedArticleV1{})
+}
+
+func (dao *GORMArticleDAO) purge(ctx context.Context, id int64, author int64, pub any) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND author_id = ? AND deleted_at > 0", id, author).
+			Delete(&Article{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		return tx.Where("id = ?", id).Delete(pub).Error
+	})
+}
+
+func (dao *GORMArticleDAO) ListExpiredTrash(ctx context.Context, before int64, limit int) ([]Article, error) {
+	var res []Article
+	err := dao.db.WithContext(ctx).
+		Where("deleted_at > 0 AND deleted_at < ?", before).
+		Order("deleted_at").Limit(limit).Find(&res).Error
+	return res, err
+}
+
 func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id int64, author int64, status uint8) error {
 	now := time.Now().UnixMilli()
 	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -74,12 +277,16 @@ func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id int64, author int6
 			// 用 Prometheus 打点，只要频繁出现，你就告警，然后手工介入排查
 			return fmt.Errorf("可能有人在搞你，误操作非自己的文章, uid: %d, author: %d", id, author)
 		}
-		return tx.Model(&Article{}).
+		err := tx.Model(&PublishedArticleV1{}).
 			Where("id = ?", id).
 			Updates(map[string]interface{}{
 				"status": status,
 				"utime":  now,
 			}).Error
+		if err != nil {
+			return err
+		}
+		return syncStatusEvent(tx, id, author, status, now)
 	})
 }
 
@@ -92,17 +299,24 @@ func (dao *GORMArticleDAO) Sync(ctx context.Context, art Article) (int64, error)
 	)
 	err := dao.db.Transaction(func(tx *gorm.DB) error {
 		var err error
-		txDAO := NewGORMArticleDAO(tx)
-		if id > 0 {
-			err = txDAO.UpdateById(ctx, art)
-		} else {
-			id, err = txDAO.Insert(ctx, art)
+		txDAO := &GORMArticleDAO{db: tx, nextId: dao.nextId}
+		id, err = txDAO.save(ctx, art)
+		if err != nil {
+			return err
 		}
+		art.Id = id
+		// 线上库的版本号和制作库保持一致
+		err = tx.Model(&Article{}).Where("id = ?", id).Pluck("version", &art.Version).Error
 		if err != nil {
 			return err
 		}
 		// 操作线上库了
-		return txDAO.Upsert(ctx, PublishedArticleV1{Article: art})
+		err = txDAO.Upsert(ctx, PublishedArticleV1{Article: art})
+		if err != nil {
+			return err
+		}
+		return insertArticleEvent(tx, ArticleEvent{Id: id, AuthorId: art.AuthorId,
+			Status: art.Status, Version: art.Version, Utime: time.Now().UnixMilli()})
 	})
 	return id, err
 }
@@ -114,11 +328,14 @@ func (dao *GORMArticleDAO) Upsert(ctx context.Context, art PublishedArticleV1) e
 	art.Ctime = now
 	art.Utime = now
 	err := dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			"title":   art.Title,
-			"content": art.Content,
-			"utime":   now,
-			"status":  art.Status,
+			"title":      art.Title,
+			"content":    art.Content,
+			"utime":      now,
+			"status":     art.Status,
+			"publish_at": art.PublishAt,
+			"version":    art.Version,
 		}),
 	}).Create(&art).Error
 	// INSERT xxx on DUPLICATE KEY UPDATE xxx
@@ -126,26 +343,106 @@ func (dao *GORMArticleDAO) Upsert(ctx context.Context, art PublishedArticleV1) e
 }
 
 func (dao *GORMArticleDAO) UpdateById(ctx context.Context, art Article) error {
+	if art.Tags == nil && art.RevisionKind == 0 && art.Status != articleStatusScheduled {
+		return dao.updateById(ctx, art)
+	}
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txDAO := &GORMArticleDAO{db: tx, nextId: dao.nextId}
+		if err := txDAO.updateById(ctx, art); err != nil {
+			return err
+		}
+		return txDAO.afterSave(art.Id, art)
+	})
+}
+
+// save 保存制作库，art.Tags 不为 nil 的时候顺便整体覆盖标签，RevisionKind 不为 0 的时候记一个历史版本
+// dao.db 必须是事务，标签、历史版本和文章要么一起成功，要么一起失败
+func (dao *GORMArticleDAO) save(ctx context.Context, art Article) (int64, error) {
+	var (
+		id  = art.Id
+		err error
+	)
+	if id > 0 {
+		err = dao.updateById(ctx, art)
+	} else {
+		id, err = dao.insert(ctx, art)
+	}
+	if err != nil {
+		return id, err
+	}
+	return id, dao.afterSave(id, art)
+}
+
+// afterSave 制作库保存成功之后，在同一个事务里面覆盖标签、记录历史版本
+// 定时发表的文章还要写一个事件，发表的任务由 BFF 消费之后安排，这样定时状态和任务不会只成功一半
+func (dao *GORMArticleDAO) afterSave(id int64, art Article) error {
+	if art.Tags != nil {
+		if err := setArticleTags(dao.db, id, art.Tags); err != nil {
+			return err
+		}
+	}
+	if art.Status == articleStatusScheduled {
+		err := insertArticleEvent(dao.db, ArticleEvent{Id: id, AuthorId: art.AuthorId,
+			Status: art.Status, PublishAt: art.PublishAt, Utime: time.Now().UnixMilli()})
+		if err != nil {
+			return err
+		}
+	}
+	if art.RevisionKind == 0 {
+		return nil
+	}
+	// 更新的时候已经锁住了文章那一行，同一篇文章的保存在这里排队，版本号不会重复
+	rev := ArticleRevision{
+		ArticleId: id,
+		AuthorId:  art.AuthorId,
+		Title:     art.Title,
+		Content:   art.Content,
+		Kind:      art.RevisionKind,
+	}
+	if dao.nextId != nil {
+		// 分库之后历史版本也要带着作者的基因，按照 ID 查询的时候才能路由
+		rev.Id = dao.nextId(art.AuthorId)
+	}
+	_, err := insertRevision(dao.db, rev)
+	return err
+}
+
+func (dao *GORMArticleDAO) updateById(ctx context.Context, art Article) error {
 	art.Utime = time.Now().UnixMilli()
-	// 依赖 gorm 忽略零值, 会用主键进行更新
-	res := dao.db.WithContext(ctx).Model(&art).
-		Where("id = ? AND author_id = ?", art.Id, art.AuthorId).
-		Updates(map[string]interface{}{
-			"title":   art.Title,
-			"content": art.Content,
-			"status":  art.Status,
-			"utime":   art.Utime,
-		})
+	db := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND deleted_at = 0", art.Id, art.AuthorId)
+	if art.Version > 0 {
+		db = db.Where("version = ?", art.Version)
+	}
+	res := db.Updates(map[string]interface{}{
+		"title":      art.Title,
+		"content":    art.Content,
+		"status":     art.Status,
+		"publish_at": art.PublishAt,
+		"utime":      art.Utime,
+		"version":    gorm.Expr("version + 1"),
+	})
 	// 你要不要检查真的更新了
 	if res.Error != nil {
 		return res.Error
 	}
 	if res.RowsAffected == 0 {
+		if art.Version > 0 && dao.exists(ctx, art.Id, art.AuthorId) {
+			return ErrVersionConflict
+		}
 		return fmt.Errorf("update art failed, the author maybe invalid. id:[%d], author_id:[%d]", art.Id, art.AuthorId)
 	}
 	return res.Error
 }
 
+// exists 用来区分版本冲突和作者不对
+func (dao *GORMArticleDAO) exists(ctx context.Context, id int64, author int64) bool {
+	var cnt int64
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND deleted_at = 0", id, author).Count(&cnt).Error
+	return err == nil && cnt > 0
+}
+
 func NewGORMArticleDAO(db *gorm.DB) ArticleDAO {
 	return &GORMArticleDAO{
 		db: db,
@@ -153,9 +450,30 @@ func NewGORMArticleDAO(db *gorm.DB) ArticleDAO {
 }
 
 func (dao *GORMArticleDAO) Insert(ctx context.Context, art Article) (int64, error) {
+	if art.Tags == nil && art.RevisionKind == 0 && art.Status != articleStatusScheduled {
+		return dao.insert(ctx, art)
+	}
+	var id int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txDAO := &GORMArticleDAO{db: tx, nextId: dao.nextId}
+		var err error
+		id, err = txDAO.insert(ctx, art)
+		if err != nil {
+			return err
+		}
+		return txDAO.afterSave(id, art)
+	})
+	return id, err
+}
+
+func (dao *GORMArticleDAO) insert(ctx context.Context, art Article) (int64, error) {
 	now := time.Now().UnixMilli()
 	art.Ctime = now
 	art.Utime = now
+	art.Version = 1
+	if dao.nextId != nil {
+		art.Id = dao.nextId(art.AuthorId)
+	}
 	err := dao.db.WithContext(ctx).Create(&art).Error
 	return art.Id, err
 }
@@ -165,9 +483,22 @@ type Article struct {
 	Title   string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
 	Content string `gorm:"type=BLOB" bson:"content,omitempty"`
 	// 我要根据创作者ID来查询
-	AuthorId int64 `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8 `bson:"status,omitempty"`
-	Ctime    int64 `bson:"ctime,omitempty"`
-	// 更新时间
-	Utime int64 `bson:"utime,omitempty"`
+	AuthorId int64 `gorm:"index;index:idx_author_utime_id,priority:1" bson:"author_id,omitempty"`
+	// 审核队列按照 (status, utime) 查询
+	Status uint8 `gorm:"index:idx_status_utime,priority:1" bson:"status,omitempty"`
+	// 定时发表的时间
+	PublishAt int64 `bson:"publish_at,omitempty"`
+	Ctime     int64 `bson:"ctime,omitempty"`
+	// Version 乐观锁，每次更新制作库都会加一，线上库直接复制制作库的
+	Version int64 `gorm:"not null;default:1" bson:"version,omitempty"`
+	// DeletedAt 移进回收站的时间，0 表示没有删除
+	DeletedAt int64 `gorm:"index" bson:"deleted_at,omitempty"`
+	// 更新时间，翻页按照 (utime, id) 倒序
+	Utime int64 `gorm:"index:idx_utime_id,priority:1;index:idx_author_utime_id,priority:2;index:idx_status_utime,priority:2" bson:"utime,omitempty"`
+
+	// Tags 不是表里的字段，保存的时候不为 nil 就在同一个事务里面整体覆盖文章的标签
+	// nil 表示不动标签，空切片表示清空
+	Tags []string `gorm:"-" bson:"-"`
+	// RevisionKind 也不是表里的字段，不为 0 的时候在同一个事务里面把这次保存的内容记成历史版本
+	RevisionKind uint8 `gorm:"-" bson:"-"`
 }
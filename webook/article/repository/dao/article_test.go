@@ -0,0 +1,183 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMArticleDAO_UpdateById(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+		art  Article
+
+		wantErr error
+	}{
+		{
+			name: "带版本号更新成功",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .*`version`=version \\+ 1.* WHERE \\(id = \\? AND author_id = \\? AND deleted_at = 0\\) AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDb
+			},
+			art: Article{Id: 1, AuthorId: 123, Version: 3},
+		},
+		{
+			name: "不带版本号不检查",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .* WHERE id = \\? AND author_id = \\? AND deleted_at = 0$").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDb
+			},
+			art: Article{Id: 1, AuthorId: 123},
+		},
+		{
+			name: "版本冲突",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				return mockDb
+			},
+			art:     Article{Id: 1, AuthorId: 123, Version: 3},
+			wantErr: ErrVersionConflict,
+		},
+		{
+			name: "作者不对",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				return mockDb
+			},
+			art:     Article{Id: 1, AuthorId: 123, Version: 3},
+			wantErr: errors.New("update art failed, the author maybe invalid. id:[1], author_id:[123]"),
+		},
+		{
+			name: "历史版本写失败，文章也不更新",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(version\\), 0\\) FROM `article_revisions`").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+				mock.ExpectExec("INSERT INTO `article_revisions`").
+					WillReturnError(errors.New("mock db error"))
+				mock.ExpectRollback()
+				return mockDb
+			},
+			art:     Article{Id: 1, AuthorId: 123, Version: 3, RevisionKind: 1},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db)
+			err = d.UpdateById(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMArticleDAO_Delete(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+
+		wantErr error
+	}{
+		{
+			name: "制作库和线上库一起删除",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `deleted_at`=.* WHERE \\(id = \\? AND author_id = \\?\\) AND deleted_at = 0").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `published_article_v1` SET `deleted_at`=.* WHERE id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				// 发表过的文章进回收站要通知下游
+				mock.ExpectQuery("SELECT \\* FROM `articles` WHERE id = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "version"}).AddRow(1, 2, 3))
+				mock.ExpectExec("INSERT INTO `outbox_messages`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDb
+			},
+		},
+		{
+			name: "从来没有发表过",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `deleted_at`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `published_article_v1` SET `deleted_at`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return mockDb
+			},
+		},
+		{
+			name: "文章不存在或者已经删除",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `deleted_at`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return mockDb
+			},
+			wantErr: ErrArticleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db)
+			err = d.Delete(context.Background(), 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAttachmentNotFound 附件不存在，或者不属于该作者
+var ErrAttachmentNotFound = gorm.ErrRecordNotFound
+
+type AttachmentDAO interface {
+	Insert(ctx context.Context, a Attachment) (int64, error)
+	GetById(ctx context.Context, id int64) (Attachment, error)
+	// MarkUploaded 只有还在等待上传的附件才能标记，用实际上传的大小和类型覆盖
+	MarkUploaded(ctx context.Context, id int64, size int64, contentType string) error
+	// ListByAuthor 按照 id 倒序
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Attachment, error)
+	// SumSize 作者所有附件的总大小
+	SumSize(ctx context.Context, author int64) (int64, error)
+	Delete(ctx context.Context, id int64) error
+	// ListBefore 按照 id 升序列出 id 大于 startId 并且在 before 之前创建的附件
+	ListBefore(ctx context.Context, before int64, startId int64, limit int) ([]Attachment, error)
+	// Referenced 有没有历史版本引用了 key
+	Referenced(ctx context.Context, key string) (bool, error)
+}
+
+type GORMAttachmentDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMAttachmentDAO(db *gorm.DB) AttachmentDAO {
+	return &GORMAttachmentDAO{db: db}
+}
+
+func (dao *GORMAttachmentDAO) Insert(ctx context.Context, a Attachment) (int64, error) {
+	now := time.Now().UnixMilli()
+	a.Ctime = now
+	a.Utime = now
+	err := dao.db.WithContext(ctx).Create(&a).Error
+	return a.Id, err
+}
+
+func (dao *GORMAttachmentDAO) GetById(ctx context.Context, id int64) (Attachment, error) {
+	var a Attachment
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&a).Error
+	return a, err
+}
+
+func (dao *GORMAttachmentDAO) MarkUploaded(ctx context.Context, id int64, size int64, contentType string) error {
+	res := dao.db.WithContext(ctx).Model(&Attachment{}).
+		Where("id = ? AND status = ?", id, attachmentStatusPending).
+		Updates(map[string]any{
+			"size":         size,
+			"content_type": contentType,
+			"status":       attachmentStatusUploaded,
+			"utime":        time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func (dao *GORMAttachmentDAO) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Attachment, error) {
+	var res []Attachment
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ?", author).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMAttachmentDAO) SumSize(ctx context.Context, author int64) (int64, error) {
+	var size int64
+	err := dao.db.WithContext(ctx).Model(&Attachment{}).
+		Select("COALESCE(SUM(size), 0)").
+		Where("author_id = ?", author).
+		Scan(&size).Error
+	return size, err
+}
+
+func (dao *GORMAttachmentDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Where("id = ?", id).Delete(&Attachment{}).Error
+}
+
+func (dao *GORMAttachmentDAO) ListBefore(ctx context.Context, before int64, startId int64, limit int) ([]Attachment, error) {
+	var res []Attachment
+	err := dao.db.WithContext(ctx).
+		Where("id > ? AND ctime < ?", startId, before).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// Referenced 引用关系在保存历史版本的时候就记下来了，不需要再扫描内容
+func (dao *GORMAttachmentDAO) Referenced(ctx context.Context, key string) (bool, error) {
+	var ref AttachmentRef
+	err := dao.db.WithContext(ctx).Select("id").
+		Where("`key` = ?", key).
+		First(&ref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// attachmentKeyPattern 和 service 里面生成的 key 保持一致：attachments/{作者}/{uuid}{扩展名}
+var attachmentKeyPattern = regexp.MustCompile(`attachments/[0-9]+/[0-9A-Za-z-]+\.[0-9A-Za-z]+`)
+
+// insertAttachmentRefs 和历史版本、文章在同一个事务里面写入
+// 写不进去的话这次保存也失败，不会出现文章里面还在用的附件没有引用、被定时任务删掉的情况
+func insertAttachmentRefs(tx *gorm.DB, rev ArticleRevision) error {
+	keys := attachmentKeyPattern.FindAllString(rev.Content, -1)
+	if len(keys) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(keys))
+	refs := make([]AttachmentRef, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		refs = append(refs, AttachmentRef{
+			Key:        key,
+			ArticleId:  rev.ArticleId,
+			RevisionId: rev.Id,
+			Ctime:      rev.Ctime,
+		})
+	}
+	return tx.Create(&refs).Error
+}
+
+const (
+	attachmentStatusPending  uint8 = 1
+	attachmentStatusUploaded uint8 = 2
+)
+
+// Attachment 附件的元数据，内容在对象存储里面
+type Attachment struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	AuthorId    int64  `gorm:"index"`
+	Key         string `gorm:"type=varchar(256);uniqueIndex"`
+	Filename    string `gorm:"type=varchar(256)"`
+	ContentType string `gorm:"type=varchar(128)"`
+	Size        int64
+	Status      uint8
+	Ctime       int64 `gorm:"index"`
+	Utime       int64
+}
+
+// AttachmentRef 历史版本引用了哪个附件，历史版本只插入不更新，所以引用也一样
+type AttachmentRef struct {
+	Id         int64  `gorm:"primaryKey,autoIncrement"`
+	Key        string `gorm:"type:varchar(256);index"`
+	ArticleId  int64  `gorm:"index"`
+	RevisionId int64
+	Ctime      int64
+}
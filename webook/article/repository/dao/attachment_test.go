@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMAttachmentDAO(t *testing.T) {
+	db := initSQLiteDB(t)
+	dao := NewGORMAttachmentDAO(db)
+	revDAO := NewGORMArticleRevisionDAO(db)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, key := range []string{"attachments/123/a.png", "attachments/123/b.png", "attachments/456/c.gif"} {
+		author := int64(123)
+		if key == "attachments/456/c.gif" {
+			author = 456
+		}
+		id, err := dao.Insert(ctx, Attachment{AuthorId: author, Key: key, Size: 100,
+			ContentType: "image/png", Status: attachmentStatusPending})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// 等待上传的也占用空间
+	size, err := dao.SumSize(ctx, 123)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), size)
+
+	require.NoError(t, dao.MarkUploaded(ctx, ids[0], 80, "image/png"))
+	// 已经上传过的不能再标记
+	assert.Equal(t, ErrAttachmentNotFound, dao.MarkUploaded(ctx, ids[0], 80, "image/png"))
+	a, err := dao.GetById(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, attachmentStatusUploaded, a.Status)
+	assert.Equal(t, int64(80), a.Size)
+
+	arts, err := dao.ListByAuthor(ctx, 123, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, arts, 2)
+	assert.Equal(t, ids[1], arts[0].Id)
+
+	// 按照 id 翻页
+	now := time.Now().Add(time.Second).UnixMilli()
+	arts, err = dao.ListBefore(ctx, now, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, arts, 2)
+	arts, err = dao.ListBefore(ctx, now, arts[1].Id, 2)
+	require.NoError(t, err)
+	require.Len(t, arts, 1)
+	assert.Equal(t, ids[2], arts[0].Id)
+	arts, err = dao.ListBefore(ctx, 0, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+
+	ok, err := dao.Referenced(ctx, "attachments/123/a.png")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	// 引用跟着文章的保存一起写进去
+	artId, err := NewGORMArticleDAO(db).Insert(ctx, Article{AuthorId: 123, RevisionKind: 1,
+		Content: `<p><img src="http://localhost:8088/attachments/attachments/123/a.png"/></p>`})
+	require.NoError(t, err)
+	ok, err = dao.Referenced(ctx, "attachments/123/a.png")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = dao.Referenced(ctx, "attachments/123/b.png")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	// 文章彻底删除之后就没有引用了
+	require.NoError(t, revDAO.DeleteByArticle(ctx, artId))
+	ok, err = dao.Referenced(ctx, "attachments/123/a.png")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, dao.Delete(ctx, ids[1]))
+	_, err = dao.GetById(ctx, ids[1])
+	assert.Equal(t, ErrAttachmentNotFound, err)
+}
+
+// TestGORMArticleDAO_AttachmentRefs 引用写失败的时候文章也不能保存成功，
+// 不然文章里面的图片没有引用，会被定时任务删掉
+func TestGORMArticleDAO_AttachmentRefs(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `articles`").
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(version\\), 0\\) FROM `article_revisions`").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(0))
+	mock.ExpectExec("INSERT INTO `article_revisions`").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("INSERT INTO `attachment_refs`").
+		WillReturnError(errors.New("mock db error"))
+	mock.ExpectRollback()
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	_, err = NewGORMArticleDAO(db).Insert(context.Background(), Article{AuthorId: 123, RevisionKind: 1,
+		Content: `<img src="http://localhost:8088/attachments/attachments/123/a.png"/>`})
+	assert.Equal(t, errors.New("mock db error"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
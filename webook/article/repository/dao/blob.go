@@ -0,0 +1,356 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/ecodeclub/ekit/slice"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleBlobDAO 线上库只保存元数据和比较短的内容，大的内容放到对象存储里面
+// 制作库流量不大、并发不高，还是完整保存在数据库里面
+type ArticleBlobDAO struct {
+	GORMArticleDAO
+	store blobstore.Store
+	// threshold 内容超过这个字节数就放到对象存储
+	threshold int
+}
+
+func NewArticleBlobDAO(db *gorm.DB, store blobstore.Store, threshold int) *ArticleBlobDAO {
+	return &ArticleBlobDAO{GORMArticleDAO: GORMArticleDAO{db: db}, store: store, threshold: threshold}
+}
+
+func (a *ArticleBlobDAO) SyncStatus(ctx context.Context, id int64, author int64, status uint8) error {
+	now := time.Now().UnixMilli()
+	var oldKey string
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? and author_id = ?", id, author).
+			Updates(map[string]any{
+				"utime":  now,
+				"status": status,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return errors.New("ID 不对或者创作者不对")
+		}
+		updates := map[string]any{
+			"utime":  now,
+			"status": status,
+		}
+		if status == articleStatusPrivate {
+			// 撤回之后对象存储里面的内容就没用了，重新发表的时候会再写一次
+			// content_key 要一起清掉，不然还会去读一个已经删掉了的对象
+			var err error
+			oldKey, err = a.contentKey(tx, id)
+			if err != nil {
+				return err
+			}
+			updates["content_key"] = ""
+		}
+		err := tx.Model(&PublishedArticleV2{}).
+			Where("id = ?", id).
+			Updates(updates).Error
+		if err != nil {
+			return err
+		}
+		return syncStatusEvent(tx, id, author, status, now)
+	})
+	if err != nil {
+		return err
+	}
+	a.removeStale(ctx, oldKey, "")
+	return nil
+}
+
+func (a *ArticleBlobDAO) Sync(ctx context.Context, art Article) (int64, error) {
+	var (
+		id     = art.Id
+		oldKey string
+	)
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var (
+			err error
+		)
+
+		id, err = (&GORMArticleDAO{db: tx}).save(ctx, art)
+		if err != nil {
+			return err
+		}
+		art.Id = id
+		// 线上库的版本号和制作库保持一致
+		err = tx.Model(&Article{}).Where("id = ?", id).Pluck("version", &art.Version).Error
+		if err != nil {
+			return err
+		}
+		oldKey, err = a.contentKey(tx, id)
+		if err != nil {
+			return err
+		}
+		err = a.upsert(ctx, tx, art)
+		if err != nil {
+			return err
+		}
+		return insertArticleEvent(tx, ArticleEvent{Id: id, AuthorId: art.AuthorId,
+			Status: art.Status, Version: art.Version, Utime: time.Now().UnixMilli()})
+	})
+	if err != nil {
+		return 0, err
+	}
+	a.removeStale(ctx, oldKey, a.newKey(art))
+	return id, nil
+}
+
+// Upsert 和 Sync 一样，长的内容要放到对象存储
+func (a *ArticleBlobDAO) Upsert(ctx context.Context, art PublishedArticleV1) error {
+	db := a.db.WithContext(ctx)
+	oldKey, err := a.contentKey(db, art.Id)
+	if err != nil {
+		return err
+	}
+	err = a.upsert(ctx, db, art.Article)
+	if err != nil {
+		return err
+	}
+	a.removeStale(ctx, oldKey, a.newKey(art.Article))
+	return nil
+}
+
+// upsert 长的内容先写对象存储，成功了再写线上库
+// 每个版本的内容都有自己的 key，在提交之前读者看到的还是旧的 key 和旧的内容，
+// 提交失败也只是留下一个没有引用的对象
+func (a *ArticleBlobDAO) upsert(ctx context.Context, tx *gorm.DB, art Article) error {
+	now := time.Now().UnixMilli()
+	pubArt := PublishedArticleV2{
+		Id:         art.Id,
+		Title:      art.Title,
+		ContentKey: a.newKey(art),
+		AuthorId:   art.AuthorId,
+		Ctime:      now,
+		Utime:      now,
+		Status:     art.Status,
+		PublishAt:  art.PublishAt,
+		Version:    art.Version,
+	}
+	if pubArt.ContentKey != "" {
+		// 你要有监控、你要有补偿机制
+		err := a.store.Put(ctx, pubArt.ContentKey, []byte(art.Content), "text/plain;charset=utf-8")
+		if err != nil {
+			return err
+		}
+	} else {
+		pubArt.Content = art.Content
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"title":       pubArt.Title,
+			"content":     pubArt.Content,
+			"content_key": pubArt.ContentKey,
+			"utime":       now,
+			"status":      pubArt.Status,
+			"publish_at":  pubArt.PublishAt,
+			"version":     pubArt.Version,
+		}),
+	}).Create(&pubArt).Error
+}
+
+// contentKey 线上库现在引用的对象，没有发表过或者内容不在对象存储里面返回空字符串
+func (a *ArticleBlobDAO) contentKey(db *gorm.DB, id int64) (string, error) {
+	var keys []string
+	err := db.Model(&PublishedArticleV2{}).Where("id = ?", id).Pluck("content_key", &keys).Error
+	if err != nil || len(keys) == 0 {
+		return "", err
+	}
+	return keys[0], nil
+}
+
+// newKey 内容要放到对象存储的话，返回这个版本的 key
+func (a *ArticleBlobDAO) newKey(art Article) string {
+	if !a.external(art) {
+		return ""
+	}
+	return a.key(art.Id, art.Version)
+}
+
+// removeStale 线上库已经不再引用旧的对象了，删除失败只是多留下一个对象，不影响读者
+func (a *ArticleBlobDAO) removeStale(ctx context.Context, oldKey string, newKey string) {
+	if oldKey == "" || oldKey == newKey {
+		return
+	}
+	_ = a.store.Delete(ctx, oldKey)
+}
+
+func (a *ArticleBlobDAO) external(art Article) bool {
+	return len(art.Content) > a.threshold
+}
+
+// GetPubById 内容在对象存储里面的话要再读一次
+func (a *ArticleBlobDAO) GetPubById(ctx context.Context, id int64) (Article, error) {
+	var pub PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("id = ? AND deleted_at = 0", id).First(&pub).Error
+	if err != nil {
+		return Article{}, err
+	}
+	return a.toArticle(ctx, pub)
+}
+
+func (a *ArticleBlobDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
+	var pubs []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("utime < ? AND deleted_at = 0", start.UnixMilli()).
+		Order("utime desc").Offset(offset).Limit(limit).Find(&pubs).Error
+	if err != nil {
+		return nil, err
+	}
+	return a.toArticles(ctx, pubs)
+}
+
+func (a *ArticleBlobDAO) ListPubByTag(ctx context.Context, tag string, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	var pubs []PublishedArticleV2
+	err := pubByTag(a.db.WithContext(ctx).Model(&PublishedArticleV2{}), "published_article_v2",
+		tag, status, start, offset, limit).Find(&pubs).Error
+	if err != nil {
+		return nil, err
+	}
+	return a.toArticles(ctx, pubs)
+}
+
+func (a *ArticleBlobDAO) ListPubByAuthor(ctx context.Context, author int64, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	var pubs []PublishedArticleV2
+	err := pubByAuthor(a.db.WithContext(ctx).Model(&PublishedArticleV2{}),
+		author, status, start, offset, limit).Find(&pubs).Error
+	if err != nil {
+		return nil, err
+	}
+	return a.toArticles(ctx, pubs)
+}
+
+// GetPubTitles 不需要内容，不用去对象存储里面取
+func (a *ArticleBlobDAO) GetPubTitles(ctx context.Context, ids []int64, status uint8) ([]Article, error) {
+	var pubs []PublishedArticleV2
+	err := pubTitles(a.db.WithContext(ctx).Model(&PublishedArticleV2{}), ids, status).Find(&pubs).Error
+	return slice.Map(pubs, func(idx int, src PublishedArticleV2) Article {
+		return Article{Id: src.Id, Title: src.Title, AuthorId: src.AuthorId, Status: src.Status}
+	}), err
+}
+
+func (a *ArticleBlobDAO) ListPubCursor(ctx context.Context, utime int64, id int64, limit int) ([]Article, error) {
+	var pubs []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("utime < ? OR (utime = ? AND id < ?)", utime, utime, id).
+		Where("deleted_at = 0").
+		Order("utime DESC, id DESC").Limit(limit).Find(&pubs).Error
+	if err != nil {
+		return nil, err
+	}
+	return a.toArticles(ctx, pubs)
+}
+
+func (a *ArticleBlobDAO) toArticles(ctx context.Context, pubs []PublishedArticleV2) ([]Article, error) {
+	res := make([]Article, 0, len(pubs))
+	for _, pub := range pubs {
+		art, err := a.toArticle(ctx, pub)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, art)
+	}
+	return res, nil
+}
+
+func (a *ArticleBlobDAO) toArticle(ctx context.Context, pub PublishedArticleV2) (Article, error) {
+	content := pub.Content
+	if pub.ContentKey != "" {
+		data, err := a.store.Get(ctx, pub.ContentKey)
+		if err != nil {
+			return Article{}, err
+		}
+		content = string(data)
+	}
+	return Article{
+		Id:        pub.Id,
+		Title:     pub.Title,
+		Content:   content,
+		AuthorId:  pub.AuthorId,
+		Status:    pub.Status,
+		PublishAt: pub.PublishAt,
+		Version:   pub.Version,
+		Ctime:     pub.Ctime,
+		Utime:     pub.Utime,
+		DeletedAt: pub.DeletedAt,
+	}, nil
+}
+
+// Delete 放进回收站的时候对象存储上的内容先留着，恢复的时候还要用
+func (a *ArticleBlobDAO) Delete(ctx context.Context, id int64, author int64) error {
+	return a.setDeletedAt(ctx, id, author, time.Now().UnixMilli(), &PublishedArticleV2{})
+}
+
+func (a *ArticleBlobDAO) Restore(ctx context.Context, id int64, author int64) error {
+	return a.setDeletedAt(ctx, id, author, 0, &PublishedArticleV2{})
+}
+
+// Purge 彻底删除的时候才把对象存储上的内容删掉
+// 先删对象再删数据库，删对象失败的话文章还在回收站里面，下次还可以重试；
+// 反过来的话，数据库删掉之后就再也找不到这个对象了
+func (a *ArticleBlobDAO) Purge(ctx context.Context, id int64, author int64) error {
+	db := a.db.WithContext(ctx)
+	var cnt int64
+	err := db.Model(&Article{}).
+		Where("id = ? AND author_id = ? AND deleted_at > 0", id, author).
+		Count(&cnt).Error
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		// 不在回收站里面的文章，对象不能删
+		return ErrArticleNotFound
+	}
+	key, err := a.contentKey(db, id)
+	if err != nil {
+		return err
+	}
+	if key != "" {
+		err = a.store.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+	return a.purge(ctx, id, author, &PublishedArticleV2{})
+}
+
+// key 每个版本一个 key，同一个版本重复同步会覆盖
+func (a *ArticleBlobDAO) key(id int64, version int64) string {
+	return "articles/" + strconv.FormatInt(id, 10) + "/v" + strconv.FormatInt(version, 10)
+}
+
+var _ ArticleDAO = (*ArticleBlobDAO)(nil)
+
+type PublishedArticleV2 struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	Title string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	// Content 比较短的内容直接放在这里
+	Content string `gorm:"type:BLOB" bson:"content,omitempty"`
+	// ContentKey 不为空说明内容在对象存储里面
+	ContentKey string `gorm:"type:varchar(256)" bson:"content_key,omitempty"`
+	// 我要根据创作者ID来查询
+	AuthorId int64 `gorm:"index" bson:"author_id,omitempty"`
+	Status   uint8 `bson:"status,omitempty"`
+	// 定时发表的时间
+	PublishAt int64 `bson:"publish_at,omitempty"`
+	// Version 和制作库保持一致
+	Version int64 `gorm:"not null;default:1" bson:"version,omitempty"`
+	Ctime   int64 `bson:"ctime,omitempty"`
+	// 更新时间
+	Utime     int64 `bson:"utime,omitempty"`
+	DeletedAt int64 `gorm:"index" bson:"deleted_at,omitempty"`
+}
@@ -0,0 +1,168 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestArticleBlobDAO_Sync(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+
+		wantStored string
+	}{
+		{
+			name:       "大的内容放到对象存储",
+			content:    "这是一篇很长的文章",
+			wantStored: "这是一篇很长的文章",
+		},
+		{
+			name:    "小的内容直接放在线上库",
+			content: "短",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDb, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO `articles`").
+				WillReturnResult(sqlmock.NewResult(12, 1))
+			mock.ExpectQuery("SELECT `version` FROM `articles`").
+				WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+			mock.ExpectQuery("SELECT `content_key` FROM `published_article_v2`").
+				WillReturnRows(sqlmock.NewRows([]string{"content_key"}))
+			mock.ExpectExec("INSERT INTO `published_article_v2`").
+				WillReturnResult(sqlmock.NewResult(12, 1))
+			mock.ExpectCommit()
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      mockDb,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			store, err := blobstore.NewLocalStore(t.TempDir())
+			require.NoError(t, err)
+			// 中文一个字三个字节
+			d := NewArticleBlobDAO(db, store, 9)
+
+			id, err := d.Sync(context.Background(), Article{
+				Title:    "标题",
+				Content:  tc.content,
+				AuthorId: 123,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int64(12), id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+
+			data, err := store.Get(context.Background(), "articles/12/v1")
+			if tc.wantStored == "" {
+				assert.Equal(t, blobstore.ErrNotFound, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStored, string(data))
+		})
+	}
+}
+
+func TestArticleBlobDAO_ContentKey(t *testing.T) {
+	ctx := context.Background()
+	db := initSQLiteDB(t)
+	store, err := blobstore.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	d := NewArticleBlobDAO(db, store, 9)
+	contentKey := func(id int64) string {
+		var pub PublishedArticleV2
+		require.NoError(t, db.First(&pub, id).Error)
+		return pub.ContentKey
+	}
+
+	id, err := d.Sync(ctx, Article{Title: "标题", Content: "第一个版本的内容", AuthorId: 123, Status: statusPublished})
+	require.NoError(t, err)
+	firstKey := contentKey(id)
+	require.NotEmpty(t, firstKey)
+
+	// 新的版本写到新的 key，旧的对象在提交之后删掉
+	_, err = d.Sync(ctx, Article{Id: id, Title: "标题", Content: "第二个版本的内容", AuthorId: 123, Status: statusPublished})
+	require.NoError(t, err)
+	secondKey := contentKey(id)
+	assert.NotEqual(t, firstKey, secondKey)
+	_, err = store.Get(ctx, firstKey)
+	assert.Equal(t, blobstore.ErrNotFound, err)
+	art, err := d.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "第二个版本的内容", art.Content)
+
+	// 撤回之后对象和 content_key 一起清掉，重新发表之后还能读到
+	require.NoError(t, d.SyncStatus(ctx, id, 123, statusPrivate))
+	assert.Empty(t, contentKey(id))
+	_, err = store.Get(ctx, secondKey)
+	assert.Equal(t, blobstore.ErrNotFound, err)
+	_, err = d.GetPubById(ctx, id)
+	require.NoError(t, err)
+
+	_, err = d.Sync(ctx, Article{Id: id, Title: "标题", Content: "重新发表的内容", AuthorId: 123, Status: statusPublished})
+	require.NoError(t, err)
+	art, err = d.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "重新发表的内容", art.Content)
+}
+
+func TestArticleBlobDAO_Purge(t *testing.T) {
+	ctx := context.Background()
+	db := initSQLiteDB(t)
+	store, err := blobstore.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	d := NewArticleBlobDAO(db, store, 9)
+	id, err := d.Sync(ctx, Article{Title: "标题", Content: "放在对象存储里面的内容", AuthorId: 123, Status: statusPublished})
+	require.NoError(t, err)
+	var pub PublishedArticleV2
+	require.NoError(t, db.First(&pub, id).Error)
+
+	// 不在回收站里面，对象不能删
+	assert.Equal(t, ErrArticleNotFound, d.Purge(ctx, id, 123))
+	_, err = store.Get(ctx, pub.ContentKey)
+	require.NoError(t, err)
+
+	require.NoError(t, d.Delete(ctx, id, 123))
+	require.NoError(t, d.Purge(ctx, id, 123))
+	_, err = store.Get(ctx, pub.ContentKey)
+	assert.Equal(t, blobstore.ErrNotFound, err)
+	assert.Equal(t, ErrArticleNotFound, db.First(&PublishedArticleV2{}, id).Error)
+}
+
+func TestArticleBlobDAO_GetPubById(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT \\* FROM `published_article_v2` WHERE id = \\? AND deleted_at = 0").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content", "content_key", "author_id"}).
+			AddRow(12, "标题", "", "articles/12", 123))
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	store, err := blobstore.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	err = store.Put(context.Background(), "articles/12", []byte("对象存储里面的内容"), "text/plain")
+	require.NoError(t, err)
+
+	art, err := NewArticleBlobDAO(db, store, 0).GetPubById(context.Background(), 12)
+	require.NoError(t, err)
+	assert.Equal(t, "对象存储里面的内容", art.Content)
+	assert.Equal(t, int64(123), art.AuthorId)
+}
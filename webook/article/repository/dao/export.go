@@ -0,0 +1,186 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/gormx"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrExportNotFound 导出任务不存在
+	ErrExportNotFound = gorm.ErrRecordNotFound
+	// ErrExportInProgress 作者已经有一个还没有完成的导出任务
+	ErrExportInProgress = errors.New("已经有一个导出任务在执行了")
+	// ErrNoExportTask 没有可以执行的导出任务，或者被别的实例抢先了
+	ErrNoExportTask = errors.New("没有等待执行的导出任务")
+	// ErrExportPreempted 执行太久没有更新进度，任务被别的实例接手了
+	ErrExportPreempted = errors.New("导出任务已经被别的实例接手了")
+)
+
+type ExportDAO interface {
+	// Insert 作者已经有一个没有完成的任务的时候返回 ErrExportInProgress
+	Insert(ctx context.Context, e ExportTask) (int64, error)
+	GetById(ctx context.Context, id int64) (ExportTask, error)
+	// ListByAuthor 按照 id 倒序
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]ExportTask, error)
+	// Claim 抢占一个等待执行的任务，或者 utime 早于 staleBefore 的执行中的任务
+	// 后者说明执行它的实例可能已经挂了。抢占成功之后版本号会加一
+	Claim(ctx context.Context, staleBefore int64) (ExportTask, error)
+	// UpdateProgress 同时刷新 utime，相当于续约
+	// 版本号对不上说明任务被别的实例接手了，返回 ErrExportPreempted，下面两个方法也是
+	UpdateProgress(ctx context.Context, id int64, version int64, total int, exported int) error
+	Complete(ctx context.Context, id int64, version int64, key string, size int64) error
+	Fail(ctx context.Context, id int64, version int64, reason string) error
+	// CountArticles 作者没有移进回收站的文章数量
+	CountArticles(ctx context.Context, author int64) (int64, error)
+}
+
+type GORMExportDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMExportDAO(db *gorm.DB) ExportDAO {
+	return &GORMExportDAO{db: db}
+}
+
+// Insert 并发发起的时候可能会插入两个任务，只是多导出一次，可以接受
+func (dao *GORMExportDAO) Insert(ctx context.Context, e ExportTask) (int64, error) {
+	var cnt int64
+	err := dao.db.WithContext(ctx).Model(&ExportTask{}).
+		Where("author_id = ? AND status IN (?, ?)", e.AuthorId,
+			exportStatusPending, exportStatusRunning).
+		Count(&cnt).Error
+	if err != nil {
+		return 0, err
+	}
+	if cnt > 0 {
+		return 0, ErrExportInProgress
+	}
+	now := time.Now().UnixMilli()
+	e.Status = exportStatusPending
+	e.Ctime = now
+	e.Utime = now
+	err = dao.db.WithContext(ctx).Create(&e).Error
+	return e.Id, err
+}
+
+func (dao *GORMExportDAO) GetById(ctx context.Context, id int64) (ExportTask, error) {
+	var e ExportTask
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&e).Error
+	return e, err
+}
+
+func (dao *GORMExportDAO) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]ExportTask, error) {
+	var res []ExportTask
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ?", author).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMExportDAO) Claim(ctx context.Context, staleBefore int64) (ExportTask, error) {
+	// 乐观锁要读主库，从库上的版本号可能是旧的，CAS 会一直失败
+	db := dao.db.WithContext(gormx.WithPrimary(ctx))
+	var e ExportTask
+	err := db.Where("status = ? OR (status = ? AND utime < ?)",
+		exportStatusPending, exportStatusRunning, staleBefore).
+		Order("id ASC").
+		First(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ExportTask{}, ErrNoExportTask
+	}
+	if err != nil {
+		return ExportTask{}, err
+	}
+	now := time.Now().UnixMilli()
+	res := db.Model(&ExportTask{}).
+		Where("id = ? AND version = ?", e.Id, e.Version).
+		Updates(map[string]any{
+			"status":  exportStatusRunning,
+			"version": e.Version + 1,
+			"utime":   now,
+		})
+	if res.Error != nil {
+		return ExportTask{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ExportTask{}, ErrNoExportTask
+	}
+	e.Status = exportStatusRunning
+	e.Version++
+	e.Utime = now
+	return e, nil
+}
+
+func (dao *GORMExportDAO) UpdateProgress(ctx context.Context, id int64, version int64, total int, exported int) error {
+	return dao.update(ctx, id, version, map[string]any{
+		"total":    total,
+		"exported": exported,
+	})
+}
+
+func (dao *GORMExportDAO) Complete(ctx context.Context, id int64, version int64, key string, size int64) error {
+	return dao.update(ctx, id, version, map[string]any{
+		"status":  exportStatusDone,
+		"obj_key": key,
+		"size":    size,
+	})
+}
+
+func (dao *GORMExportDAO) Fail(ctx context.Context, id int64, version int64, reason string) error {
+	return dao.update(ctx, id, version, map[string]any{
+		"status": exportStatusFailed,
+		"error":  reason,
+	})
+}
+
+func (dao *GORMExportDAO) update(ctx context.Context, id int64, version int64, updates map[string]any) error {
+	updates["utime"] = time.Now().UnixMilli()
+	res := dao.db.WithContext(ctx).Model(&ExportTask{}).
+		Where("id = ? AND version = ? AND status = ?", id, version, exportStatusRunning).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrExportPreempted
+	}
+	return nil
+}
+
+func (dao *GORMExportDAO) CountArticles(ctx context.Context, author int64) (int64, error) {
+	var cnt int64
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? AND deleted_at = 0", author).
+		Count(&cnt).Error
+	return cnt, err
+}
+
+const (
+	exportStatusPending uint8 = 1
+	exportStatusRunning uint8 = 2
+	exportStatusDone    uint8 = 3
+	exportStatusFailed  uint8 = 4
+)
+
+// ExportTask 导出任务，压缩包本身在对象存储里面
+type ExportTask struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	AuthorId int64 `gorm:"index"`
+	// Status 和 Utime 一起用来找等待执行和执行超时的任务
+	Status   uint8 `gorm:"index:idx_export_status_utime"`
+	Total    int
+	Exported int
+	Size     int64
+	ObjKey   string `gorm:"type=varchar(256)"`
+	Error    string `gorm:"type=varchar(1024)"`
+	// Version 每次被抢占加一，防止超时之后原来的实例还在更新
+	Version int64
+	Ctime   int64
+	Utime   int64 `gorm:"index:idx_export_status_utime"`
+}
@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMExportDAO(t *testing.T) {
+	db := initSQLiteDB(t)
+	dao := NewGORMExportDAO(db)
+	artDAO := NewGORMArticleDAO(db)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := artDAO.Insert(ctx, Article{Title: "标题", Content: "内容", AuthorId: 123})
+		require.NoError(t, err)
+	}
+	_, err := artDAO.Insert(ctx, Article{Title: "标题", Content: "内容", AuthorId: 456})
+	require.NoError(t, err)
+	cnt, err := dao.CountArticles(ctx, 123)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cnt)
+
+	_, err = dao.Claim(ctx, 0)
+	assert.Equal(t, ErrNoExportTask, err)
+
+	id, err := dao.Insert(ctx, ExportTask{AuthorId: 123})
+	require.NoError(t, err)
+	// 上一个还没有完成
+	_, err = dao.Insert(ctx, ExportTask{AuthorId: 123})
+	assert.Equal(t, ErrExportInProgress, err)
+
+	task, err := dao.Claim(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, id, task.Id)
+	assert.Equal(t, int64(1), task.Version)
+	// 正在执行，还没有超时
+	_, err = dao.Claim(ctx, time.Now().Add(-time.Minute).UnixMilli())
+	assert.Equal(t, ErrNoExportTask, err)
+	require.NoError(t, dao.UpdateProgress(ctx, id, task.Version, 3, 1))
+
+	// 超时了，被别的实例接手，原来的实例不能再更新
+	task2, err := dao.Claim(ctx, time.Now().Add(time.Minute).UnixMilli())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), task2.Version)
+	assert.Equal(t, 1, task2.Exported)
+	assert.Equal(t, ErrExportPreempted, dao.UpdateProgress(ctx, id, task.Version, 3, 2))
+	assert.Equal(t, ErrExportPreempted, dao.Complete(ctx, id, task.Version, "exports/123/1.zip", 10))
+
+	require.NoError(t, dao.Complete(ctx, id, task2.Version, "exports/123/1.zip", 10))
+	// 完成了就不能再改了
+	assert.Equal(t, ErrExportPreempted, dao.Fail(ctx, id, task2.Version, "x"))
+	res, err := dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, exportStatusDone, res.Status)
+	assert.Equal(t, "exports/123/1.zip", res.ObjKey)
+	assert.Equal(t, int64(10), res.Size)
+
+	// 完成之后可以再次导出
+	id2, err := dao.Insert(ctx, ExportTask{AuthorId: 123})
+	require.NoError(t, err)
+	list, err := dao.ListByAuthor(ctx, 123, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, id2, list[0].Id)
+	assert.Equal(t, exportStatusPending, list[0].Status)
+}
@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrFingerprintNotFound 文章没有发表过，所以没有指纹
+var ErrFingerprintNotFound = gorm.ErrRecordNotFound
+
+type FingerprintDAO interface {
+	// Upsert 重新发表的时候更新指纹，ctime 保持第一次发表的时间
+	Upsert(ctx context.Context, fp ArticleFingerprint) error
+	GetByArticleId(ctx context.Context, artId int64) (ArticleFingerprint, error)
+	// FindCandidates 至少有一段和 fp 相同的指纹，不包括 fp 自己
+	// 只是候选，调用方还要自己计算海明距离
+	FindCandidates(ctx context.Context, fp ArticleFingerprint, limit int) ([]ArticleFingerprint, error)
+	Delete(ctx context.Context, artId int64) error
+}
+
+type GORMFingerprintDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFingerprintDAO(db *gorm.DB) FingerprintDAO {
+	return &GORMFingerprintDAO{db: db}
+}
+
+func (dao *GORMFingerprintDAO) Upsert(ctx context.Context, fp ArticleFingerprint) error {
+	now := time.Now().UnixMilli()
+	fp.Ctime = now
+	fp.Utime = now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "article_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"simhash": fp.Simhash,
+			"band0":   fp.Band0,
+			"band1":   fp.Band1,
+			"band2":   fp.Band2,
+			"band3":   fp.Band3,
+			"utime":   now,
+		}),
+	}).Create(&fp).Error
+}
+
+func (dao *GORMFingerprintDAO) GetByArticleId(ctx context.Context, artId int64) (ArticleFingerprint, error) {
+	var res ArticleFingerprint
+	err := dao.db.WithContext(ctx).Where("article_id = ?", artId).First(&res).Error
+	return res, err
+}
+
+// FindCandidates 每一段都有索引，OR 起来 MySQL 会用 index merge
+func (dao *GORMFingerprintDAO) FindCandidates(ctx context.Context, fp ArticleFingerprint, limit int) ([]ArticleFingerprint, error) {
+	var res []ArticleFingerprint
+	err := dao.db.WithContext(ctx).
+		Where("band0 = ? OR band1 = ? OR band2 = ? OR band3 = ?", fp.Band0, fp.Band1, fp.Band2, fp.Band3).
+		Where("article_id <> ?", fp.ArticleId).
+		Order("article_id").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFingerprintDAO) Delete(ctx context.Context, artId int64) error {
+	return dao.db.WithContext(ctx).Where("article_id = ?", artId).Delete(&ArticleFingerprint{}).Error
+}
+
+// ArticleFingerprint 已发表文章的 SimHash，64 位指纹切成 4 段分别建索引
+// 海明距离不超过 3 的指纹至少有一段完全相同
+type ArticleFingerprint struct {
+	ArticleId int64 `gorm:"primaryKey;autoIncrement:false"`
+	AuthorId  int64
+	// Simhash 无符号的指纹按位存成有符号的，MySQL 的 BIGINT 是有符号的
+	Simhash int64
+	Band0   uint16 `gorm:"index"`
+	Band1   uint16 `gorm:"index"`
+	Band2   uint16 `gorm:"index"`
+	Band3   uint16 `gorm:"index"`
+	// Ctime 第一次发表的时间，用来判断谁抄了谁
+	Ctime int64
+	Utime int64
+}
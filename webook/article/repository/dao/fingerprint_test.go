@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMFingerprintDAO(t *testing.T) {
+	dao := NewGORMFingerprintDAO(initSQLiteDB(t))
+	ctx := context.Background()
+
+	_, err := dao.GetByArticleId(ctx, 1)
+	assert.Equal(t, ErrFingerprintNotFound, err)
+
+	fps := []ArticleFingerprint{
+		{ArticleId: 1, AuthorId: 123, Simhash: -1, Band0: 1, Band1: 2, Band2: 3, Band3: 4},
+		// 只有第三段一样
+		{ArticleId: 2, AuthorId: 456, Simhash: 2, Band0: 9, Band1: 9, Band2: 3, Band3: 9},
+		{ArticleId: 3, AuthorId: 456, Simhash: 3, Band0: 9, Band1: 9, Band2: 9, Band3: 9},
+	}
+	for _, fp := range fps {
+		require.NoError(t, dao.Upsert(ctx, fp))
+	}
+	res, err := dao.FindCandidates(ctx, fps[0], 10)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, int64(2), res[0].ArticleId)
+
+	got, err := dao.GetByArticleId(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), got.Simhash)
+	// 重新发表，ctime 不变
+	fps[0].Simhash, fps[0].Band2 = 5, 5
+	require.NoError(t, dao.Upsert(ctx, fps[0]))
+	updated, err := dao.GetByArticleId(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), updated.Simhash)
+	assert.Equal(t, got.Ctime, updated.Ctime)
+	res, err = dao.FindCandidates(ctx, fps[0], 10)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	require.NoError(t, dao.Delete(ctx, 1))
+	_, err = dao.GetByArticleId(ctx, 1)
+	assert.Equal(t, ErrFingerprintNotFound, err)
+}
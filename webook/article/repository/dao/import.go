@@ -0,0 +1,178 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/gormx"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrImportNotFound 导入任务不存在
+	ErrImportNotFound = gorm.ErrRecordNotFound
+	// ErrImportInProgress 作者已经有一个还没有完成的导入任务
+	ErrImportInProgress = errors.New("已经有一个导入任务在执行了")
+	// ErrNoImportTask 没有可以执行的导入任务，或者被别的实例抢先了
+	ErrNoImportTask = errors.New("没有等待执行的导入任务")
+	// ErrImportPreempted 执行太久没有更新进度，任务被别的实例接手了
+	ErrImportPreempted = errors.New("导入任务已经被别的实例接手了")
+)
+
+// ImportDAO 和 ExportDAO 一样靠版本号抢占任务
+type ImportDAO interface {
+	// Insert 作者已经有一个没有完成的任务的时候返回 ErrImportInProgress
+	Insert(ctx context.Context, i ImportTask) (int64, error)
+	GetById(ctx context.Context, id int64) (ImportTask, error)
+	// ListByAuthor 按照 id 倒序
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]ImportTask, error)
+	// Claim 抢占一个等待执行的任务，或者 utime 早于 staleBefore 的执行中的任务
+	Claim(ctx context.Context, staleBefore int64) (ImportTask, error)
+	// UpdateProgress 同时刷新 utime，相当于续约
+	// 版本号对不上说明任务被别的实例接手了，返回 ErrImportPreempted，下面两个方法也是
+	UpdateProgress(ctx context.Context, id int64, version int64, total int, imported int, results []byte) error
+	Complete(ctx context.Context, id int64, version int64, results []byte) error
+	Fail(ctx context.Context, id int64, version int64, reason string) error
+}
+
+type GORMImportDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMImportDAO(db *gorm.DB) ImportDAO {
+	return &GORMImportDAO{db: db}
+}
+
+// Insert 并发发起的时候可能会插入两个任务，都会执行，可以接受
+func (dao *GORMImportDAO) Insert(ctx context.Context, i ImportTask) (int64, error) {
+	var cnt int64
+	err := dao.db.WithContext(ctx).Model(&ImportTask{}).
+		Where("author_id = ? AND status IN (?, ?)", i.AuthorId,
+			importStatusPending, importStatusRunning).
+		Count(&cnt).Error
+	if err != nil {
+		return 0, err
+	}
+	if cnt > 0 {
+		return 0, ErrImportInProgress
+	}
+	now := time.Now().UnixMilli()
+	i.Status = importStatusPending
+	i.Ctime = now
+	i.Utime = now
+	err = dao.db.WithContext(ctx).Create(&i).Error
+	return i.Id, err
+}
+
+func (dao *GORMImportDAO) GetById(ctx context.Context, id int64) (ImportTask, error) {
+	var i ImportTask
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&i).Error
+	return i, err
+}
+
+func (dao *GORMImportDAO) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]ImportTask, error) {
+	var res []ImportTask
+	// 列表不需要每个文件的结果
+	err := dao.db.WithContext(ctx).Omit("results").
+		Where("author_id = ?", author).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMImportDAO) Claim(ctx context.Context, staleBefore int64) (ImportTask, error) {
+	// 乐观锁要读主库，从库上的版本号可能是旧的，CAS 会一直失败
+	db := dao.db.WithContext(gormx.WithPrimary(ctx))
+	var i ImportTask
+	err := db.Where("status = ? OR (status = ? AND utime < ?)",
+		importStatusPending, importStatusRunning, staleBefore).
+		Order("id ASC").
+		First(&i).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ImportTask{}, ErrNoImportTask
+	}
+	if err != nil {
+		return ImportTask{}, err
+	}
+	now := time.Now().UnixMilli()
+	res := db.Model(&ImportTask{}).
+		Where("id = ? AND version = ?", i.Id, i.Version).
+		Updates(map[string]any{
+			"status":  importStatusRunning,
+			"version": i.Version + 1,
+			"utime":   now,
+		})
+	if res.Error != nil {
+		return ImportTask{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ImportTask{}, ErrNoImportTask
+	}
+	i.Status = importStatusRunning
+	i.Version++
+	i.Utime = now
+	return i, nil
+}
+
+func (dao *GORMImportDAO) UpdateProgress(ctx context.Context, id int64, version int64, total int, imported int, results []byte) error {
+	return dao.update(ctx, id, version, map[string]any{
+		"total":    total,
+		"imported": imported,
+		"results":  results,
+	})
+}
+
+func (dao *GORMImportDAO) Complete(ctx context.Context, id int64, version int64, results []byte) error {
+	return dao.update(ctx, id, version, map[string]any{
+		"status":  importStatusDone,
+		"results": results,
+	})
+}
+
+func (dao *GORMImportDAO) Fail(ctx context.Context, id int64, version int64, reason string) error {
+	return dao.update(ctx, id, version, map[string]any{
+		"status": importStatusFailed,
+		"error":  reason,
+	})
+}
+
+func (dao *GORMImportDAO) update(ctx context.Context, id int64, version int64, updates map[string]any) error {
+	updates["utime"] = time.Now().UnixMilli()
+	res := dao.db.WithContext(ctx).Model(&ImportTask{}).
+		Where("id = ? AND version = ? AND status = ?", id, version, importStatusRunning).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrImportPreempted
+	}
+	return nil
+}
+
+const (
+	importStatusPending uint8 = 1
+	importStatusRunning uint8 = 2
+	importStatusDone    uint8 = 3
+	importStatusFailed  uint8 = 4
+)
+
+// ImportTask 导入任务，上传的压缩包在对象存储里面
+type ImportTask struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	AuthorId int64 `gorm:"index"`
+	// Status 和 Utime 一起用来找等待执行和执行超时的任务
+	Status   uint8 `gorm:"index:idx_import_status_utime"`
+	Total    int
+	Imported int
+	ObjKey   string `gorm:"type=varchar(256)"`
+	// Results 每个文件的导入结果，JSON 数组
+	Results []byte `gorm:"type:BLOB"`
+	Error   string `gorm:"type=varchar(1024)"`
+	// Version 每次被抢占加一，防止超时之后原来的实例还在更新
+	Version int64
+	Ctime   int64
+	Utime   int64 `gorm:"index:idx_import_status_utime"`
+}
@@ -0,0 +1,54 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMImportDAO(t *testing.T) {
+	db := initSQLiteDB(t)
+	dao := NewGORMImportDAO(db)
+	ctx := context.Background()
+
+	_, err := dao.Claim(ctx, 0)
+	assert.Equal(t, ErrNoImportTask, err)
+
+	id, err := dao.Insert(ctx, ImportTask{AuthorId: 123, ObjKey: "imports/123/1.zip"})
+	require.NoError(t, err)
+	// 上一个还没有完成
+	_, err = dao.Insert(ctx, ImportTask{AuthorId: 123})
+	assert.Equal(t, ErrImportInProgress, err)
+
+	task, err := dao.Claim(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, id, task.Id)
+	assert.Equal(t, "imports/123/1.zip", task.ObjKey)
+	assert.Equal(t, int64(1), task.Version)
+	require.NoError(t, dao.UpdateProgress(ctx, id, task.Version, 2, 1, []byte(`[{"id":1}]`)))
+
+	// 超时了，被别的实例接手，原来的实例不能再更新
+	task2, err := dao.Claim(ctx, time.Now().Add(time.Minute).UnixMilli())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), task2.Version)
+	assert.Equal(t, 1, task2.Imported)
+	assert.Equal(t, ErrImportPreempted, dao.Complete(ctx, id, task.Version, nil))
+
+	require.NoError(t, dao.Complete(ctx, id, task2.Version, []byte(`[{"id":1},{"id":2}]`)))
+	res, err := dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, importStatusDone, res.Status)
+	assert.Equal(t, `[{"id":1},{"id":2}]`, string(res.Results))
+
+	// 完成之后可以再次导入，列表不带每个文件的结果
+	id2, err := dao.Insert(ctx, ImportTask{AuthorId: 123})
+	require.NoError(t, err)
+	list, err := dao.ListByAuthor(ctx, 123, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, id2, list[0].Id)
+	assert.Empty(t, list[1].Results)
+}
@@ -5,5 +5,19 @@ import "gorm.io/gorm"
 func InitTables(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&Article{},
+		&PublishedArticleV1{},
+		&PublishedArticleV2{},
+		&ArticleRevision{},
+		&Tag{},
+		&ArticleTag{},
+		&Series{},
+		&SeriesArticle{},
+		&OutboxMessage{},
+		&Attachment{},
+		&AttachmentRef{},
+		&ModerationLog{},
+		&ExportTask{},
+		&ImportTask{},
+		&ArticleFingerprint{},
 	)
 }
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./outbox.go -destination=./mocks/outbox.mock.go -package=daomocks OutboxDAO
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dao "github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxDAO is a mock of OutboxDAO interface.
+type MockOutboxDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxDAOMockRecorder
+}
+
+// MockOutboxDAOMockRecorder is the mock recorder for MockOutboxDAO.
+type MockOutboxDAOMockRecorder struct {
+	mock *MockOutboxDAO
+}
+
+// NewMockOutboxDAO creates a new mock instance.
+func NewMockOutboxDAO(ctrl *gomock.Controller) *MockOutboxDAO {
+	mock := &MockOutboxDAO{ctrl: ctrl}
+	mock.recorder = &MockOutboxDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxDAO) EXPECT() *MockOutboxDAOMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockOutboxDAO) Claim(ctx context.Context, now int64, lease time.Duration, limit int) ([]dao.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]dao.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockOutboxDAOMockRecorder) Claim(ctx, now, lease, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockOutboxDAO)(nil).Claim), ctx, now, lease, limit)
+}
+
+// Delete mocks base method.
+func (m *MockOutboxDAO) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOutboxDAOMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOutboxDAO)(nil).Delete), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockOutboxDAO) Insert(ctx context.Context, msg dao.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockOutboxDAOMockRecorder) Insert(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockOutboxDAO)(nil).Insert), ctx, msg)
+}
+
+// MarkDead mocks base method.
+func (m *MockOutboxDAO) MarkDead(ctx context.Context, id int64, retries int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDead", ctx, id, retries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDead indicates an expected call of MarkDead.
+func (mr *MockOutboxDAOMockRecorder) MarkDead(ctx, id, retries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDead", reflect.TypeOf((*MockOutboxDAO)(nil).MarkDead), ctx, id, retries)
+}
+
+// Retry mocks base method.
+func (m *MockOutboxDAO) Retry(ctx context.Context, id int64, retries int, nextRetryAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id, retries, nextRetryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockOutboxDAOMockRecorder) Retry(ctx, id, retries, nextRetryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockOutboxDAO)(nil).Retry), ctx, id, retries, nextRetryAt)
+}
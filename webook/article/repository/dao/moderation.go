@@ -0,0 +1,161 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotPendingReview 文章不处于待审核状态，可能已经被别人审核过，或者作者撤回了
+var ErrNotPendingReview = errors.New("文章不处于待审核状态")
+
+// TopicArticleRejected 审核没有通过，通知作者
+const TopicArticleRejected = "article_rejected"
+
+const (
+	articleStatusPendingReview uint8 = 5
+	articleStatusRejected      uint8 = 6
+)
+
+// RejectedEvent 审核没有通过的时候写进 outbox 的事件
+type RejectedEvent struct {
+	Id       int64  `json:"id"`
+	AuthorId int64  `json:"author_id"`
+	Title    string `json:"title"`
+	Reviewer int64  `json:"reviewer"`
+	Reason   string `json:"reason"`
+	Utime    int64  `json:"utime"`
+}
+
+type ModerationDAO interface {
+	// Insert 只记录日志，不修改文章
+	Insert(ctx context.Context, log ModerationLog) (int64, error)
+	// Reject 把待审核的文章改成审核不通过，在同一个事务里面记录日志和通知作者的 outbox 消息
+	// version 大于 0 的时候要求制作库的版本号一致，否则返回 ErrVersionConflict
+	Reject(ctx context.Context, log ModerationLog, version int64) error
+	// ListPending 待审核的文章，先提交的排在前面
+	ListPending(ctx context.Context, offset int, limit int) ([]Article, error)
+	// ListLogs 按照时间倒序
+	ListLogs(ctx context.Context, artId int64, offset int, limit int) ([]ModerationLog, error)
+	// ListLogsByAction 所有文章的某一种日志，按照时间倒序
+	ListLogsByAction(ctx context.Context, action uint8, offset int, limit int) ([]ModerationLog, error)
+}
+
+type GORMModerationDAO struct {
+	db *gorm.DB
+	// nextId 为空的时候用数据库的自增主键，分库之后由 ShardingModerationDAO 设置
+	// 日志和文章在同一个库，所以用文章 ID 的基因
+	nextId func(artId int64) int64
+}
+
+func NewGORMModerationDAO(db *gorm.DB) ModerationDAO {
+	return &GORMModerationDAO{db: db}
+}
+
+func (dao *GORMModerationDAO) Insert(ctx context.Context, log ModerationLog) (int64, error) {
+	log.Ctime = time.Now().UnixMilli()
+	if dao.nextId != nil {
+		log.Id = dao.nextId(log.ArticleId)
+	}
+	err := dao.db.WithContext(ctx).Create(&log).Error
+	return log.Id, err
+}
+
+func (dao *GORMModerationDAO) Reject(ctx context.Context, log ModerationLog, version int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var art Article
+		err := tx.Where("id = ? AND deleted_at = 0", log.ArticleId).First(&art).Error
+		if err != nil {
+			return err
+		}
+		if art.Status != articleStatusPendingReview {
+			return ErrNotPendingReview
+		}
+		if version > 0 && art.Version != version {
+			return ErrVersionConflict
+		}
+		// 查询和更新之间作者可能刚好修改了文章
+		res := tx.Model(&Article{}).
+			Where("id = ? AND status = ? AND version = ?", art.Id, articleStatusPendingReview, art.Version).
+			Updates(map[string]any{
+				"status": articleStatusRejected,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotPendingReview
+		}
+		log.AuthorId = art.AuthorId
+		log.Status = articleStatusRejected
+		log.Ctime = now
+		if dao.nextId != nil {
+			log.Id = dao.nextId(log.ArticleId)
+		}
+		if err = tx.Create(&log).Error; err != nil {
+			return err
+		}
+		payload, err := json.Marshal(RejectedEvent{
+			Id:       art.Id,
+			AuthorId: art.AuthorId,
+			Title:    art.Title,
+			Reviewer: log.Reviewer,
+			Reason:   log.Reason,
+			Utime:    now,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Create(&OutboxMessage{
+			Topic:   TopicArticleRejected,
+			Key:     strconv.FormatInt(art.Id, 10),
+			Payload: payload,
+			Ctime:   now,
+			Utime:   now,
+		}).Error
+	})
+}
+
+func (dao *GORMModerationDAO) ListPending(ctx context.Context, offset int, limit int) ([]Article, error) {
+	var res []Article
+	err := dao.db.WithContext(ctx).
+		Where("status = ? AND deleted_at = 0", articleStatusPendingReview).
+		Order("utime ASC, id ASC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMModerationDAO) ListLogs(ctx context.Context, artId int64, offset int, limit int) ([]ModerationLog, error) {
+	var res []ModerationLog
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMModerationDAO) ListLogsByAction(ctx context.Context, action uint8, offset int, limit int) ([]ModerationLog, error) {
+	var res []ModerationLog
+	err := dao.db.WithContext(ctx).
+		Where("action = ?", action).
+		Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// ModerationLog 审核日志，只增不改
+type ModerationLog struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"index"`
+	AuthorId  int64
+	// Reviewer 0 表示系统自动处理
+	Reviewer int64 `gorm:"index"`
+	// Action 疑似重复的文章按照这个查询
+	Action uint8 `gorm:"index"`
+	Status uint8
+	Reason string `gorm:"type=varchar(1024)"`
+	Ctime  int64
+}
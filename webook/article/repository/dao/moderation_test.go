@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMModerationDAO(t *testing.T) {
+	db := initSQLiteDB(t)
+	dao := NewGORMModerationDAO(db)
+	artDAO := NewGORMArticleDAO(db)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, status := range []uint8{articleStatusPendingReview, 1, articleStatusPendingReview} {
+		id, err := artDAO.Insert(ctx, Article{Title: "标题", Content: "内容", AuthorId: 123, Status: status})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	arts, err := dao.ListPending(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, arts, 2)
+	assert.Equal(t, ids[0], arts[0].Id)
+	assert.Equal(t, ids[2], arts[1].Id)
+
+	_, err = dao.Insert(ctx, ModerationLog{ArticleId: ids[0], AuthorId: 123, Action: 1, Status: articleStatusPendingReview})
+	require.NoError(t, err)
+
+	log := ModerationLog{ArticleId: ids[0], Reviewer: 1, Action: 5, Reason: "标题党"}
+	// 作者在审核期间修改过
+	assert.Equal(t, ErrVersionConflict, dao.Reject(ctx, log, 2))
+	// 不在审核队列里面
+	assert.Equal(t, ErrNotPendingReview, dao.Reject(ctx, ModerationLog{ArticleId: ids[1], Reason: "x"}, 0))
+	assert.Equal(t, ErrArticleNotFound, dao.Reject(ctx, ModerationLog{ArticleId: 10086, Reason: "x"}, 0))
+
+	require.NoError(t, dao.Reject(ctx, log, 1))
+	assert.Equal(t, ErrNotPendingReview, dao.Reject(ctx, log, 1))
+	art, err := artDAO.GetById(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, articleStatusRejected, art.Status)
+
+	arts, err = dao.ListPending(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, arts, 1)
+
+	logs, err := dao.ListLogs(ctx, ids[0], 0, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, uint8(5), logs[0].Action)
+	assert.Equal(t, articleStatusRejected, logs[0].Status)
+	assert.Equal(t, int64(123), logs[0].AuthorId)
+	assert.Equal(t, "标题党", logs[0].Reason)
+	logs, err = dao.ListLogsByAction(ctx, 5, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, ids[0], logs[0].ArticleId)
+
+	// 通知作者的消息和状态在同一个事务里面
+	var msgs []OutboxMessage
+	require.NoError(t, db.Where("topic = ?", TopicArticleRejected).Find(&msgs).Error)
+	require.Len(t, msgs, 1)
+	var evt RejectedEvent
+	require.NoError(t, json.Unmarshal(msgs[0].Payload, &evt))
+	assert.Equal(t, RejectedEvent{Id: ids[0], AuthorId: 123, Title: "标题",
+		Reviewer: 1, Reason: "标题党", Utime: evt.Utime}, evt)
+}
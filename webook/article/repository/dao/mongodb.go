@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/bwmarrin/snowflake"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -14,30 +15,47 @@ type MongoDBArticleDAO struct {
 	node    *snowflake.Node
 	col     *mongo.Collection
 	liveCol *mongo.Collection
+	// tags 标签还是放在 MySQL 里面，和文章不在一个事务，
+	// 文章写成功了标签失败的话，重新保存一次就可以
+	tags TagDAO
+	// revs 历史版本也在 MySQL 里面，和标签一样在文章写成功之后再写
+	revs ArticleRevisionDAO
 }
 
 func (m *MongoDBArticleDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
-	//TODO implement me
-	panic("implement me")
+	filter := bson.D{bson.E{Key: "utime", Value: bson.M{"$lt": start.UnixMilli()}}, notDeleted}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "utime", Value: -1}})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cur.All(ctx, &res)
+	return res, err
 }
 
+// Upsert 和 GORM 的实现一样，按照 id 冲突
 func (m *MongoDBArticleDAO) Upsert(ctx context.Context, art PublishedArticleV1) error {
 	now := time.Now().UnixMilli()
-	filter := bson.D{bson.E{Key: "id", Value: art.Id},
-		bson.E{Key: "author_id", Value: art.AuthorId}}
+	filter := bson.D{bson.E{Key: "id", Value: art.Id}}
 	set := bson.D{bson.E{Key: "$set", Value: bson.M{
-		"title":   art.Title,
-		"content": art.Content,
-		"status":  art.Status,
-		"utime":   now,
-	}}}
+		"author_id":  art.AuthorId,
+		"title":      art.Title,
+		"content":    art.Content,
+		"status":     art.Status,
+		"publish_at": art.PublishAt,
+		"version":    art.Version,
+		"utime":      now,
+	}}, bson.E{Key: "$setOnInsert", Value: bson.M{"ctime": now}}}
 	_, err := m.liveCol.UpdateOne(ctx, filter, set, options.Update().SetUpsert(true))
 	return err
 }
 
 func (m *MongoDBArticleDAO) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
-	filter := bson.D{bson.E{Key: "author_id", Value: uid}}
-	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).SetSort(bson.D{{"ctime", -1}}) // 按 ctime 倒序排列
+	filter := bson.D{bson.E{Key: "author_id", Value: uid}, notDeleted}
+	// 和 GORM 的实现一样，按 utime 倒序排列
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).SetSort(bson.D{{Key: "utime", Value: -1}})
 	cur, err := m.col.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
@@ -57,13 +75,92 @@ func (m *MongoDBArticleDAO) GetByAuthor(ctx context.Context, uid int64, offset i
 	return articles, nil
 }
 
+func (m *MongoDBArticleDAO) GetByAuthorCursor(ctx context.Context, author int64, utime int64, id int64, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: author}, notDeleted}
+	if utime > 0 {
+		filter = append(filter, m.cursorFilter(utime, id))
+	}
+	return m.findByCursor(ctx, m.col, filter, limit)
+}
+
+func (m *MongoDBArticleDAO) ListPubByTag(ctx context.Context, tag string, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	ids, err := m.tags.GetArticleIds(ctx, tag)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	filter := bson.D{bson.E{Key: "id", Value: bson.M{"$in": ids}},
+		bson.E{Key: "status", Value: status},
+		bson.E{Key: "utime", Value: bson.M{"$lt": start.UnixMilli()}}, notDeleted}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "utime", Value: -1}})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDAO) ListPubByAuthor(ctx context.Context, author int64, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: author},
+		bson.E{Key: "status", Value: status},
+		bson.E{Key: "utime", Value: bson.M{"$lt": start.UnixMilli()}}, notDeleted}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "utime", Value: -1}})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDAO) GetPubTitles(ctx context.Context, ids []int64, status uint8) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "id", Value: bson.M{"$in": ids}},
+		bson.E{Key: "status", Value: status}, notDeleted}
+	opts := options.Find().SetProjection(bson.M{"id": 1, "title": 1, "author_id": 1, "status": 1})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDAO) ListPubCursor(ctx context.Context, utime int64, id int64, limit int) ([]Article, error) {
+	return m.findByCursor(ctx, m.liveCol, bson.D{m.cursorFilter(utime, id), notDeleted}, limit)
+}
+
+// cursorFilter 等价于 utime < ? OR (utime = ? AND id < ?)
+func (m *MongoDBArticleDAO) cursorFilter(utime int64, id int64) bson.E {
+	return bson.E{Key: "$or", Value: bson.A{
+		bson.M{"utime": bson.M{"$lt": utime}},
+		bson.M{"utime": utime, "id": bson.M{"$lt": id}},
+	}}
+}
+
+func (m *MongoDBArticleDAO) findByCursor(ctx context.Context, col *mongo.Collection, filter bson.D, limit int) ([]Article, error) {
+	opts := options.Find().SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "utime", Value: -1}, {Key: "id", Value: -1}})
+	cur, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
 func (m *MongoDBArticleDAO) GetById(ctx context.Context, id int64) (Article, error) {
-	filter := bson.D{bson.E{Key: "id", Value: id}}
+	filter := bson.D{bson.E{Key: "id", Value: id}, notDeleted}
 	var art Article
 	err := m.col.FindOne(ctx, filter).Decode(&art)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return art, errors.New("art not found")
+			return art, ErrArticleNotFound
 		}
 		return art, err
 	}
@@ -71,12 +168,12 @@ func (m *MongoDBArticleDAO) GetById(ctx context.Context, id int64) (Article, err
 }
 
 func (m *MongoDBArticleDAO) GetPubById(ctx context.Context, id int64) (Article, error) {
-	filter := bson.D{bson.E{Key: "id", Value: id}}
+	filter := bson.D{bson.E{Key: "id", Value: id}, notDeleted}
 	var art Article
 	err := m.liveCol.FindOne(ctx, filter).Decode(&art)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return art, errors.New("published art not found")
+			return art, ErrArticleNotFound
 		}
 		return art, err
 	}
@@ -88,29 +185,64 @@ func (m *MongoDBArticleDAO) Insert(ctx context.Context, art Article) (int64, err
 	art.Ctime = now
 	art.Utime = now
 	art.Id = m.node.Generate().Int64()
+	art.Version = 1
 	_, err := m.col.InsertOne(ctx, &art)
-	return art.Id, err
+	if err != nil {
+		return 0, err
+	}
+	return art.Id, m.afterSave(ctx, art)
+}
+
+// afterSave 在文章写成功之后再写标签和历史版本
+func (m *MongoDBArticleDAO) afterSave(ctx context.Context, art Article) error {
+	if art.Tags != nil {
+		if err := m.tags.SetArticleTags(ctx, art.Id, art.Tags); err != nil {
+			return err
+		}
+	}
+	if art.RevisionKind == 0 {
+		return nil
+	}
+	_, err := m.revs.Insert(ctx, ArticleRevision{
+		ArticleId: art.Id,
+		AuthorId:  art.AuthorId,
+		Title:     art.Title,
+		Content:   art.Content,
+		Kind:      art.RevisionKind,
+	})
+	return err
 }
 
 func (m *MongoDBArticleDAO) UpdateById(ctx context.Context, art Article) error {
 	now := time.Now().UnixMilli()
 	filter := bson.D{bson.E{Key: "id", Value: art.Id},
-		bson.E{Key: "author_id", Value: art.AuthorId}}
+		bson.E{Key: "author_id", Value: art.AuthorId}, notDeleted}
+	if art.Version > 0 {
+		filter = append(filter, bson.E{Key: "version", Value: art.Version})
+	}
 	set := bson.D{bson.E{Key: "$set", Value: bson.M{
-		"title":   art.Title,
-		"content": art.Content,
-		"status":  art.Status,
-		"utime":   now,
-	}}}
+		"title":      art.Title,
+		"content":    art.Content,
+		"status":     art.Status,
+		"publish_at": art.PublishAt,
+		"utime":      now,
+	}}, bson.E{Key: "$inc", Value: bson.M{"version": 1}}}
 	res, err := m.col.UpdateOne(ctx, filter, set)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount == 0 {
+	if res.MatchedCount == 0 {
+		if art.Version > 0 {
+			cnt, er := m.col.CountDocuments(ctx, bson.D{bson.E{Key: "id", Value: art.Id},
+				bson.E{Key: "author_id", Value: art.AuthorId}, notDeleted})
+			if er == nil && cnt > 0 {
+				return ErrVersionConflict
+			}
+		}
 		// 创作者不对，说明有人在瞎搞
-		return errors.New("ID 不对或者创作者不对")
+		return fmt.Errorf("update art failed, the author maybe invalid. id:[%d], author_id:[%d]", art.Id, art.AuthorId)
 	}
-	return nil
+	return m.afterSave(ctx, art)
 }
 
 func (m *MongoDBArticleDAO) Sync(ctx context.Context, art Article) (int64, error) {
@@ -127,51 +259,116 @@ func (m *MongoDBArticleDAO) Sync(ctx context.Context, art Article) (int64, error
 		return 0, err
 	}
 	art.Id = id
-	now := time.Now().UnixMilli()
-	art.Utime = now
-
+	// 线上库的版本号和制作库保持一致
+	cur, err := m.GetById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	art.Version = cur.Version
 	// liveCol 是 INSERT or Update 语义
-	//filter := bson.D{bson.E{Key: "id", Value: id},
-	//	bson.E{Key: "author_id", Value: art.AuthorId}}
-	filter := bson.M{"id": art.Id}
-	//set := bson.D{bson.E{Key: "$set", Value: art},
-	//	bson.E{Key: "$setOnInsert",
-	//		Value: bson.D{bson.E{Key: "ctime", Value: now}}}}
-	updateV1 := bson.M{
-		"$set":         PublishedArticle(art),
-		"$setOnInsert": bson.M{"ctime": now},
+	// 这里没有事务，不写 outbox，要可靠的事件还是用 MySQL
+	return id, m.Upsert(ctx, PublishedArticleV1{Article: art})
+}
+
+func (m *MongoDBArticleDAO) SyncStatus(ctx context.Context, id int64, author int64, status uint8) error {
+	filter := bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "author_id", Value: author}}
+	sets := bson.D{bson.E{Key: "$set",
+		Value: bson.D{bson.E{Key: "status", Value: status},
+			bson.E{Key: "utime", Value: time.Now().UnixMilli()}}}}
+	res, err := m.col.UpdateOne(ctx, filter, sets)
+	if err != nil {
+		return err
+	}
+	// 用 MatchedCount，状态没变的时候 ModifiedCount 可能是 0
+	if res.MatchedCount != 1 {
+		return fmt.Errorf("可能有人在搞你，误操作非自己的文章, uid: %d, author: %d", id, author)
 	}
+	_, err = m.liveCol.UpdateOne(ctx, bson.D{bson.E{Key: "id", Value: id}}, sets)
+	return err
+}
+
+// notDeleted deleted_at 是 omitempty 的，没有删除的文档可能没有这个字段
+var notDeleted = bson.E{Key: "deleted_at", Value: bson.M{"$not": bson.M{"$gt": 0}}}
+
+var deleted = bson.E{Key: "deleted_at", Value: bson.M{"$gt": 0}}
+
+func (m *MongoDBArticleDAO) Delete(ctx context.Context, id int64, author int64) error {
+	return m.setDeletedAt(ctx, id, author, time.Now().UnixMilli())
+}
 
-	_, err = m.liveCol.UpdateOne(ctx, filter,
-		updateV1,
-		//set,
-		options.Update().SetUpsert(true))
-	return id, err
+func (m *MongoDBArticleDAO) Restore(ctx context.Context, id int64, author int64) error {
+	return m.setDeletedAt(ctx, id, author, 0)
 }
 
-func (m *MongoDBArticleDAO) SyncStatus(ctx context.Context, uid int64, id int64, status uint8) error {
+func (m *MongoDBArticleDAO) setDeletedAt(ctx context.Context, id int64, author int64, deletedAt int64) error {
+	cond := notDeleted
+	if deletedAt == 0 {
+		cond = deleted
+	}
 	filter := bson.D{bson.E{Key: "id", Value: id},
-		bson.E{Key: "author_id", Value: uid}}
+		bson.E{Key: "author_id", Value: author}, cond}
 	sets := bson.D{bson.E{Key: "$set",
-		Value: bson.D{bson.E{Key: "status", Value: status}}}}
+		Value: bson.D{bson.E{Key: "deleted_at", Value: deletedAt}}}}
 	res, err := m.col.UpdateOne(ctx, filter, sets)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount != 1 {
-		return errors.New("ID 不对或者创作者不对")
+	if res.ModifiedCount == 0 {
+		return ErrArticleNotFound
 	}
-	_, err = m.liveCol.UpdateOne(ctx, filter, sets)
+	_, err = m.liveCol.UpdateOne(ctx, bson.D{bson.E{Key: "id", Value: id}}, sets)
 	return err
 }
 
+func (m *MongoDBArticleDAO) ListTrash(ctx context.Context, author int64, offset int, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "author_id", Value: author}, deleted}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+	cur, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
+func (m *MongoDBArticleDAO) Purge(ctx context.Context, id int64, author int64) error {
+	res, err := m.col.DeleteOne(ctx, bson.D{bson.E{Key: "id", Value: id},
+		bson.E{Key: "author_id", Value: author}, deleted})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrArticleNotFound
+	}
+	_, err = m.liveCol.DeleteOne(ctx, bson.D{bson.E{Key: "id", Value: id}})
+	return err
+}
+
+func (m *MongoDBArticleDAO) ListExpiredTrash(ctx context.Context, before int64, limit int) ([]Article, error) {
+	filter := bson.D{bson.E{Key: "deleted_at", Value: bson.M{"$gt": 0, "$lt": before}}}
+	opts := options.Find().SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}})
+	cur, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cur.All(ctx, &res)
+	return res, err
+}
+
 var _ ArticleDAO = &MongoDBArticleDAO{}
 
-func NewMongoDBArticleDAO(mdb *mongo.Database, node *snowflake.Node) *MongoDBArticleDAO {
+func NewMongoDBArticleDAO(mdb *mongo.Database, node *snowflake.Node, tags TagDAO, revs ArticleRevisionDAO) *MongoDBArticleDAO {
 	return &MongoDBArticleDAO{
 		node:    node,
 		liveCol: mdb.Collection("published_articles"),
 		col:     mdb.Collection("articles"),
+		tags:    tags,
+		revs:    revs,
 	}
 }
 
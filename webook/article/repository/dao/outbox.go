@@ -0,0 +1,175 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	TopicArticlePublished = "article_published"
+	TopicArticleWithdrawn = "article_withdrawn"
+	// TopicArticleScheduled 定时发表，BFF 消费之后安排发表的任务
+	TopicArticleScheduled = "article_scheduled"
+	TopicReadArticle      = "read_article"
+)
+
+const (
+	// OutboxStatusPending 等待投递，包括投递失败等待重试的
+	OutboxStatusPending uint8 = iota
+	// OutboxStatusDead 重试次数用完了，需要人工介入
+	OutboxStatusDead
+)
+
+// 和 domain.ArticleStatus 保持一致，dao 不依赖 domain
+const (
+	articleStatusPublished uint8 = 2
+	articleStatusPrivate   uint8 = 3
+	articleStatusScheduled uint8 = 4
+)
+
+// ArticleEvent 线上库变化之后写进 outbox 的事件
+// 只带 ID 和版本号，消费者要用文章的数据就自己去查
+type ArticleEvent struct {
+	Id       int64 `json:"id"`
+	AuthorId int64 `json:"author_id"`
+	Status   uint8 `json:"status"`
+	Version  int64 `json:"version"`
+	// PublishAt 只有定时发表的事件才有
+	PublishAt int64 `json:"publish_at,omitempty"`
+	Utime     int64 `json:"utime"`
+}
+
+type OutboxDAO interface {
+	Insert(ctx context.Context, msg OutboxMessage) error
+	// Claim 认领 next_retry_at 不晚于 now 的待投递消息，按照 id 升序
+	// 认领之后 lease 时间内别的 relay 不会再拿到这些消息，
+	// 投递结果要在这段时间内通过 Delete、Retry 或者 MarkDead 写回去，不然租约到期会被重新认领
+	Claim(ctx context.Context, now int64, lease time.Duration, limit int) ([]OutboxMessage, error)
+	// Delete 投递成功之后直接删除，表里面只留下没投递成功的
+	Delete(ctx context.Context, id int64) error
+	// Retry 投递失败，nextRetryAt 之后再试
+	Retry(ctx context.Context, id int64, retries int, nextRetryAt int64) error
+	// MarkDead 不再重试
+	MarkDead(ctx context.Context, id int64, retries int) error
+}
+
+type GORMOutboxDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMOutboxDAO(db *gorm.DB) OutboxDAO {
+	return &GORMOutboxDAO{db: db}
+}
+
+func (dao *GORMOutboxDAO) Insert(ctx context.Context, msg OutboxMessage) error {
+	now := time.Now().UnixMilli()
+	msg.Ctime = now
+	msg.Utime = now
+	return dao.db.WithContext(ctx).Create(&msg).Error
+}
+
+func (dao *GORMOutboxDAO) Claim(ctx context.Context, now int64, lease time.Duration, limit int) ([]OutboxMessage, error) {
+	var res []OutboxMessage
+	// 在事务里面认领，查询走的是主库，不会读到从库上已经删除了的消息
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED 别的 relay 正在认领的行直接跳过，不会互相等待
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_retry_at <= ?", OutboxStatusPending, now).
+			Order("id").Limit(limit).Find(&res).Error
+		if err != nil || len(res) == 0 {
+			return err
+		}
+		ids := make([]int64, 0, len(res))
+		for _, msg := range res {
+			ids = append(ids, msg.Id)
+		}
+		// 把 next_retry_at 推到租约到期的时候，就算事务提交之后 relay 挂了，消息也只是晚一点投递
+		return tx.Model(&OutboxMessage{}).Where("id IN ?", ids).
+			Updates(map[string]any{
+				"next_retry_at": now + lease.Milliseconds(),
+				"utime":         time.Now().UnixMilli(),
+			}).Error
+	})
+	return res, err
+}
+
+func (dao *GORMOutboxDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Where("id = ?", id).Delete(&OutboxMessage{}).Error
+}
+
+func (dao *GORMOutboxDAO) Retry(ctx context.Context, id int64, retries int, nextRetryAt int64) error {
+	return dao.db.WithContext(ctx).Model(&OutboxMessage{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"retries":       retries,
+			"next_retry_at": nextRetryAt,
+			"utime":         time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *GORMOutboxDAO) MarkDead(ctx context.Context, id int64, retries int) error {
+	return dao.db.WithContext(ctx).Model(&OutboxMessage{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"status":  OutboxStatusDead,
+			"retries": retries,
+			"utime":   time.Now().UnixMilli(),
+		}).Error
+}
+
+// insertArticleEvent 在制作库和线上库的事务里面写 outbox，保证事件不会丢
+// 只有发表、撤回和定时发表需要通知下游
+func insertArticleEvent(tx *gorm.DB, evt ArticleEvent) error {
+	var topic string
+	switch evt.Status {
+	case articleStatusPublished:
+		topic = TopicArticlePublished
+	case articleStatusPrivate:
+		topic = TopicArticleWithdrawn
+	case articleStatusScheduled:
+		topic = TopicArticleScheduled
+	default:
+		return nil
+	}
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	return tx.Create(&OutboxMessage{
+		Topic:   topic,
+		Key:     strconv.FormatInt(evt.Id, 10),
+		Payload: payload,
+		Ctime:   now,
+		Utime:   now,
+	}).Error
+}
+
+// syncStatusEvent 状态变化的事件也带上版本号，方便消费者丢弃旧的事件
+func syncStatusEvent(tx *gorm.DB, id int64, author int64, status uint8, utime int64) error {
+	var version int64
+	err := tx.Model(&Article{}).Where("id = ?", id).Pluck("version", &version).Error
+	if err != nil {
+		return err
+	}
+	return insertArticleEvent(tx, ArticleEvent{Id: id, AuthorId: author,
+		Status: status, Version: version, Utime: utime})
+}
+
+// OutboxMessage 和业务数据在同一个事务里面写入，由 relay 投递到 Kafka
+type OutboxMessage struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Topic string `gorm:"type:varchar(128)"`
+	// Key 决定分区，同一篇文章的事件要有序就用文章 ID
+	Key     string `gorm:"column:msg_key;type:varchar(128)"`
+	Payload []byte `gorm:"type:BLOB"`
+	Status  uint8  `gorm:"index:idx_status_next_retry,priority:1"`
+	Retries int
+	// NextRetryAt 新写入的消息是 0，马上就可以投递
+	NextRetryAt int64 `gorm:"index:idx_status_next_retry,priority:2"`
+	Ctime       int64
+	Utime       int64
+}
@@ -0,0 +1,148 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestArticleDAO_Outbox(t *testing.T) {
+	testCases := []struct {
+		name   string
+		newDAO func(t *testing.T, db *gorm.DB) ArticleDAO
+	}{
+		{
+			name: "GORM",
+			newDAO: func(t *testing.T, db *gorm.DB) ArticleDAO {
+				return NewGORMArticleDAO(db)
+			},
+		},
+		{
+			name: "对象存储",
+			newDAO: func(t *testing.T, db *gorm.DB) ArticleDAO {
+				store, err := blobstore.NewLocalStore(t.TempDir())
+				require.NoError(t, err)
+				return NewArticleBlobDAO(db, store, 8)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := initSQLiteDB(t)
+			artDAO := tc.newDAO(t, db)
+			outbox := NewGORMOutboxDAO(db)
+			ctx := context.Background()
+
+			// 保存草稿不需要通知下游
+			id, err := artDAO.Insert(ctx, Article{Title: "草稿", AuthorId: 123, Status: statusUnpublished})
+			require.NoError(t, err)
+			msgs, err := outbox.Claim(ctx, time.Now().UnixMilli(), time.Minute, 10)
+			require.NoError(t, err)
+			assert.Empty(t, msgs)
+
+			_, err = artDAO.Sync(ctx, Article{Id: id, Title: "发表", Content: "一篇比较长的文章",
+				AuthorId: 123, Status: statusPublished})
+			require.NoError(t, err)
+			err = artDAO.SyncStatus(ctx, id, 123, statusPrivate)
+			require.NoError(t, err)
+			// 别人的文章，事务回滚，不会有事件
+			_, err = artDAO.Sync(ctx, Article{Id: id, Title: "搞事", AuthorId: 456, Status: statusPublished})
+			require.Error(t, err)
+
+			msgs, err = outbox.Claim(ctx, time.Now().UnixMilli(), time.Minute, 10)
+			require.NoError(t, err)
+			require.Len(t, msgs, 2)
+			assert.Equal(t, TopicArticlePublished, msgs[0].Topic)
+			assert.Equal(t, TopicArticleWithdrawn, msgs[1].Topic)
+			for i, wantStatus := range []uint8{statusPublished, statusPrivate} {
+				assert.Equal(t, strconv.FormatInt(id, 10), msgs[i].Key)
+				var evt ArticleEvent
+				require.NoError(t, json.Unmarshal(msgs[i].Payload, &evt))
+				assert.Equal(t, id, evt.Id)
+				assert.Equal(t, int64(123), evt.AuthorId)
+				assert.Equal(t, wantStatus, evt.Status)
+				assert.Equal(t, int64(2), evt.Version)
+				require.NoError(t, outbox.Delete(ctx, msgs[i].Id))
+			}
+
+			// 进回收站对下游来说就是撤回，恢复之后按照原来的状态再通知一次
+			_, err = artDAO.Sync(ctx, Article{Id: id, Title: "再次发表", Content: "一篇比较长的文章",
+				AuthorId: 123, Status: statusPublished})
+			require.NoError(t, err)
+			require.NoError(t, artDAO.Delete(ctx, id, 123))
+			require.NoError(t, artDAO.Restore(ctx, id, 123))
+			msgs, err = outbox.Claim(ctx, time.Now().UnixMilli(), time.Minute, 10)
+			require.NoError(t, err)
+			require.Len(t, msgs, 3)
+			for i, wantTopic := range []string{TopicArticlePublished, TopicArticleWithdrawn, TopicArticlePublished} {
+				assert.Equal(t, wantTopic, msgs[i].Topic)
+				require.NoError(t, outbox.Delete(ctx, msgs[i].Id))
+			}
+
+			// 定时发表的事件和定时状态一起提交，没有发表过的文章删除的时候不通知，恢复之后要重新安排
+			publishAt := time.Now().Add(time.Hour).UnixMilli()
+			sid, err := artDAO.Insert(ctx, Article{Title: "定时", Content: "内容", AuthorId: 123,
+				Status: articleStatusScheduled, PublishAt: publishAt})
+			require.NoError(t, err)
+			require.NoError(t, artDAO.Delete(ctx, sid, 123))
+			require.NoError(t, artDAO.Restore(ctx, sid, 123))
+			msgs, err = outbox.Claim(ctx, time.Now().UnixMilli(), time.Minute, 10)
+			require.NoError(t, err)
+			require.Len(t, msgs, 2)
+			for _, msg := range msgs {
+				assert.Equal(t, TopicArticleScheduled, msg.Topic)
+				var evt ArticleEvent
+				require.NoError(t, json.Unmarshal(msg.Payload, &evt))
+				assert.Equal(t, sid, evt.Id)
+				assert.Equal(t, publishAt, evt.PublishAt)
+			}
+		})
+	}
+}
+
+func TestGORMOutboxDAO(t *testing.T) {
+	db := initSQLiteDB(t)
+	dao := NewGORMOutboxDAO(db)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		err := dao.Insert(ctx, OutboxMessage{Topic: TopicReadArticle, Payload: []byte(`{}`)})
+		require.NoError(t, err)
+	}
+	now := time.Now().UnixMilli()
+	msgs, err := dao.Claim(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+
+	require.NoError(t, dao.Delete(ctx, msgs[0].Id))
+	require.NoError(t, dao.Retry(ctx, msgs[1].Id, 1, now+1000))
+	require.NoError(t, dao.MarkDead(ctx, msgs[2].Id, 5))
+
+	// 还没有到重试时间
+	res, err := dao.Claim(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+	res, err = dao.Claim(ctx, now+1000, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, msgs[1].Id, res[0].Id)
+	assert.Equal(t, 1, res[0].Retries)
+	// 已经被认领了，租约到期之前别人拿不到
+	res, err = dao.Claim(ctx, now+1000, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+	res, err = dao.Claim(ctx, now+1000+time.Minute.Milliseconds(), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	var dead OutboxMessage
+	require.NoError(t, db.Where("id = ?", msgs[2].Id).First(&dead).Error)
+	assert.Equal(t, OutboxStatusDead, dead.Status)
+	assert.Equal(t, 5, dead.Retries)
+}
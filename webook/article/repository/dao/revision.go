@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type ArticleRevisionDAO interface {
+	Insert(ctx context.Context, rev ArticleRevision) (int64, error)
+	GetById(ctx context.Context, id int64) (ArticleRevision, error)
+	ListByArticle(ctx context.Context, artId int64, offset int, limit int) ([]ArticleRevision, error)
+	// DeleteByArticle 文章被彻底删除的时候，历史版本也一起删除
+	DeleteByArticle(ctx context.Context, artId int64) error
+}
+
+type GORMArticleRevisionDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleRevisionDAO(db *gorm.DB) ArticleRevisionDAO {
+	return &GORMArticleRevisionDAO{db: db}
+}
+
+// Insert 给 MongoDB 这种没法和文章在同一个事务里面写历史版本的实现用
+// 先锁住文章那一行，同一篇文章并发保存的时候排队计算版本号，不然会拿到同一个 MAX(version)
+func (dao *GORMArticleRevisionDAO) Insert(ctx context.Context, rev ArticleRevision) (int64, error) {
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+		err := tx.Model(&Article{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", rev.ArticleId).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		rev.Id, err = insertRevision(tx, rev)
+		return err
+	})
+	return rev.Id, err
+}
+
+// insertRevision tx 必须是事务，并且已经锁住了文章那一行
+// 版本号在事务里面计算，(article_id, version) 上有唯一索引兜底；附件的引用也一起写进去
+func insertRevision(tx *gorm.DB, rev ArticleRevision) (int64, error) {
+	rev.Ctime = time.Now().UnixMilli()
+	var version int64
+	err := tx.Model(&ArticleRevision{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("article_id = ?", rev.ArticleId).
+		Scan(&version).Error
+	if err != nil {
+		return 0, err
+	}
+	rev.Version = version + 1
+	if err = tx.Create(&rev).Error; err != nil {
+		return 0, err
+	}
+	return rev.Id, insertAttachmentRefs(tx, rev)
+}
+
+func (dao *GORMArticleRevisionDAO) GetById(ctx context.Context, id int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&rev).Error
+	return rev, err
+}
+
+func (dao *GORMArticleRevisionDAO) ListByArticle(ctx context.Context, artId int64, offset int, limit int) ([]ArticleRevision, error) {
+	var res []ArticleRevision
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", artId).
+		Order("version DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// DeleteByArticle 历史版本删掉之后，附件的引用也没有了，定时任务会清理附件
+func (dao *GORMArticleRevisionDAO) DeleteByArticle(ctx context.Context, artId int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id = ?", artId).Delete(&AttachmentRef{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("article_id = ?", artId).Delete(&ArticleRevision{}).Error
+	})
+}
+
+// ArticleRevision 文章的历史版本，只插入不更新
+type ArticleRevision struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64  `gorm:"uniqueIndex:article_version"`
+	Version   int64  `gorm:"uniqueIndex:article_version"`
+	AuthorId  int64  `gorm:"index"`
+	Title     string `gorm:"type=varchar(4096)"`
+	Content   string `gorm:"type=BLOB"`
+	Kind      uint8
+	Ctime     int64
+}
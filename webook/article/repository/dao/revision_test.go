@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMArticleRevisionDAO_Insert(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectBegin()
+	// 先锁住文章，再计算版本号
+	mock.ExpectQuery("SELECT `id` FROM `articles` WHERE id = \\? FOR UPDATE").
+		WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	mock.ExpectQuery("SELECT COALESCE\\(MAX\\(version\\), 0\\) FROM `article_revisions`").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	mock.ExpectExec("INSERT INTO `article_revisions`").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	id, err := NewGORMArticleRevisionDAO(db).Insert(context.Background(),
+		ArticleRevision{ArticleId: 12, AuthorId: 123, Title: "标题", Content: "内容"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGORMArticleRevisionDAO(t *testing.T) {
+	db := initSQLiteDB(t)
+	dao := NewGORMArticleRevisionDAO(db)
+	ctx := context.Background()
+	artId, err := NewGORMArticleDAO(db).Insert(ctx, Article{Title: "标题", AuthorId: 123})
+	require.NoError(t, err)
+
+	const cnt = 10
+	var wg sync.WaitGroup
+	for i := 0; i < cnt; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := dao.Insert(ctx, ArticleRevision{ArticleId: artId, AuthorId: 123, Content: "内容"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	revs, err := dao.ListByArticle(ctx, artId, 0, cnt+1)
+	require.NoError(t, err)
+	require.Len(t, revs, cnt)
+	for i, rev := range revs {
+		assert.Equal(t, int64(cnt-i), rev.Version)
+	}
+
+	rev, err := dao.GetById(ctx, revs[0].Id)
+	require.NoError(t, err)
+	assert.Equal(t, revs[0], rev)
+	require.NoError(t, dao.DeleteByArticle(ctx, artId))
+	revs, err = dao.ListByArticle(ctx, artId, 0, cnt)
+	require.NoError(t, err)
+	assert.Empty(t, revs)
+}
+
+// TestGORMArticleDAO_Revision 保存的时候在同一个事务里面记历史版本
+func TestGORMArticleDAO_Revision(t *testing.T) {
+	db := initSQLiteDB(t)
+	artDAO := NewGORMArticleDAO(db)
+	revDAO := NewGORMArticleRevisionDAO(db)
+	ctx := context.Background()
+
+	id, err := artDAO.Insert(ctx, Article{Title: "v1", Content: "内容 1", AuthorId: 123, RevisionKind: 1})
+	require.NoError(t, err)
+	// 不要求记历史版本的保存不会多出来一个
+	require.NoError(t, artDAO.UpdateById(ctx, Article{Id: id, Title: "v2", Content: "内容 2", AuthorId: 123}))
+	require.NoError(t, artDAO.UpdateById(ctx, Article{Id: id, Title: "v3", Content: "内容 3", AuthorId: 123, RevisionKind: 3}))
+	_, err = artDAO.Sync(ctx, Article{Id: id, Title: "v4", Content: "内容 4", AuthorId: 123, RevisionKind: 2})
+	require.NoError(t, err)
+	// 文章没有保存成功，也不会有历史版本
+	err = artDAO.UpdateById(ctx, Article{Id: id, Title: "v5", AuthorId: 456, RevisionKind: 1})
+	require.Error(t, err)
+
+	revs, err := revDAO.ListByArticle(ctx, id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, revs, 3)
+	titles := make([]string, 0, len(revs))
+	for i, rev := range revs {
+		assert.Equal(t, int64(len(revs)-i), rev.Version)
+		titles = append(titles, rev.Title)
+	}
+	assert.Equal(t, []string{"v4", "v3", "v1"}, titles)
+	assert.Equal(t, []uint8{2, 3, 1}, []uint8{revs[0].Kind, revs[1].Kind, revs[2].Kind})
+}
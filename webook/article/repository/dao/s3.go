@@ -1,122 +0,0 @@
-package dao
-
-import (
-	"bytes"
-	"context"
-	"errors"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/ecodeclub/ekit"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
-	"strconv"
-	"time"
-)
-
-type ArticleS3DAO struct {
-	GORMArticleDAO
-	oss    *s3.S3
-	bucket *string
-}
-
-func (a *ArticleS3DAO) SyncStatus(ctx context.Context, uid int64, id int64, status uint8) error {
-	now := time.Now().UnixMilli()
-	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		res := tx.Model(&Article{}).
-			Where("id = ? and author_id = ?", uid, id).
-			Updates(map[string]any{
-				"utime":  now,
-				"status": status,
-			})
-		if res.Error != nil {
-			return res.Error
-		}
-		if res.RowsAffected != 1 {
-			return errors.New("ID 不对或者创作者不对")
-		}
-		return tx.Model(&PublishedArticleV2{}).
-			Where("id = ?", uid).
-			Updates(map[string]any{
-				"utime":  now,
-				"status": status,
-			}).Error
-	})
-	if err != nil {
-		return err
-	}
-	const statusPrivate = 3
-	if status == statusPrivate {
-		_, err = a.oss.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
-			Bucket: ekit.ToPtr[string]("webook-1314583317"),
-			Key:    ekit.ToPtr[string](strconv.FormatInt(id, 10)),
-		})
-	}
-	return err
-}
-
-func (a *ArticleS3DAO) Sync(ctx context.Context, art Article) (int64, error) {
-	var id = art.Id
-	// 制作库流量不大、并发不高、保存到数据库即可
-	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var (
-			err error
-		)
-
-		dao := NewGORMArticleDAO(tx)
-		if id > 0 {
-			err = dao.UpdateById(ctx, art)
-		} else {
-			id, err = dao.Insert(ctx, art)
-		}
-		if err != nil {
-			return err
-		}
-		art.Id = id
-		now := time.Now().UnixMilli()
-		pubArt := PublishedArticleV2{
-			Id:       art.Id,
-			Title:    art.Title,
-			AuthorId: art.AuthorId,
-			Ctime:    now,
-			Utime:    now,
-			Status:   art.Status,
-		}
-		// 因为你线上库不会保存这个 Content
-		err = tx.Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "id"}},
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":  pubArt.Title,
-				"utime":  now,
-				"status": pubArt.Status,
-				// 要参与 SQL 运算的
-			}),
-		}).Create(&pubArt).Error
-		return err
-	})
-	if err != nil {
-		return 0, err
-	}
-	// 保存到 OSS
-	// 你要有监控、你要有补偿机制
-	_, err = a.oss.PutObjectWithContext(ctx, &s3.PutObjectInput{
-		Bucket:      a.bucket,
-		Key:         ekit.ToPtr[string](strconv.FormatInt(art.Id, 10)),
-		Body:        bytes.NewReader([]byte(art.Content)),
-		ContentType: ekit.ToPtr[string]("text/plain;charset=utf-8"),
-	})
-	return id, err
-}
-
-func NewArticleS3DAO(db *gorm.DB, oss *s3.S3, bucket *string) *ArticleS3DAO {
-	return &ArticleS3DAO{GORMArticleDAO: GORMArticleDAO{db: db}, oss: oss, bucket: bucket}
-}
-
-type PublishedArticleV2 struct {
-	Id    int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
-	Title string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
-	// 我要根据创作者ID来查询
-	AuthorId int64 `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8 `bson:"status,omitempty"`
-	Ctime    int64 `bson:"ctime,omitempty"`
-	// 更新时间
-	Utime int64 `bson:"utime,omitempty"`
-}
@@ -0,0 +1,202 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSeriesNotFound 系列不存在，或者不属于该作者
+	ErrSeriesNotFound = gorm.ErrRecordNotFound
+	// ErrInvalidSeriesArticles 文章不存在、已经删除或者不属于该作者
+	ErrInvalidSeriesArticles = errors.New("系列中的文章非法")
+	// ErrArticleInOtherSeries 一篇文章只能属于一个系列
+	ErrArticleInOtherSeries = errors.New("文章已经属于别的系列")
+)
+
+type SeriesDAO interface {
+	// Insert 创建系列，同时写入文章顺序
+	Insert(ctx context.Context, s Series, artIds []int64) (int64, error)
+	// UpdateById 只更新标题和简介
+	UpdateById(ctx context.Context, s Series) error
+	// SetArticles 整体覆盖系列中的文章和顺序
+	SetArticles(ctx context.Context, id int64, author int64, artIds []int64) error
+	GetById(ctx context.Context, id int64) (Series, error)
+	// GetArticleIds 按照顺序返回系列中的文章
+	GetArticleIds(ctx context.Context, id int64) ([]int64, error)
+	// GetIdByArticle 文章所在的系列，不在任何系列里面返回 ErrSeriesNotFound
+	GetIdByArticle(ctx context.Context, artId int64) (int64, error)
+	// ListByAuthor 按照更新时间倒序
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Series, error)
+	// RemoveArticle 把文章从它所在的系列里面移除，返回系列 ID
+	// 不在任何系列里面返回 ErrSeriesNotFound
+	RemoveArticle(ctx context.Context, artId int64) (int64, error)
+}
+
+type GORMSeriesDAO struct {
+	db *gorm.DB
+	// nextId 为空的时候用数据库的自增主键，分库之后由 ShardingSeriesDAO 设置
+	nextId func(author int64) int64
+}
+
+func NewGORMSeriesDAO(db *gorm.DB) SeriesDAO {
+	return &GORMSeriesDAO{db: db}
+}
+
+func (dao *GORMSeriesDAO) Insert(ctx context.Context, s Series, artIds []int64) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	if dao.nextId != nil {
+		s.Id = dao.nextId(s.AuthorId)
+	}
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&s).Error
+		if err != nil {
+			return err
+		}
+		return dao.setArticles(tx, s.Id, s.AuthorId, artIds)
+	})
+	return s.Id, err
+}
+
+func (dao *GORMSeriesDAO) UpdateById(ctx context.Context, s Series) error {
+	res := dao.db.WithContext(ctx).Model(&Series{}).
+		Where("id = ? AND author_id = ?", s.Id, s.AuthorId).
+		Updates(map[string]any{
+			"title":       s.Title,
+			"description": s.Description,
+			"utime":       time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (dao *GORMSeriesDAO) SetArticles(ctx context.Context, id int64, author int64, artIds []int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 顺便锁住这个系列，避免并发调整顺序
+		res := tx.Model(&Series{}).
+			Where("id = ? AND author_id = ?", id, author).
+			Update("utime", time.Now().UnixMilli())
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrSeriesNotFound
+		}
+		err := tx.Where("series_id = ?", id).Delete(&SeriesArticle{}).Error
+		if err != nil {
+			return err
+		}
+		return dao.setArticles(tx, id, author, artIds)
+	})
+}
+
+// setArticles 要在事务里面调用，调用方保证 artIds 没有重复
+func (dao *GORMSeriesDAO) setArticles(tx *gorm.DB, id int64, author int64, artIds []int64) error {
+	if len(artIds) == 0 {
+		return nil
+	}
+	var cnt int64
+	err := tx.Model(&Article{}).
+		Where("id IN ? AND author_id = ? AND deleted_at = 0", artIds, author).
+		Count(&cnt).Error
+	if err != nil {
+		return err
+	}
+	if cnt != int64(len(artIds)) {
+		return ErrInvalidSeriesArticles
+	}
+	now := time.Now().UnixMilli()
+	sas := make([]SeriesArticle, 0, len(artIds))
+	for i, artId := range artIds {
+		sas = append(sas, SeriesArticle{SeriesId: id, ArticleId: artId, Position: i, Ctime: now})
+	}
+	err = tx.Create(&sas).Error
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		const uniqueConflictErrNo uint16 = 1062
+		if mysqlErr.Number == uniqueConflictErrNo {
+			return ErrArticleInOtherSeries
+		}
+	}
+	return err
+}
+
+func (dao *GORMSeriesDAO) GetById(ctx context.Context, id int64) (Series, error) {
+	var s Series
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&s).Error
+	return s, err
+}
+
+func (dao *GORMSeriesDAO) GetArticleIds(ctx context.Context, id int64) ([]int64, error) {
+	var res []int64
+	err := dao.db.WithContext(ctx).Model(&SeriesArticle{}).
+		Where("series_id = ?", id).
+		Order("position").
+		Pluck("article_id", &res).Error
+	return res, err
+}
+
+func (dao *GORMSeriesDAO) GetIdByArticle(ctx context.Context, artId int64) (int64, error) {
+	var sa SeriesArticle
+	err := dao.db.WithContext(ctx).Where("article_id = ?", artId).First(&sa).Error
+	return sa.SeriesId, err
+}
+
+func (dao *GORMSeriesDAO) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Series, error) {
+	var res []Series
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ?", author).
+		Order("utime DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMSeriesDAO) RemoveArticle(ctx context.Context, artId int64) (int64, error) {
+	var sa SeriesArticle
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id = ?", artId).First(&sa).Error
+		if err != nil {
+			return err
+		}
+		// 剩下的文章顺序不变，position 中间空一个也没有关系
+		err = tx.Where("id = ?", sa.Id).Delete(&SeriesArticle{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Series{}).Where("id = ?", sa.SeriesId).
+			Update("utime", time.Now().UnixMilli()).Error
+	})
+	return sa.SeriesId, err
+}
+
+// Series 系列本身，文章的顺序在 SeriesArticle 里面
+type Series struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	Title       string `gorm:"type:varchar(1024)"`
+	Description string `gorm:"type:varchar(4096)"`
+	AuthorId    int64  `gorm:"index"`
+	Ctime       int64
+	Utime       int64
+}
+
+// SeriesArticle 系列和文章的关系，一篇文章只能属于一个系列
+type SeriesArticle struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	SeriesId int64 `gorm:"index:series_position,priority:1"`
+	// ArticleId 上的唯一索引保证一篇文章只能属于一个系列，也用来查文章所在的系列
+	ArticleId int64 `gorm:"uniqueIndex"`
+	Position  int   `gorm:"index:series_position,priority:2"`
+	Ctime     int64
+}
@@ -0,0 +1,170 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMSeriesDAO_SetArticles(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock   func(t *testing.T) *sql.DB
+		artIds []int64
+
+		wantErr error
+	}{
+		{
+			name: "覆盖成功",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `series` SET `utime`=.* WHERE id = \\? AND author_id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `series_articles` WHERE series_id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectExec("INSERT INTO `series_articles`").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+				return mockDb
+			},
+			artIds: []int64{2, 1},
+		},
+		{
+			name: "系列不存在或者不是自己的",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `series` SET `utime`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return mockDb
+			},
+			artIds:  []int64{1},
+			wantErr: ErrSeriesNotFound,
+		},
+		{
+			name: "有文章不是自己的",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `series` SET `utime`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `series_articles`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+				return mockDb
+			},
+			artIds:  []int64{1, 2},
+			wantErr: ErrInvalidSeriesArticles,
+		},
+		{
+			name: "文章已经在别的系列里面",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `series` SET `utime`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `series_articles`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO `series_articles`").
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectRollback()
+				return mockDb
+			},
+			artIds:  []int64{1},
+			wantErr: ErrArticleInOtherSeries,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMSeriesDAO(db)
+			err = d.SetArticles(context.Background(), 1, 123, tc.artIds)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMSeriesDAO_RemoveArticle(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "移出系列",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `series_articles` WHERE article_id = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "series_id", "article_id"}).AddRow(3, 7, 12))
+				mock.ExpectExec("DELETE FROM `series_articles` WHERE id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `series` SET `utime`=.* WHERE id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDb
+			},
+			wantId: 7,
+		},
+		{
+			name: "不在任何系列里面",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `series_articles` WHERE article_id = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "series_id", "article_id"}))
+				mock.ExpectRollback()
+				return mockDb
+			},
+			wantErr: ErrSeriesNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			id, err := NewGORMSeriesDAO(db).RemoveArticle(context.Background(), 12)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
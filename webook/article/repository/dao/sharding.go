@@ -0,0 +1,505 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/idgen"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidShards = errors.New("分库的数量必须是 2 的幂，并且不超过 64")
+
+// ShardingArticleDAO 制作库和线上库按照作者 ID 分库，每个库里面的表结构和不分库的时候一样
+// 作者这边的操作按照作者 ID 路由，读者这边只有文章 ID，按照文章 ID 路由
+// 文章 ID 由 idgen 生成，带着作者 ID 的基因，所以两种路由的结果是一样的
+// 一篇文章的制作库、线上库和 outbox 都在同一个库，发表还是单库事务
+// ListPub 这种不带作者也不带 ID 的查询要查所有的库，然后在内存里面归并
+type ShardingArticleDAO struct {
+	shards []*GORMArticleDAO
+}
+
+// NewShardingArticleDAO dbs 的下标就是分库的编号，上线之后不能调整顺序
+// 原来用自增主键的数据没有基因，要先迁移才能开启分库
+func NewShardingArticleDAO(dbs []*gorm.DB, gen *idgen.Generator) (*ShardingArticleDAO, error) {
+	n := len(dbs)
+	if n == 0 || n > idgen.MaxGene+1 || n&(n-1) != 0 {
+		return nil, ErrInvalidShards
+	}
+	shards := make([]*GORMArticleDAO, 0, n)
+	for _, db := range dbs {
+		shards = append(shards, &GORMArticleDAO{db: db, nextId: gen.Next})
+	}
+	return &ShardingArticleDAO{shards: shards}, nil
+}
+
+// shard 作者 ID 和文章 ID 都用这个方法路由
+func (s *ShardingArticleDAO) shard(key int64) *GORMArticleDAO {
+	return shardOf(s.shards, key)
+}
+
+func (s *ShardingArticleDAO) Insert(ctx context.Context, art Article) (int64, error) {
+	return s.shard(art.AuthorId).Insert(ctx, art)
+}
+
+func (s *ShardingArticleDAO) UpdateById(ctx context.Context, art Article) error {
+	return s.shard(art.AuthorId).UpdateById(ctx, art)
+}
+
+func (s *ShardingArticleDAO) Sync(ctx context.Context, art Article) (int64, error) {
+	return s.shard(art.AuthorId).Sync(ctx, art)
+}
+
+func (s *ShardingArticleDAO) Upsert(ctx context.Context, art PublishedArticleV1) error {
+	return s.shard(art.Id).Upsert(ctx, art)
+}
+
+func (s *ShardingArticleDAO) SyncStatus(ctx context.Context, id int64, author int64, status uint8) error {
+	return s.shard(author).SyncStatus(ctx, id, author, status)
+}
+
+func (s *ShardingArticleDAO) GetByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Article, error) {
+	return s.shard(author).GetByAuthor(ctx, author, offset, limit)
+}
+
+func (s *ShardingArticleDAO) GetById(ctx context.Context, id int64) (Article, error) {
+	return s.shard(id).GetById(ctx, id)
+}
+
+func (s *ShardingArticleDAO) GetPubById(ctx context.Context, id int64) (Article, error) {
+	return s.shard(id).GetPubById(ctx, id)
+}
+
+func (s *ShardingArticleDAO) ListPubByAuthor(ctx context.Context, author int64, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	return s.shard(author).ListPubByAuthor(ctx, author, status, start, offset, limit)
+}
+
+// GetPubTitles 按照文章 ID 的基因分组，每个库只查自己的那部分
+func (s *ShardingArticleDAO) GetPubTitles(ctx context.Context, ids []int64, status uint8) ([]Article, error) {
+	groups := groupByShard(s.shards, ids)
+	return s.gather(ctx, func(ctx context.Context, shard *GORMArticleDAO) ([]Article, error) {
+		if len(groups[shard]) == 0 {
+			return nil, nil
+		}
+		return shard.GetPubTitles(ctx, groups[shard], status)
+	})
+}
+
+func (s *ShardingArticleDAO) GetByAuthorCursor(ctx context.Context, author int64, utime int64, id int64, limit int) ([]Article, error) {
+	return s.shard(author).GetByAuthorCursor(ctx, author, utime, id, limit)
+}
+
+func (s *ShardingArticleDAO) Delete(ctx context.Context, id int64, author int64) error {
+	return s.shard(author).Delete(ctx, id, author)
+}
+
+func (s *ShardingArticleDAO) ListTrash(ctx context.Context, author int64, offset int, limit int) ([]Article, error) {
+	return s.shard(author).ListTrash(ctx, author, offset, limit)
+}
+
+func (s *ShardingArticleDAO) Restore(ctx context.Context, id int64, author int64) error {
+	return s.shard(author).Restore(ctx, id, author)
+}
+
+func (s *ShardingArticleDAO) Purge(ctx context.Context, id int64, author int64) error {
+	return s.shard(author).Purge(ctx, id, author)
+}
+
+// ListPub 每个库都要查前 offset + limit 条，所以 offset 越大越慢，翻页尽量用 ListPubCursor
+func (s *ShardingArticleDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
+	res, err := s.gather(ctx, func(ctx context.Context, shard *GORMArticleDAO) ([]Article, error) {
+		return shard.ListPub(ctx, start, 0, offset+limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortByUtimeDesc(res)
+	return page(res, offset, limit), nil
+}
+
+func (s *ShardingArticleDAO) ListPubCursor(ctx context.Context, utime int64, id int64, limit int) ([]Article, error) {
+	res, err := s.gather(ctx, func(ctx context.Context, shard *GORMArticleDAO) ([]Article, error) {
+		return shard.ListPubCursor(ctx, utime, id, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortByUtimeDesc(res)
+	return page(res, 0, limit), nil
+}
+
+// ListPubByTag 标签和文章在同一个库，和 ListPub 一样在内存里面归并
+func (s *ShardingArticleDAO) ListPubByTag(ctx context.Context, tag string, status uint8, start time.Time, offset int, limit int) ([]Article, error) {
+	res, err := s.gather(ctx, func(ctx context.Context, shard *GORMArticleDAO) ([]Article, error) {
+		return shard.ListPubByTag(ctx, tag, status, start, 0, offset+limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortByUtimeDesc(res)
+	return page(res, offset, limit), nil
+}
+
+func (s *ShardingArticleDAO) ListExpiredTrash(ctx context.Context, before int64, limit int) ([]Article, error) {
+	res, err := s.gather(ctx, func(ctx context.Context, shard *GORMArticleDAO) ([]Article, error) {
+		return shard.ListExpiredTrash(ctx, before, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].DeletedAt < res[j].DeletedAt
+	})
+	return page(res, 0, limit), nil
+}
+
+// gather 并发查询所有的库，任何一个库出错都返回错误，不返回部分结果
+func (s *ShardingArticleDAO) gather(ctx context.Context,
+	query func(ctx context.Context, shard *GORMArticleDAO) ([]Article, error)) ([]Article, error) {
+	return gather(ctx, s.shards, query)
+}
+
+// gather 所有分库的 DAO 共用，shards 的下标就是分库编号
+func gather[S any, T any](ctx context.Context, shards []S,
+	query func(ctx context.Context, shard S) ([]T, error)) ([]T, error) {
+	results := make([][]T, len(shards))
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, shard := range shards {
+		eg.Go(func() error {
+			res, err := query(ctx, shard)
+			results[i] = res
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	var res []T
+	for _, r := range results {
+		res = append(res, r...)
+	}
+	return res, nil
+}
+
+// shardOf 作者 ID 和带着基因的 ID 都用这个方法路由，和 ShardingArticleDAO 一致
+func shardOf[S any](shards []S, key int64) S {
+	return shards[idgen.Gene(key)%int64(len(shards))]
+}
+
+// groupByShard 按照基因把 ID 分到各自的库
+func groupByShard[S comparable](shards []S, ids []int64) map[S][]int64 {
+	groups := make(map[S][]int64, len(shards))
+	for _, id := range ids {
+		shard := shardOf(shards, id)
+		groups[shard] = append(groups[shard], id)
+	}
+	return groups
+}
+
+// sortByUtimeDesc 和单库的排序保持一致，按照 (utime, id) 倒序
+func sortByUtimeDesc(arts []Article) {
+	sort.Slice(arts, func(i, j int) bool {
+		if arts[i].Utime != arts[j].Utime {
+			return arts[i].Utime > arts[j].Utime
+		}
+		return arts[i].Id > arts[j].Id
+	})
+}
+
+func page[T any](src []T, offset int, limit int) []T {
+	if offset >= len(src) {
+		return nil
+	}
+	return src[offset:min(offset+limit, len(src))]
+}
+
+// ShardingOutboxDAO 分库之后每个库都有自己的 outbox，事件和文章在同一个事务里面写进去
+// 每个库的自增主键会重复，所以对外的 ID 是 库里面的 ID * 分库数量 + 分库编号，
+// 这样 OutboxRelay 不需要知道分库的存在
+type ShardingOutboxDAO struct {
+	shards []OutboxDAO
+}
+
+// NewShardingOutboxDAO dbs 要和 NewShardingArticleDAO 的一样
+func NewShardingOutboxDAO(dbs []*gorm.DB) *ShardingOutboxDAO {
+	shards := make([]OutboxDAO, 0, len(dbs))
+	for _, db := range dbs {
+		shards = append(shards, NewGORMOutboxDAO(db))
+	}
+	return &ShardingOutboxDAO{shards: shards}
+}
+
+// Insert 阅读事件这种和文章没有关系的消息都写到第一个库
+func (s *ShardingOutboxDAO) Insert(ctx context.Context, msg OutboxMessage) error {
+	return s.shards[0].Insert(ctx, msg)
+}
+
+// Claim 每个库各自认领，按照对外的 ID 升序合并，同一个库里面的顺序不变
+// 认领了的消息都要返回，不然它们要等租约到期才能再被认领
+func (s *ShardingOutboxDAO) Claim(ctx context.Context, now int64, lease time.Duration, limit int) ([]OutboxMessage, error) {
+	n := int64(len(s.shards))
+	var res []OutboxMessage
+	for i, shard := range s.shards {
+		msgs, err := shard.Claim(ctx, now, lease, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			msg.Id = msg.Id*n + int64(i)
+			res = append(res, msg)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Id < res[j].Id
+	})
+	return res, nil
+}
+
+func (s *ShardingOutboxDAO) Delete(ctx context.Context, id int64) error {
+	shard, id := s.route(id)
+	return shard.Delete(ctx, id)
+}
+
+func (s *ShardingOutboxDAO) Retry(ctx context.Context, id int64, retries int, nextRetryAt int64) error {
+	shard, id := s.route(id)
+	return shard.Retry(ctx, id, retries, nextRetryAt)
+}
+
+func (s *ShardingOutboxDAO) MarkDead(ctx context.Context, id int64, retries int) error {
+	shard, id := s.route(id)
+	return shard.MarkDead(ctx, id, retries)
+}
+
+// route 返回分库和库里面的 ID
+func (s *ShardingOutboxDAO) route(id int64) (OutboxDAO, int64) {
+	n := int64(len(s.shards))
+	return s.shards[id%n], id / n
+}
+
+// ShardingTagDAO 文章的标签和文章在同一个库，保存文章的时候在同一个事务里面写
+// 标签字典每个库各有一份，同一个名字在不同的库里面 ID 不一样，所以只能按照名字用
+type ShardingTagDAO struct {
+	shards []*GORMTagDAO
+}
+
+// NewShardingTagDAO dbs 要和 NewShardingArticleDAO 的一样，下面几个也是
+func NewShardingTagDAO(dbs []*gorm.DB) *ShardingTagDAO {
+	shards := make([]*GORMTagDAO, 0, len(dbs))
+	for _, db := range dbs {
+		shards = append(shards, &GORMTagDAO{db: db})
+	}
+	return &ShardingTagDAO{shards: shards}
+}
+
+func (s *ShardingTagDAO) SetArticleTags(ctx context.Context, artId int64, names []string) error {
+	return shardOf(s.shards, artId).SetArticleTags(ctx, artId, names)
+}
+
+func (s *ShardingTagDAO) GetByArticle(ctx context.Context, artId int64) ([]Tag, error) {
+	return shardOf(s.shards, artId).GetByArticle(ctx, artId)
+}
+
+// GetByArticles 按照文章 ID 的基因分组，每个库只查自己的那部分
+func (s *ShardingTagDAO) GetByArticles(ctx context.Context, artIds []int64) (map[int64][]Tag, error) {
+	res := make(map[int64][]Tag, len(artIds))
+	for shard, ids := range groupByShard(s.shards, artIds) {
+		tags, err := shard.GetByArticles(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for id, t := range tags {
+			res[id] = t
+		}
+	}
+	return res, nil
+}
+
+func (s *ShardingTagDAO) GetArticleIds(ctx context.Context, name string) ([]int64, error) {
+	return gather(ctx, s.shards, func(ctx context.Context, shard *GORMTagDAO) ([]int64, error) {
+		return shard.GetArticleIds(ctx, name)
+	})
+}
+
+// ShardingSeriesDAO 系列按照作者分库，和作者的文章在同一个库，加文章的时候还是单库事务
+// 系列的 ID 也带着作者的基因，按照系列 ID 查询的时候不需要知道作者
+type ShardingSeriesDAO struct {
+	shards []*GORMSeriesDAO
+}
+
+func NewShardingSeriesDAO(dbs []*gorm.DB, gen *idgen.Generator) *ShardingSeriesDAO {
+	shards := make([]*GORMSeriesDAO, 0, len(dbs))
+	for _, db := range dbs {
+		shards = append(shards, &GORMSeriesDAO{db: db, nextId: gen.Next})
+	}
+	return &ShardingSeriesDAO{shards: shards}
+}
+
+func (s *ShardingSeriesDAO) Insert(ctx context.Context, se Series, artIds []int64) (int64, error) {
+	return shardOf(s.shards, se.AuthorId).Insert(ctx, se, artIds)
+}
+
+func (s *ShardingSeriesDAO) UpdateById(ctx context.Context, se Series) error {
+	return shardOf(s.shards, se.AuthorId).UpdateById(ctx, se)
+}
+
+func (s *ShardingSeriesDAO) SetArticles(ctx context.Context, id int64, author int64, artIds []int64) error {
+	return shardOf(s.shards, author).SetArticles(ctx, id, author, artIds)
+}
+
+func (s *ShardingSeriesDAO) GetById(ctx context.Context, id int64) (Series, error) {
+	return shardOf(s.shards, id).GetById(ctx, id)
+}
+
+func (s *ShardingSeriesDAO) GetArticleIds(ctx context.Context, id int64) ([]int64, error) {
+	return shardOf(s.shards, id).GetArticleIds(ctx, id)
+}
+
+func (s *ShardingSeriesDAO) GetIdByArticle(ctx context.Context, artId int64) (int64, error) {
+	return shardOf(s.shards, artId).GetIdByArticle(ctx, artId)
+}
+
+func (s *ShardingSeriesDAO) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]Series, error) {
+	return shardOf(s.shards, author).ListByAuthor(ctx, author, offset, limit)
+}
+
+func (s *ShardingSeriesDAO) RemoveArticle(ctx context.Context, artId int64) (int64, error) {
+	return shardOf(s.shards, artId).RemoveArticle(ctx, artId)
+}
+
+// ShardingModerationDAO 审核日志和文章在同一个库，拒绝的时候改状态、记日志和写 outbox 还是单库事务
+// 日志的 ID 带着文章的基因，所以不同的库之间不会重复，也大体上按照时间递增
+type ShardingModerationDAO struct {
+	shards []*GORMModerationDAO
+}
+
+func NewShardingModerationDAO(dbs []*gorm.DB, gen *idgen.Generator) *ShardingModerationDAO {
+	shards := make([]*GORMModerationDAO, 0, len(dbs))
+	for _, db := range dbs {
+		shards = append(shards, &GORMModerationDAO{db: db, nextId: gen.Next})
+	}
+	return &ShardingModerationDAO{shards: shards}
+}
+
+func (s *ShardingModerationDAO) Insert(ctx context.Context, log ModerationLog) (int64, error) {
+	return shardOf(s.shards, log.ArticleId).Insert(ctx, log)
+}
+
+func (s *ShardingModerationDAO) Reject(ctx context.Context, log ModerationLog, version int64) error {
+	return shardOf(s.shards, log.ArticleId).Reject(ctx, log, version)
+}
+
+// ListPending 和 ListPub 一样，每个库都查前 offset + limit 条再归并
+func (s *ShardingModerationDAO) ListPending(ctx context.Context, offset int, limit int) ([]Article, error) {
+	res, err := gather(ctx, s.shards, func(ctx context.Context, shard *GORMModerationDAO) ([]Article, error) {
+		return shard.ListPending(ctx, 0, offset+limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 和单库的排序保持一致，先提交的排在前面
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Utime != res[j].Utime {
+			return res[i].Utime < res[j].Utime
+		}
+		return res[i].Id < res[j].Id
+	})
+	return page(res, offset, limit), nil
+}
+
+func (s *ShardingModerationDAO) ListLogs(ctx context.Context, artId int64, offset int, limit int) ([]ModerationLog, error) {
+	return shardOf(s.shards, artId).ListLogs(ctx, artId, offset, limit)
+}
+
+func (s *ShardingModerationDAO) ListLogsByAction(ctx context.Context, action uint8, offset int, limit int) ([]ModerationLog, error) {
+	res, err := gather(ctx, s.shards, func(ctx context.Context, shard *GORMModerationDAO) ([]ModerationLog, error) {
+		return shard.ListLogsByAction(ctx, action, 0, offset+limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Id > res[j].Id
+	})
+	return page(res, offset, limit), nil
+}
+
+// ShardingArticleRevisionDAO 历史版本由 ArticleDAO 在保存文章的事务里面写进文章所在的库
+// 历史版本的 ID 带着作者的基因，按照 ID 查询的时候直接路由
+type ShardingArticleRevisionDAO struct {
+	shards []*GORMArticleRevisionDAO
+	gen    *idgen.Generator
+}
+
+func NewShardingArticleRevisionDAO(dbs []*gorm.DB, gen *idgen.Generator) *ShardingArticleRevisionDAO {
+	shards := make([]*GORMArticleRevisionDAO, 0, len(dbs))
+	for _, db := range dbs {
+		shards = append(shards, &GORMArticleRevisionDAO{db: db})
+	}
+	return &ShardingArticleRevisionDAO{shards: shards, gen: gen}
+}
+
+func (s *ShardingArticleRevisionDAO) Insert(ctx context.Context, rev ArticleRevision) (int64, error) {
+	rev.Id = s.gen.Next(rev.ArticleId)
+	return shardOf(s.shards, rev.ArticleId).Insert(ctx, rev)
+}
+
+func (s *ShardingArticleRevisionDAO) GetById(ctx context.Context, id int64) (ArticleRevision, error) {
+	return shardOf(s.shards, id).GetById(ctx, id)
+}
+
+func (s *ShardingArticleRevisionDAO) ListByArticle(ctx context.Context, artId int64, offset int, limit int) ([]ArticleRevision, error) {
+	return shardOf(s.shards, artId).ListByArticle(ctx, artId, offset, limit)
+}
+
+func (s *ShardingArticleRevisionDAO) DeleteByArticle(ctx context.Context, artId int64) error {
+	return shardOf(s.shards, artId).DeleteByArticle(ctx, artId)
+}
+
+// ShardingAttachmentDAO 附件本身按照上传的作者管理，不分库
+// 引用跟着历史版本写在文章所在的库里面，清理之前要查所有的库
+type ShardingAttachmentDAO struct {
+	AttachmentDAO
+	refs []*GORMAttachmentDAO
+}
+
+// NewShardingAttachmentDAO d 是不分库的附件 DAO，dbs 要和 NewShardingArticleDAO 的一样
+func NewShardingAttachmentDAO(d AttachmentDAO, dbs []*gorm.DB) *ShardingAttachmentDAO {
+	refs := make([]*GORMAttachmentDAO, 0, len(dbs))
+	for _, db := range dbs {
+		refs = append(refs, &GORMAttachmentDAO{db: db})
+	}
+	return &ShardingAttachmentDAO{AttachmentDAO: d, refs: refs}
+}
+
+// Referenced 任何一个库里面有引用就算
+func (s *ShardingAttachmentDAO) Referenced(ctx context.Context, key string) (bool, error) {
+	for _, shard := range s.refs {
+		ok, err := shard.Referenced(ctx, key)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// ShardingExportDAO 导出任务不分库，只有统计文章数量要去作者所在的库
+type ShardingExportDAO struct {
+	ExportDAO
+	articles []*GORMExportDAO
+}
+
+// NewShardingExportDAO d 是不分库的导出 DAO，dbs 要和 NewShardingArticleDAO 的一样
+func NewShardingExportDAO(d ExportDAO, dbs []*gorm.DB) *ShardingExportDAO {
+	articles := make([]*GORMExportDAO, 0, len(dbs))
+	for _, db := range dbs {
+		articles = append(articles, &GORMExportDAO{db: db})
+	}
+	return &ShardingExportDAO{ExportDAO: d, articles: articles}
+}
+
+func (s *ShardingExportDAO) CountArticles(ctx context.Context, author int64) (int64, error) {
+	return shardOf(s.articles, author).CountArticles(ctx, author)
+}
@@ -0,0 +1,172 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/idgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNewShardingArticleDAO(t *testing.T) {
+	gen, err := idgen.NewGenerator(1)
+	require.NoError(t, err)
+	for _, n := range []int{0, 3, 128} {
+		_, err = NewShardingArticleDAO(make([]*gorm.DB, n), gen)
+		assert.ErrorIs(t, err, ErrInvalidShards)
+	}
+}
+
+func TestShardingArticleDAO_Route(t *testing.T) {
+	ctx := context.Background()
+	gen, err := idgen.NewGenerator(1)
+	require.NoError(t, err)
+	dbs := []*gorm.DB{initSQLiteDB(t), initSQLiteDB(t)}
+	d, err := NewShardingArticleDAO(dbs, gen)
+	require.NoError(t, err)
+
+	// 作者 122 在 0 号库，123 在 1 号库
+	ids := make(map[int64]int64)
+	for _, author := range []int64{122, 123} {
+		id, err := d.Sync(ctx, Article{Title: "标题", Content: "内容", AuthorId: author, Status: statusPublished})
+		require.NoError(t, err)
+		ids[author] = id
+		assert.Equal(t, author%2, id%2)
+	}
+	for author, id := range ids {
+		shard := dbs[author%2]
+		var cnt int64
+		require.NoError(t, shard.Model(&Article{}).Where("id = ?", id).Count(&cnt).Error)
+		assert.Equal(t, int64(1), cnt)
+		require.NoError(t, shard.Model(&PublishedArticleV1{}).Where("id = ?", id).Count(&cnt).Error)
+		assert.Equal(t, int64(1), cnt)
+		// 事件和文章在同一个库
+		require.NoError(t, shard.Model(&OutboxMessage{}).Where("msg_key = ?", strconv.FormatInt(id, 10)).Count(&cnt).Error)
+		assert.Equal(t, int64(1), cnt)
+		// 另外一个库没有
+		require.NoError(t, dbs[(author+1)%2].Model(&Article{}).Where("id = ?", id).Count(&cnt).Error)
+		assert.Equal(t, int64(0), cnt)
+	}
+
+	// 跨库归并
+	arts, err := d.ListPub(ctx, time.Now().Add(time.Second), 0, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{ids[122], ids[123]}, []int64{arts[0].Id, arts[1].Id})
+	assert.True(t, arts[0].Utime >= arts[1].Utime)
+	arts, err = d.ListPub(ctx, time.Now().Add(time.Second), 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, arts, 1)
+}
+
+func TestShardingOutboxDAO(t *testing.T) {
+	ctx := context.Background()
+	dbs := []*gorm.DB{initSQLiteDB(t), initSQLiteDB(t)}
+	d := NewShardingOutboxDAO(dbs)
+	require.NoError(t, d.Insert(ctx, OutboxMessage{Topic: TopicReadArticle}))
+	require.NoError(t, NewGORMOutboxDAO(dbs[1]).Insert(ctx, OutboxMessage{Topic: TopicArticlePublished}))
+
+	msgs, err := d.Claim(ctx, time.Now().UnixMilli(), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	// 两个库里面的 ID 都是 1
+	assert.Equal(t, []int64{2, 3}, []int64{msgs[0].Id, msgs[1].Id})
+	assert.Equal(t, TopicReadArticle, msgs[0].Topic)
+
+	require.NoError(t, d.Delete(ctx, msgs[1].Id))
+	require.NoError(t, d.MarkDead(ctx, msgs[0].Id, 10))
+	msgs, err = d.Claim(ctx, time.Now().UnixMilli(), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+	var msg OutboxMessage
+	require.NoError(t, dbs[0].First(&msg).Error)
+	assert.Equal(t, OutboxStatusDead, msg.Status)
+	var cnt int64
+	require.NoError(t, dbs[1].Model(&OutboxMessage{}).Count(&cnt).Error)
+	assert.Equal(t, int64(0), cnt)
+}
+
+// TestShardingDAOs_Route 标签、历史版本、系列、审核都要和作者的文章在同一个库
+func TestShardingDAOs_Route(t *testing.T) {
+	ctx := context.Background()
+	gen, err := idgen.NewGenerator(1)
+	require.NoError(t, err)
+	dbs := []*gorm.DB{initSQLiteDB(t), initSQLiteDB(t)}
+	arts, err := NewShardingArticleDAO(dbs, gen)
+	require.NoError(t, err)
+	tags := NewShardingTagDAO(dbs)
+	revs := NewShardingArticleRevisionDAO(dbs, gen)
+	series := NewShardingSeriesDAO(dbs, gen)
+	mods := NewShardingModerationDAO(dbs, gen)
+	exports := NewShardingExportDAO(NewGORMExportDAO(initSQLiteDB(t)), dbs)
+
+	ids := make(map[int64]int64)
+	for _, author := range []int64{122, 123} {
+		id, err := arts.Insert(ctx, Article{Title: "标题", Content: "内容", AuthorId: author,
+			Status: articleStatusPendingReview, Tags: []string{"Go"}, RevisionKind: 1})
+		require.NoError(t, err)
+		ids[author] = id
+	}
+
+	id := ids[123]
+	ts, err := tags.GetByArticle(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, ts, 1)
+	assert.Equal(t, "Go", ts[0].Name)
+	byArt, err := tags.GetByArticles(ctx, []int64{ids[122], ids[123]})
+	require.NoError(t, err)
+	assert.Len(t, byArt, 2)
+	artIds, err := tags.GetArticleIds(ctx, "Go")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{ids[122], ids[123]}, artIds)
+
+	// 历史版本的 ID 带着作者的基因，两个库里面的 ID 不会重复
+	rs, err := revs.ListByArticle(ctx, id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, rs, 1)
+	assert.Equal(t, int64(1), rs[0].Id%2)
+	rev, err := revs.GetById(ctx, rs[0].Id)
+	require.NoError(t, err)
+	assert.Equal(t, id, rev.ArticleId)
+
+	sid, err := series.Insert(ctx, Series{Title: "系列", AuthorId: 123}, []int64{id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), sid%2)
+	se, err := series.GetById(ctx, sid)
+	require.NoError(t, err)
+	assert.Equal(t, int64(123), se.AuthorId)
+	got, err := series.GetIdByArticle(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, sid, got)
+	var cnt int64
+	require.NoError(t, dbs[0].Model(&Series{}).Count(&cnt).Error)
+	assert.Equal(t, int64(0), cnt)
+
+	// 待审核的文章跨库归并
+	pending, err := mods.ListPending(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{ids[122], ids[123]}, []int64{pending[0].Id, pending[1].Id})
+	pending, err = mods.ListPending(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+	for _, author := range []int64{122, 123} {
+		_, err = mods.Insert(ctx, ModerationLog{ArticleId: ids[author], AuthorId: author, Action: 1})
+		require.NoError(t, err)
+	}
+	logs, err := mods.ListLogs(ctx, id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.NoError(t, dbs[1].Model(&ModerationLog{}).Where("id = ?", logs[0].Id).Count(&cnt).Error)
+	assert.Equal(t, int64(1), cnt)
+	logs, err = mods.ListLogsByAction(ctx, 1, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.True(t, logs[0].Id > logs[1].Id)
+
+	cnt, err = exports.CountArticles(ctx, 123)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cnt)
+}
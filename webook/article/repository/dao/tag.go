@@ -0,0 +1,126 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagDAO interface {
+	// SetArticleTags 整体覆盖一篇文章的标签，不存在的标签会被创建
+	SetArticleTags(ctx context.Context, artId int64, names []string) error
+	GetByArticle(ctx context.Context, artId int64) ([]Tag, error)
+	// GetByArticles 批量查询，key 是文章 ID
+	GetByArticles(ctx context.Context, artIds []int64) (map[int64][]Tag, error)
+	// GetArticleIds 带着这个标签的所有文章，MongoDB 没法和标签表 JOIN，只能先查出来 ID
+	// MySQL 的实现直接用 ArticleDAO.ListPubByTag
+	GetArticleIds(ctx context.Context, name string) ([]int64, error)
+}
+
+type GORMTagDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMTagDAO(db *gorm.DB) TagDAO {
+	return &GORMTagDAO{db: db}
+}
+
+func (dao *GORMTagDAO) SetArticleTags(ctx context.Context, artId int64, names []string) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return setArticleTags(tx, artId, names)
+	})
+}
+
+// setArticleTags tx 必须是事务，保存文章的时候和制作库在同一个事务里面
+func setArticleTags(tx *gorm.DB, artId int64, names []string) error {
+	now := time.Now().UnixMilli()
+	// 先把旧的关联删掉，再重新插入
+	err := tx.Where("article_id = ?", artId).Delete(&ArticleTag{}).Error
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name, Ctime: now, Utime: now})
+	}
+	// 标签已经存在就什么也不做
+	err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return err
+	}
+	// ON CONFLICT DO NOTHING 的时候拿不到已经存在的标签的 ID，所以要再查一遍
+	var ids []int64
+	err = tx.Model(&Tag{}).Where("name IN ?", names).Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	ats := make([]ArticleTag, 0, len(ids))
+	for _, id := range ids {
+		ats = append(ats, ArticleTag{ArticleId: artId, TagId: id, Ctime: now})
+	}
+	return tx.Create(&ats).Error
+}
+
+func (dao *GORMTagDAO) GetByArticle(ctx context.Context, artId int64) ([]Tag, error) {
+	res, err := dao.GetByArticles(ctx, []int64{artId})
+	if err != nil {
+		return nil, err
+	}
+	return res[artId], nil
+}
+
+func (dao *GORMTagDAO) GetByArticles(ctx context.Context, artIds []int64) (map[int64][]Tag, error) {
+	res := make(map[int64][]Tag, len(artIds))
+	if len(artIds) == 0 {
+		return res, nil
+	}
+	type row struct {
+		ArticleId int64
+		Tag
+	}
+	var rows []row
+	err := dao.db.WithContext(ctx).Model(&ArticleTag{}).
+		Select("article_tags.article_id, tags.id, tags.name, tags.ctime, tags.utime").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("article_tags.article_id IN ?", artIds).
+		Order("article_tags.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		res[r.ArticleId] = append(res[r.ArticleId], r.Tag)
+	}
+	return res, nil
+}
+
+func (dao *GORMTagDAO) GetArticleIds(ctx context.Context, name string) ([]int64, error) {
+	var ids []int64
+	err := dao.db.WithContext(ctx).Model(&ArticleTag{}).
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("tags.name = ?", name).
+		Pluck("article_tags.article_id", &ids).Error
+	return ids, err
+}
+
+// Tag 标签字典
+type Tag struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Name  string `gorm:"type:varchar(128);uniqueIndex"`
+	Ctime int64
+	Utime int64
+}
+
+// ArticleTag 文章和标签的多对多关系
+type ArticleTag struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:article_tag"`
+	// 按照标签查文章
+	TagId int64 `gorm:"uniqueIndex:article_tag;index"`
+	Ctime int64
+}
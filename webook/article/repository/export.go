@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+var (
+	ErrExportNotFound   = dao.ErrExportNotFound
+	ErrExportInProgress = dao.ErrExportInProgress
+	ErrNoExportTask     = dao.ErrNoExportTask
+	ErrExportPreempted  = dao.ErrExportPreempted
+)
+
+type ExportRepository interface {
+	Create(ctx context.Context, e domain.Export) (int64, error)
+	GetById(ctx context.Context, id int64) (domain.Export, error)
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Export, error)
+	// Claim 抢占一个等待执行的任务，staleBefore 之前就没有更新过进度的执行中的任务也算
+	Claim(ctx context.Context, staleBefore time.Time) (domain.Export, error)
+	// UpdateProgress 更新 Total 和 Exported，下面的方法都要求 Version 和抢占的时候一致
+	UpdateProgress(ctx context.Context, e domain.Export) error
+	// Complete 先上传压缩包再标记完成，e.Key 和 e.Size 要填好
+	Complete(ctx context.Context, e domain.Export, data []byte) error
+	Fail(ctx context.Context, e domain.Export) error
+	// CountArticles 作者要导出的文章数量
+	CountArticles(ctx context.Context, author int64) (int, error)
+	// PresignDownload 有有效期的下载地址
+	PresignDownload(ctx context.Context, e domain.Export, expiration time.Duration) (string, error)
+}
+
+// exportRepository 压缩包放在私有的对象存储里面，只能通过预签名的地址下载
+type exportRepository struct {
+	dao   dao.ExportDAO
+	store blobstore.Presigner
+}
+
+func NewExportRepository(dao dao.ExportDAO, store blobstore.Presigner) ExportRepository {
+	return &exportRepository{dao: dao, store: store}
+}
+
+func (r *exportRepository) Create(ctx context.Context, e domain.Export) (int64, error) {
+	return r.dao.Insert(ctx, dao.ExportTask{AuthorId: e.Author.Id})
+}
+
+func (r *exportRepository) GetById(ctx context.Context, id int64) (domain.Export, error) {
+	e, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Export{}, err
+	}
+	return r.toDomain(e), nil
+}
+
+func (r *exportRepository) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Export, error) {
+	res, err := r.dao.ListByAuthor(ctx, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.ExportTask) domain.Export {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *exportRepository) Claim(ctx context.Context, staleBefore time.Time) (domain.Export, error) {
+	e, err := r.dao.Claim(ctx, staleBefore.UnixMilli())
+	if err != nil {
+		return domain.Export{}, err
+	}
+	return r.toDomain(e), nil
+}
+
+func (r *exportRepository) UpdateProgress(ctx context.Context, e domain.Export) error {
+	return r.dao.UpdateProgress(ctx, e.Id, e.Version, e.Total, e.Exported)
+}
+
+func (r *exportRepository) Complete(ctx context.Context, e domain.Export, data []byte) error {
+	err := r.store.Put(ctx, e.Key, data, "application/zip")
+	if err != nil {
+		return err
+	}
+	return r.dao.Complete(ctx, e.Id, e.Version, e.Key, e.Size)
+}
+
+func (r *exportRepository) Fail(ctx context.Context, e domain.Export) error {
+	return r.dao.Fail(ctx, e.Id, e.Version, e.Error)
+}
+
+func (r *exportRepository) CountArticles(ctx context.Context, author int64) (int, error) {
+	cnt, err := r.dao.CountArticles(ctx, author)
+	return int(cnt), err
+}
+
+func (r *exportRepository) PresignDownload(ctx context.Context, e domain.Export, expiration time.Duration) (string, error) {
+	return r.store.PresignGet(ctx, e.Key, e.Filename(), expiration)
+}
+
+func (r *exportRepository) toDomain(e dao.ExportTask) domain.Export {
+	return domain.Export{
+		Id:       e.Id,
+		Author:   domain.Author{Id: e.AuthorId},
+		Status:   domain.ExportStatus(e.Status),
+		Total:    e.Total,
+		Exported: e.Exported,
+		Size:     e.Size,
+		Key:      e.ObjKey,
+		Error:    e.Error,
+		Version:  e.Version,
+		Ctime:    time.UnixMilli(e.Ctime),
+		Utime:    time.UnixMilli(e.Utime),
+	}
+}
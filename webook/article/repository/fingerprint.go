@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/simhash"
+)
+
+var ErrFingerprintNotFound = dao.ErrFingerprintNotFound
+
+// MaxSimilarDistance 指纹切成 4 段建索引，距离超过 3 的相似文章找不全
+const MaxSimilarDistance = 3
+
+// MaxCandidates 只有一段相同的候选可能很多，最多检查这么多
+const MaxCandidates = 1000
+
+type FingerprintRepository interface {
+	Save(ctx context.Context, fp domain.Fingerprint) error
+	Get(ctx context.Context, artId int64) (domain.Fingerprint, error)
+	// FindSimilar 和 fp 的海明距离不超过 maxDistance 的文章，按照距离升序，不包括 fp 自己
+	// 指纹只在彻底删除的时候清理，结果里面可能有已经撤回或者进了回收站的文章
+	// maxDistance 超过 MaxSimilarDistance 的按照 MaxSimilarDistance 处理
+	FindSimilar(ctx context.Context, fp domain.Fingerprint, maxDistance int, limit int) ([]domain.SimilarArticle, error)
+	Delete(ctx context.Context, artId int64) error
+}
+
+type fingerprintRepository struct {
+	dao dao.FingerprintDAO
+}
+
+func NewFingerprintRepository(dao dao.FingerprintDAO) FingerprintRepository {
+	return &fingerprintRepository{dao: dao}
+}
+
+func (r *fingerprintRepository) Save(ctx context.Context, fp domain.Fingerprint) error {
+	return r.dao.Upsert(ctx, r.toEntity(fp))
+}
+
+func (r *fingerprintRepository) Get(ctx context.Context, artId int64) (domain.Fingerprint, error) {
+	fp, err := r.dao.GetByArticleId(ctx, artId)
+	if err != nil {
+		return domain.Fingerprint{}, err
+	}
+	return domain.Fingerprint{
+		ArticleId: fp.ArticleId,
+		AuthorId:  fp.AuthorId,
+		Simhash:   uint64(fp.Simhash),
+		Ctime:     time.UnixMilli(fp.Ctime),
+	}, nil
+}
+
+func (r *fingerprintRepository) FindSimilar(ctx context.Context, fp domain.Fingerprint, maxDistance int, limit int) ([]domain.SimilarArticle, error) {
+	maxDistance = min(maxDistance, MaxSimilarDistance)
+	candidates, err := r.dao.FindCandidates(ctx, r.toEntity(fp), MaxCandidates)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.SimilarArticle, 0, len(candidates))
+	for _, c := range candidates {
+		d := simhash.Distance(fp.Simhash, uint64(c.Simhash))
+		if d > maxDistance {
+			continue
+		}
+		res = append(res, domain.SimilarArticle{
+			Id:       c.ArticleId,
+			AuthorId: c.AuthorId,
+			Distance: d,
+			Ctime:    time.UnixMilli(c.Ctime),
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Distance < res[j].Distance
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (r *fingerprintRepository) Delete(ctx context.Context, artId int64) error {
+	return r.dao.Delete(ctx, artId)
+}
+
+func (r *fingerprintRepository) toEntity(fp domain.Fingerprint) dao.ArticleFingerprint {
+	bands := simhash.Bands(fp.Simhash, 4)
+	return dao.ArticleFingerprint{
+		ArticleId: fp.ArticleId,
+		AuthorId:  fp.AuthorId,
+		Simhash:   int64(fp.Simhash),
+		Band0:     uint16(bands[0]),
+		Band1:     uint16(bands[1]),
+		Band2:     uint16(bands[2]),
+		Band3:     uint16(bands[3]),
+	}
+}
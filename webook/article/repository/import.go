@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/blobstore"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+var (
+	ErrImportNotFound   = dao.ErrImportNotFound
+	ErrImportInProgress = dao.ErrImportInProgress
+	ErrNoImportTask     = dao.ErrNoImportTask
+	ErrImportPreempted  = dao.ErrImportPreempted
+)
+
+type ImportRepository interface {
+	// Create 先把压缩包放进对象存储，再创建任务
+	Create(ctx context.Context, i domain.Import, data []byte) (int64, error)
+	GetById(ctx context.Context, id int64) (domain.Import, error)
+	// ListByAuthor 不带每个文件的结果
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Import, error)
+	// Claim 抢占一个等待执行的任务，staleBefore 之前就没有更新过进度的执行中的任务也算
+	Claim(ctx context.Context, staleBefore time.Time) (domain.Import, error)
+	// UpdateProgress 更新 Total、Imported 和 Results，下面的方法都要求 Version 和抢占的时候一致
+	UpdateProgress(ctx context.Context, i domain.Import) error
+	Complete(ctx context.Context, i domain.Import) error
+	Fail(ctx context.Context, i domain.Import) error
+	// GetArchive 读取上传的压缩包
+	GetArchive(ctx context.Context, i domain.Import) ([]byte, error)
+	// DeleteArchive 任务结束之后压缩包就没用了
+	DeleteArchive(ctx context.Context, i domain.Import) error
+}
+
+// importRepository 压缩包和导出的放在同一个私有的对象存储里面
+type importRepository struct {
+	dao   dao.ImportDAO
+	store blobstore.Store
+}
+
+func NewImportRepository(dao dao.ImportDAO, store blobstore.Store) ImportRepository {
+	return &importRepository{dao: dao, store: store}
+}
+
+func (r *importRepository) Create(ctx context.Context, i domain.Import, data []byte) (int64, error) {
+	// 还没有任务 ID，用时间区分同一个作者的多次导入
+	key := fmt.Sprintf("imports/%d/%d.zip", i.Author.Id, time.Now().UnixNano())
+	err := r.store.Put(ctx, key, data, "application/zip")
+	if err != nil {
+		return 0, err
+	}
+	id, err := r.dao.Insert(ctx, dao.ImportTask{AuthorId: i.Author.Id, ObjKey: key})
+	if err != nil {
+		// 任务没有建起来，压缩包也不要了
+		_ = r.store.Delete(ctx, key)
+	}
+	return id, err
+}
+
+func (r *importRepository) GetById(ctx context.Context, id int64) (domain.Import, error) {
+	i, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Import{}, err
+	}
+	return r.toDomain(i)
+}
+
+func (r *importRepository) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Import, error) {
+	res, err := r.dao.ListByAuthor(ctx, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	imports := make([]domain.Import, 0, len(res))
+	for _, src := range res {
+		i, err := r.toDomain(src)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, i)
+	}
+	return imports, nil
+}
+
+func (r *importRepository) Claim(ctx context.Context, staleBefore time.Time) (domain.Import, error) {
+	i, err := r.dao.Claim(ctx, staleBefore.UnixMilli())
+	if err != nil {
+		return domain.Import{}, err
+	}
+	return r.toDomain(i)
+}
+
+func (r *importRepository) UpdateProgress(ctx context.Context, i domain.Import) error {
+	results, err := r.marshalResults(i.Results)
+	if err != nil {
+		return err
+	}
+	return r.dao.UpdateProgress(ctx, i.Id, i.Version, i.Total, i.Imported, results)
+}
+
+func (r *importRepository) Complete(ctx context.Context, i domain.Import) error {
+	results, err := r.marshalResults(i.Results)
+	if err != nil {
+		return err
+	}
+	return r.dao.Complete(ctx, i.Id, i.Version, results)
+}
+
+func (r *importRepository) Fail(ctx context.Context, i domain.Import) error {
+	return r.dao.Fail(ctx, i.Id, i.Version, i.Error)
+}
+
+func (r *importRepository) GetArchive(ctx context.Context, i domain.Import) ([]byte, error) {
+	return r.store.Get(ctx, i.Key)
+}
+
+func (r *importRepository) DeleteArchive(ctx context.Context, i domain.Import) error {
+	return r.store.Delete(ctx, i.Key)
+}
+
+// importResult 存进数据库的格式，和 domain 分开，改字段名不影响已经存下来的数据
+type importResult struct {
+	Filename string `json:"filename"`
+	Title    string `json:"title"`
+	Id       int64  `json:"id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (r *importRepository) marshalResults(results []domain.ImportResult) ([]byte, error) {
+	return json.Marshal(slice.Map(results, func(idx int, src domain.ImportResult) importResult {
+		return importResult(src)
+	}))
+}
+
+func (r *importRepository) toDomain(i dao.ImportTask) (domain.Import, error) {
+	var results []importResult
+	if len(i.Results) > 0 {
+		if err := json.Unmarshal(i.Results, &results); err != nil {
+			return domain.Import{}, err
+		}
+	}
+	return domain.Import{
+		Id:       i.Id,
+		Author:   domain.Author{Id: i.AuthorId},
+		Status:   domain.ImportStatus(i.Status),
+		Total:    i.Total,
+		Imported: i.Imported,
+		Key:      i.ObjKey,
+		Results: slice.Map(results, func(idx int, src importResult) domain.ImportResult {
+			return domain.ImportResult(src)
+		}),
+		Error:   i.Error,
+		Version: i.Version,
+		Ctime:   time.UnixMilli(i.Ctime),
+		Utime:   time.UnixMilli(i.Utime),
+	}, nil
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webook/article/repository/fingerprint.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webook/article/repository/fingerprint.go -package=repomocks -destination=webook/article/repository/mocks/fingerprint.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/TengFeiyang01/webook/webook/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFingerprintRepository is a mock of FingerprintRepository interface.
+type MockFingerprintRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFingerprintRepositoryMockRecorder
+}
+
+// MockFingerprintRepositoryMockRecorder is the mock recorder for MockFingerprintRepository.
+type MockFingerprintRepositoryMockRecorder struct {
+	mock *MockFingerprintRepository
+}
+
+// NewMockFingerprintRepository creates a new mock instance.
+func NewMockFingerprintRepository(ctrl *gomock.Controller) *MockFingerprintRepository {
+	mock := &MockFingerprintRepository{ctrl: ctrl}
+	mock.recorder = &MockFingerprintRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFingerprintRepository) EXPECT() *MockFingerprintRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFingerprintRepository) Delete(ctx context.Context, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFingerprintRepositoryMockRecorder) Delete(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFingerprintRepository)(nil).Delete), ctx, artId)
+}
+
+// FindSimilar mocks base method.
+func (m *MockFingerprintRepository) FindSimilar(ctx context.Context, fp domain.Fingerprint, maxDistance, limit int) ([]domain.SimilarArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilar", ctx, fp, maxDistance, limit)
+	ret0, _ := ret[0].([]domain.SimilarArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilar indicates an expected call of FindSimilar.
+func (mr *MockFingerprintRepositoryMockRecorder) FindSimilar(ctx, fp, maxDistance, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilar", reflect.TypeOf((*MockFingerprintRepository)(nil).FindSimilar), ctx, fp, maxDistance, limit)
+}
+
+// Get mocks base method.
+func (m *MockFingerprintRepository) Get(ctx context.Context, artId int64) (domain.Fingerprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, artId)
+	ret0, _ := ret[0].(domain.Fingerprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockFingerprintRepositoryMockRecorder) Get(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFingerprintRepository)(nil).Get), ctx, artId)
+}
+
+// Save mocks base method.
+func (m *MockFingerprintRepository) Save(ctx context.Context, fp domain.Fingerprint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, fp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFingerprintRepositoryMockRecorder) Save(ctx, fp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFingerprintRepository)(nil).Save), ctx, fp)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webook/article/repository/moderation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webook/article/repository/moderation.go -package=repomocks -destination=webook/article/repository/mocks/moderation.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/TengFeiyang01/webook/webook/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModerationRepository is a mock of ModerationRepository interface.
+type MockModerationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationRepositoryMockRecorder
+}
+
+// MockModerationRepositoryMockRecorder is the mock recorder for MockModerationRepository.
+type MockModerationRepositoryMockRecorder struct {
+	mock *MockModerationRepository
+}
+
+// NewMockModerationRepository creates a new mock instance.
+func NewMockModerationRepository(ctrl *gomock.Controller) *MockModerationRepository {
+	mock := &MockModerationRepository{ctrl: ctrl}
+	mock.recorder = &MockModerationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationRepository) EXPECT() *MockModerationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockModerationRepository) Create(ctx context.Context, log domain.ModerationLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockModerationRepositoryMockRecorder) Create(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockModerationRepository)(nil).Create), ctx, log)
+}
+
+// ListLogs mocks base method.
+func (m *MockModerationRepository) ListLogs(ctx context.Context, artId int64, offset, limit int) ([]domain.ModerationLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogs", ctx, artId, offset, limit)
+	ret0, _ := ret[0].([]domain.ModerationLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogs indicates an expected call of ListLogs.
+func (mr *MockModerationRepositoryMockRecorder) ListLogs(ctx, artId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogs", reflect.TypeOf((*MockModerationRepository)(nil).ListLogs), ctx, artId, offset, limit)
+}
+
+// ListLogsByAction mocks base method.
+func (m *MockModerationRepository) ListLogsByAction(ctx context.Context, action domain.ModerationAction, offset, limit int) ([]domain.ModerationLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogsByAction", ctx, action, offset, limit)
+	ret0, _ := ret[0].([]domain.ModerationLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogsByAction indicates an expected call of ListLogsByAction.
+func (mr *MockModerationRepositoryMockRecorder) ListLogsByAction(ctx, action, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogsByAction", reflect.TypeOf((*MockModerationRepository)(nil).ListLogsByAction), ctx, action, offset, limit)
+}
+
+// ListPending mocks base method.
+func (m *MockModerationRepository) ListPending(ctx context.Context, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockModerationRepositoryMockRecorder) ListPending(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockModerationRepository)(nil).ListPending), ctx, offset, limit)
+}
+
+// Reject mocks base method.
+func (m *MockModerationRepository) Reject(ctx context.Context, log domain.ModerationLog, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, log, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockModerationRepositoryMockRecorder) Reject(ctx, log, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockModerationRepository)(nil).Reject), ctx, log, version)
+}
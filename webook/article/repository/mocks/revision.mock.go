@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webook/article/repository/revision.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webook/article/repository/revision.go -package=repomocks -destination=webook/article/repository/mocks/revision.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/TengFeiyang01/webook/webook/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleRevisionRepository is a mock of ArticleRevisionRepository interface.
+type MockArticleRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleRevisionRepositoryMockRecorder
+}
+
+// MockArticleRevisionRepositoryMockRecorder is the mock recorder for MockArticleRevisionRepository.
+type MockArticleRevisionRepositoryMockRecorder struct {
+	mock *MockArticleRevisionRepository
+}
+
+// NewMockArticleRevisionRepository creates a new mock instance.
+func NewMockArticleRevisionRepository(ctrl *gomock.Controller) *MockArticleRevisionRepository {
+	mock := &MockArticleRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleRevisionRepository) EXPECT() *MockArticleRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockArticleRevisionRepository) DeleteByArticle(ctx context.Context, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockArticleRevisionRepositoryMockRecorder) DeleteByArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockArticleRevisionRepository)(nil).DeleteByArticle), ctx, artId)
+}
+
+// GetById mocks base method.
+func (m *MockArticleRevisionRepository) GetById(ctx context.Context, id int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockArticleRevisionRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleRevisionRepository)(nil).GetById), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockArticleRevisionRepository) List(ctx context.Context, artId int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, artId, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockArticleRevisionRepositoryMockRecorder) List(ctx, artId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleRevisionRepository)(nil).List), ctx, artId, offset, limit)
+}
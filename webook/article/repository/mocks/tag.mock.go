@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webook/article/repository/tag.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webook/article/repository/tag.go -package=repomocks -destination=webook/article/repository/mocks/tag.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleTagRepository is a mock of ArticleTagRepository interface.
+type MockArticleTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleTagRepositoryMockRecorder
+}
+
+// MockArticleTagRepositoryMockRecorder is the mock recorder for MockArticleTagRepository.
+type MockArticleTagRepositoryMockRecorder struct {
+	mock *MockArticleTagRepository
+}
+
+// NewMockArticleTagRepository creates a new mock instance.
+func NewMockArticleTagRepository(ctrl *gomock.Controller) *MockArticleTagRepository {
+	mock := &MockArticleTagRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleTagRepository) EXPECT() *MockArticleTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchGetTags mocks base method.
+func (m *MockArticleTagRepository) BatchGetTags(ctx context.Context, artIds []int64) (map[int64][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetTags", ctx, artIds)
+	ret0, _ := ret[0].(map[int64][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetTags indicates an expected call of BatchGetTags.
+func (mr *MockArticleTagRepositoryMockRecorder) BatchGetTags(ctx, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetTags", reflect.TypeOf((*MockArticleTagRepository)(nil).BatchGetTags), ctx, artIds)
+}
+
+// GetTags mocks base method.
+func (m *MockArticleTagRepository) GetTags(ctx context.Context, artId int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, artId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockArticleTagRepositoryMockRecorder) GetTags(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockArticleTagRepository)(nil).GetTags), ctx, artId)
+}
+
+// SetTags mocks base method.
+func (m *MockArticleTagRepository) SetTags(ctx context.Context, artId, author int64, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", ctx, artId, author, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockArticleTagRepositoryMockRecorder) SetTags(ctx, artId, author, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockArticleTagRepository)(nil).SetTags), ctx, artId, author, tags)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/cache"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+var ErrNotPendingReview = dao.ErrNotPendingReview
+
+type ModerationRepository interface {
+	// Create 记录审核日志
+	Create(ctx context.Context, log domain.ModerationLog) error
+	// Reject 文章改成审核不通过并且通知作者，log 里面的状态不需要填
+	Reject(ctx context.Context, log domain.ModerationLog, version int64) error
+	ListPending(ctx context.Context, offset int, limit int) ([]domain.Article, error)
+	ListLogs(ctx context.Context, artId int64, offset int, limit int) ([]domain.ModerationLog, error)
+	ListLogsByAction(ctx context.Context, action domain.ModerationAction, offset int, limit int) ([]domain.ModerationLog, error)
+}
+
+type moderationRepository struct {
+	dao   dao.ModerationDAO
+	cache cache.ArticleCache
+	l     logger.LoggerV1
+}
+
+func NewModerationRepository(dao dao.ModerationDAO, cache cache.ArticleCache, l logger.LoggerV1) ModerationRepository {
+	return &moderationRepository{dao: dao, cache: cache, l: l}
+}
+
+func (r *moderationRepository) Create(ctx context.Context, log domain.ModerationLog) error {
+	_, err := r.dao.Insert(ctx, r.toEntity(log))
+	return err
+}
+
+func (r *moderationRepository) Reject(ctx context.Context, log domain.ModerationLog, version int64) error {
+	err := r.dao.Reject(ctx, r.toEntity(log), version)
+	if err != nil {
+		return err
+	}
+	// 作者的第一页里面有文章的状态
+	if err = r.cache.DelFirstPage(ctx, log.AuthorId); err != nil {
+		r.l.Error("删除缓存失败", logger.Int64("author", log.AuthorId), logger.Error(err))
+	}
+	return nil
+}
+
+func (r *moderationRepository) ListPending(ctx context.Context, offset int, limit int) ([]domain.Article, error) {
+	arts, err := r.dao.ListPending(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.Article) domain.Article {
+		return domain.Article{
+			Id:      src.Id,
+			Title:   src.Title,
+			Content: src.Content,
+			Author: domain.Author{
+				Id: src.AuthorId,
+			},
+			Status:  domain.ArticleStatus(src.Status),
+			Version: src.Version,
+			Ctime:   time.UnixMilli(src.Ctime),
+			Utime:   time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (r *moderationRepository) ListLogs(ctx context.Context, artId int64, offset int, limit int) ([]domain.ModerationLog, error) {
+	logs, err := r.dao.ListLogs(ctx, artId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainLogs(logs), nil
+}
+
+func (r *moderationRepository) ListLogsByAction(ctx context.Context, action domain.ModerationAction, offset int, limit int) ([]domain.ModerationLog, error) {
+	logs, err := r.dao.ListLogsByAction(ctx, action.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainLogs(logs), nil
+}
+
+func (r *moderationRepository) toDomainLogs(logs []dao.ModerationLog) []domain.ModerationLog {
+	return slice.Map(logs, func(idx int, src dao.ModerationLog) domain.ModerationLog {
+		return domain.ModerationLog{
+			Id:        src.Id,
+			ArticleId: src.ArticleId,
+			AuthorId:  src.AuthorId,
+			Reviewer:  src.Reviewer,
+			Action:    domain.ModerationAction(src.Action),
+			Status:    domain.ArticleStatus(src.Status),
+			Reason:    src.Reason,
+			Ctime:     time.UnixMilli(src.Ctime),
+		}
+	})
+}
+
+func (r *moderationRepository) toEntity(log domain.ModerationLog) dao.ModerationLog {
+	return dao.ModerationLog{
+		ArticleId: log.ArticleId,
+		AuthorId:  log.AuthorId,
+		Reviewer:  log.Reviewer,
+		Action:    log.Action.ToUint8(),
+		Status:    log.Status.ToUint8(),
+		Reason:    log.Reason,
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+type ArticleRevisionRepository interface {
+	GetById(ctx context.Context, id int64) (domain.ArticleRevision, error)
+	List(ctx context.Context, artId int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	DeleteByArticle(ctx context.Context, artId int64) error
+}
+
+// articleRevisionRepository 历史版本在保存文章的时候由 ArticleDAO 在同一个事务里面写入，这里只负责读
+// 历史版本是不可变的，访问频率也很低，所以没有引入缓存
+type articleRevisionRepository struct {
+	dao dao.ArticleRevisionDAO
+}
+
+func NewArticleRevisionRepository(dao dao.ArticleRevisionDAO) ArticleRevisionRepository {
+	return &articleRevisionRepository{dao: dao}
+}
+
+func (r *articleRevisionRepository) GetById(ctx context.Context, id int64) (domain.ArticleRevision, error) {
+	rev, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return r.toDomain(rev), nil
+}
+
+func (r *articleRevisionRepository) List(ctx context.Context, artId int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	revs, err := r.dao.ListByArticle(ctx, artId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(revs, func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *articleRevisionRepository) DeleteByArticle(ctx context.Context, artId int64) error {
+	return r.dao.DeleteByArticle(ctx, artId)
+}
+
+func (r *articleRevisionRepository) toDomain(rev dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		AuthorId:  rev.AuthorId,
+		Version:   rev.Version,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Kind:      domain.RevisionKind(rev.Kind),
+		Ctime:     time.UnixMilli(rev.Ctime),
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository/cache"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+var (
+	ErrSeriesNotFound        = dao.ErrSeriesNotFound
+	ErrInvalidSeriesArticles = dao.ErrInvalidSeriesArticles
+	ErrArticleInOtherSeries  = dao.ErrArticleInOtherSeries
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	// Update 只更新标题和简介
+	Update(ctx context.Context, s domain.Series) error
+	// SetArticles 整体覆盖系列中的文章，调整顺序也是调用这个
+	SetArticles(ctx context.Context, id int64, author int64, artIds []int64) error
+	// GetById 带上按照顺序排列的文章 ID
+	GetById(ctx context.Context, id int64) (domain.Series, error)
+	// GetByArticle 文章所在的系列，不在任何系列里面返回 ErrSeriesNotFound
+	GetByArticle(ctx context.Context, artId int64) (domain.Series, error)
+	// ListByAuthor 列表不带文章 ID
+	ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Series, error)
+	// RemoveArticle 文章被彻底删除之后从系列里面移除，不在任何系列里面不算错误
+	RemoveArticle(ctx context.Context, artId int64) error
+}
+
+type CachedSeriesRepository struct {
+	dao   dao.SeriesDAO
+	cache cache.SeriesCache
+	l     logger.LoggerV1
+}
+
+func NewCachedSeriesRepository(dao dao.SeriesDAO, cache cache.SeriesCache, l logger.LoggerV1) SeriesRepository {
+	return &CachedSeriesRepository{dao: dao, cache: cache, l: l}
+}
+
+func (c *CachedSeriesRepository) Create(ctx context.Context, s domain.Series) (int64, error) {
+	return c.dao.Insert(ctx, c.toEntity(s), s.ArticleIds)
+}
+
+func (c *CachedSeriesRepository) Update(ctx context.Context, s domain.Series) error {
+	err := c.dao.UpdateById(ctx, c.toEntity(s))
+	if err != nil {
+		return err
+	}
+	c.evict(ctx, s.Id)
+	return nil
+}
+
+func (c *CachedSeriesRepository) SetArticles(ctx context.Context, id int64, author int64, artIds []int64) error {
+	err := c.dao.SetArticles(ctx, id, author, artIds)
+	if err != nil {
+		return err
+	}
+	c.evict(ctx, id)
+	return nil
+}
+
+func (c *CachedSeriesRepository) GetById(ctx context.Context, id int64) (domain.Series, error) {
+	res, err := c.cache.Get(ctx, id)
+	if err == nil {
+		return res, nil
+	}
+	s, err := c.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	ids, err := c.dao.GetArticleIds(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	res = c.toDomain(s)
+	res.ArticleIds = ids
+	err = c.cache.Set(ctx, res)
+	if err != nil {
+		c.l.Error("回写系列缓存失败", logger.Int64("sid", id), logger.Error(err))
+	}
+	return res, nil
+}
+
+func (c *CachedSeriesRepository) GetByArticle(ctx context.Context, artId int64) (domain.Series, error) {
+	id, err := c.dao.GetIdByArticle(ctx, artId)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return c.GetById(ctx, id)
+}
+
+func (c *CachedSeriesRepository) ListByAuthor(ctx context.Context, author int64, offset int, limit int) ([]domain.Series, error) {
+	res, err := c.dao.ListByAuthor(ctx, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Series) domain.Series {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedSeriesRepository) RemoveArticle(ctx context.Context, artId int64) error {
+	id, err := c.dao.RemoveArticle(ctx, artId)
+	if errors.Is(err, ErrSeriesNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.evict(ctx, id)
+	return nil
+}
+
+func (c *CachedSeriesRepository) evict(ctx context.Context, id int64) {
+	err := c.cache.Del(ctx, id)
+	if err != nil {
+		c.l.Error("删除系列缓存失败", logger.Int64("sid", id), logger.Error(err))
+	}
+}
+
+func (c *CachedSeriesRepository) toEntity(s domain.Series) dao.Series {
+	return dao.Series{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		AuthorId:    s.Author.Id,
+	}
+}
+
+func (c *CachedSeriesRepository) toDomain(s dao.Series) domain.Series {
+	return domain.Series{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		Author: domain.Author{
+			Id: s.AuthorId,
+		},
+		Ctime: time.UnixMilli(s.Ctime),
+		Utime: time.UnixMilli(s.Utime),
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/TengFeiyang01/webook/webook/article/repository/cache"
+	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+type ArticleTagRepository interface {
+	// SetTags 整体覆盖文章的标签
+	SetTags(ctx context.Context, artId int64, author int64, tags []string) error
+	GetTags(ctx context.Context, artId int64) ([]string, error)
+	// BatchGetTags key 是文章 ID，没有标签的文章不会出现在结果里
+	BatchGetTags(ctx context.Context, artIds []int64) (map[int64][]string, error)
+}
+
+// CachedArticleTagRepository 标签本身不缓存，但是标签变了要让文章的缓存失效
+type CachedArticleTagRepository struct {
+	dao   dao.TagDAO
+	cache cache.ArticleCache
+	l     logger.LoggerV1
+}
+
+func NewCachedArticleTagRepository(dao dao.TagDAO, cache cache.ArticleCache, l logger.LoggerV1) ArticleTagRepository {
+	return &CachedArticleTagRepository{dao: dao, cache: cache, l: l}
+}
+
+func (r *CachedArticleTagRepository) SetTags(ctx context.Context, artId int64, author int64, tags []string) error {
+	err := r.dao.SetArticleTags(ctx, artId, tags)
+	if err != nil {
+		return err
+	}
+	// 缓存里面的文章带着旧的标签
+	if err = r.cache.Del(ctx, artId); err != nil {
+		r.l.Error("删除缓存失败", logger.Int64("artId", artId), logger.Error(err))
+	}
+	if err = r.cache.DelFirstPage(ctx, author); err != nil {
+		r.l.Error("删除缓存失败", logger.Int64("author", author), logger.Error(err))
+	}
+	return nil
+}
+
+func (r *CachedArticleTagRepository) GetTags(ctx context.Context, artId int64) ([]string, error) {
+	tags, err := r.dao.GetByArticle(ctx, artId)
+	if err != nil {
+		return nil, err
+	}
+	return r.toNames(tags), nil
+}
+
+func (r *CachedArticleTagRepository) BatchGetTags(ctx context.Context, artIds []int64) (map[int64][]string, error) {
+	tags, err := r.dao.GetByArticles(ctx, artIds)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64][]string, len(tags))
+	for id, ts := range tags {
+		res[id] = r.toNames(ts)
+	}
+	return res, nil
+}
+
+func (r *CachedArticleTagRepository) toNames(tags []dao.Tag) []string {
+	return slice.Map(tags, func(idx int, src dao.Tag) string {
+		return src.Name
+	})
+}
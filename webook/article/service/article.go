@@ -2,14 +2,33 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
 	"github.com/TengFeiyang01/webook/webook/article/events"
 	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/diff"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/sensitive"
 )
 
-//go:generate mockgen -source=./art.go -destination=./mocks/service.mock.go -package=svcmocks ArticleService
+// ErrInvalidRevision 版本不存在，或者不属于当前作者
+var ErrInvalidRevision = errors.New("版本不存在或者不属于该作者")
+
+var (
+	// ErrInvalidPublishAt 定时发表的时间必须在未来
+	ErrInvalidPublishAt = errors.New("定时发表的时间非法")
+	// ErrNotScheduled 文章不存在、不属于该作者，或者并不处于定时发表状态
+	ErrNotScheduled = errors.New("文章不处于定时发表状态")
+	// ErrTooManyTags 标签数量超过了 domain.MaxTagsPerArticle
+	ErrTooManyTags = errors.New("标签太多")
+	// ErrVersionConflict 文章已经在别的地方被修改过了
+	ErrVersionConflict = repository.ErrVersionConflict
+	// ErrArticleNotFound 文章不存在、不属于该作者，或者不在回收站里（恢复、彻底删除的时候）
+	ErrArticleNotFound = repository.ErrArticleNotFound
+)
+
+//go:generate mockgen -source=./article.go -destination=./mocks/service.mock.go -package=svcmocks ArticleService
 type ArticleService interface {
 	Save(ctx context.Context, art domain.Article) (int64, error)
 	WithDraw(ctx context.Context, art domain.Article) error
@@ -20,10 +39,108 @@ type ArticleService interface {
 	ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 	GetPublishedById(ctx context.Context, id int64, uid int64) (domain.Article, error)
+	// LookupPublished 查线上库的文章，和 GetPublishedById 一样，但是不产生阅读事件
+	// 给其它服务用，撤回了的文章也会返回，调用方自己看状态
+	LookupPublished(ctx context.Context, id int64) (domain.Article, error)
+	// ListByCursor 按照 (utime, id) 倒序翻页，cursor 是上一页返回的 next，空字符串表示第一页
+	// next 为空说明已经没有下一页了
+	ListByCursor(ctx context.Context, uid int64, cursor string, limit int) (arts []domain.Article, next string, err error)
+	// ListPubByCursor 和 ListPub 一样只取 start 之前的，但是翻页过程中有文章更新也不会跳过或者重复
+	ListPubByCursor(ctx context.Context, start time.Time, cursor string, limit int) (arts []domain.Article, next string, err error)
+	// ListPubByTag 按照标签列出已发表的文章，和 ListPub 一样只取 start 之前的
+	ListPubByTag(ctx context.Context, tag string, start time.Time, offset, limit int) ([]domain.Article, error)
+	// ListPubByAuthor 某个作者已发表的文章，和 ListPub 一样只取 start 之前的
+	ListPubByAuthor(ctx context.Context, author int64, start time.Time, offset, limit int) ([]domain.Article, error)
+
+	// ListRevisions 按照版本号倒序列出某篇文章的历史版本
+	ListRevisions(ctx context.Context, uid int64, artId int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, uid int64, id int64) (domain.ArticleRevision, error)
+	// DiffRevisions 按行比较同一篇文章的两个版本
+	DiffRevisions(ctx context.Context, uid int64, from int64, to int64) (domain.RevisionDiff, error)
+	// RestoreRevision 把制作库里的文章恢复成某个版本，恢复本身也会产生一个新版本
+	RestoreRevision(ctx context.Context, uid int64, id int64) (int64, error)
+
+	// SchedulePublish 保存文章并标记为定时发表，真正的发表由定时任务完成
+	// 对已经处于定时发表状态的文章再次调用，就是修改发表时间
+	SchedulePublish(ctx context.Context, art domain.Article) (int64, error)
+	// CancelSchedule 取消定时发表，文章回到草稿状态
+	CancelSchedule(ctx context.Context, uid int64, id int64) error
+
+	// Delete 把文章移进回收站，已经发表的文章读者也看不到了
+	Delete(ctx context.Context, uid int64, id int64) error
+	// ListTrash 按照删除时间倒序列出回收站里的文章
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// Restore 从回收站恢复，文章回到删除之前的状态
+	Restore(ctx context.Context, uid int64, id int64) error
+	// Purge 彻底删除回收站里的文章，包括标签和历史版本，不能恢复
+	Purge(ctx context.Context, uid int64, id int64) error
+	// PurgeExpired 彻底删除在 before 之前就进了回收站的文章，返回删除了的文章 ID
+	// 每次最多处理 limit 篇，调用方根据返回的数量决定要不要继续
+	PurgeExpired(ctx context.Context, before time.Time, limit int) ([]int64, error)
+
+	// CreateSeries 创建系列，ArticleIds 就是阅读顺序
+	CreateSeries(ctx context.Context, s domain.Series) (int64, error)
+	// ReorderSeries 整体覆盖系列中的文章，调整顺序、添加和移除文章都用这个
+	ReorderSeries(ctx context.Context, uid int64, id int64, artIds []int64) error
+	// ListSeries 列出作者的系列，不带文章 ID
+	ListSeries(ctx context.Context, uid int64, offset int, limit int) ([]domain.Series, error)
+	// GetSeriesNav 文章所在的系列以及上一篇、下一篇，文章不在系列里面返回 ErrSeriesNotFound
+	GetSeriesNav(ctx context.Context, artId int64) (domain.SeriesNav, error)
+
+	// CreateUpload 检查类型、大小和空间，返回记录下来的附件和预签名的上传 URL
+	CreateUpload(ctx context.Context, a domain.Attachment) (domain.Attachment, string, error)
+	// CompleteUpload 客户端上传完成之后调用，确认对象存储里面的内容和申请的一致
+	CompleteUpload(ctx context.Context, uid int64, id int64) (domain.Attachment, error)
+	// ListAttachments 按照创建时间倒序
+	ListAttachments(ctx context.Context, uid int64, offset int, limit int) ([]domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, uid int64, id int64) error
+	// GCAttachments 清理 before 之前创建的、没有被任何历史版本引用的附件，从 startId 之后开始
+	// 每次最多检查 limit 个，返回下一次的 startId，为 0 说明已经检查完了
+	GCAttachments(ctx context.Context, before time.Time, startId int64, limit int) (next int64, deleted int, err error)
+
+	// ListPendingReview 审核队列，先提交的排在前面
+	ListPendingReview(ctx context.Context, offset int, limit int) ([]domain.Article, error)
+	// Approve 审核通过，文章上线。version 大于 0 的时候要求作者在审核期间没有修改过
+	Approve(ctx context.Context, reviewer int64, id int64, version int64) error
+	// Reject 审核不通过，会通知作者
+	Reject(ctx context.Context, reviewer int64, id int64, version int64, reason string) error
+	// ListModerationLogs 文章的审核记录，按照时间倒序
+	ListModerationLogs(ctx context.Context, artId int64, offset int, limit int) ([]domain.ModerationLog, error)
+
+	// StartExport 把作者所有的文章导出成 Markdown 压缩包，由 Exporter 在后台执行
+	StartExport(ctx context.Context, uid int64) (domain.Export, error)
+	// GetExport 导出完成的话带上有有效期的下载地址
+	GetExport(ctx context.Context, uid int64, id int64) (domain.Export, error)
+	ListExports(ctx context.Context, uid int64, offset int, limit int) ([]domain.Export, error)
+	// StartImport 把 Markdown 压缩包里面的文章导入成草稿，由 Importer 在后台执行
+	// 压缩包有问题的话直接返回 ErrInvalidArchive
+	StartImport(ctx context.Context, uid int64, data []byte) (domain.Import, error)
+	// GetImport 带着每个文件的导入结果
+	GetImport(ctx context.Context, uid int64, id int64) (domain.Import, error)
+	ListImports(ctx context.Context, uid int64, offset int, limit int) ([]domain.Import, error)
+
+	// FindSimilar 和已发表的文章 id 内容几乎一样的文章，按照相似程度排序
+	// 文章没有发表过的时候返回 ErrFingerprintNotFound
+	FindSimilar(ctx context.Context, id int64, limit int) ([]domain.SimilarArticle, error)
+	// ListFlaggedDuplicates 发表的时候被标记为疑似重复的审核日志，按照时间倒序
+	ListFlaggedDuplicates(ctx context.Context, offset int, limit int) ([]domain.ModerationLog, error)
 }
 
 type articleService struct {
-	repo repository.ArticleRepository
+	repo    repository.ArticleRepository
+	revRepo repository.ArticleRevisionRepository
+	tagRepo repository.ArticleTagRepository
+	// seriesRepo 系列
+	seriesRepo repository.SeriesRepository
+	attachRepo repository.AttachmentRepository
+	modRepo    repository.ModerationRepository
+	exportRepo repository.ExportRepository
+	importRepo repository.ImportRepository
+	// fpRepo 已发表文章的 SimHash 指纹
+	fpRepo     repository.FingerprintRepository
+	moderation Moderation
+	// filter 敏感词，为 nil 的时候不检查
+	filter *sensitive.Filter
 
 	// V1
 	author   repository.ArticleAuthorRepository
@@ -46,42 +163,158 @@ type readInfo struct {
 func (svc *articleService) GetPublishedById(ctx context.Context, id int64, uid int64) (domain.Article, error) {
 	art, err := svc.repo.GetPublishedById(ctx, id)
 	if err == nil {
-		go func() {
-			// 生产者也可以通过改批量来提高性能
-			err := svc.producer.ProduceReadEvent(
-				ctx,
-				events.ReadEvent{
-					// 即便你的消费者要用 art 的数据
-					// 让它去查, 你不要在 event 里面带
-					Uid: uid,
-					Aid: art.Id,
-				},
-			)
-			if err != nil {
-				svc.l.Error("failed to send read event")
-			}
-		}()
+		art.Tags, err = svc.tagRepo.GetTags(ctx, art.Id)
+	}
+	if err == nil {
+		svc.produceReadEvent(ctx, art.Id, uid)
+	}
+	return art, err
+}
 
-		go func() {
-			svc.ch <- readInfo{
-				aid: art.Id,
-				uid: uid,
-			}
-		}()
+func (svc *articleService) LookupPublished(ctx context.Context, id int64) (domain.Article, error) {
+	art, err := svc.repo.GetPublishedById(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
 	}
+	art.Tags, err = svc.tagRepo.GetTags(ctx, art.Id)
 	return art, err
 }
 
+// produceReadEvent 批量模式下交给后台的 goroutine 攒批，否则直接发送
+func (svc *articleService) produceReadEvent(ctx context.Context, aid int64, uid int64) {
+	if svc.ch != nil {
+		select {
+		case svc.ch <- readInfo{aid: aid, uid: uid}:
+		case <-ctx.Done():
+			svc.l.Error("阅读事件攒批超时", logger.Int64("art_id", aid))
+		}
+		return
+	}
+	// 阅读事件先写 outbox，Kafka 不可用的时候也不会丢
+	// 不要开 goroutine，请求结束之后 ctx 就被取消了
+	err := svc.producer.ProduceReadEvent(
+		ctx,
+		events.ReadEvent{
+			// 即便你的消费者要用 art 的数据
+			// 让它去查, 你不要在 event 里面带
+			Uid: uid,
+			Aid: aid,
+		},
+	)
+	if err != nil {
+		svc.l.Error("failed to send read event",
+			logger.Int64("art_id", aid),
+			logger.Error(err))
+	}
+}
+
 func (svc *articleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
-	return svc.repo.GetByID(ctx, id)
+	art, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	art.Tags, err = svc.tagRepo.GetTags(ctx, id)
+	return art, err
 }
 
 func (svc *articleService) List(ctx context.Context, id int64, offset int, limit int) ([]domain.Article, error) {
-	return svc.repo.List(ctx, id, offset, limit)
+	arts, err := svc.repo.List(ctx, id, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return arts, svc.fillTags(ctx, arts)
+}
+
+func (svc *articleService) ListByCursor(ctx context.Context, uid int64, cursor string, limit int) ([]domain.Article, string, error) {
+	c, err := domain.ParseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	arts, err := svc.repo.ListByCursor(ctx, uid, c, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return arts, svc.nextCursor(arts, limit), svc.fillTags(ctx, arts)
+}
+
+func (svc *articleService) ListPubByCursor(ctx context.Context, start time.Time, cursor string, limit int) ([]domain.Article, string, error) {
+	c, err := domain.ParseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if c.IsZero() {
+		// 第一页，id 为 0 相当于 utime < start
+		c = domain.Cursor{Utime: start}
+	}
+	arts, err := svc.repo.ListPubByCursor(ctx, c, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return arts, svc.nextCursor(arts, limit), nil
+}
+
+// nextCursor 不满一页说明已经到底了
+func (svc *articleService) nextCursor(arts []domain.Article, limit int) string {
+	if len(arts) == 0 || len(arts) < limit {
+		return ""
+	}
+	return domain.NewCursor(arts[len(arts)-1]).Encode()
+}
+
+func (svc *articleService) ListPubByTag(ctx context.Context, tag string, start time.Time, offset, limit int) ([]domain.Article, error) {
+	arts, err := svc.repo.ListPubByTag(ctx, tag, start, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return arts, svc.fillTags(ctx, arts)
+}
+
+func (svc *articleService) ListPubByAuthor(ctx context.Context, author int64, start time.Time, offset, limit int) ([]domain.Article, error) {
+	arts, err := svc.repo.ListPubByAuthor(ctx, author, start, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return arts, svc.fillTags(ctx, arts)
+}
+
+// fillTags 批量查询标签，避免 N+1
+func (svc *articleService) fillTags(ctx context.Context, arts []domain.Article) error {
+	if len(arts) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	tags, err := svc.tagRepo.BatchGetTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range arts {
+		arts[i].Tags = tags[arts[i].Id]
+	}
+	return nil
+}
+
+// normalizeTags 在保存文章之前校验标签，避免文章保存了但是标签不合法
+// art.Tags 为 nil 的时候不修改标签，否则标签和文章在同一个事务里面整体覆盖
+func (svc *articleService) normalizeTags(art *domain.Article) error {
+	if art.Tags == nil {
+		return nil
+	}
+	art.Tags = domain.NormalizeTags(art.Tags)
+	if len(art.Tags) > domain.MaxTagsPerArticle {
+		return ErrTooManyTags
+	}
+	return nil
 }
 
 func (svc *articleService) WithDraw(ctx context.Context, art domain.Article) error {
-	return svc.repo.SyncStatus(ctx, art.Id, art.Author.Id, domain.ArticleStatusPrivate)
+	err := svc.repo.SyncStatus(ctx, art.Id, art.Author.Id, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
 func (svc *articleService) Publish(ctx context.Context, art domain.Article) (int64, error) {
@@ -89,8 +322,25 @@ func (svc *articleService) Publish(ctx context.Context, art domain.Article) (int
 	//id, err := svc.repo.Save(ctx, art)
 	// 线上库呢?
 	//panic("implement me")
+	if err := svc.normalizeTags(&art); err != nil {
+		return 0, err
+	}
+	verdict, err := svc.screen(&art)
+	if err != nil {
+		return 0, err
+	}
+	if svc.moderation.Enabled || verdict.Decision != domain.ModerationDecisionPass {
+		return svc.submitReview(ctx, art, verdict)
+	}
 	art.Status = domain.ArticleStatusPublished
-	return svc.repo.Sync(ctx, art)
+	art.RevisionKind = domain.RevisionKindPublish
+	id, err := svc.repo.Sync(ctx, art)
+	if err != nil {
+		return id, err
+	}
+	art.Id = id
+	svc.detectDuplicate(ctx, art)
+	return id, nil
 }
 
 func (svc *articleService) PublishV1(ctx context.Context, art domain.Article) (int64, error) {
@@ -132,47 +382,93 @@ func (svc *articleService) PublishV1(ctx context.Context, art domain.Article) (i
 	return id, err
 }
 
-func NewArticleService(repo repository.ArticleRepository, producer events.Producer, l logger.LoggerV1) ArticleService {
+func NewArticleService(repo repository.ArticleRepository, revRepo repository.ArticleRevisionRepository,
+	tagRepo repository.ArticleTagRepository, seriesRepo repository.SeriesRepository,
+	attachRepo repository.AttachmentRepository, modRepo repository.ModerationRepository,
+	exportRepo repository.ExportRepository, importRepo repository.ImportRepository,
+	fpRepo repository.FingerprintRepository, moderation Moderation, filter *sensitive.Filter, producer events.Producer, l logger.LoggerV1) ArticleService {
 	return &articleService{
-		repo:     repo,
-		producer: producer,
-		l:        l,
+		repo:       repo,
+		revRepo:    revRepo,
+		tagRepo:    tagRepo,
+		seriesRepo: seriesRepo,
+		attachRepo: attachRepo,
+		modRepo:    modRepo,
+		exportRepo: exportRepo,
+		importRepo: importRepo,
+		fpRepo:     fpRepo,
+		moderation: moderation,
+		filter:     filter,
+		producer:   producer,
+		l:          l,
 	}
 }
 
-func NewArticleServiceV2(repo repository.ArticleRepository, producer events.Producer, l logger.LoggerV1) ArticleService {
-	ch := make(chan readInfo, 10)
-	go func() {
-		for {
-			uids := make([]int64, 0, 10)
-			aids := make([]int64, 0, 10)
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-			for i := 0; i < 10; i++ {
-				select {
-				case info, ok := <-ch:
-					if !ok {
-						cancel()
-						return
-					}
-					uids = append(uids, info.uid)
-					aids = append(aids, info.aid)
-				case <-ctx.Done():
-					break
+func NewArticleServiceV2(repo repository.ArticleRepository, revRepo repository.ArticleRevisionRepository,
+	tagRepo repository.ArticleTagRepository, seriesRepo repository.SeriesRepository,
+	attachRepo repository.AttachmentRepository, modRepo repository.ModerationRepository,
+	exportRepo repository.ExportRepository, importRepo repository.ImportRepository,
+	fpRepo repository.FingerprintRepository, moderation Moderation, filter *sensitive.Filter, producer events.Producer, l logger.LoggerV1) ArticleService {
+	ch := make(chan readInfo, readBatchSize)
+	go batchReadEvents(ch, producer, l)
+	return &articleService{
+		repo:       repo,
+		revRepo:    revRepo,
+		tagRepo:    tagRepo,
+		seriesRepo: seriesRepo,
+		attachRepo: attachRepo,
+		modRepo:    modRepo,
+		exportRepo: exportRepo,
+		importRepo: importRepo,
+		fpRepo:     fpRepo,
+		moderation: moderation,
+		filter:     filter,
+		producer:   producer,
+		l:          l,
+		ch:         ch,
+	}
+}
+
+const readBatchSize = 10
+
+// batchReadEvents 攒够 readBatchSize 个或者等了一秒就发一次 ReadEventV1
+func batchReadEvents(ch chan readInfo, producer events.Producer, l logger.LoggerV1) {
+	for {
+		uids := make([]int64, 0, readBatchSize)
+		aids := make([]int64, 0, readBatchSize)
+		timer := time.NewTimer(time.Second)
+		closed := false
+	loop:
+		for len(aids) < readBatchSize {
+			select {
+			case info, ok := <-ch:
+				if !ok {
+					closed = true
+					break loop
 				}
+				uids = append(uids, info.uid)
+				aids = append(aids, info.aid)
+			case <-timer.C:
+				break loop
 			}
-			cancel()
-			ctx, cancel = context.WithTimeout(context.Background(), time.Second)
-			_ = producer.ProduceReadEventV1(ctx, events.ReadEventV1{
+		}
+		timer.Stop()
+		if len(aids) > 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			err := producer.ProduceReadEventV1(ctx, events.ReadEventV1{
 				Uid: uids,
 				Aid: aids,
 			})
+			cancel()
+			if err != nil {
+				l.Error("发送批量阅读事件失败",
+					logger.Int64("size", int64(len(aids))),
+					logger.Error(err))
+			}
+		}
+		if closed {
+			return
 		}
-	}()
-	return &articleService{
-		repo:     repo,
-		producer: producer,
-		l:        l,
-		ch:       ch,
 	}
 }
 
@@ -185,11 +481,178 @@ func NewArticleServiceV1(author repository.ArticleAuthorRepository, reader repos
 }
 
 func (svc *articleService) Save(ctx context.Context, art domain.Article) (int64, error) {
+	if err := svc.normalizeTags(&art); err != nil {
+		return 0, err
+	}
+	if _, err := svc.screen(&art); err != nil {
+		return 0, err
+	}
 	art.Status = domain.ArticleStatusUnPublished
-	println(art.Id)
+	// 历史版本和草稿在同一个事务里面写，记不下来的话这次保存也算失败
+	art.RevisionKind = domain.RevisionKindSave
+	if art.Id > 0 {
+		return art.Id, svc.repo.Update(ctx, art)
+	}
+	return svc.repo.Create(ctx, art)
+}
+
+func (svc *articleService) ListRevisions(ctx context.Context, uid int64, artId int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	art, err := svc.repo.GetByID(ctx, artId)
+	if err != nil {
+		return nil, err
+	}
+	if art.Author.Id != uid {
+		return nil, ErrInvalidRevision
+	}
+	return svc.revRepo.List(ctx, artId, offset, limit)
+}
+
+func (svc *articleService) GetRevision(ctx context.Context, uid int64, id int64) (domain.ArticleRevision, error) {
+	rev, err := svc.revRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	if rev.AuthorId != uid {
+		return domain.ArticleRevision{}, ErrInvalidRevision
+	}
+	return rev, nil
+}
+
+func (svc *articleService) DiffRevisions(ctx context.Context, uid int64, from int64, to int64) (domain.RevisionDiff, error) {
+	fromRev, err := svc.GetRevision(ctx, uid, from)
+	if err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	toRev, err := svc.GetRevision(ctx, uid, to)
+	if err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	if fromRev.ArticleId != toRev.ArticleId {
+		return domain.RevisionDiff{}, ErrInvalidRevision
+	}
+	lines := diff.Lines(fromRev.Content, toRev.Content)
+	res := domain.RevisionDiff{
+		From:  fromRev,
+		To:    toRev,
+		Lines: make([]domain.DiffLine, 0, len(lines)),
+	}
+	for _, l := range lines {
+		res.Lines = append(res.Lines, domain.DiffLine{
+			Op:   domain.DiffOp(l.Op),
+			Text: l.Text,
+		})
+	}
+	return res, nil
+}
+
+func (svc *articleService) RestoreRevision(ctx context.Context, uid int64, id int64) (int64, error) {
+	rev, err := svc.GetRevision(ctx, uid, id)
+	if err != nil {
+		return 0, err
+	}
+	// 恢复只动制作库，恢复之后还是草稿，作者需要重新发表
+	art := domain.Article{
+		Id:      rev.ArticleId,
+		Title:   rev.Title,
+		Content: rev.Content,
+		Author: domain.Author{
+			Id: uid,
+		},
+		Status:       domain.ArticleStatusUnPublished,
+		RevisionKind: domain.RevisionKindRestore,
+	}
+	err = svc.repo.Update(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	return art.Id, nil
+}
+
+func (svc *articleService) SchedulePublish(ctx context.Context, art domain.Article) (int64, error) {
+	if !art.PublishAt.After(time.Now()) {
+		return 0, ErrInvalidPublishAt
+	}
+	if err := svc.normalizeTags(&art); err != nil {
+		return 0, err
+	}
+	// 到时间发表的时候还会再检查一次，需要审核的那个时候再送审
+	if _, err := svc.screen(&art); err != nil {
+		return 0, err
+	}
+	art.Status = domain.ArticleStatusScheduled
+	art.RevisionKind = domain.RevisionKindSave
 	if art.Id > 0 {
-		err := svc.repo.Update(ctx, art)
-		return art.Id, err
+		return art.Id, svc.repo.Update(ctx, art)
 	}
 	return svc.repo.Create(ctx, art)
 }
+
+func (svc *articleService) CancelSchedule(ctx context.Context, uid int64, id int64) error {
+	art, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if art.Author.Id != uid || art.Status != domain.ArticleStatusScheduled {
+		return ErrNotScheduled
+	}
+	art.Status = domain.ArticleStatusUnPublished
+	art.PublishAt = time.Time{}
+	return svc.repo.Update(ctx, art)
+}
+
+func (svc *articleService) Delete(ctx context.Context, uid int64, id int64) error {
+	// 下游的通知和回收站状态在同一个事务里面写进 outbox
+	return svc.repo.Delete(ctx, id, uid)
+}
+
+func (svc *articleService) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := svc.repo.ListTrash(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return arts, svc.fillTags(ctx, arts)
+}
+
+// Restore 已经发表过的文章恢复之后下游要重新可见，事件和恢复在同一个事务里面写进 outbox
+func (svc *articleService) Restore(ctx context.Context, uid int64, id int64) error {
+	return svc.repo.Restore(ctx, id, uid)
+}
+
+func (svc *articleService) Purge(ctx context.Context, uid int64, id int64) error {
+	err := svc.repo.Purge(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	// 文章本身已经删掉了，剩下的清理失败也不影响读者，只记录日志
+	if err = svc.tagRepo.SetTags(ctx, id, uid, nil); err != nil {
+		svc.l.Error("清理文章标签失败", logger.Int64("art_id", id), logger.Error(err))
+	}
+	if err = svc.revRepo.DeleteByArticle(ctx, id); err != nil {
+		svc.l.Error("清理文章历史版本失败", logger.Int64("art_id", id), logger.Error(err))
+	}
+	if err = svc.fpRepo.Delete(ctx, id); err != nil {
+		svc.l.Error("清理文章指纹失败", logger.Int64("art_id", id), logger.Error(err))
+	}
+	if err = svc.seriesRepo.RemoveArticle(ctx, id); err != nil {
+		svc.l.Error("把文章移出系列失败", logger.Int64("art_id", id), logger.Error(err))
+	}
+	return nil
+}
+
+func (svc *articleService) PurgeExpired(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	arts, err := svc.repo.ListExpiredTrash(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		err = svc.Purge(ctx, art.Author.Id, art.Id)
+		if err != nil {
+			// 可能刚好被作者恢复了，或者别的实例已经删掉了
+			svc.l.Error("彻底删除文章失败", logger.Int64("art_id", art.Id), logger.Error(err))
+			continue
+		}
+		ids = append(ids, art.Id)
+	}
+	return ids, nil
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
 	"github.com/TengFeiyang01/webook/webook/article/repository"
+	repomocks "github.com/TengFeiyang01/webook/webook/article/repository/mocks"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
 )
 
@@ -27,7 +28,7 @@ func Test_articleService_Publish(t *testing.T) {
 			name: "新建发表成功",
 			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
 				repository.ArticleReaderRepository) {
-				author := svcmocks.NewMockArticleAuthorRepository(ctrl)
+				author := repomocks.NewMockArticleAuthorRepository(ctrl)
 				author.EXPECT().Create(gomock.Any(), domain.Article{
 					Title:   "my title",
 					Content: "my content",
@@ -36,7 +37,7 @@ func Test_articleService_Publish(t *testing.T) {
 					},
 					Status: domain.ArticleStatusPublished,
 				}).Return(int64(1), nil)
-				reader := svcmocks.NewMockArticleReaderRepository(ctrl)
+				reader := repomocks.NewMockArticleReaderRepository(ctrl)
 				reader.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      1,
 					Title:   "my title",
@@ -64,7 +65,7 @@ func Test_articleService_Publish(t *testing.T) {
 			name: "修改并发表成功",
 			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
 				repository.ArticleReaderRepository) {
-				author := svcmocks.NewMockArticleAuthorRepository(ctrl)
+				author := repomocks.NewMockArticleAuthorRepository(ctrl)
 				author.EXPECT().Update(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "my title",
@@ -72,8 +73,9 @@ func Test_articleService_Publish(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
+					Status: domain.ArticleStatusPublished,
 				}).Return(nil)
-				reader := svcmocks.NewMockArticleReaderRepository(ctrl)
+				reader := repomocks.NewMockArticleReaderRepository(ctrl)
 				reader.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "my title",
@@ -81,6 +83,7 @@ func Test_articleService_Publish(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
+					Status: domain.ArticleStatusPublished,
 				}).Return(int64(2), nil)
 				return author, reader
 			},
@@ -99,7 +102,7 @@ func Test_articleService_Publish(t *testing.T) {
 			name: "保存到制作库失败",
 			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
 				repository.ArticleReaderRepository) {
-				author := svcmocks.NewMockArticleAuthorRepository(ctrl)
+				author := repomocks.NewMockArticleAuthorRepository(ctrl)
 				author.EXPECT().Create(gomock.Any(), domain.Article{
 					Title:   "my title",
 					Content: "my content",
@@ -108,7 +111,7 @@ func Test_articleService_Publish(t *testing.T) {
 					},
 					Status: domain.ArticleStatusPublished,
 				}).Return(int64(0), errors.New("mock error"))
-				reader := svcmocks.NewMockArticleReaderRepository(ctrl)
+				reader := repomocks.NewMockArticleReaderRepository(ctrl)
 				return author, reader
 			},
 
@@ -127,7 +130,7 @@ func Test_articleService_Publish(t *testing.T) {
 			name: "保存到制作库成功，重试到线上库成功",
 			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
 				repository.ArticleReaderRepository) {
-				author := svcmocks.NewMockArticleAuthorRepository(ctrl)
+				author := repomocks.NewMockArticleAuthorRepository(ctrl)
 				author.EXPECT().Update(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "my title",
@@ -135,8 +138,9 @@ func Test_articleService_Publish(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
+					Status: domain.ArticleStatusPublished,
 				}).Return(nil)
-				reader := svcmocks.NewMockArticleReaderRepository(ctrl)
+				reader := repomocks.NewMockArticleReaderRepository(ctrl)
 				reader.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "my title",
@@ -173,7 +177,7 @@ func Test_articleService_Publish(t *testing.T) {
 			name: "保存到制作库成功，重试全部失败",
 			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository,
 				repository.ArticleReaderRepository) {
-				author := svcmocks.NewMockArticleAuthorRepository(ctrl)
+				author := repomocks.NewMockArticleAuthorRepository(ctrl)
 				author.EXPECT().Create(gomock.Any(), domain.Article{
 					Title:   "my title",
 					Content: "my content",
@@ -182,7 +186,7 @@ func Test_articleService_Publish(t *testing.T) {
 					},
 					Status: domain.ArticleStatusPublished,
 				}).Return(int64(1), nil)
-				reader := svcmocks.NewMockArticleReaderRepository(ctrl)
+				reader := repomocks.NewMockArticleReaderRepository(ctrl)
 				reader.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      1,
 					Title:   "my title",
@@ -220,3 +224,44 @@ func Test_articleService_Publish(t *testing.T) {
 		})
 	}
 }
+
+func Test_articleService_normalizeTags(t *testing.T) {
+	testCases := []struct {
+		name string
+		tags []string
+
+		wantTags []string
+		wantErr  error
+	}{
+		{
+			name: "没传标签，不修改",
+		},
+		{
+			name:     "传了空的标签，清空",
+			tags:     []string{},
+			wantTags: []string{},
+		},
+		{
+			name:     "去掉空白和重复的标签",
+			tags:     []string{" Go ", "", "Go", "MySQL"},
+			wantTags: []string{"Go", "MySQL"},
+		},
+		{
+			name:    "标签太多",
+			tags:    []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"},
+			wantErr: ErrTooManyTags,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &articleService{}
+			art := domain.Article{Tags: tc.tags}
+			err := svc.normalizeTags(&art)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantTags, art.Tags)
+		})
+	}
+}
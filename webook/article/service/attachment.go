@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAttachmentTypeNotAllowed 类型不在 domain.AttachmentTypes 里面
+	ErrAttachmentTypeNotAllowed = errors.New("不允许上传这种类型的附件")
+	// ErrAttachmentTooLarge 超过了 domain.MaxAttachmentSize
+	ErrAttachmentTooLarge = errors.New("附件太大")
+	// ErrAttachmentQuotaExceeded 超过了 domain.AttachmentQuota
+	ErrAttachmentQuotaExceeded = errors.New("附件空间不足")
+	// ErrAttachmentNotFound 附件不存在，或者不属于该作者
+	ErrAttachmentNotFound = repository.ErrAttachmentNotFound
+	// ErrAttachmentNotUploaded 还没有上传，或者上传的内容和申请的时候不一致
+	ErrAttachmentNotUploaded = errors.New("附件没有上传成功")
+)
+
+// uploadExpiration 上传 URL 的有效期
+const uploadExpiration = time.Minute * 15
+
+func (svc *articleService) CreateUpload(ctx context.Context, a domain.Attachment) (domain.Attachment, string, error) {
+	ext, ok := domain.AttachmentTypes[a.ContentType]
+	if !ok {
+		return domain.Attachment{}, "", ErrAttachmentTypeNotAllowed
+	}
+	if a.Size <= 0 || a.Size > domain.MaxAttachmentSize {
+		return domain.Attachment{}, "", ErrAttachmentTooLarge
+	}
+	// 并发申请的时候可能会稍微超出一点，可以接受
+	used, err := svc.attachRepo.UsedBytes(ctx, a.Author.Id)
+	if err != nil {
+		return domain.Attachment{}, "", err
+	}
+	if used+a.Size > domain.AttachmentQuota {
+		return domain.Attachment{}, "", ErrAttachmentQuotaExceeded
+	}
+	// 不用用户的文件名，避免各种奇怪的字符
+	a.Key = fmt.Sprintf("attachments/%d/%s%s", a.Author.Id, uuid.New().String(), ext)
+	a.Status = domain.AttachmentStatusPending
+	a, err = svc.attachRepo.Create(ctx, a)
+	if err != nil {
+		return domain.Attachment{}, "", err
+	}
+	url, err := svc.attachRepo.PresignUpload(ctx, a, uploadExpiration)
+	return a, url, err
+}
+
+func (svc *articleService) CompleteUpload(ctx context.Context, uid int64, id int64) (domain.Attachment, error) {
+	a, err := svc.attachRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if a.Author.Id != uid {
+		return domain.Attachment{}, ErrAttachmentNotFound
+	}
+	if a.Status == domain.AttachmentStatusUploaded {
+		return a, nil
+	}
+	info, err := svc.attachRepo.Stat(ctx, a.Key)
+	if errors.Is(err, repository.ErrAttachmentObjectNotFound) {
+		return domain.Attachment{}, ErrAttachmentNotUploaded
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	// 预签名已经限制了大小和类型，这里再检查一遍，本地存储是按照内容推断类型的
+	if info.Size != a.Size || info.ContentType != a.ContentType {
+		svc.l.Warn("上传的附件和申请的不一致",
+			logger.Int64("id", a.Id),
+			logger.Int64("size", info.Size),
+			logger.String("content_type", info.ContentType))
+		return domain.Attachment{}, ErrAttachmentNotUploaded
+	}
+	err = svc.attachRepo.MarkUploaded(ctx, a.Id, info.Size, info.ContentType)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	a.Status = domain.AttachmentStatusUploaded
+	return a, nil
+}
+
+func (svc *articleService) ListAttachments(ctx context.Context, uid int64, offset int, limit int) ([]domain.Attachment, error) {
+	return svc.attachRepo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+func (svc *articleService) DeleteAttachment(ctx context.Context, uid int64, id int64) error {
+	a, err := svc.attachRepo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if a.Author.Id != uid {
+		return ErrAttachmentNotFound
+	}
+	return svc.attachRepo.Delete(ctx, a)
+}
+
+func (svc *articleService) GCAttachments(ctx context.Context, before time.Time, startId int64, limit int) (int64, int, error) {
+	atts, err := svc.attachRepo.ListBefore(ctx, before, startId, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+	deleted := 0
+	for _, a := range atts {
+		// 一直没有确认上传的也清理掉
+		if a.Status == domain.AttachmentStatusUploaded {
+			ok, err := svc.attachRepo.Referenced(ctx, a)
+			if err != nil {
+				return 0, deleted, err
+			}
+			if ok {
+				continue
+			}
+		}
+		if err = svc.attachRepo.Delete(ctx, a); err != nil {
+			// 下一轮再删
+			svc.l.Error("清理附件失败", logger.Int64("id", a.Id), logger.Error(err))
+			continue
+		}
+		deleted++
+	}
+	if len(atts) < limit {
+		return 0, deleted, nil
+	}
+	return atts[len(atts)-1].Id, deleted, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/simhash"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+// ErrFingerprintNotFound 文章没有发表过，没有指纹
+var ErrFingerprintNotFound = repository.ErrFingerprintNotFound
+
+// maxFlaggedOriginals 审核日志里面最多列出几篇原文
+const maxFlaggedOriginals = 3
+
+// detectDuplicate 文章上线之后计算指纹，和比它早发表的文章几乎一样的话记录一条审核日志
+// 文章已经上线了，这里的失败都只记录日志
+func (svc *articleService) detectDuplicate(ctx context.Context, art domain.Article) {
+	fp := domain.Fingerprint{
+		ArticleId: art.Id,
+		AuthorId:  art.Author.Id,
+		Simhash:   simhash.Fingerprint(art.Title + "\n" + art.Content),
+	}
+	if fp.Simhash == 0 {
+		// 没有正文，和谁比都没有意义
+		return
+	}
+	if err := svc.fpRepo.Save(ctx, fp); err != nil {
+		svc.l.Error("保存文章指纹失败", logger.Int64("art_id", art.Id), logger.Error(err))
+		return
+	}
+	if svc.moderation.DuplicateDistance <= 0 {
+		return
+	}
+	// 重新发表的时候 ctime 是第一次发表的时间
+	fp, err := svc.fpRepo.Get(ctx, art.Id)
+	if err != nil {
+		svc.l.Error("查询文章指纹失败", logger.Int64("art_id", art.Id), logger.Error(err))
+		return
+	}
+	similar, err := svc.findPublishedSimilar(ctx, fp, svc.moderation.DuplicateDistance, maxFlaggedOriginals*2)
+	if err != nil {
+		svc.l.Error("查询相似文章失败", logger.Int64("art_id", art.Id), logger.Error(err))
+		return
+	}
+	reasons := make([]string, 0, maxFlaggedOriginals)
+	for _, s := range similar {
+		// 比它晚发表的文章抄的是它，那篇发表的时候已经标记过了
+		if !s.Ctime.Before(fp.Ctime) {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("和文章 %d（作者 %d）相似，距离 %d", s.Id, s.AuthorId, s.Distance))
+		if len(reasons) == maxFlaggedOriginals {
+			break
+		}
+	}
+	if len(reasons) == 0 {
+		return
+	}
+	svc.recordModeration(ctx, domain.ModerationLog{
+		ArticleId: art.Id,
+		AuthorId:  art.Author.Id,
+		Action:    domain.ModerationActionFlagDuplicate,
+		Status:    domain.ArticleStatusPublished,
+		Reason:    strings.Join(reasons, "; "),
+	})
+}
+
+func (svc *articleService) FindSimilar(ctx context.Context, id int64, limit int) ([]domain.SimilarArticle, error) {
+	fp, err := svc.fpRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	distance := svc.moderation.DuplicateDistance
+	if distance <= 0 {
+		distance = repository.MaxSimilarDistance
+	}
+	return svc.findPublishedSimilar(ctx, fp, distance, limit)
+}
+
+// findPublishedSimilar 撤回和进了回收站的文章还留着指纹，重新发表的时候要用第一次发表的时间，
+// 所以这里过滤掉不在线上的文章，而不是撤回的时候删掉指纹
+func (svc *articleService) findPublishedSimilar(ctx context.Context, fp domain.Fingerprint,
+	maxDistance int, limit int) ([]domain.SimilarArticle, error) {
+	// 先不截断，过滤之后还能凑够 limit
+	similar, err := svc.fpRepo.FindSimilar(ctx, fp, maxDistance, repository.MaxCandidates)
+	if err != nil || len(similar) == 0 {
+		return similar, err
+	}
+	arts, err := svc.repo.GetPubTitles(ctx, slice.Map(similar, func(idx int, src domain.SimilarArticle) int64 {
+		return src.Id
+	}))
+	if err != nil {
+		return nil, err
+	}
+	published := make(map[int64]struct{}, len(arts))
+	for _, art := range arts {
+		published[art.Id] = struct{}{}
+	}
+	res := make([]domain.SimilarArticle, 0, min(len(similar), limit))
+	for _, s := range similar {
+		if _, ok := published[s.Id]; !ok {
+			continue
+		}
+		res = append(res, s)
+		if len(res) == limit {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (svc *articleService) ListFlaggedDuplicates(ctx context.Context, offset int, limit int) ([]domain.ModerationLog, error) {
+	return svc.modRepo.ListLogsByAction(ctx, domain.ModerationActionFlagDuplicate, offset, limit)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	repomocks "github.com/TengFeiyang01/webook/webook/article/repository/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_articleService_FindSimilar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	fp := domain.Fingerprint{ArticleId: 1, AuthorId: 123, Simhash: 0xff, Ctime: time.UnixMilli(100)}
+	fpRepo := repomocks.NewMockFingerprintRepository(ctrl)
+	fpRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(fp, nil)
+	fpRepo.EXPECT().FindSimilar(gomock.Any(), fp, 2, repository.MaxCandidates).
+		Return([]domain.SimilarArticle{
+			{Id: 2, AuthorId: 456, Distance: 0},
+			{Id: 3, AuthorId: 456, Distance: 1},
+			{Id: 4, AuthorId: 789, Distance: 1},
+			{Id: 5, AuthorId: 789, Distance: 2},
+		}, nil)
+	repo := repomocks.NewMockArticleRepository(ctrl)
+	// 2 撤回了，3 进了回收站，指纹都还在
+	repo.EXPECT().GetPubTitles(gomock.Any(), []int64{2, 3, 4, 5}).
+		Return([]domain.Article{{Id: 5}, {Id: 4}}, nil)
+	svc := &articleService{
+		repo:       repo,
+		fpRepo:     fpRepo,
+		moderation: Moderation{DuplicateDistance: 2},
+		l:          &logger.NopLogger{},
+	}
+
+	res, err := svc.FindSimilar(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SimilarArticle{{Id: 4, AuthorId: 789, Distance: 1}}, res)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/gormx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/mdarchive"
+)
+
+var (
+	// ErrExportNotFound 导出任务不存在，或者不属于该作者
+	ErrExportNotFound = repository.ErrExportNotFound
+	// ErrExportInProgress 上一次导出还没有完成
+	ErrExportInProgress = repository.ErrExportInProgress
+)
+
+// downloadExpiration 下载地址的有效期，过期了重新获取一次就可以
+const downloadExpiration = time.Hour
+
+func (svc *articleService) StartExport(ctx context.Context, uid int64) (domain.Export, error) {
+	e := domain.Export{
+		Author: domain.Author{Id: uid},
+		Status: domain.ExportStatusPending,
+	}
+	id, err := svc.exportRepo.Create(ctx, e)
+	if err != nil {
+		return domain.Export{}, err
+	}
+	// 刚刚插入的，从库可能还没有
+	return svc.exportRepo.GetById(gormx.WithPrimary(ctx), id)
+}
+
+func (svc *articleService) GetExport(ctx context.Context, uid int64, id int64) (domain.Export, error) {
+	e, err := svc.exportRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.Export{}, err
+	}
+	if e.Author.Id != uid {
+		return domain.Export{}, ErrExportNotFound
+	}
+	if e.Status == domain.ExportStatusDone {
+		e.URL, err = svc.exportRepo.PresignDownload(ctx, e, downloadExpiration)
+	}
+	return e, err
+}
+
+// ListExports 列表不带下载地址，要下载的时候再调用 GetExport
+func (svc *articleService) ListExports(ctx context.Context, uid int64, offset int, limit int) ([]domain.Export, error) {
+	return svc.exportRepo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+// Exporter 在后台执行导出任务，靠抢占保证一个任务同时只有一个实例在执行
+// 压缩包是在内存里面生成的，一个作者的文章一般不会太多
+type Exporter struct {
+	repo       repository.ArticleRepository
+	tagRepo    repository.ArticleTagRepository
+	seriesRepo repository.SeriesRepository
+	exportRepo repository.ExportRepository
+	l          logger.LoggerV1
+
+	// interval 没有任务的时候隔多久再查一次
+	interval time.Duration
+	// timeout 执行中的任务超过这么久没有更新进度，就认为执行它的实例挂了
+	timeout   time.Duration
+	batchSize int
+}
+
+func NewExporter(repo repository.ArticleRepository, tagRepo repository.ArticleTagRepository,
+	seriesRepo repository.SeriesRepository, exportRepo repository.ExportRepository, l logger.LoggerV1) *Exporter {
+	return &Exporter{
+		repo:       repo,
+		tagRepo:    tagRepo,
+		seriesRepo: seriesRepo,
+		exportRepo: exportRepo,
+		l:          l,
+		interval:   time.Second * 5,
+		timeout:    time.Minute * 5,
+		batchSize:  50,
+	}
+}
+
+// Start 一直运行到 ctx 被取消
+func (e *Exporter) Start(ctx context.Context) {
+	for {
+		task, err := e.exportRepo.Claim(ctx, time.Now().Add(-e.timeout))
+		if err == nil {
+			e.run(ctx, task)
+			continue
+		}
+		if !errors.Is(err, repository.ErrNoExportTask) {
+			e.l.Error("抢占导出任务失败", logger.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// maxErrorLength 和数据库里面的字段长度一致
+const maxErrorLength = 1024
+
+func (e *Exporter) run(ctx context.Context, task domain.Export) {
+	data, err := e.export(ctx, &task)
+	if err == nil {
+		task.Key = fmt.Sprintf("exports/%d/%d.zip", task.Author.Id, task.Id)
+		task.Size = int64(len(data))
+		err = e.exportRepo.Complete(ctx, task, data)
+	}
+	switch {
+	case err == nil:
+		e.l.Info("导出完成",
+			logger.Int64("id", task.Id),
+			logger.Int64("author", task.Author.Id),
+			logger.Int64("size", task.Size))
+		return
+	case errors.Is(err, repository.ErrExportPreempted):
+		// 别的实例会重新执行，什么都不用做
+		e.l.Warn("导出任务被别的实例接手了", logger.Int64("id", task.Id))
+		return
+	}
+	e.l.Error("导出失败", logger.Int64("id", task.Id), logger.Error(err))
+	task.Error = err.Error()
+	if len(task.Error) > maxErrorLength {
+		task.Error = strings.ToValidUTF8(task.Error[:maxErrorLength], "")
+	}
+	if err = e.exportRepo.Fail(ctx, task); err != nil {
+		// 超时之后会被重新执行
+		e.l.Error("标记导出失败失败", logger.Int64("id", task.Id), logger.Error(err))
+	}
+}
+
+// export 按照 (utime, id) 倒序翻页，每一页更新一次进度
+func (e *Exporter) export(ctx context.Context, task *domain.Export) ([]byte, error) {
+	uid := task.Author.Id
+	total, err := e.exportRepo.CountArticles(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	task.Total, task.Exported = total, 0
+	if err = e.exportRepo.UpdateProgress(ctx, *task); err != nil {
+		return nil, err
+	}
+	series, err := e.seriesTitles(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := mdarchive.NewWriter(&buf)
+	var cursor domain.Cursor
+	for {
+		arts, err := e.repo.ListByCursor(ctx, uid, cursor, e.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int64, 0, len(arts))
+		for _, art := range arts {
+			ids = append(ids, art.Id)
+		}
+		tags, err := e.tagRepo.BatchGetTags(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range arts {
+			err = w.Add(mdarchive.Document{
+				Meta: mdarchive.Meta{
+					Id:        art.Id,
+					Title:     art.Title,
+					Status:    strings.ToLower(art.Status.String()),
+					Tags:      tags[art.Id],
+					Series:    series[art.Id],
+					Ctime:     art.Ctime,
+					Utime:     art.Utime,
+					PublishAt: art.PublishAt,
+				},
+				Content: art.Content,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		// 导出的过程中作者可能又写了新的文章
+		task.Exported += len(arts)
+		task.Total = max(task.Total, task.Exported)
+		if err = e.exportRepo.UpdateProgress(ctx, *task); err != nil {
+			return nil, err
+		}
+		if len(arts) < e.batchSize {
+			break
+		}
+		cursor = domain.NewCursor(arts[len(arts)-1])
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// seriesTitles key 是文章 ID，value 是所在系列的标题
+func (e *Exporter) seriesTitles(ctx context.Context, uid int64) (map[int64]string, error) {
+	const limit = 100
+	res := make(map[int64]string)
+	for offset := 0; ; offset += limit {
+		list, err := e.seriesRepo.ListByAuthor(ctx, uid, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range list {
+			s, err = e.seriesRepo.GetById(ctx, s.Id)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range s.ArticleIds {
+				res[id] = s.Title
+			}
+		}
+		if len(list) < limit {
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/gormx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/mdarchive"
+)
+
+var (
+	// ErrInvalidArchive 压缩包太大、格式不对，或者文件太多、太大
+	ErrInvalidArchive = errors.New("压缩包非法")
+	// ErrImportNotFound 导入任务不存在，或者不属于该作者
+	ErrImportNotFound = repository.ErrImportNotFound
+	// ErrImportInProgress 上一次导入还没有完成
+	ErrImportInProgress = repository.ErrImportInProgress
+)
+
+var importLimits = mdarchive.Limits{
+	MaxFiles:    domain.MaxImportFiles,
+	MaxFileSize: domain.MaxImportFileSize,
+}
+
+// StartImport 先检查一遍压缩包，有问题直接返回，不用等后台任务
+func (svc *articleService) StartImport(ctx context.Context, uid int64, data []byte) (domain.Import, error) {
+	if len(data) > domain.MaxImportSize {
+		return domain.Import{}, ErrInvalidArchive
+	}
+	_, err := mdarchive.Read(bytes.NewReader(data), int64(len(data)), importLimits)
+	if err != nil {
+		return domain.Import{}, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	id, err := svc.importRepo.Create(ctx, domain.Import{Author: domain.Author{Id: uid}}, data)
+	if err != nil {
+		return domain.Import{}, err
+	}
+	// 刚刚插入的，从库可能还没有
+	return svc.importRepo.GetById(gormx.WithPrimary(ctx), id)
+}
+
+func (svc *articleService) GetImport(ctx context.Context, uid int64, id int64) (domain.Import, error) {
+	i, err := svc.importRepo.GetById(ctx, id)
+	if err != nil {
+		return domain.Import{}, err
+	}
+	if i.Author.Id != uid {
+		return domain.Import{}, ErrImportNotFound
+	}
+	return i, nil
+}
+
+func (svc *articleService) ListImports(ctx context.Context, uid int64, offset int, limit int) ([]domain.Import, error) {
+	return svc.importRepo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+// Importer 在后台执行导入任务，和 Exporter 一样靠抢占保证一个任务同时只有一个实例在执行
+// 每一篇都通过 Save 保存成新的草稿，front-matter 里面只有标题和标签会被用到
+type Importer struct {
+	svc        ArticleService
+	importRepo repository.ImportRepository
+	l          logger.LoggerV1
+
+	// interval 没有任务的时候隔多久再查一次
+	interval time.Duration
+	// timeout 执行中的任务超过这么久没有更新进度，就认为执行它的实例挂了
+	timeout time.Duration
+	// batchSize 每导入这么多篇更新一次进度
+	batchSize int
+}
+
+func NewImporter(svc ArticleService, importRepo repository.ImportRepository, l logger.LoggerV1) *Importer {
+	return &Importer{
+		svc:        svc,
+		importRepo: importRepo,
+		l:          l,
+		interval:   time.Second * 5,
+		timeout:    time.Minute * 5,
+		batchSize:  10,
+	}
+}
+
+// Start 一直运行到 ctx 被取消
+func (i *Importer) Start(ctx context.Context) {
+	for {
+		task, err := i.importRepo.Claim(ctx, time.Now().Add(-i.timeout))
+		if err == nil {
+			i.run(ctx, task)
+			continue
+		}
+		if !errors.Is(err, repository.ErrNoImportTask) {
+			i.l.Error("抢占导入任务失败", logger.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(i.interval):
+		}
+	}
+}
+
+func (i *Importer) run(ctx context.Context, task domain.Import) {
+	err := i.importFiles(ctx, &task)
+	if err == nil {
+		err = i.importRepo.Complete(ctx, task)
+	}
+	switch {
+	case err == nil:
+		i.l.Info("导入完成",
+			logger.Int64("id", task.Id),
+			logger.Int64("author", task.Author.Id),
+			logger.Int64("total", int64(task.Total)))
+		i.deleteArchive(ctx, task)
+		return
+	case errors.Is(err, repository.ErrImportPreempted):
+		// 别的实例会接着执行，压缩包还要留着
+		i.l.Warn("导入任务被别的实例接手了", logger.Int64("id", task.Id))
+		return
+	}
+	i.l.Error("导入失败", logger.Int64("id", task.Id), logger.Error(err))
+	task.Error = err.Error()
+	if len(task.Error) > maxErrorLength {
+		task.Error = strings.ToValidUTF8(task.Error[:maxErrorLength], "")
+	}
+	if err = i.importRepo.Fail(ctx, task); err != nil {
+		// 超时之后会被重新执行
+		i.l.Error("标记导入失败失败", logger.Int64("id", task.Id), logger.Error(err))
+		return
+	}
+	i.deleteArchive(ctx, task)
+}
+
+// importFiles 每 batchSize 篇更新一次进度
+// 被别的实例接手的时候，已经有结果的文件跳过，不会重复导入
+// 不过最后一次更新进度之后保存的那几篇还是会重复
+func (i *Importer) importFiles(ctx context.Context, task *domain.Import) error {
+	data, err := i.importRepo.GetArchive(ctx, *task)
+	if err != nil {
+		return err
+	}
+	files, err := mdarchive.Read(bytes.NewReader(data), int64(len(data)), importLimits)
+	if err != nil {
+		return err
+	}
+	task.Total = len(files)
+	if err = i.importRepo.UpdateProgress(ctx, *task); err != nil {
+		return err
+	}
+	for idx := len(task.Results); idx < len(files); idx++ {
+		task.Results = append(task.Results, i.importFile(ctx, task.Author.Id, files[idx]))
+		task.Imported = len(task.Results)
+		if task.Imported%i.batchSize == 0 {
+			if err = i.importRepo.UpdateProgress(ctx, *task); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (i *Importer) importFile(ctx context.Context, uid int64, file mdarchive.File) domain.ImportResult {
+	id, err := i.svc.Save(ctx, domain.Article{
+		Title:   file.Doc.Meta.Title,
+		Content: file.Doc.Content,
+		Tags:    file.Doc.Meta.Tags,
+		Author:  domain.Author{Id: uid},
+	})
+	res := domain.ImportResult{Filename: file.Name, Title: file.Doc.Meta.Title}
+	switch {
+	case err == nil:
+		res.Id = id
+	case errors.Is(err, ErrSensitiveContent):
+		res.Error = "标题或者内容包含违禁词"
+	case errors.Is(err, ErrTooManyTags):
+		res.Error = fmt.Sprintf("最多只能有 %d 个标签", domain.MaxTagsPerArticle)
+	default:
+		i.l.Error("导入文章失败",
+			logger.Int64("uid", uid),
+			logger.String("filename", file.Name),
+			logger.Error(err))
+		res.Error = "保存失败"
+	}
+	return res
+}
+
+func (i *Importer) deleteArchive(ctx context.Context, task domain.Import) {
+	if err := i.importRepo.DeleteArchive(ctx, task); err != nil {
+		i.l.Error("删除导入的压缩包失败", logger.Int64("id", task.Id), logger.Error(err))
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+)
+
+var (
+	// ErrNotPendingReview 文章不处于待审核状态，可能已经被别人审核过了
+	ErrNotPendingReview = repository.ErrNotPendingReview
+	// ErrEmptyRejectReason 拒绝的时候必须告诉作者原因
+	ErrEmptyRejectReason = errors.New("拒绝的原因不能为空")
+)
+
+// Moderation 发表前的审核，Enabled 为 false 的时候发表直接上线
+// 不过命中了需要人工审核的敏感词的文章，不管有没有开启都要进审核队列
+type Moderation struct {
+	Enabled bool
+	// AutoApprove 预审全部通过的时候直接上线，否则都要等人工审核
+	AutoApprove bool
+	Checkers    []ModerationChecker
+	// DuplicateDistance 发表的时候 SimHash 距离不超过这个值的文章算作重复，0 表示不检查
+	DuplicateDistance int
+}
+
+// ModerationChecker 自动预审，比如关键词、外链数量
+// 返回 error 的时候交给人工审核
+type ModerationChecker interface {
+	Name() string
+	Check(ctx context.Context, art domain.Article) (domain.ModerationVerdict, error)
+}
+
+// submitReview 需要审核的发表，先保存到制作库，预审之后进入审核队列
+// 线上库里面如果有之前发表的版本，审核通过之前读者看到的还是它
+// flagged 是敏感词检查的结果，会和预审的结果合并
+func (svc *articleService) submitReview(ctx context.Context, art domain.Article, flagged domain.ModerationVerdict) (int64, error) {
+	art.Status = domain.ArticleStatusPendingReview
+	art.RevisionKind = domain.RevisionKindSave
+	var (
+		id  = art.Id
+		err error
+	)
+	if art.Id > 0 {
+		err = svc.repo.Update(ctx, art)
+	} else {
+		id, err = svc.repo.Create(ctx, art)
+	}
+	if err != nil {
+		return id, err
+	}
+	art.Id = id
+	verdict := svc.preScreen(ctx, art, flagged)
+	svc.recordModeration(ctx, domain.ModerationLog{
+		ArticleId: id,
+		AuthorId:  art.Author.Id,
+		Action:    domain.ModerationActionSubmit,
+		Status:    domain.ArticleStatusPendingReview,
+		Reason:    verdict.Reason,
+	})
+	switch {
+	case verdict.Decision == domain.ModerationDecisionReject:
+		return id, svc.modRepo.Reject(ctx, domain.ModerationLog{
+			ArticleId: id,
+			AuthorId:  art.Author.Id,
+			Action:    domain.ModerationActionAutoReject,
+			Reason:    verdict.Reason,
+		}, 0)
+	case verdict.Decision == domain.ModerationDecisionPass && svc.moderation.AutoApprove:
+		// 上面的 Update 已经把版本号加一了，这里不再检查
+		art.Version = 0
+		return id, svc.approve(ctx, art, 0, domain.ModerationActionAutoApprove)
+	}
+	return id, nil
+}
+
+// preScreen 取所有预审里面最严重的结果，原因拼在一起给审核人参考
+func (svc *articleService) preScreen(ctx context.Context, art domain.Article, flagged domain.ModerationVerdict) domain.ModerationVerdict {
+	res := domain.ModerationVerdict{Decision: flagged.Decision}
+	reasons := make([]string, 0, len(svc.moderation.Checkers)+1)
+	if flagged.Reason != "" {
+		reasons = append(reasons, flagged.Reason)
+	}
+	for _, c := range svc.moderation.Checkers {
+		v, err := c.Check(ctx, art)
+		if err != nil {
+			svc.l.Error("自动预审失败",
+				logger.Int64("art_id", art.Id),
+				logger.String("checker", c.Name()),
+				logger.Error(err))
+			v = domain.ModerationVerdict{Decision: domain.ModerationDecisionReview, Reason: "预审失败"}
+		}
+		if v.Decision > res.Decision {
+			res.Decision = v.Decision
+		}
+		if v.Reason != "" {
+			reasons = append(reasons, c.Name()+": "+v.Reason)
+		}
+	}
+	res.Reason = strings.Join(reasons, "; ")
+	return res
+}
+
+// approve 同步到线上库，art.Version 大于 0 的时候要求制作库的版本号没有变过
+func (svc *articleService) approve(ctx context.Context, art domain.Article, reviewer int64, action domain.ModerationAction) error {
+	art.Status = domain.ArticleStatusPublished
+	art.RevisionKind = domain.RevisionKindPublish
+	_, err := svc.repo.Sync(ctx, art)
+	if err != nil {
+		return err
+	}
+	svc.recordModeration(ctx, domain.ModerationLog{
+		ArticleId: art.Id,
+		AuthorId:  art.Author.Id,
+		Reviewer:  reviewer,
+		Action:    action,
+		Status:    domain.ArticleStatusPublished,
+	})
+	svc.detectDuplicate(ctx, art)
+	return nil
+}
+
+// recordModeration 状态已经改成功了，记录日志失败不回滚，只记录日志
+func (svc *articleService) recordModeration(ctx context.Context, log domain.ModerationLog) {
+	err := svc.modRepo.Create(ctx, log)
+	if err != nil {
+		svc.l.Error("记录审核日志失败",
+			logger.Int64("art_id", log.ArticleId),
+			logger.String("action", log.Action.String()),
+			logger.Error(err))
+	}
+}
+
+func (svc *articleService) ListPendingReview(ctx context.Context, offset int, limit int) ([]domain.Article, error) {
+	arts, err := svc.modRepo.ListPending(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return arts, svc.fillTags(ctx, arts)
+}
+
+func (svc *articleService) Approve(ctx context.Context, reviewer int64, id int64, version int64) error {
+	art, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if art.Status != domain.ArticleStatusPendingReview {
+		return ErrNotPendingReview
+	}
+	if version > 0 && art.Version != version {
+		return ErrVersionConflict
+	}
+	// 发表会整体覆盖下游的标签，所以要带上
+	art.Tags, err = svc.tagRepo.GetTags(ctx, id)
+	if err != nil {
+		return err
+	}
+	return svc.approve(ctx, art, reviewer, domain.ModerationActionApprove)
+}
+
+func (svc *articleService) Reject(ctx context.Context, reviewer int64, id int64, version int64, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrEmptyRejectReason
+	}
+	art, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return svc.modRepo.Reject(ctx, domain.ModerationLog{
+		ArticleId: id,
+		AuthorId:  art.Author.Id,
+		Reviewer:  reviewer,
+		Action:    domain.ModerationActionReject,
+		Reason:    reason,
+	}, version)
+}
+
+func (svc *articleService) ListModerationLogs(ctx context.Context, artId int64, offset int, limit int) ([]domain.ModerationLog, error) {
+	return svc.modRepo.ListLogs(ctx, artId, offset, limit)
+}
+
+// KeywordChecker 标题或者内容里面出现了关键词就拒绝，或者交给人工审核
+type KeywordChecker struct {
+	reject []string
+	review []string
+}
+
+func NewKeywordChecker(reject []string, review []string) *KeywordChecker {
+	return &KeywordChecker{reject: reject, review: review}
+}
+
+func (k *KeywordChecker) Name() string {
+	return "keyword"
+}
+
+func (k *KeywordChecker) Check(ctx context.Context, art domain.Article) (domain.ModerationVerdict, error) {
+	text := art.Title + "\n" + art.Content
+	if w, ok := k.contains(text, k.reject); ok {
+		return domain.ModerationVerdict{Decision: domain.ModerationDecisionReject, Reason: "包含违禁词 " + w}, nil
+	}
+	if w, ok := k.contains(text, k.review); ok {
+		return domain.ModerationVerdict{Decision: domain.ModerationDecisionReview, Reason: "包含敏感词 " + w}, nil
+	}
+	return domain.ModerationVerdict{Decision: domain.ModerationDecisionPass}, nil
+}
+
+func (k *KeywordChecker) contains(text string, words []string) (string, bool) {
+	for _, w := range words {
+		if w != "" && strings.Contains(text, w) {
+			return w, true
+		}
+	}
+	return "", false
+}
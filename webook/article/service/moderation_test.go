@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	repomocks "github.com/TengFeiyang01/webook/webook/article/repository/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// 测试用的文章都没有标题和正文，审核通过的时候不会计算指纹
+
+func Test_articleService_submitReview(t *testing.T) {
+	pending := func(id int64) domain.Article {
+		return domain.Article{
+			Id:           id,
+			Author:       domain.Author{Id: 123},
+			Status:       domain.ArticleStatusPendingReview,
+			RevisionKind: domain.RevisionKindSave,
+		}
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository)
+
+		moderation Moderation
+		art        domain.Article
+		flagged    domain.ModerationVerdict
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "新建的文章进入审核队列",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), pending(0)).Return(int64(1), nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Create(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Action:    domain.ModerationActionSubmit,
+					Status:    domain.ArticleStatusPendingReview,
+				}).Return(nil)
+				return repo, modRepo
+			},
+			moderation: Moderation{Enabled: true},
+			art:        domain.Article{Author: domain.Author{Id: 123}},
+			wantId:     1,
+		},
+		{
+			name: "修改已有的文章，审核日志失败不影响",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Update(gomock.Any(), pending(1)).Return(nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+				return repo, modRepo
+			},
+			moderation: Moderation{Enabled: true},
+			art:        domain.Article{Id: 1, Author: domain.Author{Id: 123}},
+			wantId:     1,
+		},
+		{
+			name: "预审拒绝，自动拒绝",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Update(gomock.Any(), pending(1)).Return(nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Create(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Action:    domain.ModerationActionSubmit,
+					Status:    domain.ArticleStatusPendingReview,
+					Reason:    "keyword: 包含违禁词 赌博",
+				}).Return(nil)
+				modRepo.EXPECT().Reject(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Action:    domain.ModerationActionAutoReject,
+					Reason:    "keyword: 包含违禁词 赌博",
+				}, int64(0)).Return(nil)
+				return repo, modRepo
+			},
+			moderation: Moderation{
+				Enabled:     true,
+				AutoApprove: true,
+				Checkers: []ModerationChecker{stubChecker{name: "keyword", verdict: domain.ModerationVerdict{
+					Decision: domain.ModerationDecisionReject, Reason: "包含违禁词 赌博"}}},
+			},
+			art:    domain.Article{Id: 1, Author: domain.Author{Id: 123}},
+			wantId: 1,
+		},
+		{
+			name: "预审全部通过，自动上线",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Update(gomock.Any(), domain.Article{
+					Id: 1, Author: domain.Author{Id: 123}, Version: 2,
+					Status: domain.ArticleStatusPendingReview, RevisionKind: domain.RevisionKindSave,
+				}).Return(nil)
+				// Update 已经把版本号加一了，同步的时候不再检查版本号
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{
+					Id: 1, Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPublished, RevisionKind: domain.RevisionKindPublish,
+				}).Return(int64(1), nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Create(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Action:    domain.ModerationActionSubmit,
+					Status:    domain.ArticleStatusPendingReview,
+				}).Return(nil)
+				modRepo.EXPECT().Create(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Action:    domain.ModerationActionAutoApprove,
+					Status:    domain.ArticleStatusPublished,
+				}).Return(nil)
+				return repo, modRepo
+			},
+			moderation: Moderation{
+				Enabled:     true,
+				AutoApprove: true,
+				Checkers:    []ModerationChecker{stubChecker{name: "keyword"}},
+			},
+			art:    domain.Article{Id: 1, Author: domain.Author{Id: 123}, Version: 2},
+			wantId: 1,
+		},
+		{
+			name: "命中了需要人工审核的敏感词，不会自动上线",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Update(gomock.Any(), pending(1)).Return(nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Create(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Action:    domain.ModerationActionSubmit,
+					Status:    domain.ArticleStatusPendingReview,
+					Reason:    "包含敏感词",
+				}).Return(nil)
+				return repo, modRepo
+			},
+			moderation: Moderation{AutoApprove: true},
+			art:        domain.Article{Id: 1, Author: domain.Author{Id: 123}},
+			flagged:    domain.ModerationVerdict{Decision: domain.ModerationDecisionReview, Reason: "包含敏感词"},
+			wantId:     1,
+		},
+		{
+			name: "保存失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+				return repo, repomocks.NewMockModerationRepository(ctrl)
+			},
+			moderation: Moderation{Enabled: true},
+			art:        domain.Article{Id: 1, Author: domain.Author{Id: 123}},
+			wantId:     1,
+			wantErr:    errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, modRepo := tc.mock(ctrl)
+			svc := &articleService{
+				repo:       repo,
+				modRepo:    modRepo,
+				moderation: tc.moderation,
+				l:          logger.NewNopLogger(),
+			}
+			id, err := svc.submitReview(context.Background(), tc.art, tc.flagged)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func Test_articleService_preScreen(t *testing.T) {
+	testCases := []struct {
+		name     string
+		checkers []ModerationChecker
+		flagged  domain.ModerationVerdict
+
+		wantVerdict domain.ModerationVerdict
+	}{
+		{
+			name:        "没有预审",
+			wantVerdict: domain.ModerationVerdict{Decision: domain.ModerationDecisionPass},
+		},
+		{
+			name:        "全部通过",
+			checkers:    []ModerationChecker{stubChecker{name: "keyword"}, stubChecker{name: "link"}},
+			wantVerdict: domain.ModerationVerdict{Decision: domain.ModerationDecisionPass},
+		},
+		{
+			name: "取最严重的结果，原因拼在一起",
+			checkers: []ModerationChecker{
+				stubChecker{name: "keyword", verdict: domain.ModerationVerdict{
+					Decision: domain.ModerationDecisionReject, Reason: "包含违禁词 赌博"}},
+				stubChecker{name: "link", verdict: domain.ModerationVerdict{
+					Decision: domain.ModerationDecisionReview, Reason: "外链太多"}},
+			},
+			flagged: domain.ModerationVerdict{Decision: domain.ModerationDecisionReview, Reason: "包含敏感词"},
+			wantVerdict: domain.ModerationVerdict{
+				Decision: domain.ModerationDecisionReject,
+				Reason:   "包含敏感词; keyword: 包含违禁词 赌博; link: 外链太多",
+			},
+		},
+		{
+			name:     "预审出错交给人工审核",
+			checkers: []ModerationChecker{stubChecker{name: "keyword", err: errors.New("mock error")}},
+			wantVerdict: domain.ModerationVerdict{
+				Decision: domain.ModerationDecisionReview,
+				Reason:   "keyword: 预审失败",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &articleService{
+				moderation: Moderation{Checkers: tc.checkers},
+				l:          logger.NewNopLogger(),
+			}
+			verdict := svc.preScreen(context.Background(), domain.Article{Id: 1}, tc.flagged)
+			assert.Equal(t, tc.wantVerdict, verdict)
+		})
+	}
+}
+
+func Test_articleService_Approve(t *testing.T) {
+	pending := domain.Article{
+		Id:      1,
+		Author:  domain.Author{Id: 123},
+		Status:  domain.ArticleStatusPendingReview,
+		Version: 3,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository,
+			repository.ArticleTagRepository, repository.ModerationRepository)
+		version int64
+
+		wantErr error
+	}{
+		{
+			name: "审核通过，带上标签发表",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository,
+				repository.ArticleTagRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(pending, nil)
+				tagRepo := repomocks.NewMockArticleTagRepository(ctrl)
+				tagRepo.EXPECT().GetTags(gomock.Any(), int64(1)).Return([]string{"Go"}, nil)
+				repo.EXPECT().Sync(gomock.Any(), domain.Article{
+					Id: 1, Author: domain.Author{Id: 123}, Version: 3, Tags: []string{"Go"},
+					Status: domain.ArticleStatusPublished, RevisionKind: domain.RevisionKindPublish,
+				}).Return(int64(1), nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Create(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Reviewer:  99,
+					Action:    domain.ModerationActionApprove,
+					Status:    domain.ArticleStatusPublished,
+				}).Return(nil)
+				return repo, tagRepo, modRepo
+			},
+			version: 3,
+		},
+		{
+			name: "已经被别人审核过了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository,
+				repository.ArticleTagRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				art := pending
+				art.Status = domain.ArticleStatusPublished
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(art, nil)
+				return repo, repomocks.NewMockArticleTagRepository(ctrl), repomocks.NewMockModerationRepository(ctrl)
+			},
+			wantErr: ErrNotPendingReview,
+		},
+		{
+			name: "审核期间作者又改了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository,
+				repository.ArticleTagRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(pending, nil)
+				return repo, repomocks.NewMockArticleTagRepository(ctrl), repomocks.NewMockModerationRepository(ctrl)
+			},
+			version: 2,
+			wantErr: ErrVersionConflict,
+		},
+		{
+			name: "发表失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository,
+				repository.ArticleTagRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(pending, nil)
+				tagRepo := repomocks.NewMockArticleTagRepository(ctrl)
+				tagRepo.EXPECT().GetTags(gomock.Any(), int64(1)).Return(nil, nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("mock error"))
+				return repo, tagRepo, repomocks.NewMockModerationRepository(ctrl)
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, tagRepo, modRepo := tc.mock(ctrl)
+			svc := &articleService{
+				repo:    repo,
+				tagRepo: tagRepo,
+				modRepo: modRepo,
+				l:       logger.NewNopLogger(),
+			}
+			err := svc.Approve(context.Background(), 99, 1, tc.version)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_Reject(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository)
+		reason string
+
+		wantErr error
+	}{
+		{
+			name: "拒绝成功",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Reject(gomock.Any(), domain.ModerationLog{
+					ArticleId: 1,
+					AuthorId:  123,
+					Reviewer:  99,
+					Action:    domain.ModerationActionReject,
+					Reason:    "标题党",
+				}, int64(3)).Return(nil)
+				return repo, modRepo
+			},
+			reason: " 标题党 ",
+		},
+		{
+			name: "没有原因",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				return repomocks.NewMockArticleRepository(ctrl), repomocks.NewMockModerationRepository(ctrl)
+			},
+			reason:  "  ",
+			wantErr: ErrEmptyRejectReason,
+		},
+		{
+			name: "已经被别人审核过了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ModerationRepository) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				modRepo := repomocks.NewMockModerationRepository(ctrl)
+				modRepo.EXPECT().Reject(gomock.Any(), gomock.Any(), int64(3)).Return(repository.ErrNotPendingReview)
+				return repo, modRepo
+			},
+			reason:  "标题党",
+			wantErr: ErrNotPendingReview,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, modRepo := tc.mock(ctrl)
+			svc := &articleService{
+				repo:    repo,
+				modRepo: modRepo,
+				l:       logger.NewNopLogger(),
+			}
+			err := svc.Reject(context.Background(), 99, 1, 3, tc.reason)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+type stubChecker struct {
+	name    string
+	verdict domain.ModerationVerdict
+	err     error
+}
+
+func (s stubChecker) Name() string {
+	return s.name
+}
+
+func (s stubChecker) Check(ctx context.Context, art domain.Article) (domain.ModerationVerdict, error) {
+	return s.verdict, s.err
+}
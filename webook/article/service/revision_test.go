@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	repomocks "github.com/TengFeiyang01/webook/webook/article/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_articleService_DiffRevisions(t *testing.T) {
+	from := domain.ArticleRevision{Id: 1, ArticleId: 10, AuthorId: 123, Version: 1, Content: "标题\n旧的内容"}
+	to := domain.ArticleRevision{Id: 2, ArticleId: 10, AuthorId: 123, Version: 2, Content: "标题\n新的内容"}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.ArticleRevisionRepository
+		uid  int64
+
+		wantDiff domain.RevisionDiff
+		wantErr  error
+	}{
+		{
+			name: "比较成功",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRevisionRepository {
+				repo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(from, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).Return(to, nil)
+				return repo
+			},
+			uid: 123,
+			wantDiff: domain.RevisionDiff{
+				From: from,
+				To:   to,
+				Lines: []domain.DiffLine{
+					{Op: domain.DiffOpEqual, Text: "标题"},
+					{Op: domain.DiffOpDelete, Text: "旧的内容"},
+					{Op: domain.DiffOpInsert, Text: "新的内容"},
+				},
+			},
+		},
+		{
+			name: "不是自己的版本",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRevisionRepository {
+				repo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(from, nil)
+				return repo
+			},
+			uid:     456,
+			wantErr: ErrInvalidRevision,
+		},
+		{
+			name: "不是同一篇文章的版本",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRevisionRepository {
+				repo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(from, nil)
+				other := to
+				other.ArticleId = 11
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).Return(other, nil)
+				return repo
+			},
+			uid:     123,
+			wantErr: ErrInvalidRevision,
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) repository.ArticleRevisionRepository {
+				repo := repomocks.NewMockArticleRevisionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(from, nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.ArticleRevision{}, errors.New("mock error"))
+				return repo
+			},
+			uid:     123,
+			wantErr: errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := &articleService{revRepo: tc.mock(ctrl)}
+			res, err := svc.DiffRevisions(context.Background(), tc.uid, 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantDiff, res)
+		})
+	}
+}
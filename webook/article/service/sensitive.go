@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/pkg/sensitive"
+)
+
+// ErrSensitiveContent 标题或者内容里面有直接拒绝的敏感词
+var ErrSensitiveContent = errors.New("内容包含违禁词")
+
+// screen 检查标题和内容，需要打码的直接改掉 art
+// 有需要人工审核的词的时候返回的 Decision 是 Review，由调用方决定要不要送审
+func (svc *articleService) screen(art *domain.Article) (domain.ModerationVerdict, error) {
+	res := domain.ModerationVerdict{Decision: domain.ModerationDecisionPass}
+	if svc.filter == nil {
+		return res, nil
+	}
+	title := svc.filter.Check(art.Title)
+	content := svc.filter.Check(art.Content)
+	words := append(title.Words(), content.Words()...)
+	switch max(title.Action, content.Action) {
+	case sensitive.ActionBlock:
+		return res, fmt.Errorf("%w: %s", ErrSensitiveContent, strings.Join(words, ", "))
+	case sensitive.ActionReview:
+		res.Decision = domain.ModerationDecisionReview
+		res.Reason = "sensitive: " + strings.Join(words, ", ")
+	}
+	art.Title = title.Masked
+	art.Content = content.Masked
+	return res, nil
+}
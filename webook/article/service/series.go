@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/article/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+)
+
+var (
+	// ErrInvalidSeries 标题为空、文章太多或者有重复的文章
+	ErrInvalidSeries = errors.New("系列参数非法")
+	// ErrSeriesNotFound 系列不存在、不属于该作者，或者文章不在任何系列里面
+	ErrSeriesNotFound = repository.ErrSeriesNotFound
+	// ErrInvalidSeriesArticles 文章不存在、已经删除或者不属于该作者
+	ErrInvalidSeriesArticles = repository.ErrInvalidSeriesArticles
+	// ErrArticleInOtherSeries 一篇文章只能属于一个系列
+	ErrArticleInOtherSeries = repository.ErrArticleInOtherSeries
+)
+
+func (svc *articleService) CreateSeries(ctx context.Context, s domain.Series) (int64, error) {
+	s.Title = strings.TrimSpace(s.Title)
+	if s.Title == "" {
+		return 0, ErrInvalidSeries
+	}
+	if err := svc.checkSeriesArticles(s.ArticleIds); err != nil {
+		return 0, err
+	}
+	return svc.seriesRepo.Create(ctx, s)
+}
+
+func (svc *articleService) ReorderSeries(ctx context.Context, uid int64, id int64, artIds []int64) error {
+	if err := svc.checkSeriesArticles(artIds); err != nil {
+		return err
+	}
+	return svc.seriesRepo.SetArticles(ctx, id, uid, artIds)
+}
+
+func (svc *articleService) checkSeriesArticles(artIds []int64) error {
+	if len(artIds) > domain.MaxArticlesPerSeries {
+		return ErrInvalidSeries
+	}
+	seen := make(map[int64]struct{}, len(artIds))
+	for _, id := range artIds {
+		if _, ok := seen[id]; ok {
+			return ErrInvalidSeries
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+func (svc *articleService) ListSeries(ctx context.Context, uid int64, offset int, limit int) ([]domain.Series, error) {
+	return svc.seriesRepo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+func (svc *articleService) GetSeriesNav(ctx context.Context, artId int64) (domain.SeriesNav, error) {
+	s, err := svc.seriesRepo.GetByArticle(ctx, artId)
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	res := domain.SeriesNav{Series: s}
+	pos := s.Position(artId)
+	if pos < 0 {
+		// 缓存里面的顺序还没有更新
+		return res, nil
+	}
+	// 系列里面可能有还没发表或者已经撤回的文章，要跳过去
+	// 一次查出整个系列已发表的文章，不要一篇一篇地查
+	ids := make([]int64, 0, len(s.ArticleIds)-1)
+	for _, id := range s.ArticleIds {
+		if id != artId {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return res, nil
+	}
+	arts, err := svc.repo.GetPubTitles(ctx, ids)
+	if err != nil {
+		// 导航只是锦上添花，查询失败就当作没有前后文章
+		svc.l.Error("查询系列导航的文章失败", logger.Int64("series_id", s.Id), logger.Error(err))
+		return res, nil
+	}
+	published := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		published[art.Id] = art
+	}
+	for i := pos - 1; i >= 0; i-- {
+		if art, ok := published[s.ArticleIds[i]]; ok {
+			res.Prev = art
+			break
+		}
+	}
+	for i := pos + 1; i < len(s.ArticleIds); i++ {
+		if art, ok := published[s.ArticleIds[i]]; ok {
+			res.Next = art
+			break
+		}
+	}
+	return res, nil
+}
@@ -22,10 +22,31 @@ var thirdPartySet = wire.NewSet(
 )
 
 var articleSvcSet = wire.NewSet(
-	dao.NewGORMArticleDAO,
+	ioc.InitArticleShards,
+	ioc.InitArticleDAO,
+	ioc.InitOutboxDAO,
+	ioc.InitArticleRevisionDAO,
+	ioc.InitTagDAO,
+	ioc.InitSeriesDAO,
+	ioc.InitAttachmentDAO,
+	ioc.InitModerationDAO,
+	ioc.InitExportDAO,
+	dao.NewGORMImportDAO,
+	dao.NewGORMFingerprintDAO,
 	repository.NewCachedArticleRepository,
-	service.NewArticleService,
-	cache.NewArticleCache,
+	repository.NewArticleRevisionRepository,
+	repository.NewCachedArticleTagRepository,
+	repository.NewCachedSeriesRepository,
+	ioc.InitAttachmentRepository,
+	ioc.InitExportRepository,
+	ioc.InitImportRepository,
+	repository.NewModerationRepository,
+	repository.NewFingerprintRepository,
+	ioc.InitModeration,
+	ioc.InitSensitiveFilter,
+	ioc.InitArticleService,
+	ioc.InitArticleCache,
+	cache.NewSeriesCache,
 	usrdao.NewUserDAO,
 )
 
@@ -34,7 +55,11 @@ func InitAPP() *App {
 		thirdPartySet,
 		articleSvcSet,
 		grpc.NewArticleServiceServer,
-		events.NewKafkaProducer,
+		events.NewOutboxProducer,
+		events.NewOutboxRelay,
+		service.NewExporter,
+		service.NewImporter,
+		ioc.InitLocalBlobServers,
 		ioc.NewGRPCxServer,
 		ioc.NewSyncProducer,
 		wire.Struct(new(App), "*"),
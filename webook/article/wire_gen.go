@@ -12,9 +12,9 @@ import (
 	"github.com/TengFeiyang01/webook/webook/article/ioc"
 	"github.com/TengFeiyang01/webook/webook/article/repository"
 	"github.com/TengFeiyang01/webook/webook/article/repository/cache"
-	"github.com/TengFeiyang01/webook/webook/article/repository/dao"
+	dao2 "github.com/TengFeiyang01/webook/webook/article/repository/dao"
 	"github.com/TengFeiyang01/webook/webook/article/service"
-	dao2 "github.com/TengFeiyang01/webook/webook/internal/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/internal/repository/dao"
 	"github.com/google/wire"
 )
 
@@ -23,19 +23,48 @@ import (
 func InitAPP() *App {
 	loggerV1 := ioc.InitLogger()
 	db := ioc.InitDB(loggerV1)
-	articleDAO := dao.NewGORMArticleDAO(db)
-	userDAO := dao2.NewUserDAO(db)
+	articleShards := ioc.InitArticleShards(loggerV1)
+	articleDAO := ioc.InitArticleDAO(db, articleShards)
+	userDAO := dao.NewUserDAO(db)
 	cmdable := ioc.InitRedis()
-	articleCache := cache.NewArticleCache(cmdable)
+	articleCache := ioc.InitArticleCache(cmdable)
 	articleRepository := repository.NewCachedArticleRepository(articleDAO, loggerV1, userDAO, articleCache)
+	articleRevisionDAO := ioc.InitArticleRevisionDAO(db, articleShards)
+	articleRevisionRepository := repository.NewArticleRevisionRepository(articleRevisionDAO)
+	tagDAO := ioc.InitTagDAO(db, articleShards)
+	articleTagRepository := repository.NewCachedArticleTagRepository(tagDAO, articleCache, loggerV1)
+	seriesDAO := ioc.InitSeriesDAO(db, articleShards)
+	seriesCache := cache.NewSeriesCache(cmdable)
+	seriesRepository := repository.NewCachedSeriesRepository(seriesDAO, seriesCache, loggerV1)
+	attachmentDAO := ioc.InitAttachmentDAO(db, articleShards)
+	attachmentRepository := ioc.InitAttachmentRepository(attachmentDAO)
+	moderationDAO := ioc.InitModerationDAO(db, articleShards)
+	moderationRepository := repository.NewModerationRepository(moderationDAO, articleCache, loggerV1)
+	exportDAO := ioc.InitExportDAO(db, articleShards)
+	exportRepository := ioc.InitExportRepository(exportDAO)
+	importDAO := dao2.NewGORMImportDAO(db)
+	importRepository := ioc.InitImportRepository(importDAO)
+	fingerprintDAO := dao2.NewGORMFingerprintDAO(db)
+	fingerprintRepository := repository.NewFingerprintRepository(fingerprintDAO)
+	moderation := ioc.InitModeration()
+	filter := ioc.InitSensitiveFilter(loggerV1)
 	client := ioc.InitKafka()
 	syncProducer := ioc.NewSyncProducer(client)
-	producer := events.NewKafkaProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer, loggerV1)
+	outboxDAO := ioc.InitOutboxDAO(db, articleShards)
+	producer := events.NewOutboxProducer(syncProducer, outboxDAO)
+	articleService := ioc.InitArticleService(articleRepository, articleRevisionRepository, articleTagRepository, seriesRepository, attachmentRepository, moderationRepository, exportRepository, importRepository, fingerprintRepository, moderation, filter, producer, loggerV1)
 	articleServiceServer := grpc.NewArticleServiceServer(articleService)
 	server := ioc.NewGRPCxServer(articleServiceServer)
+	outboxRelay := events.NewOutboxRelay(outboxDAO, syncProducer, loggerV1)
+	exporter := service.NewExporter(articleRepository, articleTagRepository, seriesRepository, exportRepository, loggerV1)
+	importer := service.NewImporter(articleService, importRepository, loggerV1)
+	localBlobServers := ioc.InitLocalBlobServers()
 	app := &App{
-		server: server,
+		server:      server,
+		relay:       outboxRelay,
+		exporter:    exporter,
+		importer:    importer,
+		blobServers: localBlobServers,
 	}
 	return app
 }
@@ -44,4 +73,4 @@ func InitAPP() *App {
 
 var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.InitRedis)
 
-var articleSvcSet = wire.NewSet(dao.NewGORMArticleDAO, repository.NewCachedArticleRepository, service.NewArticleService, cache.NewArticleCache, dao2.NewUserDAO)
+var articleSvcSet = wire.NewSet(ioc.InitArticleShards, ioc.InitArticleDAO, ioc.InitOutboxDAO, ioc.InitArticleRevisionDAO, ioc.InitTagDAO, ioc.InitSeriesDAO, ioc.InitAttachmentDAO, ioc.InitModerationDAO, ioc.InitExportDAO, dao2.NewGORMImportDAO, dao2.NewGORMFingerprintDAO, repository.NewCachedArticleRepository, repository.NewArticleRevisionRepository, repository.NewCachedArticleTagRepository, repository.NewCachedSeriesRepository, ioc.InitAttachmentRepository, ioc.InitExportRepository, ioc.InitImportRepository, repository.NewModerationRepository, repository.NewFingerprintRepository, ioc.InitModeration, ioc.InitSensitiveFilter, ioc.InitArticleService, ioc.InitArticleCache, cache.NewSeriesCache, dao.NewUserDAO)
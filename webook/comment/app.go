@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
+)
+
+type App struct {
+	server *grpcx.Server
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// BizArticle 目前只有文章可以评论
+const BizArticle = "art"
+
+// BizComment 评论的点赞在互动服务里面用的 biz
+const BizComment = "comment"
+
+type Comment struct {
+	Id int64
+	// Uid 发表评论的用户
+	Uid     int64
+	Biz     string
+	BizId   int64
+	Content string
+	// RootId 根评论是 0，回复是所在的根评论，多层回复都挂在同一条根评论下面
+	RootId int64
+	// ParentId 根评论是 0，回复是直接回复的那条评论
+	ParentId int64
+	// ReplyToUid 直接回复的那条评论的作者，展示成 “A 回复 B”
+	ReplyToUid int64
+	// ReplyCnt 只有根评论有，是下面所有回复的数量
+	ReplyCnt int64
+	LikeCnt  int64
+	// Replies 列表里面的根评论会带上最早的几条回复
+	Replies []Comment
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+func (c Comment) IsRoot() bool {
+	return c.RootId == 0
+}
+
+// Root 回复所在的根评论，根评论就是自己
+func (c Comment) Root() int64 {
+	if c.IsRoot() {
+		return c.Id
+	}
+	return c.RootId
+}
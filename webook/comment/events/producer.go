@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+const TopicCommentEvent = "comment_event"
+
+// CommentEvent 每发表一条评论发一条，通知、审核之类的下游自己订阅
+type CommentEvent struct {
+	Id    int64  `json:"id"`
+	Uid   int64  `json:"uid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// RootId ParentId 根评论是 0
+	RootId   int64 `json:"root_id"`
+	ParentId int64 `json:"parent_id"`
+	// ReplyToUid 被回复的人，根评论是 0
+	ReplyToUid int64 `json:"reply_to_uid"`
+	// BizOwner 资源的作者，例如文章的作者，通知的时候用得上
+	BizOwner int64  `json:"biz_owner"`
+	Content  string `json:"content"`
+	Ctime    int64  `json:"ctime"`
+}
+
+type Producer interface {
+	ProduceCommentEvent(ctx context.Context, evt CommentEvent) error
+}
+
+type KafkaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewKafkaProducer(pc sarama.SyncProducer) Producer {
+	return &KafkaProducer{producer: pc}
+}
+
+// ProduceCommentEvent 用资源做 key，同一篇文章下面的评论是有序的
+func (k *KafkaProducer) ProduceCommentEvent(ctx context.Context, evt CommentEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicCommentEvent,
+		Key:   sarama.StringEncoder(evt.Biz + ":" + strconv.FormatInt(evt.BizId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
@@ -0,0 +1,2 @@
+// Package grpc 是用来将业务暴露成为一个 GRPC 接口的
+package grpc
@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	commentv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/comment/v1"
+	"github.com/TengFeiyang01/webook/webook/comment/domain"
+	"github.com/TengFeiyang01/webook/webook/comment/service"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CommentServiceServer struct {
+	commentv1.UnimplementedCommentServiceServer
+	svc service.CommentService
+}
+
+func NewCommentServiceServer(svc service.CommentService) *CommentServiceServer {
+	return &CommentServiceServer{svc: svc}
+}
+
+func (c *CommentServiceServer) Register(server *grpc.Server) {
+	commentv1.RegisterCommentServiceServer(server, c)
+}
+
+func (c *CommentServiceServer) CreateComment(ctx context.Context, request *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
+	res, err := c.svc.Create(ctx, domain.Comment{
+		Uid:      request.GetUid(),
+		Biz:      request.GetBiz(),
+		BizId:    request.GetBizId(),
+		ParentId: request.GetParentId(),
+		Content:  request.GetContent(),
+	})
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.CreateCommentResponse{Comment: c.toDTO(res)}, nil
+}
+
+func (c *CommentServiceServer) DeleteComment(ctx context.Context, request *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
+	err := c.svc.Delete(ctx, request.GetId(), request.GetUid())
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.DeleteCommentResponse{}, nil
+}
+
+func (c *CommentServiceServer) GetCommentList(ctx context.Context, request *commentv1.CommentListRequest) (*commentv1.CommentListResponse, error) {
+	res, err := c.svc.List(ctx, request.GetBiz(), request.GetBizId(), request.GetMinId(),
+		int(request.GetLimit()), int(request.GetReplyLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.CommentListResponse{Comments: c.toDTOs(res)}, nil
+}
+
+func (c *CommentServiceServer) GetMoreReplies(ctx context.Context, request *commentv1.GetMoreRepliesRequest) (*commentv1.GetMoreRepliesResponse, error) {
+	res, err := c.svc.Replies(ctx, request.GetRootId(), request.GetMaxId(), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.GetMoreRepliesResponse{Replies: c.toDTOs(res)}, nil
+}
+
+func (c *CommentServiceServer) Like(ctx context.Context, request *commentv1.LikeRequest) (*commentv1.LikeResponse, error) {
+	err := c.svc.Like(ctx, request.GetId(), request.GetUid())
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.LikeResponse{}, nil
+}
+
+func (c *CommentServiceServer) CancelLike(ctx context.Context, request *commentv1.CancelLikeRequest) (*commentv1.CancelLikeResponse, error) {
+	err := c.svc.CancelLike(ctx, request.GetId(), request.GetUid())
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.CancelLikeResponse{}, nil
+}
+
+func (c *CommentServiceServer) toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound), errors.Is(err, service.ErrBizNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidComment):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return err
+	}
+}
+
+func (c *CommentServiceServer) toDTOs(cs []domain.Comment) []*commentv1.Comment {
+	return slice.Map(cs, func(idx int, src domain.Comment) *commentv1.Comment {
+		return c.toDTO(src)
+	})
+}
+
+func (c *CommentServiceServer) toDTO(cmt domain.Comment) *commentv1.Comment {
+	res := &commentv1.Comment{
+		Id:         cmt.Id,
+		Uid:        cmt.Uid,
+		Biz:        cmt.Biz,
+		BizId:      cmt.BizId,
+		Content:    cmt.Content,
+		RootId:     cmt.RootId,
+		ParentId:   cmt.ParentId,
+		ReplyToUid: cmt.ReplyToUid,
+		ReplyCnt:   cmt.ReplyCnt,
+		LikeCnt:    cmt.LikeCnt,
+		Ctime:      cmt.Ctime.UnixMilli(),
+		Utime:      cmt.Utime.UnixMilli(),
+	}
+	if len(cmt.Replies) > 0 {
+		res.Replies = c.toDTOs(cmt.Replies)
+	}
+	return res
+}
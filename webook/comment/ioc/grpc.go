@@ -0,0 +1,62 @@
+package ioc
+
+import (
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
+	grpc2 "github.com/TengFeiyang01/webook/webook/comment/grpc"
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func NewGRPCxServer(commentServer *grpc2.CommentServiceServer) *grpcx.Server {
+	type Config struct {
+		Addr string `yaml:"addr"`
+	}
+
+	var cfg Config
+	if err := viper.UnmarshalKey("grpc.server", &cfg); err != nil {
+		panic(err)
+	}
+
+	server := grpc.NewServer()
+	commentServer.Register(server)
+
+	return &grpcx.Server{
+		Server: server,
+		Addr:   cfg.Addr,
+	}
+}
+
+// InitArtGRPCClient 查文章的作者，判断能不能评论、能不能删评论
+func InitArtGRPCClient() artv1.ArticleServiceClient {
+	return artv1.NewArticleServiceClient(newClientConn("grpc.client.art"))
+}
+
+// InitIntrGRPCClient 评论的点赞放在互动服务里面
+func InitIntrGRPCClient() intrv1.InteractiveServiceClient {
+	return intrv1.NewInteractiveServiceClient(newClientConn("grpc.client.intr"))
+}
+
+func newClientConn(key string) *grpc.ClientConn {
+	type Config struct {
+		Addr   string `yaml:"addr"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey(key, &cfg); err != nil {
+		panic(err)
+	}
+	var opts []grpc.DialOption
+	if cfg.Secure {
+		// 加载你的证书之类的
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient(cfg.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return cc
+}
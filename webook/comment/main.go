@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"log"
+)
+
+func initViper() {
+	cfile := pflag.String("config", "config/dev.yaml", "指定配置文件路径")
+	pflag.Parse()
+	viper.SetConfigFile(*cfile)
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("Fatal error config file: %s \n", err))
+	}
+}
+
+func main() {
+	initViper()
+	app := InitAPP()
+	err := app.server.Serve()
+	log.Println(err)
+}
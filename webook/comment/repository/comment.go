@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/comment/domain"
+	"github.com/TengFeiyang01/webook/webook/comment/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
+)
+
+var ErrCommentNotFound = dao.ErrRecordNotFound
+
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (domain.Comment, error)
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	// FindRoots 根评论带上回复数和最早的 replyLimit 条回复
+	FindRoots(ctx context.Context, biz string, bizId int64, minId int64, limit int, replyLimit int) ([]domain.Comment, error)
+	FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error)
+	// Delete 返回所有被删除的评论 id，包括下面的回复
+	Delete(ctx context.Context, c domain.Comment) ([]int64, error)
+}
+
+type commentRepository struct {
+	dao dao.CommentDAO
+}
+
+func NewCommentRepository(dao dao.CommentDAO) CommentRepository {
+	return &commentRepository{dao: dao}
+}
+
+func (c *commentRepository) Create(ctx context.Context, cmt domain.Comment) (domain.Comment, error) {
+	res, err := c.dao.Insert(ctx, c.toEntity(cmt))
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return c.toDomain(res), nil
+}
+
+func (c *commentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	res, err := c.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return c.toDomain(res), nil
+}
+
+func (c *commentRepository) FindRoots(ctx context.Context, biz string, bizId int64,
+	minId int64, limit int, replyLimit int) ([]domain.Comment, error) {
+	roots, err := c.dao.FindRoots(ctx, biz, bizId, minId, limit)
+	if err != nil || len(roots) == 0 {
+		return nil, err
+	}
+	res := slice.Map(roots, func(idx int, src dao.Comment) domain.Comment {
+		return c.toDomain(src)
+	})
+	ids := slice.Map(roots, func(idx int, src dao.Comment) int64 {
+		return src.Id
+	})
+	var eg errgroup.Group
+	eg.Go(func() error {
+		cnts, err := c.dao.CountReplies(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for i := range res {
+			res[i].ReplyCnt = cnts[res[i].Id]
+		}
+		return nil
+	})
+	if replyLimit > 0 {
+		// 每条根评论查一次，一页的根评论不多，并发查
+		for i := range res {
+			i := i
+			eg.Go(func() error {
+				replies, err := c.FindReplies(ctx, res[i].Id, 0, replyLimit)
+				res[i].Replies = replies
+				return err
+			})
+		}
+	}
+	return res, eg.Wait()
+}
+
+func (c *commentRepository) FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error) {
+	replies, err := c.dao.FindReplies(ctx, rootId, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(replies, func(idx int, src dao.Comment) domain.Comment {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *commentRepository) Delete(ctx context.Context, cmt domain.Comment) ([]int64, error) {
+	return c.dao.Delete(ctx, c.toEntity(cmt))
+}
+
+func (c *commentRepository) toEntity(cmt domain.Comment) dao.Comment {
+	return dao.Comment{
+		Id:         cmt.Id,
+		Uid:        cmt.Uid,
+		Biz:        cmt.Biz,
+		BizId:      cmt.BizId,
+		Content:    cmt.Content,
+		RootId:     cmt.RootId,
+		ParentId:   cmt.ParentId,
+		ReplyToUid: cmt.ReplyToUid,
+	}
+}
+
+func (c *commentRepository) toDomain(cmt dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:         cmt.Id,
+		Uid:        cmt.Uid,
+		Biz:        cmt.Biz,
+		BizId:      cmt.BizId,
+		Content:    cmt.Content,
+		RootId:     cmt.RootId,
+		ParentId:   cmt.ParentId,
+		ReplyToUid: cmt.ReplyToUid,
+		Ctime:      time.UnixMilli(cmt.Ctime),
+		Utime:      time.UnixMilli(cmt.Utime),
+	}
+}
@@ -0,0 +1,135 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrRecordNotFound = gorm.ErrRecordNotFound
+
+type CommentDAO interface {
+	Insert(ctx context.Context, c Comment) (Comment, error)
+	FindById(ctx context.Context, id int64) (Comment, error)
+	// FindRoots 按照 id 倒序，只取 id 小于 minId 的，minId 为 0 表示第一页
+	FindRoots(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]Comment, error)
+	// FindReplies 按照 id 正序，只取 id 大于 maxId 的
+	FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]Comment, error)
+	// CountReplies 每条根评论下面有多少条回复，没有回复的不在结果里面
+	CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error)
+	// Delete 删除评论以及它下面所有的回复，返回所有被删除的 id
+	Delete(ctx context.Context, c Comment) ([]int64, error)
+}
+
+type GORMCommentDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCommentDAO(db *gorm.DB) CommentDAO {
+	return &GORMCommentDAO{db: db}
+}
+
+func (dao *GORMCommentDAO) Insert(ctx context.Context, c Comment) (Comment, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Create(&c).Error
+	return c, err
+}
+
+func (dao *GORMCommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var res Comment
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *GORMCommentDAO) FindRoots(ctx context.Context, biz string, bizId int64, minId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	query := dao.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND root_id = 0", biz, bizId)
+	if minId > 0 {
+		query = query.Where("id < ?", minId)
+	}
+	err := query.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMCommentDAO) FindReplies(ctx context.Context, rootId int64, maxId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := dao.db.WithContext(ctx).
+		Where("root_id = ? AND id > ?", rootId, maxId).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMCommentDAO) CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error) {
+	var rows []struct {
+		RootId int64
+		Cnt    int64
+	}
+	err := dao.db.WithContext(ctx).Model(&Comment{}).
+		Select("root_id, COUNT(*) AS cnt").
+		Where("root_id IN ?", rootIds).
+		Group("root_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		res[row.RootId] = row.Cnt
+	}
+	return res, nil
+}
+
+// Delete 根评论直接按照 root_id 删；回复要沿着 parent_id 找出下面的子孙
+// 同一条根评论下面的回复不会太多，一次捞出来在内存里面找
+func (dao *GORMCommentDAO) Delete(ctx context.Context, c Comment) ([]int64, error) {
+	var ids []int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if c.RootId == 0 {
+			err := tx.Model(&Comment{}).Where("root_id = ?", c.Id).Pluck("id", &ids).Error
+			if err != nil {
+				return err
+			}
+			ids = append(ids, c.Id)
+			return tx.Where("id = ? OR root_id = ?", c.Id, c.Id).Delete(&Comment{}).Error
+		}
+		var replies []Comment
+		err := tx.Select("id", "parent_id").Where("root_id = ?", c.RootId).Find(&replies).Error
+		if err != nil {
+			return err
+		}
+		ids = descendants(c.Id, replies)
+		return tx.Where("id IN ?", ids).Delete(&Comment{}).Error
+	})
+	return ids, err
+}
+
+// descendants 包括 id 自己
+func descendants(id int64, replies []Comment) []int64 {
+	children := make(map[int64][]int64, len(replies))
+	for _, r := range replies {
+		children[r.ParentId] = append(children[r.ParentId], r.Id)
+	}
+	res := []int64{id}
+	for i := 0; i < len(res); i++ {
+		res = append(res, children[res[i]]...)
+	}
+	return res
+}
+
+type Comment struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64
+	// 翻根评论：WHERE biz = ? AND biz_id = ? AND root_id = 0 ORDER BY id DESC
+	Biz   string `gorm:"type:varchar(128);index:biz_type_id_root"`
+	BizId int64  `gorm:"index:biz_type_id_root"`
+	// 翻回复和统计回复数：WHERE root_id = ?
+	RootId     int64 `gorm:"index:biz_type_id_root;index:root_id"`
+	ParentId   int64
+	ReplyToUid int64
+	Content    string `gorm:"type:text"`
+	Ctime      int64
+	Utime      int64
+}
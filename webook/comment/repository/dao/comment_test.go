@@ -0,0 +1,81 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMCommentDAO(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// 内存数据库每个连接都是独立的
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+	require.NoError(t, InitTables(db))
+	dao := NewGORMCommentDAO(db)
+	ctx := context.Background()
+
+	insert := func(c Comment) Comment {
+		c.Biz, c.BizId = "art", 1
+		res, err := dao.Insert(ctx, c)
+		require.NoError(t, err)
+		return res
+	}
+	// r1 <- a <- b, r1 <- c；r2 没有回复
+	r1 := insert(Comment{Uid: 1, Content: "r1"})
+	a := insert(Comment{Uid: 2, RootId: r1.Id, ParentId: r1.Id, Content: "a"})
+	b := insert(Comment{Uid: 3, RootId: r1.Id, ParentId: a.Id, Content: "b"})
+	c := insert(Comment{Uid: 4, RootId: r1.Id, ParentId: r1.Id, Content: "c"})
+	r2 := insert(Comment{Uid: 5, Content: "r2"})
+	_, err = dao.Insert(ctx, Comment{Biz: "art", BizId: 2, Uid: 1, Content: "别的文章"})
+	require.NoError(t, err)
+
+	found, err := dao.FindById(ctx, b.Id)
+	require.NoError(t, err)
+	assert.Equal(t, b, found)
+	_, err = dao.FindById(ctx, 1000)
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+
+	roots, err := dao.FindRoots(ctx, "art", 1, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{r2, r1}, roots)
+	roots, err = dao.FindRoots(ctx, "art", 1, r2.Id, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{r1}, roots)
+
+	replies, err := dao.FindReplies(ctx, r1.Id, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{a, b}, replies)
+	replies, err = dao.FindReplies(ctx, r1.Id, b.Id, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{c}, replies)
+
+	cnts, err := dao.CountReplies(ctx, []int64{r1.Id, r2.Id})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{r1.Id: 3}, cnts)
+
+	// 删除回复的时候，回复它的回复一起删掉
+	ids, err := dao.Delete(ctx, a)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{a.Id, b.Id}, ids)
+	replies, err = dao.FindReplies(ctx, r1.Id, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{c}, replies)
+
+	// 删除根评论，下面所有的回复都删掉
+	ids, err = dao.Delete(ctx, r1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{r1.Id, c.Id}, ids)
+	roots, err = dao.FindRoots(ctx, "art", 1, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{r2}, roots)
+}
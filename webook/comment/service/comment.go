@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
+	artdomain "github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/comment/domain"
+	"github.com/TengFeiyang01/webook/webook/comment/events"
+	"github.com/TengFeiyang01/webook/webook/comment/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxContentLength 评论的最大长度，按字符算
+	MaxContentLength = 1000
+
+	defaultPageSize   = 20
+	maxPageSize       = 100
+	defaultReplyLimit = 3
+	maxReplyLimit     = 10
+)
+
+var (
+	ErrCommentNotFound = repository.ErrCommentNotFound
+	// ErrBizNotFound 评论的资源不存在，或者还没有发表
+	ErrBizNotFound = errors.New("评论的资源不存在")
+	// ErrInvalidComment 内容为空、太长，或者资源不支持评论
+	ErrInvalidComment = errors.New("评论不合法")
+	// ErrPermissionDenied 只有评论的作者和资源的作者可以删除评论
+	ErrPermissionDenied = errors.New("没有权限删除评论")
+)
+
+//go:generate mockgen -source=./comment.go -package=svcmocks -destination=./mocks/comment.mock.go CommentService
+type CommentService interface {
+	// Create ParentId 大于 0 的是回复，Biz 和 BizId 以被回复的评论为准
+	Create(ctx context.Context, c domain.Comment) (domain.Comment, error)
+	// Delete 评论的作者和资源的作者都可以删，下面的回复会一起删掉
+	Delete(ctx context.Context, id int64, uid int64) error
+	// List 按照时间倒序翻根评论，每条根评论带上最早的 replyLimit 条回复
+	List(ctx context.Context, biz string, bizId int64, minId int64, limit int, replyLimit int) ([]domain.Comment, error)
+	// Replies 按照时间正序翻某条根评论下面的回复
+	Replies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error)
+	Like(ctx context.Context, id int64, uid int64) error
+	CancelLike(ctx context.Context, id int64, uid int64) error
+}
+
+type commentService struct {
+	repo     repository.CommentRepository
+	artSvc   artv1.ArticleServiceClient
+	intrSvc  intrv1.InteractiveServiceClient
+	producer events.Producer
+	l        logger.LoggerV1
+}
+
+func NewCommentService(repo repository.CommentRepository, artSvc artv1.ArticleServiceClient,
+	intrSvc intrv1.InteractiveServiceClient, producer events.Producer, l logger.LoggerV1) CommentService {
+	return &commentService{repo: repo, artSvc: artSvc, intrSvc: intrSvc, producer: producer, l: l}
+}
+
+func (s *commentService) Create(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	c.Content = strings.TrimSpace(c.Content)
+	if c.Content == "" || utf8.RuneCountInString(c.Content) > MaxContentLength {
+		return domain.Comment{}, ErrInvalidComment
+	}
+	if c.ParentId > 0 {
+		parent, err := s.repo.FindById(ctx, c.ParentId)
+		if err != nil {
+			return domain.Comment{}, err
+		}
+		// 前端传了资源的话要和被回复的评论一致，没传就用被回复的评论的
+		if (c.Biz != "" && c.Biz != parent.Biz) || (c.BizId > 0 && c.BizId != parent.BizId) {
+			return domain.Comment{}, ErrInvalidComment
+		}
+		c.Biz, c.BizId = parent.Biz, parent.BizId
+		c.RootId = parent.Root()
+		c.ReplyToUid = parent.Uid
+	} else {
+		c.RootId, c.ReplyToUid = 0, 0
+	}
+	owner, err := s.bizOwner(ctx, c.Biz, c.BizId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	res, err := s.repo.Create(ctx, c)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	// 评论已经保存了，事件发不出去只记日志
+	err = s.producer.ProduceCommentEvent(ctx, events.CommentEvent{
+		Id:         res.Id,
+		Uid:        res.Uid,
+		Biz:        res.Biz,
+		BizId:      res.BizId,
+		RootId:     res.RootId,
+		ParentId:   res.ParentId,
+		ReplyToUid: res.ReplyToUid,
+		BizOwner:   owner,
+		Content:    res.Content,
+		Ctime:      res.Ctime.UnixMilli(),
+	})
+	if err != nil {
+		s.l.Error("发送评论事件失败", logger.Int64("cid", res.Id), logger.Error(err))
+	}
+	return res, nil
+}
+
+func (s *commentService) Delete(ctx context.Context, id int64, uid int64) error {
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		owner, err := s.bizOwner(ctx, c.Biz, c.BizId)
+		if errors.Is(err, ErrBizNotFound) {
+			// 文章撤回或者删除之后，只有评论的作者自己能删
+			return ErrPermissionDenied
+		}
+		if err != nil {
+			return err
+		}
+		if owner != uid {
+			return ErrPermissionDenied
+		}
+	}
+	ids, err := s.repo.Delete(ctx, c)
+	if err != nil {
+		return err
+	}
+	// 点赞记录清理失败不影响删除，最多留下一些没人看得到的计数
+	_, err = s.intrSvc.Delete(ctx, &intrv1.DeleteRequest{Biz: domain.BizComment, BizIds: ids})
+	if err != nil {
+		s.l.Error("清理评论的点赞失败", logger.Int64("cid", id), logger.Error(err))
+	}
+	return nil
+}
+
+func (s *commentService) List(ctx context.Context, biz string, bizId int64, minId int64, limit int, replyLimit int) ([]domain.Comment, error) {
+	limit = s.pageSize(limit)
+	if replyLimit <= 0 {
+		replyLimit = defaultReplyLimit
+	}
+	replyLimit = min(replyLimit, maxReplyLimit)
+	roots, err := s.repo.FindRoots(ctx, biz, bizId, minId, limit, replyLimit)
+	if err != nil {
+		return nil, err
+	}
+	s.fillLikes(ctx, roots)
+	return roots, nil
+}
+
+func (s *commentService) Replies(ctx context.Context, rootId int64, maxId int64, limit int) ([]domain.Comment, error) {
+	replies, err := s.repo.FindReplies(ctx, rootId, maxId, s.pageSize(limit))
+	if err != nil {
+		return nil, err
+	}
+	s.fillLikes(ctx, replies)
+	return replies, nil
+}
+
+func (s *commentService) Like(ctx context.Context, id int64, uid int64) error {
+	if _, err := s.repo.FindById(ctx, id); err != nil {
+		return err
+	}
+	_, err := s.intrSvc.Like(ctx, &intrv1.LikeRequest{Biz: domain.BizComment, BizId: id, Uid: uid})
+	return err
+}
+
+func (s *commentService) CancelLike(ctx context.Context, id int64, uid int64) error {
+	if _, err := s.repo.FindById(ctx, id); err != nil {
+		return err
+	}
+	_, err := s.intrSvc.CancelLike(ctx, &intrv1.CancelLikeRequest{Biz: domain.BizComment, BizId: id, Uid: uid})
+	return err
+}
+
+func (s *commentService) pageSize(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	return min(limit, maxPageSize)
+}
+
+// fillLikes 根评论和回复的点赞数一次查出来，互动服务出问题的时候降级成 0
+func (s *commentService) fillLikes(ctx context.Context, cs []domain.Comment) {
+	var ids []int64
+	for _, c := range cs {
+		ids = append(ids, c.Id)
+		for _, r := range c.Replies {
+			ids = append(ids, r.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	resp, err := s.intrSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{Biz: domain.BizComment, BizIds: ids})
+	if err != nil {
+		s.l.Error("查询评论的点赞数失败", logger.Error(err))
+		return
+	}
+	intrs := resp.GetIntrs()
+	for i := range cs {
+		cs[i].LikeCnt = intrs[cs[i].Id].GetLikeCnt()
+		for j := range cs[i].Replies {
+			cs[i].Replies[j].LikeCnt = intrs[cs[i].Replies[j].Id].GetLikeCnt()
+		}
+	}
+}
+
+// bizOwner 资源的作者，资源不存在或者还没有发表返回 ErrBizNotFound
+func (s *commentService) bizOwner(ctx context.Context, biz string, bizId int64) (int64, error) {
+	switch biz {
+	case domain.BizArticle:
+		// 看线上库，发表之后作者再编辑草稿，制作库的状态就不是已发表了
+		// SkipRead 不算一次阅读
+		resp, err := s.artSvc.GetPubById(ctx, &artv1.GetPubByIdRequest{Id: bizId, SkipRead: true})
+		if status.Code(err) == codes.NotFound {
+			return 0, ErrBizNotFound
+		}
+		if err != nil {
+			return 0, err
+		}
+		if artdomain.ArticleStatus(resp.GetArt().GetStatus()) != artdomain.ArticleStatusPublished {
+			return 0, ErrBizNotFound
+		}
+		return resp.GetArt().GetAuthor().GetId(), nil
+	default:
+		return 0, ErrInvalidComment
+	}
+}
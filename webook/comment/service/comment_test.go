@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	artv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1/mocks"
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
+	intrv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1/mocks"
+	"github.com/TengFeiyang01/webook/webook/comment/domain"
+	"github.com/TengFeiyang01/webook/webook/comment/events"
+	evtmocks "github.com/TengFeiyang01/webook/webook/comment/events/mocks"
+	repomocks "github.com/TengFeiyang01/webook/webook/comment/repository/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mocks struct {
+	repo     *repomocks.MockCommentRepository
+	art      *artv1mocks.MockArticleServiceClient
+	intr     *intrv1mocks.MockInteractiveServiceClient
+	producer *evtmocks.MockProducer
+}
+
+func newMocks(ctrl *gomock.Controller) mocks {
+	return mocks{
+		repo:     repomocks.NewMockCommentRepository(ctrl),
+		art:      artv1mocks.NewMockArticleServiceClient(ctrl),
+		intr:     intrv1mocks.NewMockInteractiveServiceClient(ctrl),
+		producer: evtmocks.NewMockProducer(ctrl),
+	}
+}
+
+// publishedArt 作者是 10 的已发表文章
+func (m mocks) publishedArt(id int64) {
+	m.art.EXPECT().GetPubById(gomock.Any(), &artv1.GetPubByIdRequest{Id: id, SkipRead: true}).
+		Return(&artv1.GetPubByIdResponse{Art: &artv1.Article{
+			Id:     id,
+			Status: 2,
+			Author: &artv1.Author{Id: 10},
+		}}, nil)
+}
+
+func TestCommentService_Create(t *testing.T) {
+	now := time.UnixMilli(1000)
+	testCases := []struct {
+		name  string
+		mock  func(m mocks)
+		input domain.Comment
+
+		want    domain.Comment
+		wantErr error
+	}{
+		{
+			name: "根评论",
+			mock: func(m mocks) {
+				m.publishedArt(1)
+				m.repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Uid: 2, Biz: "art", BizId: 1, Content: "你好",
+				}).Return(domain.Comment{Id: 5, Uid: 2, Biz: "art", BizId: 1, Content: "你好", Ctime: now}, nil)
+				m.producer.EXPECT().ProduceCommentEvent(gomock.Any(), events.CommentEvent{
+					Id: 5, Uid: 2, Biz: "art", BizId: 1, BizOwner: 10, Content: "你好", Ctime: 1000,
+				}).Return(nil)
+			},
+			input: domain.Comment{Uid: 2, Biz: "art", BizId: 1, Content: "  你好 "},
+			want:  domain.Comment{Id: 5, Uid: 2, Biz: "art", BizId: 1, Content: "你好", Ctime: now},
+		},
+		{
+			name: "回复的回复挂在根评论下面，事件发送失败不影响",
+			mock: func(m mocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(6)).
+					Return(domain.Comment{Id: 6, Uid: 3, Biz: "art", BizId: 1, RootId: 5, ParentId: 5}, nil)
+				m.publishedArt(1)
+				cmt := domain.Comment{Uid: 2, Biz: "art", BizId: 1, RootId: 5, ParentId: 6, ReplyToUid: 3, Content: "回复"}
+				res := cmt
+				res.Id = 7
+				m.repo.EXPECT().Create(gomock.Any(), cmt).Return(res, nil)
+				m.producer.EXPECT().ProduceCommentEvent(gomock.Any(), gomock.Any()).Return(errors.New("kafka 不可用"))
+			},
+			input: domain.Comment{Uid: 2, ParentId: 6, Content: "回复"},
+			want:  domain.Comment{Id: 7, Uid: 2, Biz: "art", BizId: 1, RootId: 5, ParentId: 6, ReplyToUid: 3, Content: "回复"},
+		},
+		{
+			name: "回复的资源和被回复的评论不一致",
+			mock: func(m mocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(6)).
+					Return(domain.Comment{Id: 6, Uid: 3, Biz: "art", BizId: 1}, nil)
+			},
+			input:   domain.Comment{Uid: 2, Biz: "art", BizId: 2, ParentId: 6, Content: "回复"},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name:    "内容为空",
+			mock:    func(m mocks) {},
+			input:   domain.Comment{Uid: 2, Biz: "art", BizId: 1, Content: "   "},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name:    "内容太长",
+			mock:    func(m mocks) {},
+			input:   domain.Comment{Uid: 2, Biz: "art", BizId: 1, Content: strings.Repeat("长", MaxContentLength+1)},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name:    "不支持的资源",
+			mock:    func(m mocks) {},
+			input:   domain.Comment{Uid: 2, Biz: "video", BizId: 1, Content: "你好"},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name: "文章不存在",
+			mock: func(m mocks) {
+				m.art.EXPECT().GetPubById(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "文章不存在"))
+			},
+			input:   domain.Comment{Uid: 2, Biz: "art", BizId: 1, Content: "你好"},
+			wantErr: ErrBizNotFound,
+		},
+		{
+			name: "文章还没有发表",
+			mock: func(m mocks) {
+				m.art.EXPECT().GetPubById(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "文章不存在"))
+			},
+			input:   domain.Comment{Uid: 2, Biz: "art", BizId: 1, Content: "你好"},
+			wantErr: ErrBizNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newMocks(ctrl)
+			tc.mock(m)
+			svc := NewCommentService(m.repo, m.art, m.intr, m.producer, logger.NewNopLogger())
+			res, err := svc.Create(context.Background(), tc.input)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	cmt := domain.Comment{Id: 5, Uid: 2, Biz: "art", BizId: 1}
+	testCases := []struct {
+		name string
+		mock func(m mocks)
+		uid  int64
+
+		wantErr error
+	}{
+		{
+			name: "评论的作者",
+			mock: func(m mocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(cmt, nil)
+				m.repo.EXPECT().Delete(gomock.Any(), cmt).Return([]int64{5, 6}, nil)
+				m.intr.EXPECT().Delete(gomock.Any(), &intrv1.DeleteRequest{Biz: "comment", BizIds: []int64{5, 6}}).
+					Return(&intrv1.DeleteResponse{}, nil)
+			},
+			uid: 2,
+		},
+		{
+			name: "文章的作者，清理点赞失败不影响",
+			mock: func(m mocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(cmt, nil)
+				m.publishedArt(1)
+				m.repo.EXPECT().Delete(gomock.Any(), cmt).Return([]int64{5}, nil)
+				m.intr.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("互动服务不可用"))
+			},
+			uid: 10,
+		},
+		{
+			name: "别人",
+			mock: func(m mocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(cmt, nil)
+				m.publishedArt(1)
+			},
+			uid:     3,
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "文章已经撤回",
+			mock: func(m mocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(cmt, nil)
+				m.art.EXPECT().GetPubById(gomock.Any(), gomock.Any()).
+					Return(&artv1.GetPubByIdResponse{Art: &artv1.Article{Id: 1, Status: 3, Author: &artv1.Author{Id: 10}}}, nil)
+			},
+			uid:     10,
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "评论不存在",
+			mock: func(m mocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(domain.Comment{}, ErrCommentNotFound)
+			},
+			uid:     2,
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newMocks(ctrl)
+			tc.mock(m)
+			svc := NewCommentService(m.repo, m.art, m.intr, m.producer, logger.NewNopLogger())
+			err := svc.Delete(context.Background(), 5, tc.uid)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestCommentService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newMocks(ctrl)
+	// 没有传分页参数用默认值
+	m.repo.EXPECT().FindRoots(gomock.Any(), "art", int64(1), int64(0), defaultPageSize, defaultReplyLimit).
+		Return([]domain.Comment{
+			{Id: 3, ReplyCnt: 1, Replies: []domain.Comment{{Id: 4, RootId: 3}}},
+			{Id: 1},
+		}, nil)
+	m.intr.EXPECT().GetByIds(gomock.Any(), &intrv1.GetByIdsRequest{Biz: "comment", BizIds: []int64{3, 4, 1}}).
+		Return(&intrv1.GetByIdsResponse{Intrs: map[int64]*intrv1.Interactive{
+			3: {LikeCnt: 2},
+			4: {LikeCnt: 1},
+		}}, nil)
+	svc := NewCommentService(m.repo, m.art, m.intr, m.producer, logger.NewNopLogger())
+	res, err := svc.List(context.Background(), "art", 1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Comment{
+		{Id: 3, ReplyCnt: 1, LikeCnt: 2, Replies: []domain.Comment{{Id: 4, RootId: 3, LikeCnt: 1}}},
+		{Id: 1},
+	}, res)
+
+	// 互动服务出问题的时候点赞数降级成 0
+	m.repo.EXPECT().FindReplies(gomock.Any(), int64(3), int64(4), maxPageSize).
+		Return([]domain.Comment{{Id: 5, RootId: 3}}, nil)
+	m.intr.EXPECT().GetByIds(gomock.Any(), gomock.Any()).Return(nil, errors.New("互动服务不可用"))
+	res, err = svc.Replies(context.Background(), 3, 4, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Comment{{Id: 5, RootId: 3}}, res)
+}
+
+func TestCommentService_Like(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newMocks(ctrl)
+	m.repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(domain.Comment{Id: 5}, nil)
+	m.intr.EXPECT().Like(gomock.Any(), &intrv1.LikeRequest{Biz: "comment", BizId: 5, Uid: 2}).
+		Return(&intrv1.LikeResponse{}, nil)
+	m.repo.EXPECT().FindById(gomock.Any(), int64(6)).Return(domain.Comment{}, ErrCommentNotFound)
+	svc := NewCommentService(m.repo, m.art, m.intr, m.producer, logger.NewNopLogger())
+
+	assert.NoError(t, svc.Like(context.Background(), 5, 2))
+	// 评论不存在的时候不会去点赞
+	assert.ErrorIs(t, svc.Like(context.Background(), 6, 2), ErrCommentNotFound)
+}
@@ -0,0 +1,38 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/TengFeiyang01/webook/webook/comment/events"
+	"github.com/TengFeiyang01/webook/webook/comment/grpc"
+	"github.com/TengFeiyang01/webook/webook/comment/ioc"
+	"github.com/TengFeiyang01/webook/webook/comment/repository"
+	"github.com/TengFeiyang01/webook/webook/comment/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/comment/service"
+	"github.com/google/wire"
+)
+
+var thirdPartySet = wire.NewSet(
+	ioc.InitDB,
+	ioc.InitLogger,
+	ioc.InitKafka,
+	ioc.NewSyncProducer,
+	ioc.InitArtGRPCClient,
+	ioc.InitIntrGRPCClient,
+)
+
+var commentSvcSet = wire.NewSet(
+	dao.NewGORMCommentDAO,
+	repository.NewCommentRepository,
+	service.NewCommentService,
+	events.NewKafkaProducer,
+)
+
+func InitAPP() *App {
+	wire.Build(commentSvcSet,
+		thirdPartySet,
+		grpc.NewCommentServiceServer,
+		ioc.NewGRPCxServer,
+		wire.Struct(new(App), "*"))
+	return new(App)
+}
@@ -0,0 +1,44 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/TengFeiyang01/webook/webook/comment/events"
+	"github.com/TengFeiyang01/webook/webook/comment/grpc"
+	"github.com/TengFeiyang01/webook/webook/comment/ioc"
+	"github.com/TengFeiyang01/webook/webook/comment/repository"
+	"github.com/TengFeiyang01/webook/webook/comment/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/comment/service"
+	"github.com/google/wire"
+)
+
+// Injectors from wire.go:
+
+func InitAPP() *App {
+	loggerV1 := ioc.InitLogger()
+	db := ioc.InitDB(loggerV1)
+	commentDAO := dao.NewGORMCommentDAO(db)
+	commentRepository := repository.NewCommentRepository(commentDAO)
+	articleServiceClient := ioc.InitArtGRPCClient()
+	interactiveServiceClient := ioc.InitIntrGRPCClient()
+	client := ioc.InitKafka()
+	syncProducer := ioc.NewSyncProducer(client)
+	producer := events.NewKafkaProducer(syncProducer)
+	commentService := service.NewCommentService(commentRepository, articleServiceClient, interactiveServiceClient, producer, loggerV1)
+	commentServiceServer := grpc.NewCommentServiceServer(commentService)
+	server := ioc.NewGRPCxServer(commentServiceServer)
+	app := &App{
+		server: server,
+	}
+	return app
+}
+
+// wire.go:
+
+var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.NewSyncProducer, ioc.InitArtGRPCClient, ioc.InitIntrGRPCClient)
+
+var commentSvcSet = wire.NewSet(dao.NewGORMCommentDAO, repository.NewCommentRepository, service.NewCommentService, events.NewKafkaProducer)
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// StatsGranularity 趋势统计的时间粒度
+type StatsGranularity uint8
+
+const (
+	StatsGranularityUnknown StatsGranularity = iota
+	StatsGranularityHour
+	StatsGranularityDay
+)
+
+func (g StatsGranularity) ToUint8() uint8 {
+	return uint8(g)
+}
+
+func (g StatsGranularity) Valid() bool {
+	return g == StatsGranularityHour || g == StatsGranularityDay
+}
+
+// Truncate t 所在的时间段的开始，天按照本地时区的零点切分
+func (g StatsGranularity) Truncate(t time.Time) time.Time {
+	if g == StatsGranularityDay {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(time.Hour)
+}
+
+// Next 下一个时间段的开始，t 必须是 Truncate 过的
+// 天不能直接加 24 小时，有夏令时的时区一天不一定是 24 小时
+func (g StatsGranularity) Next(t time.Time) time.Time {
+	if g == StatsGranularityDay {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(time.Hour)
+}
+
+// StatsDelta 一次阅读、点赞、取消点赞或者收藏带来的计数变化
+type StatsDelta struct {
+	Biz        string
+	BizId      int64
+	Time       time.Time
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+}
+
+// StatsPoint 一个时间段内的计数
+// 汇总多个资源的时候 BizId 为 0
+type StatsPoint struct {
+	BizId      int64
+	Time       time.Time
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+}
+
+// StatsOrder 排行榜按照哪个计数排序
+type StatsOrder uint8
+
+const (
+	StatsOrderRead StatsOrder = iota
+	StatsOrderLike
+	StatsOrderCollect
+)
+
+func (o StatsOrder) ToUint8() uint8 {
+	return uint8(o)
+}
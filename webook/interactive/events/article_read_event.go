@@ -1,7 +1,9 @@
 package events
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/IBM/sarama"
 	"golang.org/x/net/context"
 )
@@ -9,15 +11,25 @@ import (
 type Producer interface {
 	ProduceReadEvent(ctx context.Context, event ReadEvent) error
 	ProduceReadEventV1(ctx context.Context, event ReadEventV1) error
+	// ProduceInteractiveEvent 点赞、取消点赞和收藏，趋势统计会用到
+	ProduceInteractiveEvent(ctx context.Context, event InteractiveEvent) error
 }
 
 type KafkaProducer struct {
 	producer sarama.SyncProducer
 }
 
+// ProduceReadEventV1 和 ReadEvent 用同一个 topic，消费者两种格式都认识
 func (k *KafkaProducer) ProduceReadEventV1(ctx context.Context, event ReadEventV1) error {
-	//TODO implement me
-	panic("implement me")
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicReadEvent,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
 }
 
 // ProduceReadEvent 如果你有很复杂的重试逻辑, 就用装饰器
@@ -27,7 +39,7 @@ func (k *KafkaProducer) ProduceReadEvent(ctx context.Context, event ReadEvent) e
 		return err
 	}
 	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
-		Topic: "read_article",
+		Topic: TopicReadEvent,
 		Value: sarama.ByteEncoder(data),
 	})
 	return err
@@ -44,7 +56,51 @@ type ReadEvent struct {
 	Aid int64
 }
 
+const TopicReadEvent = "read_article"
+
+// ReadEventV1 批量的阅读事件，Uid 和 Aid 一一对应
 type ReadEventV1 struct {
 	Uid []int64
 	Aid []int64
 }
+
+// UnmarshalJSON 兼容期内同一个 topic 上面两种格式都有
+// 单条的 ReadEvent 当成只有一个元素的 ReadEventV1
+func (r *ReadEventV1) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Uid json.RawMessage
+		Aid json.RawMessage
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	uids, err := unmarshalIds(raw.Uid)
+	if err != nil {
+		return err
+	}
+	aids, err := unmarshalIds(raw.Aid)
+	if err != nil {
+		return err
+	}
+	if len(uids) != len(aids) {
+		return fmt.Errorf("阅读事件的 Uid 和 Aid 数量不一致 %d, %d", len(uids), len(aids))
+	}
+	r.Uid, r.Aid = uids, aids
+	return nil
+}
+
+// unmarshalIds 数组是 ReadEventV1，数字是 ReadEvent
+func unmarshalIds(data json.RawMessage) ([]int64, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if data[0] == '[' {
+		var ids []int64
+		err := json.Unmarshal(data, &ids)
+		return ids, err
+	}
+	var id int64
+	err := json.Unmarshal(data, &id)
+	return []int64{id}, err
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/TengFeiyang01/webook/webook/interactive/repository"
+	repomocks "github.com/TengFeiyang01/webook/webook/interactive/repository/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadEventV1_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+
+		wantEvt ReadEventV1
+		wantErr bool
+	}{
+		{
+			name:    "单条的 ReadEvent",
+			data:    `{"Uid":1,"Aid":12}`,
+			wantEvt: ReadEventV1{Uid: []int64{1}, Aid: []int64{12}},
+		},
+		{
+			name:    "批量的 ReadEventV1",
+			data:    `{"Uid":[1,2],"Aid":[12,13]}`,
+			wantEvt: ReadEventV1{Uid: []int64{1, 2}, Aid: []int64{12, 13}},
+		},
+		{
+			name: "空的",
+			data: `{}`,
+		},
+		{
+			name:    "数量不一致",
+			data:    `{"Uid":[1],"Aid":[12,13]}`,
+			wantErr: true,
+		},
+		{
+			name:    "格式不对",
+			data:    `{"Uid":"abc","Aid":12}`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var evt ReadEventV1
+			err := json.Unmarshal([]byte(tc.data), &evt)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantEvt, evt)
+		})
+	}
+
+	// 生产者发出来的能被解析回去
+	data, err := json.Marshal(ReadEventV1{Uid: []int64{1, 2}, Aid: []int64{12, 13}})
+	assert.NoError(t, err)
+	var evt ReadEventV1
+	assert.NoError(t, json.Unmarshal(data, &evt))
+	assert.Equal(t, []int64{12, 13}, evt.Aid)
+}
+
+func TestInteractiveEventConsumer_Consume(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+		evt  ReadEventV1
+
+		wantErr error
+	}{
+		{
+			name: "单条",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().IncrReadCnt(gomock.Any(), "art", int64(12)).Return(nil)
+				return repo
+			},
+			evt: ReadEventV1{Uid: []int64{1}, Aid: []int64{12}},
+		},
+		{
+			name: "批量",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().BatchIncrReadCnt(gomock.Any(), []string{"art", "art"}, []int64{12, 13}).
+					Return(errors.New("db 错误"))
+				return repo
+			},
+			evt:     ReadEventV1{Uid: []int64{1, 2}, Aid: []int64{12, 13}},
+			wantErr: errors.New("db 错误"),
+		},
+		{
+			name: "空的",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				return repomocks.NewMockInteractiveRepository(ctrl)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewInteractiveEventConsumer(nil, tc.mock(ctrl), logger.NewNopLogger())
+			err := c.Consume(&sarama.ConsumerMessage{}, tc.evt)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestInteractiveReadEventBatchConsumer_Consume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockInteractiveRepository(ctrl)
+	// 单条的和批量的混在一起，摊平之后一次更新
+	repo.EXPECT().BatchIncrReadCnt(gomock.Any(), []string{"art", "art", "art"}, []int64{11, 12, 13}).
+		Return(nil)
+	c := NewInteractiveReadEventBatchConsumer(nil, repo, logger.NewNopLogger())
+	err := c.Consume(make([]*sarama.ConsumerMessage, 2), []ReadEventV1{
+		{Uid: []int64{1}, Aid: []int64{11}},
+		{Uid: []int64{1, 2}, Aid: []int64{12, 13}},
+	})
+	assert.NoError(t, err)
+}
@@ -26,8 +26,8 @@ func (r *InteractiveReadEventBatchConsumer) Start() error {
 	}
 	go func() {
 		err := cg.Consume(context.Background(),
-			[]string{"read_article"},
-			saramax.NewBatchHandler[ReadEvent](r.l, r.Consume))
+			[]string{TopicReadEvent},
+			saramax.NewBatchHandler[ReadEventV1](r.l, r.Consume))
 		if err != nil {
 			r.l.Error("退出消费循环异常", logger.Error(err))
 		}
@@ -36,12 +36,18 @@ func (r *InteractiveReadEventBatchConsumer) Start() error {
 }
 
 // Consume 这个不是幂等的
-func (r *InteractiveReadEventBatchConsumer) Consume(message []*sarama.ConsumerMessage, ts []ReadEvent) error {
+// 单条的和批量的事件都会被摊平，一次性更新
+func (r *InteractiveReadEventBatchConsumer) Consume(message []*sarama.ConsumerMessage, ts []ReadEventV1) error {
 	ids := make([]int64, 0, len(ts))
 	bizs := make([]string, 0, len(ts))
 	for _, evt := range ts {
-		ids = append(ids, evt.Aid)
-		bizs = append(bizs, "art")
+		for _, aid := range evt.Aid {
+			ids = append(ids, aid)
+			bizs = append(bizs, "art")
+		}
+	}
+	if len(ids) == 0 {
+		return nil
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
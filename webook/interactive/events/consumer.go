@@ -31,8 +31,8 @@ func (r *InteractiveEventConsumer) Start() error {
 	}
 	go func() {
 		err := cg.Consume(context.Background(),
-			[]string{"read_article"},
-			saramax.NewHandler[ReadEvent](r.l, r.Consume))
+			[]string{TopicReadEvent},
+			saramax.NewHandler[ReadEventV1](r.l, r.Consume))
 		if err != nil {
 			r.l.Error("退出消费循环异常", logger.Error(err))
 		}
@@ -41,8 +41,20 @@ func (r *InteractiveEventConsumer) Start() error {
 }
 
 // Consume 这个不是幂等的
-func (r *InteractiveEventConsumer) Consume(message *sarama.ConsumerMessage, t ReadEvent) error {
+// 兼容期内也会收到批量的事件
+func (r *InteractiveEventConsumer) Consume(message *sarama.ConsumerMessage, t ReadEventV1) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return r.repo.IncrReadCnt(ctx, "art", t.Aid)
+	switch len(t.Aid) {
+	case 0:
+		return nil
+	case 1:
+		return r.repo.IncrReadCnt(ctx, "art", t.Aid[0])
+	default:
+		bizs := make([]string, len(t.Aid))
+		for i := range bizs {
+			bizs[i] = "art"
+		}
+		return r.repo.BatchIncrReadCnt(ctx, bizs, t.Aid)
+	}
 }
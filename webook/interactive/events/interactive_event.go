@@ -0,0 +1,39 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+const TopicInteractiveEvent = "interactive_event"
+
+const (
+	InteractiveActionLike       = "like"
+	InteractiveActionCancelLike = "cancel_like"
+	InteractiveActionCollect    = "collect"
+)
+
+// InteractiveEvent 点赞、取消点赞或者收藏成功之后发出来
+type InteractiveEvent struct {
+	Biz    string `json:"biz"`
+	BizId  int64  `json:"biz_id"`
+	Uid    int64  `json:"uid"`
+	Action string `json:"action"`
+}
+
+// ProduceInteractiveEvent 用资源 ID 做 key，同一个资源的点赞和取消点赞是有序的
+func (k *KafkaProducer) ProduceInteractiveEvent(ctx context.Context, event InteractiveEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicInteractiveEvent,
+		Key:   sarama.StringEncoder(event.Biz + ":" + strconv.FormatInt(event.BizId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
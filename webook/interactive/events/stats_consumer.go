@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+	"github.com/TengFeiyang01/webook/webook/interactive/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/saramax"
+)
+
+// InteractiveStatsConsumer 把阅读、点赞和收藏事件按照小时和天汇总，给创作者看趋势
+// 用的是自己的消费者组，和更新总数的消费者互不影响
+// 和总数一样，这个也不是幂等的，重复消费会多算
+type InteractiveStatsConsumer struct {
+	client sarama.Client
+	repo   repository.StatsRepository
+	l      logger.LoggerV1
+}
+
+func NewInteractiveStatsConsumer(client sarama.Client, repo repository.StatsRepository, l logger.LoggerV1) *InteractiveStatsConsumer {
+	return &InteractiveStatsConsumer{client: client, repo: repo, l: l}
+}
+
+func (c *InteractiveStatsConsumer) Start() error {
+	// 两个 topic 的消息格式不一样，分成两个消费者组
+	readCG, err := sarama.NewConsumerGroupFromClient("interactive_stats_read", c.client)
+	if err != nil {
+		return err
+	}
+	intrCG, err := sarama.NewConsumerGroupFromClient("interactive_stats", c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := readCG.Consume(context.Background(),
+			[]string{TopicReadEvent},
+			saramax.NewBatchHandler[ReadEventV1](c.l, c.ConsumeRead))
+		if err != nil {
+			c.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	go func() {
+		err := intrCG.Consume(context.Background(),
+			[]string{TopicInteractiveEvent},
+			saramax.NewBatchHandler[InteractiveEvent](c.l, c.ConsumeInteractive))
+		if err != nil {
+			c.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+// ConsumeRead 阅读事件里面没有时间，用消息写入 Kafka 的时间
+func (c *InteractiveStatsConsumer) ConsumeRead(msgs []*sarama.ConsumerMessage, ts []ReadEventV1) error {
+	deltas := make([]domain.StatsDelta, 0, len(ts))
+	for i, evt := range ts {
+		t := c.msgTime(msgs[i])
+		for _, aid := range evt.Aid {
+			deltas = append(deltas, domain.StatsDelta{Biz: "art", BizId: aid, Time: t, ReadCnt: 1})
+		}
+	}
+	return c.incr(deltas)
+}
+
+func (c *InteractiveStatsConsumer) ConsumeInteractive(msgs []*sarama.ConsumerMessage, ts []InteractiveEvent) error {
+	deltas := make([]domain.StatsDelta, 0, len(ts))
+	for i, evt := range ts {
+		d := domain.StatsDelta{Biz: evt.Biz, BizId: evt.BizId, Time: c.msgTime(msgs[i])}
+		switch evt.Action {
+		case InteractiveActionLike:
+			d.LikeCnt = 1
+		case InteractiveActionCancelLike:
+			d.LikeCnt = -1
+		case InteractiveActionCollect:
+			d.CollectCnt = 1
+		default:
+			c.l.Warn("未知的互动事件", logger.String("action", evt.Action))
+			continue
+		}
+		deltas = append(deltas, d)
+	}
+	return c.incr(deltas)
+}
+
+func (c *InteractiveStatsConsumer) incr(deltas []domain.StatsDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return c.repo.Incr(ctx, deltas)
+}
+
+// msgTime 老版本的 Kafka 消息里面没有时间，只能用消费的时间
+func (c *InteractiveStatsConsumer) msgTime(msg *sarama.ConsumerMessage) time.Time {
+	if msg.Timestamp.IsZero() || msg.Timestamp.Unix() <= 0 {
+		return time.Now()
+	}
+	return msg.Timestamp
+}
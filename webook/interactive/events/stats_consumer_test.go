@@ -0,0 +1,60 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+	repomocks "github.com/TengFeiyang01/webook/webook/interactive/repository/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInteractiveStatsConsumer_ConsumeRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	t1 := time.UnixMilli(1700000000000)
+	t2 := t1.Add(time.Hour)
+	repo := repomocks.NewMockStatsRepository(ctrl)
+	// 批量的阅读事件用的是同一条消息的时间
+	repo.EXPECT().Incr(gomock.Any(), []domain.StatsDelta{
+		{Biz: "art", BizId: 11, Time: t1, ReadCnt: 1},
+		{Biz: "art", BizId: 12, Time: t2, ReadCnt: 1},
+		{Biz: "art", BizId: 13, Time: t2, ReadCnt: 1},
+	}).Return(errors.New("db 错误"))
+	c := NewInteractiveStatsConsumer(nil, repo, logger.NewNopLogger())
+	err := c.ConsumeRead([]*sarama.ConsumerMessage{{Timestamp: t1}, {Timestamp: t2}}, []ReadEventV1{
+		{Uid: []int64{1}, Aid: []int64{11}},
+		{Uid: []int64{1, 2}, Aid: []int64{12, 13}},
+	})
+	assert.Equal(t, errors.New("db 错误"), err)
+}
+
+func TestInteractiveStatsConsumer_ConsumeInteractive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	t1 := time.UnixMilli(1700000000000)
+	repo := repomocks.NewMockStatsRepository(ctrl)
+	repo.EXPECT().Incr(gomock.Any(), []domain.StatsDelta{
+		{Biz: "art", BizId: 11, Time: t1, LikeCnt: 1},
+		{Biz: "art", BizId: 11, Time: t1, LikeCnt: -1},
+		{Biz: "art", BizId: 12, Time: t1, CollectCnt: 1},
+	}).Return(nil)
+	c := NewInteractiveStatsConsumer(nil, repo, logger.NewNopLogger())
+	msg := &sarama.ConsumerMessage{Timestamp: t1}
+	err := c.ConsumeInteractive([]*sarama.ConsumerMessage{msg, msg, msg, msg}, []InteractiveEvent{
+		{Biz: "art", BizId: 11, Uid: 1, Action: InteractiveActionLike},
+		{Biz: "art", BizId: 11, Uid: 1, Action: InteractiveActionCancelLike},
+		// 不认识的直接跳过
+		{Biz: "art", BizId: 12, Uid: 1, Action: "share"},
+		{Biz: "art", BizId: 12, Uid: 1, Action: InteractiveActionCollect},
+	})
+	assert.NoError(t, err)
+
+	// 全部都跳过的时候不会更新数据库
+	err = c.ConsumeInteractive([]*sarama.ConsumerMessage{msg}, []InteractiveEvent{{Action: "share"}})
+	assert.NoError(t, err)
+}
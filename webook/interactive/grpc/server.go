@@ -2,6 +2,8 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"time"
 	"github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
 	"github.com/TengFeiyang01/webook/webook/interactive/domain"
 	"github.com/TengFeiyang01/webook/webook/interactive/service"
@@ -74,6 +76,67 @@ func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1
 	}, nil
 }
 
+func (i *InteractiveServiceServer) Delete(ctx context.Context, request *intrv1.DeleteRequest) (*intrv1.DeleteResponse, error) {
+	err := i.svc.Delete(ctx, request.GetBiz(), request.GetBizIds())
+	return &intrv1.DeleteResponse{}, err
+}
+
+func (i *InteractiveServiceServer) GetStats(ctx context.Context, request *intrv1.GetStatsRequest) (*intrv1.GetStatsResponse, error) {
+	q := request.GetQuery()
+	points, err := i.svc.GetStats(ctx, q.GetBiz(), q.GetBizIds(), domain.StatsGranularity(q.GetGranularity()),
+		time.UnixMilli(q.GetStart()), time.UnixMilli(q.GetEnd()))
+	if err != nil {
+		return nil, i.statsErr(err)
+	}
+	return &intrv1.GetStatsResponse{Points: i.toPointDTOs(points)}, nil
+}
+
+func (i *InteractiveServiceServer) ListStats(ctx context.Context, request *intrv1.ListStatsRequest) (*intrv1.ListStatsResponse, error) {
+	q := request.GetQuery()
+	points, err := i.svc.ListStats(ctx, q.GetBiz(), q.GetBizIds(), domain.StatsGranularity(q.GetGranularity()),
+		time.UnixMilli(q.GetStart()), time.UnixMilli(q.GetEnd()))
+	if err != nil {
+		return nil, i.statsErr(err)
+	}
+	return &intrv1.ListStatsResponse{Points: i.toPointDTOs(points)}, nil
+}
+
+func (i *InteractiveServiceServer) TopStats(ctx context.Context, request *intrv1.TopStatsRequest) (*intrv1.TopStatsResponse, error) {
+	q := request.GetQuery()
+	intrs, err := i.svc.TopStats(ctx, q.GetBiz(), q.GetBizIds(), domain.StatsGranularity(q.GetGranularity()),
+		time.UnixMilli(q.GetStart()), time.UnixMilli(q.GetEnd()),
+		domain.StatsOrder(request.GetOrderBy()), int(request.GetLimit()))
+	if err != nil {
+		return nil, i.statsErr(err)
+	}
+	res := make([]*intrv1.Interactive, 0, len(intrs))
+	for _, intr := range intrs {
+		res = append(res, i.toDTO(intr))
+	}
+	return &intrv1.TopStatsResponse{Intrs: res}, nil
+}
+
+func (i *InteractiveServiceServer) statsErr(err error) error {
+	if errors.Is(err, service.ErrInvalidStatsQuery) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (i *InteractiveServiceServer) toPointDTOs(points []domain.StatsPoint) []*intrv1.StatsPoint {
+	res := make([]*intrv1.StatsPoint, 0, len(points))
+	for _, p := range points {
+		res = append(res, &intrv1.StatsPoint{
+			BizId:      p.BizId,
+			Time:       p.Time.UnixMilli(),
+			ReadCnt:    p.ReadCnt,
+			LikeCnt:    p.LikeCnt,
+			CollectCnt: p.CollectCnt,
+		})
+	}
+	return res
+}
+
 // DTO data transfer object
 func (i *InteractiveServiceServer) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
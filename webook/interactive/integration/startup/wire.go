@@ -4,6 +4,7 @@ package startup
 
 import (
 	"github.com/google/wire"
+	"github.com/TengFeiyang01/webook/webook/interactive/events"
 	"github.com/TengFeiyang01/webook/webook/interactive/grpc"
 	"github.com/TengFeiyang01/webook/webook/interactive/repository"
 	"github.com/TengFeiyang01/webook/webook/interactive/repository/cache"
@@ -22,11 +23,14 @@ var interactiveSvcSet = wire.NewSet(
 	service.NewInteractiveService,
 	cache.NewInteractiveRedisCache,
 	repository.NewCachedInteractiveRepository,
+	dao.NewGORMStatsDAO,
+	repository.NewStatsRepository,
+	events.NewKafkaProducer,
 )
 
 func InitInteractiveService() service.InteractiveService {
 	wire.Build(thirdPartySet, interactiveSvcSet)
-	return service.NewInteractiveService(nil)
+	return service.NewInteractiveService(nil, nil, nil, nil)
 }
 
 func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
@@ -7,12 +7,13 @@
 package startup
 
 import (
-	"github.com/google/wire"
+	"github.com/TengFeiyang01/webook/webook/interactive/events"
 	"github.com/TengFeiyang01/webook/webook/interactive/grpc"
 	"github.com/TengFeiyang01/webook/webook/interactive/repository"
 	"github.com/TengFeiyang01/webook/webook/interactive/repository/cache"
 	"github.com/TengFeiyang01/webook/webook/interactive/repository/dao"
 	"github.com/TengFeiyang01/webook/webook/interactive/service"
+	"github.com/google/wire"
 )
 
 // Injectors from wire.go:
@@ -24,7 +25,12 @@ func InitInteractiveService() service.InteractiveService {
 	cmdable := InitRedis()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, loggerV1, interactiveCache)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	statsDAO := dao.NewGORMStatsDAO(gormDB)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	client := InitKafka()
+	syncProducer := NewSyncProducer(client)
+	producer := events.NewKafkaProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, statsRepository, producer, loggerV1)
 	return interactiveService
 }
 
@@ -35,7 +41,12 @@ func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
 	cmdable := InitRedis()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, loggerV1, interactiveCache)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	statsDAO := dao.NewGORMStatsDAO(gormDB)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	client := InitKafka()
+	syncProducer := NewSyncProducer(client)
+	producer := events.NewKafkaProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, statsRepository, producer, loggerV1)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	return interactiveServiceServer
 }
@@ -48,4 +59,4 @@ var thirdPartySet = wire.NewSet(
 	InitKafka,
 )
 
-var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, service.NewInteractiveService, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository)
+var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, service.NewInteractiveService, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, dao.NewGORMStatsDAO, repository.NewStatsRepository, events.NewKafkaProducer)
@@ -25,7 +25,31 @@ func InitKafka() sarama.Client {
 	return client
 }
 
+func NewSyncProducer(client sarama.Client) sarama.SyncProducer {
+	res, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // NewConsumers 面临的问题依旧是所有的 Consumer 在这里注册一下
-func NewConsumers(c1 *events.InteractiveEventConsumer) []saramax.Consumer {
-	return []saramax.Consumer{c1}
+// 阅读事件的两个消费者用的是同一个消费者组，只能启动一个
+// 两个都能处理 ReadEvent 和 ReadEventV1，batch 模式下攒批更新数据库
+// 趋势统计用的是自己的消费者组，一直都会启动
+func NewConsumers(c1 *events.InteractiveEventConsumer,
+	c2 *events.InteractiveReadEventBatchConsumer,
+	stats *events.InteractiveStatsConsumer) []saramax.Consumer {
+	type Config struct {
+		// Mode 可选 single 和 batch
+		Mode string `yaml:"mode"`
+	}
+	cfg := Config{Mode: "single"}
+	if err := viper.UnmarshalKey("readEvent", &cfg); err != nil {
+		panic(err)
+	}
+	if cfg.Mode == "batch" {
+		return []saramax.Consumer{c2, stats}
+	}
+	return []saramax.Consumer{c1, stats}
 }
@@ -45,6 +45,8 @@ type InteractiveCache interface {
 	// 3. 借助定时任务，我每分钟计算一次
 	// 4. 定时计算，算 1000 名; 而后我借助 zset 来维护 1000 名的分数
 	LikeTop(ctx context.Context, biz string) ([]domain.Interactive, error)
+	// Del 删除计数缓存，同时从排行榜里面移除
+	Del(ctx context.Context, biz string, bizIds []int64) error
 }
 
 type InteractiveRedisCache struct {
@@ -164,6 +166,23 @@ func (i *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context,
 	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldReadCnt, 1).Err()
 }
 
+func (i *InteractiveRedisCache) Del(ctx context.Context, biz string, bizIds []int64) error {
+	if len(bizIds) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(bizIds))
+	members := make([]any, 0, len(bizIds))
+	for _, id := range bizIds {
+		keys = append(keys, i.key(biz, id))
+		members = append(members, id)
+	}
+	pipe := i.client.TxPipeline()
+	pipe.Del(ctx, keys...)
+	pipe.ZRem(ctx, i.rankingKey(biz), members...)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (i *InteractiveRedisCache) key(biz string, bizId int64) string {
 	return fmt.Sprintf("interactive:%s:%d", biz, bizId)
 }
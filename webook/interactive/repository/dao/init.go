@@ -9,5 +9,7 @@ func InitTables(db *gorm.DB) error {
 		&Interactive{},
 		&UserLikeBiz{},
 		&UserCollectionBiz{},
+		&InteractiveHourlyStats{},
+		&InteractiveDailyStats{},
 	)
 }
@@ -21,6 +21,8 @@ type InteractiveDAO interface {
 	Get(ctx context.Context, biz string, id int64) (Interactive, error)
 	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+	// Delete 删除计数以及所有的点赞、收藏记录
+	Delete(ctx context.Context, biz string, ids []int64) error
 }
 
 type GORMInteractiveDAO struct {
@@ -36,6 +38,18 @@ func (dao *GORMInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []i
 	return res, err
 }
 
+func (dao *GORMInteractiveDAO) Delete(ctx context.Context, biz string, ids []int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&UserLikeBiz{}, &UserCollectionBiz{}, &Interactive{}} {
+			err := tx.Where("biz = ? AND biz_id IN ?", biz, ids).Delete(model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (dao *GORMInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
 	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		txDAO := NewGORMInteractiveDAO(tx)
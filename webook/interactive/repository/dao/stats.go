@@ -0,0 +1,149 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// StatsGranularityHour 和 domain.StatsGranularity 的取值一致
+	StatsGranularityHour uint8 = 1
+	StatsGranularityDay  uint8 = 2
+)
+
+// statsOrderColumns 下标是 domain.StatsOrder
+var statsOrderColumns = []string{"read_cnt", "like_cnt", "collect_cnt"}
+
+type StatsDAO interface {
+	// Incr 一个批次的增量，小时表和天表在同一个事务里面累加
+	Incr(ctx context.Context, hourly []InteractiveHourlyStats, daily []InteractiveDailyStats) error
+	// Series ids 在 [start, end) 里面每个时间段的计数之和，按照时间升序，没有数据的时间段不返回
+	Series(ctx context.Context, granularity uint8, biz string, ids []int64, start, end int64) ([]StatsRow, error)
+	// List 每个资源每个时间段一行，按照时间、资源 ID 升序
+	List(ctx context.Context, granularity uint8, biz string, ids []int64, start, end int64, limit int) ([]StatsRow, error)
+	// Top ids 在 [start, end) 里面的计数之和，按照 order 对应的计数降序
+	Top(ctx context.Context, granularity uint8, biz string, ids []int64, start, end int64, order uint8, limit int) ([]StatsRow, error)
+}
+
+type GORMStatsDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMStatsDAO(db *gorm.DB) StatsDAO {
+	return &GORMStatsDAO{db: db}
+}
+
+func (dao *GORMStatsDAO) Incr(ctx context.Context, hourly []InteractiveHourlyStats, daily []InteractiveDailyStats) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, s := range hourly {
+			s.Ctime, s.Utime = now, now
+			if err := tx.Clauses(dao.incrClause(s.ReadCnt, s.LikeCnt, s.CollectCnt, now)).Create(&s).Error; err != nil {
+				return err
+			}
+		}
+		for _, s := range daily {
+			s.Ctime, s.Utime = now, now
+			if err := tx.Clauses(dao.incrClause(s.ReadCnt, s.LikeCnt, s.CollectCnt, now)).Create(&s).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dao *GORMStatsDAO) incrClause(readCnt, likeCnt, collectCnt int64, now int64) clause.OnConflict {
+	return clause.OnConflict{
+		Columns: []clause.Column{{Name: "biz_id"}, {Name: "biz"}, {Name: "bucket"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"read_cnt":    gorm.Expr("`read_cnt` + ?", readCnt),
+			"like_cnt":    gorm.Expr("`like_cnt` + ?", likeCnt),
+			"collect_cnt": gorm.Expr("`collect_cnt` + ?", collectCnt),
+			"utime":       now,
+		}),
+	}
+}
+
+func (dao *GORMStatsDAO) Series(ctx context.Context, granularity uint8, biz string, ids []int64, start, end int64) ([]StatsRow, error) {
+	var res []StatsRow
+	err := dao.query(ctx, granularity, biz, ids, start, end).
+		Select("bucket, SUM(read_cnt) AS read_cnt, SUM(like_cnt) AS like_cnt, SUM(collect_cnt) AS collect_cnt").
+		Group("bucket").Order("bucket").
+		Scan(&res).Error
+	return res, err
+}
+
+func (dao *GORMStatsDAO) List(ctx context.Context, granularity uint8, biz string, ids []int64, start, end int64, limit int) ([]StatsRow, error) {
+	var res []StatsRow
+	err := dao.query(ctx, granularity, biz, ids, start, end).
+		Select("biz_id, bucket, read_cnt, like_cnt, collect_cnt").
+		Order("bucket").Order("biz_id").Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
+
+func (dao *GORMStatsDAO) Top(ctx context.Context, granularity uint8, biz string, ids []int64, start, end int64, order uint8, limit int) ([]StatsRow, error) {
+	col := statsOrderColumns[0]
+	if int(order) < len(statsOrderColumns) {
+		col = statsOrderColumns[order]
+	}
+	var res []StatsRow
+	err := dao.query(ctx, granularity, biz, ids, start, end).
+		Select("biz_id, SUM(read_cnt) AS read_cnt, SUM(like_cnt) AS like_cnt, SUM(collect_cnt) AS collect_cnt").
+		Group("biz_id").
+		// 计数一样的时候 ID 大的在前面，也就是新的文章在前面
+		Order(col + " DESC").Order("biz_id DESC").Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
+
+func (dao *GORMStatsDAO) query(ctx context.Context, granularity uint8, biz string, ids []int64, start, end int64) *gorm.DB {
+	var model any = &InteractiveHourlyStats{}
+	if granularity == StatsGranularityDay {
+		model = &InteractiveDailyStats{}
+	}
+	return dao.db.WithContext(ctx).Model(model).
+		Where("biz = ? AND biz_id IN ?", biz, ids).
+		Where("bucket >= ? AND bucket < ?", start, end)
+}
+
+// StatsRow 查询的结果，汇总的时候 BizId 或者 Bucket 为 0
+type StatsRow struct {
+	BizId      int64
+	Bucket     int64
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+}
+
+// InteractiveHourlyStats 每个资源每个小时的计数，只记录有变化的小时
+// 只保存增量，取消点赞在那个小时记成负数
+type InteractiveHourlyStats struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	BizId int64  `gorm:"uniqueIndex:biz_type_id_hour"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_hour"`
+	// Bucket 这个小时开始的毫秒时间戳
+	Bucket     int64 `gorm:"uniqueIndex:biz_type_id_hour;index"`
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+	Utime      int64
+	Ctime      int64
+}
+
+// InteractiveDailyStats 和小时表一样，时间跨度大的时候查这张表
+type InteractiveDailyStats struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	BizId int64  `gorm:"uniqueIndex:biz_type_id_day"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_day"`
+	// Bucket 这一天本地时间零点的毫秒时间戳
+	Bucket     int64 `gorm:"uniqueIndex:biz_type_id_day;index"`
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+	Utime      int64
+	Ctime      int64
+}
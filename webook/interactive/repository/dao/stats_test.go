@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGORMStatsDAO(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// 内存数据库每个连接都是独立的
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+	// SQLite 的索引名字是全局的，点赞和收藏表的索引名会冲突，只建需要的表
+	require.NoError(t, db.AutoMigrate(&InteractiveHourlyStats{}, &InteractiveDailyStats{}))
+	dao := NewGORMStatsDAO(db)
+	ctx := context.Background()
+
+	const hour = int64(3600 * 1000)
+	require.NoError(t, dao.Incr(ctx, []InteractiveHourlyStats{
+		{Biz: "art", BizId: 1, Bucket: 0, ReadCnt: 3, LikeCnt: 1},
+		{Biz: "art", BizId: 1, Bucket: hour, ReadCnt: 1},
+		{Biz: "art", BizId: 2, Bucket: hour, ReadCnt: 2, CollectCnt: 1},
+		{Biz: "comment", BizId: 1, Bucket: hour, ReadCnt: 100},
+	}, []InteractiveDailyStats{
+		{Biz: "art", BizId: 1, Bucket: 0, ReadCnt: 4, LikeCnt: 1},
+	}))
+	// 同一个小时再来一批，累加；取消点赞是负数
+	require.NoError(t, dao.Incr(ctx, []InteractiveHourlyStats{
+		{Biz: "art", BizId: 1, Bucket: hour, ReadCnt: 1, LikeCnt: -1},
+	}, []InteractiveDailyStats{
+		{Biz: "art", BizId: 1, Bucket: 0, ReadCnt: 1, LikeCnt: -1},
+	}))
+
+	series, err := dao.Series(ctx, StatsGranularityHour, "art", []int64{1, 2}, 0, 2*hour)
+	require.NoError(t, err)
+	assert.Equal(t, []StatsRow{
+		{Bucket: 0, ReadCnt: 3, LikeCnt: 1},
+		{Bucket: hour, ReadCnt: 4, LikeCnt: -1, CollectCnt: 1},
+	}, series)
+
+	// 结束时间是开区间
+	series, err = dao.Series(ctx, StatsGranularityHour, "art", []int64{1, 2}, 0, hour)
+	require.NoError(t, err)
+	assert.Len(t, series, 1)
+
+	series, err = dao.Series(ctx, StatsGranularityDay, "art", []int64{1}, 0, 24*hour)
+	require.NoError(t, err)
+	assert.Equal(t, []StatsRow{{Bucket: 0, ReadCnt: 5}}, series)
+
+	rows, err := dao.List(ctx, StatsGranularityHour, "art", []int64{1, 2}, 0, 2*hour, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []StatsRow{
+		{BizId: 1, Bucket: 0, ReadCnt: 3, LikeCnt: 1},
+		{BizId: 1, Bucket: hour, ReadCnt: 2, LikeCnt: -1},
+		{BizId: 2, Bucket: hour, ReadCnt: 2, CollectCnt: 1},
+	}, rows)
+
+	top, err := dao.Top(ctx, StatsGranularityHour, "art", []int64{1, 2}, 0, 2*hour, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []StatsRow{
+		{BizId: 1, ReadCnt: 5},
+		{BizId: 2, ReadCnt: 2, CollectCnt: 1},
+	}, top)
+	// 按照收藏数排序
+	top, err = dao.Top(ctx, StatsGranularityHour, "art", []int64{1, 2}, 0, 2*hour, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []StatsRow{{BizId: 2, ReadCnt: 2, CollectCnt: 1}}, top)
+}
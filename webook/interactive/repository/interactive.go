@@ -20,6 +20,7 @@ type InteractiveRepository interface {
 	Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	Delete(ctx context.Context, biz string, ids []int64) error
 }
 
 type CachedInteractiveRepository struct {
@@ -38,6 +39,19 @@ func (c *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string,
 	}), nil
 }
 
+func (c *CachedInteractiveRepository) Delete(ctx context.Context, biz string, ids []int64) error {
+	err := c.dao.Delete(ctx, biz, ids)
+	if err != nil {
+		return err
+	}
+	// 缓存删除失败，最多就是计数在缓存里面多留一会
+	err = c.cache.Del(ctx, biz, ids)
+	if err != nil {
+		c.l.Error("删除计数缓存失败", logger.String("biz", biz), logger.Error(err))
+	}
+	return nil
+}
+
 func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context,
 	bizs []string, bizIds []int64) error {
 	// 要不要检测 bizs 和 ids 的长度是否相等
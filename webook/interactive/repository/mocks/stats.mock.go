@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./stats.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./stats.go -destination=./mocks/stats.mock.go -package=repomocks StatsRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/TengFeiyang01/webook/webook/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Incr mocks base method.
+func (m *MockStatsRepository) Incr(ctx context.Context, deltas []domain.StatsDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockStatsRepositoryMockRecorder) Incr(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockStatsRepository)(nil).Incr), ctx, deltas)
+}
+
+// List mocks base method.
+func (m *MockStatsRepository) List(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time, limit int) ([]domain.StatsPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, granularity, biz, ids, start, end, limit)
+	ret0, _ := ret[0].([]domain.StatsPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockStatsRepositoryMockRecorder) List(ctx, granularity, biz, ids, start, end, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStatsRepository)(nil).List), ctx, granularity, biz, ids, start, end, limit)
+}
+
+// Series mocks base method.
+func (m *MockStatsRepository) Series(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time) ([]domain.StatsPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Series", ctx, granularity, biz, ids, start, end)
+	ret0, _ := ret[0].([]domain.StatsPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Series indicates an expected call of Series.
+func (mr *MockStatsRepositoryMockRecorder) Series(ctx, granularity, biz, ids, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Series", reflect.TypeOf((*MockStatsRepository)(nil).Series), ctx, granularity, biz, ids, start, end)
+}
+
+// Top mocks base method.
+func (m *MockStatsRepository) Top(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time, order domain.StatsOrder, limit int) ([]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Top", ctx, granularity, biz, ids, start, end, order, limit)
+	ret0, _ := ret[0].([]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Top indicates an expected call of Top.
+func (mr *MockStatsRepositoryMockRecorder) Top(ctx, granularity, biz, ids, start, end, order, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Top", reflect.TypeOf((*MockStatsRepository)(nil).Top), ctx, granularity, biz, ids, start, end, order, limit)
+}
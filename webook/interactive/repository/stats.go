@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+	"github.com/TengFeiyang01/webook/webook/interactive/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+type StatsRepository interface {
+	// Incr 按照小时和天汇总之后累加，同一个时间段的增量会合并成一次更新
+	Incr(ctx context.Context, deltas []domain.StatsDelta) error
+	// Series [start, end) 里面 ids 的计数之和，没有数据的时间段不返回
+	Series(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time) ([]domain.StatsPoint, error)
+	List(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time, limit int) ([]domain.StatsPoint, error)
+	// Top 返回的是 [start, end) 里面的计数，不是总数
+	Top(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time, order domain.StatsOrder, limit int) ([]domain.Interactive, error)
+}
+
+type statsRepository struct {
+	dao dao.StatsDAO
+}
+
+func NewStatsRepository(dao dao.StatsDAO) StatsRepository {
+	return &statsRepository{dao: dao}
+}
+
+type statsKey struct {
+	biz    string
+	bizId  int64
+	bucket int64
+}
+
+func (r *statsRepository) Incr(ctx context.Context, deltas []domain.StatsDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	daily := r.merge(deltas, domain.StatsGranularityDay)
+	return r.dao.Incr(ctx, r.merge(deltas, domain.StatsGranularityHour),
+		// 两张表的结构是一样的
+		slice.Map(daily, func(idx int, src dao.InteractiveHourlyStats) dao.InteractiveDailyStats {
+			return dao.InteractiveDailyStats(src)
+		}))
+}
+
+// merge 同一个资源同一个时间段的增量合并，顺序和第一次出现的顺序一致
+func (r *statsRepository) merge(deltas []domain.StatsDelta, granularity domain.StatsGranularity) []dao.InteractiveHourlyStats {
+	idx := make(map[statsKey]int, len(deltas))
+	res := make([]dao.InteractiveHourlyStats, 0, len(deltas))
+	for _, d := range deltas {
+		key := statsKey{biz: d.Biz, bizId: d.BizId, bucket: granularity.Truncate(d.Time).UnixMilli()}
+		i, ok := idx[key]
+		if !ok {
+			i = len(res)
+			idx[key] = i
+			res = append(res, dao.InteractiveHourlyStats{Biz: d.Biz, BizId: d.BizId, Bucket: key.bucket})
+		}
+		res[i].ReadCnt += d.ReadCnt
+		res[i].LikeCnt += d.LikeCnt
+		res[i].CollectCnt += d.CollectCnt
+	}
+	return res
+}
+
+func (r *statsRepository) Series(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time) ([]domain.StatsPoint, error) {
+	rows, err := r.dao.Series(ctx, granularity.ToUint8(), biz, ids, start.UnixMilli(), end.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rows, func(idx int, src dao.StatsRow) domain.StatsPoint {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *statsRepository) List(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time, limit int) ([]domain.StatsPoint, error) {
+	rows, err := r.dao.List(ctx, granularity.ToUint8(), biz, ids, start.UnixMilli(), end.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rows, func(idx int, src dao.StatsRow) domain.StatsPoint {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *statsRepository) Top(ctx context.Context, granularity domain.StatsGranularity, biz string, ids []int64, start, end time.Time, order domain.StatsOrder, limit int) ([]domain.Interactive, error) {
+	rows, err := r.dao.Top(ctx, granularity.ToUint8(), biz, ids, start.UnixMilli(), end.UnixMilli(), order.ToUint8(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rows, func(idx int, src dao.StatsRow) domain.Interactive {
+		return domain.Interactive{
+			Biz:        biz,
+			BizId:      src.BizId,
+			ReadCnt:    src.ReadCnt,
+			LikeCnt:    src.LikeCnt,
+			CollectCnt: src.CollectCnt,
+		}
+	}), nil
+}
+
+func (r *statsRepository) toDomain(row dao.StatsRow) domain.StatsPoint {
+	return domain.StatsPoint{
+		BizId:      row.BizId,
+		Time:       time.UnixMilli(row.Bucket),
+		ReadCnt:    row.ReadCnt,
+		LikeCnt:    row.LikeCnt,
+		CollectCnt: row.CollectCnt,
+	}
+}
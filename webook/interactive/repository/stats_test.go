@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+	"github.com/TengFeiyang01/webook/webook/interactive/repository/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsRepository_merge(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	// 本地时间 23 点多和第二天 0 点多，UTC 是同一天
+	t1 := time.Date(2024, 5, 1, 23, 10, 0, 0, loc)
+	t2 := time.Date(2024, 5, 1, 23, 50, 0, 0, loc)
+	t3 := time.Date(2024, 5, 2, 0, 5, 0, 0, loc)
+	deltas := []domain.StatsDelta{
+		{Biz: "art", BizId: 1, Time: t1, ReadCnt: 1},
+		{Biz: "art", BizId: 2, Time: t1, LikeCnt: 1},
+		{Biz: "art", BizId: 1, Time: t2, ReadCnt: 1, LikeCnt: -1},
+		{Biz: "art", BizId: 1, Time: t3, CollectCnt: 1},
+	}
+	r := &statsRepository{}
+	hour := time.Date(2024, 5, 1, 23, 0, 0, 0, loc).UnixMilli()
+	assert.Equal(t, []dao.InteractiveHourlyStats{
+		{Biz: "art", BizId: 1, Bucket: hour, ReadCnt: 2, LikeCnt: -1},
+		{Biz: "art", BizId: 2, Bucket: hour, LikeCnt: 1},
+		{Biz: "art", BizId: 1, Bucket: t3.Truncate(time.Hour).UnixMilli(), CollectCnt: 1},
+	}, r.merge(deltas, domain.StatsGranularityHour))
+
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, loc).UnixMilli()
+	assert.Equal(t, []dao.InteractiveHourlyStats{
+		{Biz: "art", BizId: 1, Bucket: day, ReadCnt: 2, LikeCnt: -1},
+		{Biz: "art", BizId: 2, Bucket: day, LikeCnt: 1},
+		{Biz: "art", BizId: 1, Bucket: time.Date(2024, 5, 2, 0, 0, 0, 0, loc).UnixMilli(), CollectCnt: 1},
+	}, r.merge(deltas, domain.StatsGranularityDay))
+}
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"golang.org/x/sync/errgroup"
+	"time"
 	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+	"github.com/TengFeiyang01/webook/webook/interactive/events"
 	"github.com/TengFeiyang01/webook/webook/interactive/repository"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
 )
 
 //go:generate mockgen -source=./interactive.go -package=svcmocks -destination=./mocks/interactive.mock.go InteractiveService
@@ -15,10 +18,21 @@ type InteractiveService interface {
 	Collect(ctx context.Context, biz string, bizId, cid, uid int64) error
 	Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error)
 	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+	// Delete 资源被彻底删除之后，清理它的计数以及点赞、收藏记录
+	Delete(ctx context.Context, biz string, bizIds []int64) error
+	// GetStats bizIds 在 [start, end) 里面每个时间段的计数之和，没有数据的时间段补 0
+	GetStats(ctx context.Context, biz string, bizIds []int64, granularity domain.StatsGranularity, start, end time.Time) ([]domain.StatsPoint, error)
+	// ListStats 每个资源每个时间段一条，只返回有数据的，用来导出
+	ListStats(ctx context.Context, biz string, bizIds []int64, granularity domain.StatsGranularity, start, end time.Time) ([]domain.StatsPoint, error)
+	// TopStats bizIds 里面 [start, end) 期间计数最多的
+	TopStats(ctx context.Context, biz string, bizIds []int64, granularity domain.StatsGranularity, start, end time.Time, order domain.StatsOrder, limit int) ([]domain.Interactive, error)
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
+	repo      repository.InteractiveRepository
+	statsRepo repository.StatsRepository
+	producer  events.Producer
+	l         logger.LoggerV1
 }
 
 func (i *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
@@ -33,6 +47,13 @@ func (i *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []
 	return res, nil
 }
 
+func (i *interactiveService) Delete(ctx context.Context, biz string, bizIds []int64) error {
+	if len(bizIds) == 0 {
+		return nil
+	}
+	return i.repo.Delete(ctx, biz, bizIds)
+}
+
 func (i *interactiveService) Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error) {
 	intr, err := i.repo.Get(ctx, biz, id)
 	if err != nil {
@@ -54,19 +75,52 @@ func (i *interactiveService) Get(ctx context.Context, biz string, id int64, uid
 }
 
 func (i *interactiveService) Collect(ctx context.Context, biz string, bizId, cid, uid int64) error {
-	return i.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	err := i.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	if err != nil {
+		return err
+	}
+	i.produceEvent(ctx, biz, bizId, uid, events.InteractiveActionCollect)
+	return nil
 }
 
 func (i *interactiveService) Like(c context.Context, biz string, id int64, uid int64) error {
-	return i.repo.IncrLike(c, biz, id, uid)
+	err := i.repo.IncrLike(c, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	i.produceEvent(c, biz, id, uid, events.InteractiveActionLike)
+	return nil
 }
 
 func (i *interactiveService) CancelLike(c context.Context, biz string, id int64, uid int64) error {
-	return i.repo.DecrLike(c, biz, id, uid)
+	err := i.repo.DecrLike(c, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	i.produceEvent(c, biz, id, uid, events.InteractiveActionCancelLike)
+	return nil
+}
+
+// produceEvent 计数已经更新成功了，发送失败只是趋势统计少算一次，只记录日志
+func (i *interactiveService) produceEvent(ctx context.Context, biz string, bizId int64, uid int64, action string) {
+	err := i.producer.ProduceInteractiveEvent(ctx, events.InteractiveEvent{
+		Biz:    biz,
+		BizId:  bizId,
+		Uid:    uid,
+		Action: action,
+	})
+	if err != nil {
+		i.l.Error("发送互动事件失败",
+			logger.String("biz", biz),
+			logger.Int64("biz_id", bizId),
+			logger.String("action", action),
+			logger.Error(err))
+	}
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository) InteractiveService {
-	return &interactiveService{repo: repo}
+func NewInteractiveService(repo repository.InteractiveRepository, statsRepo repository.StatsRepository,
+	producer events.Producer, l logger.LoggerV1) InteractiveService {
+	return &interactiveService{repo: repo, statsRepo: statsRepo, producer: producer, l: l}
 }
 
 func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+)
+
+// ErrInvalidStatsQuery 粒度、时间范围或者资源数量不对
+var ErrInvalidStatsQuery = errors.New("趋势统计的参数不对")
+
+const (
+	// maxStatsBizIds 一次最多统计多少个资源
+	maxStatsBizIds = 1000
+	// maxStatsRows 导出的时候最多返回多少行
+	maxStatsRows = 10000
+	// maxStatsTop 排行榜最多多少个
+	maxStatsTop = 100
+)
+
+// maxStatsSpan 按小时最多查一个月，按天最多查一年
+var maxStatsSpan = map[domain.StatsGranularity]time.Duration{
+	domain.StatsGranularityHour: 31 * 24 * time.Hour,
+	domain.StatsGranularityDay:  366 * 24 * time.Hour,
+}
+
+func (i *interactiveService) GetStats(ctx context.Context, biz string, bizIds []int64,
+	granularity domain.StatsGranularity, start, end time.Time) ([]domain.StatsPoint, error) {
+	start, err := i.checkStatsQuery(bizIds, granularity, start, end)
+	if err != nil {
+		return nil, err
+	}
+	var points []domain.StatsPoint
+	if len(bizIds) > 0 {
+		points, err = i.statsRepo.Series(ctx, granularity, biz, bizIds, start, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 补齐没有数据的时间段，前端画图不用再处理
+	m := make(map[int64]domain.StatsPoint, len(points))
+	for _, p := range points {
+		m[p.Time.UnixMilli()] = p
+	}
+	res := make([]domain.StatsPoint, 0, len(points))
+	for t := start; t.Before(end); t = granularity.Next(t) {
+		p, ok := m[t.UnixMilli()]
+		if !ok {
+			p = domain.StatsPoint{Time: t}
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+func (i *interactiveService) ListStats(ctx context.Context, biz string, bizIds []int64,
+	granularity domain.StatsGranularity, start, end time.Time) ([]domain.StatsPoint, error) {
+	start, err := i.checkStatsQuery(bizIds, granularity, start, end)
+	if err != nil || len(bizIds) == 0 {
+		return nil, err
+	}
+	return i.statsRepo.List(ctx, granularity, biz, bizIds, start, end, maxStatsRows)
+}
+
+func (i *interactiveService) TopStats(ctx context.Context, biz string, bizIds []int64,
+	granularity domain.StatsGranularity, start, end time.Time, order domain.StatsOrder, limit int) ([]domain.Interactive, error) {
+	start, err := i.checkStatsQuery(bizIds, granularity, start, end)
+	if err != nil || len(bizIds) == 0 {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxStatsTop {
+		limit = maxStatsTop
+	}
+	return i.statsRepo.Top(ctx, granularity, biz, bizIds, start, end, order, limit)
+}
+
+// checkStatsQuery 返回对齐到时间段开始的 start
+func (i *interactiveService) checkStatsQuery(bizIds []int64, granularity domain.StatsGranularity,
+	start, end time.Time) (time.Time, error) {
+	if !granularity.Valid() || len(bizIds) > maxStatsBizIds {
+		return start, ErrInvalidStatsQuery
+	}
+	start = granularity.Truncate(start)
+	if !start.Before(end) || end.Sub(start) > maxStatsSpan[granularity] {
+		return start, ErrInvalidStatsQuery
+	}
+	return start, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+	repomocks "github.com/TengFeiyang01/webook/webook/interactive/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInteractiveService_GetStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	loc := time.FixedZone("CST", 8*3600)
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, loc)
+	repo := repomocks.NewMockStatsRepository(ctrl)
+	// start 对齐到当天零点
+	repo.EXPECT().Series(gomock.Any(), domain.StatsGranularityDay, "art", []int64{1, 2},
+		day, day.AddDate(0, 0, 3)).
+		Return([]domain.StatsPoint{{Time: day.AddDate(0, 0, 1), ReadCnt: 3}}, nil)
+	svc := &interactiveService{statsRepo: repo}
+
+	points, err := svc.GetStats(context.Background(), "art", []int64{1, 2}, domain.StatsGranularityDay,
+		day.Add(time.Hour*10), day.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	// 没有数据的时间段补 0
+	assert.Equal(t, []domain.StatsPoint{
+		{Time: day},
+		{Time: day.AddDate(0, 0, 1), ReadCnt: 3},
+		{Time: day.AddDate(0, 0, 2)},
+	}, points)
+}
+
+func TestInteractiveService_checkStatsQuery(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name        string
+		ids         []int64
+		granularity domain.StatsGranularity
+		start       time.Time
+		end         time.Time
+
+		wantErr error
+	}{
+		{
+			name:        "按小时",
+			ids:         []int64{1},
+			granularity: domain.StatsGranularityHour,
+			start:       now.Add(-time.Hour * 24),
+			end:         now,
+		},
+		{
+			name:        "粒度不对",
+			ids:         []int64{1},
+			granularity: domain.StatsGranularityUnknown,
+			start:       now.Add(-time.Hour),
+			end:         now,
+			wantErr:     ErrInvalidStatsQuery,
+		},
+		{
+			name:        "结束时间在开始之前",
+			ids:         []int64{1},
+			granularity: domain.StatsGranularityHour,
+			start:       now,
+			end:         now.Add(-time.Hour * 2),
+			wantErr:     ErrInvalidStatsQuery,
+		},
+		{
+			name:        "按小时超过一个月",
+			ids:         []int64{1},
+			granularity: domain.StatsGranularityHour,
+			start:       now.AddDate(0, 0, -40),
+			end:         now,
+			wantErr:     ErrInvalidStatsQuery,
+		},
+		{
+			name:        "资源太多",
+			ids:         make([]int64, maxStatsBizIds+1),
+			granularity: domain.StatsGranularityDay,
+			start:       now.AddDate(0, 0, -7),
+			end:         now,
+			wantErr:     ErrInvalidStatsQuery,
+		},
+	}
+	svc := &interactiveService{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.checkStatsQuery(tc.ids, tc.granularity, tc.start, tc.end)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
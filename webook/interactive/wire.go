@@ -17,6 +17,7 @@ var thirdPartySet = wire.NewSet(
 	ioc.InitDB,
 	ioc.InitLogger,
 	ioc.InitKafka,
+	ioc.NewSyncProducer,
 	ioc.InitRedis,
 )
 
@@ -25,6 +26,9 @@ var interactiveSvcSet = wire.NewSet(
 	service.NewInteractiveService,
 	cache.NewInteractiveRedisCache,
 	repository.NewCachedInteractiveRepository,
+	dao.NewGORMStatsDAO,
+	repository.NewStatsRepository,
+	events.NewKafkaProducer,
 )
 
 func InitAPP() *App {
@@ -32,6 +36,8 @@ func InitAPP() *App {
 		thirdPartySet,
 		grpc.NewInteractiveServiceServer,
 		events.NewInteractiveEventConsumer,
+		events.NewInteractiveReadEventBatchConsumer,
+		events.NewInteractiveStatsConsumer,
 		ioc.NewConsumers,
 		ioc.NewGRPCxServer,
 		wire.Struct(new(App), "*"))
@@ -26,12 +26,18 @@ func InitAPP() *App {
 	cmdable := ioc.InitRedis()
 	interactiveCache := cache.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, loggerV1, interactiveCache)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	statsDAO := dao.NewGORMStatsDAO(db)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	client := ioc.InitKafka()
+	syncProducer := ioc.NewSyncProducer(client)
+	producer := events.NewKafkaProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, statsRepository, producer, loggerV1)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	server := ioc.NewGRPCxServer(interactiveServiceServer)
-	client := ioc.InitKafka()
 	interactiveEventConsumer := events.NewInteractiveEventConsumer(client, interactiveRepository, loggerV1)
-	v := ioc.NewConsumers(interactiveEventConsumer)
+	interactiveReadEventBatchConsumer := events.NewInteractiveReadEventBatchConsumer(client, interactiveRepository, loggerV1)
+	interactiveStatsConsumer := events.NewInteractiveStatsConsumer(client, statsRepository, loggerV1)
+	v := ioc.NewConsumers(interactiveEventConsumer, interactiveReadEventBatchConsumer, interactiveStatsConsumer)
 	app := &App{
 		server:    server,
 		consumers: v,
@@ -41,6 +47,6 @@ func InitAPP() *App {
 
 // wire.go:
 
-var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.InitRedis)
+var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.NewSyncProducer, ioc.InitRedis)
 
-var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, service.NewInteractiveService, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository)
+var interactiveSvcSet = wire.NewSet(dao.NewGORMInteractiveDAO, service.NewInteractiveService, cache.NewInteractiveRedisCache, repository.NewCachedInteractiveRepository, dao.NewGORMStatsDAO, repository.NewStatsRepository, events.NewKafkaProducer)
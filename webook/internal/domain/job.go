@@ -13,12 +13,25 @@ type Job struct {
 	Cfg        string
 	Cron       string
 	CancelFunc func() error
+
+	// Retries 之前已经连续失败了几次
+	Retries int
+	// Version 抢占之后的版本号，执行完之后修改任务都要带上
+	// 执行期间任务被重新安排了，版本号就对不上了，不会把新安排的任务改掉
+	Version int
 }
 
 var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 
+// NextTime 没有 cron 表达式的是一次性任务，返回零值，表示不需要再调度
 func (j Job) NextTime() time.Time {
+	if j.Cron == "" {
+		return time.Time{}
+	}
 	// 你怎么算
-	s, _ := parser.Parse(j.Cron)
+	s, err := parser.Parse(j.Cron)
+	if err != nil {
+		return time.Time{}
+	}
 	return s.Next(time.Now())
 }
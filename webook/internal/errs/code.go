@@ -8,12 +8,20 @@ const (
 	UserInvalidOrPassword = 401002
 	// UserDuplicateEmail 用户邮箱冲突
 	UserDuplicateEmail = 401003
+	// UserSensitiveContent 昵称或者关于我里面有违禁词
+	UserSensitiveContent = 401004
 	// UserInternalServerError 统一的用户模块的系统错误
 	UserInternalServerError = 501001
 )
 
 const (
 	// ArticleInvalidInput 文章模块的统一的错误码
-	ArticleInvalidInput        = 402001
+	ArticleInvalidInput = 402001
+	// ArticleVersionConflict 保存的时候版本号对不上，文章已经在别处被修改
+	ArticleVersionConflict = 402002
+	// ArticleSensitiveContent 标题或者内容里面有违禁词
+	ArticleSensitiveContent = 402003
+	// ArticleTooManyTags 标签数量超过了上限
+	ArticleTooManyTags         = 402004
 	ArticleInternalServerError = 502001
 )
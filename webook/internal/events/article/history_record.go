@@ -2,7 +2,7 @@ package article
 
 import (
 	"github.com/IBM/sarama"
-	"github.com/TengFeiyang01/webook/webook/article/events"
+	"github.com/TengFeiyang01/webook/webook/interactive/events"
 	"github.com/TengFeiyang01/webook/webook/internal/domain"
 	"github.com/TengFeiyang01/webook/webook/internal/repository"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
@@ -28,8 +28,8 @@ func (r *HistoryRecordConsumer) Start() error {
 	}
 	go func() {
 		err := cg.Consume(context.Background(),
-			[]string{"read_article"},
-			saramax.NewHandler[events.ReadEvent](r.l, r.Consume))
+			[]string{events.TopicReadEvent},
+			saramax.NewHandler[events.ReadEventV1](r.l, r.Consume))
 		if err != nil {
 			r.l.Error("退出消费循环异常", logger.Error(err))
 		}
@@ -38,13 +38,20 @@ func (r *HistoryRecordConsumer) Start() error {
 }
 
 // Consume 这个不是幂等的
+// ReadEventV1 兼容单条的格式，批量的事件一条条记录
 func (r *HistoryRecordConsumer) Consume(msg *sarama.ConsumerMessage,
-	event events.ReadEvent) error {
+	event events.ReadEventV1) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return r.repo.AddRecord(ctx, domain.HistoryRecord{
-		BizId: event.Aid,
-		Biz:   "art",
-		Uid:   event.Uid,
-	})
+	for i, aid := range event.Aid {
+		err := r.repo.AddRecord(ctx, domain.HistoryRecord{
+			BizId: aid,
+			Biz:   "art",
+			Uid:   event.Uid[i],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
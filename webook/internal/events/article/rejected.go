@@ -0,0 +1,89 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/TengFeiyang01/webook/webook/internal/service"
+	"github.com/TengFeiyang01/webook/webook/internal/service/sms"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/saramax"
+)
+
+const (
+	// TopicArticleRejected 和 article 模块 outbox 里面的 topic 保持一致
+	TopicArticleRejected = "article_rejected"
+	// maxSMSArgLength 短信模板的参数有长度限制，标题和原因太长的要截断
+	maxSMSArgLength = 20
+)
+
+// RejectedEvent 和 article 模块写进 outbox 的事件保持一致
+type RejectedEvent struct {
+	Id       int64  `json:"id"`
+	AuthorId int64  `json:"author_id"`
+	Title    string `json:"title"`
+	Reviewer int64  `json:"reviewer"`
+	Reason   string `json:"reason"`
+	Utime    int64  `json:"utime"`
+}
+
+// ArticleRejectedConsumer 审核没有通过的时候发短信通知作者
+type ArticleRejectedConsumer struct {
+	client  sarama.Client
+	userSvc service.UserService
+	smsSvc  sms.Service
+	// tplId 审核不通过的短信模板，参数是文章标题和原因
+	tplId string
+	l     logger.LoggerV1
+}
+
+func NewArticleRejectedConsumer(client sarama.Client, userSvc service.UserService,
+	smsSvc sms.Service, tplId string, l logger.LoggerV1) *ArticleRejectedConsumer {
+	return &ArticleRejectedConsumer{client: client, userSvc: userSvc, smsSvc: smsSvc, tplId: tplId, l: l}
+}
+
+func (r *ArticleRejectedConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("article_rejected_notifier", r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{TopicArticleRejected},
+			saramax.NewHandler[RejectedEvent](r.l, r.Consume))
+		if err != nil {
+			r.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Consume 这个不是幂等的，重复投递的时候作者会收到两条短信
+// 作者不存在或者没有绑定手机号的话没法通知，跳过
+func (r *ArticleRejectedConsumer) Consume(msg *sarama.ConsumerMessage, evt RejectedEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	u, err := r.userSvc.Profile(ctx, evt.AuthorId)
+	if errors.Is(err, service.ErrUserNotFound) {
+		r.l.Warn("审核不通过的文章找不到作者", logger.Int64("aid", evt.Id), logger.Int64("uid", evt.AuthorId))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if u.Phone == "" {
+		r.l.Info("作者没有绑定手机号，不发送审核结果", logger.Int64("aid", evt.Id), logger.Int64("uid", evt.AuthorId))
+		return nil
+	}
+	return r.smsSvc.Send(ctx, r.tplId, []string{truncate(evt.Title), truncate(evt.Reason)}, u.Phone)
+}
+
+func truncate(s string) string {
+	rs := []rune(s)
+	if len(rs) <= maxSMSArgLength {
+		return s
+	}
+	return string(rs[:maxSMSArgLength-1]) + "…"
+}
@@ -0,0 +1,59 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/TengFeiyang01/webook/webook/internal/job"
+	"github.com/TengFeiyang01/webook/webook/internal/service"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/saramax"
+)
+
+// TopicArticleScheduled 和 article 模块 outbox 里面的 topic 保持一致
+const TopicArticleScheduled = "article_scheduled"
+
+// ScheduledEvent 和 article 模块写进 outbox 的 ArticleEvent 保持一致，这里只用到这几个字段
+type ScheduledEvent struct {
+	Id        int64 `json:"id"`
+	AuthorId  int64 `json:"author_id"`
+	PublishAt int64 `json:"publish_at"`
+}
+
+// ArticleScheduledConsumer 文章改成定时发表之后，安排发表的任务
+// 事件和定时状态在文章服务的同一个事务里面提交，所以不会出现文章是定时状态但是没有任务的情况
+type ArticleScheduledConsumer struct {
+	client sarama.Client
+	jobSvc service.JobService
+	l      logger.LoggerV1
+}
+
+func NewArticleScheduledConsumer(client sarama.Client, jobSvc service.JobService,
+	l logger.LoggerV1) *ArticleScheduledConsumer {
+	return &ArticleScheduledConsumer{client: client, jobSvc: jobSvc, l: l}
+}
+
+func (s *ArticleScheduledConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("article_publish_scheduler", s.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{TopicArticleScheduled},
+			saramax.NewHandler[ScheduledEvent](s.l, s.Consume))
+		if err != nil {
+			s.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Consume 同名的任务会被覆盖，所以重复投递也没关系
+// 作者后来取消了定时或者改了时间，任务执行的时候会对照文章现在的状态，过期的任务直接跳过
+func (s *ArticleScheduledConsumer) Consume(msg *sarama.ConsumerMessage, evt ScheduledEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return s.jobSvc.Schedule(ctx, job.NewArticlePublishJob(evt.Id, evt.AuthorId), time.UnixMilli(evt.PublishAt))
+}
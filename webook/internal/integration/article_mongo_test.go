@@ -33,7 +33,9 @@ func (s *ArticleMongoDBHandlerSuite) SetupSuite() {
 	s.liveCol = s.mdb.Collection("published_articles")
 	node, err := snowflake.NewNode(1)
 	assert.NoError(s.T(), err)
-	hdl := startup.InitArticleHandler(dao.NewMongoDBArticleDAO(s.mdb, node))
+	db := startup.InitDB()
+	hdl := startup.InitArticleHandler(dao.NewMongoDBArticleDAO(s.mdb, node,
+		dao.NewGORMTagDAO(db), dao.NewGORMArticleRevisionDAO(db)))
 	server := gin.Default()
 	server.Use(func(ctx *gin.Context) {
 		ctx.Set("user", ijwt.UserClaims{
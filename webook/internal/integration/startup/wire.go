@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 	article2 "github.com/TengFeiyang01/webook/webook/article/events"
+	artioc "github.com/TengFeiyang01/webook/webook/article/ioc"
 	repository2 "github.com/TengFeiyang01/webook/webook/article/repository"
 	cache2 "github.com/TengFeiyang01/webook/webook/article/repository/cache"
 	artdao "github.com/TengFeiyang01/webook/webook/article/repository/dao"
@@ -33,17 +34,40 @@ var userSvcProvider = wire.NewSet(
 
 var articlSvcProvider = wire.NewSet(
 	repository2.NewCachedArticleRepository,
+	repository2.NewArticleRevisionRepository,
+	repository2.NewCachedArticleTagRepository,
+	repository2.NewCachedSeriesRepository,
 	artdao.NewGORMArticleDAO,
+	artdao.NewGORMArticleRevisionDAO,
+	artdao.NewGORMTagDAO,
+	artdao.NewGORMSeriesDAO,
+	artdao.NewGORMAttachmentDAO,
+	artdao.NewGORMModerationDAO,
+	artdao.NewGORMExportDAO,
+	artdao.NewGORMFingerprintDAO,
+	artioc.InitAttachmentRepository,
+	artioc.InitExportRepository,
+	repository2.NewModerationRepository,
+	repository2.NewFingerprintRepository,
+	artioc.InitModeration,
+	artioc.InitSensitiveFilter,
 	service2.NewArticleService)
 
+var jobSvcProvider = wire.NewSet(
+	dao.NewGORMJobDAO,
+	repository.NewPreemptCronJobRepository,
+	service.NewCronJobService)
+
 func InitWebServer() *gin.Engine {
 	wire.Build(
 		thirdPartySet,
 		userSvcProvider,
 		articlSvcProvider,
+		jobSvcProvider,
 
 		cache.NewRedisCodeCache,
 		cache2.NewArticleCache,
+		cache2.NewSeriesCache,
 
 		article2.NewKafkaProducer,
 
@@ -54,11 +78,22 @@ func InitWebServer() *gin.Engine {
 		InitWechatService,
 
 		web.NewArticleHandler,
+		web.NewSearchHandler,
+		web.NewArticleShareHandler,
+		web.NewAttachmentHandler,
+		web.NewArchiveHandler,
+		web.NewArticleStatsHandler,
+		ioc.InitModerationHandler,
+		ioc.InitFeedHandler,
+		ioc.InitSearchGRPCClient,
+		ioc.InitCommentGRPCClient,
+		web.NewCommentHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewUserHandler,
 
 		InitWechatHandlerConfig,
 		ijwt.NewRedisJWT,
+		ioc.InitShareHandler,
 		ioc.InitGinMiddlewares,
 		ioc.InitWebServer,
 	)
@@ -49,9 +49,21 @@ func InitWebServer() *gin.Engine {
 	client := InitKafka()
 	syncProducer := ioc.NewSyncProducer(client)
 	producer := article3.NewKafkaProducer(syncProducer)
-	articleService := service2.NewArticleService(articleRepository, producer, loggerV1)
-	articleHandler := web.NewArticleHandler(articleService, loggerV1)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	articleRevisionDAO := dao2.NewGORMArticleRevisionDAO(gormDB)
+	articleRevisionRepository := article2.NewArticleRevisionRepository(articleRevisionDAO)
+	tagDAO := dao2.NewGORMTagDAO(gormDB)
+	articleTagRepository := article2.NewCachedArticleTagRepository(tagDAO, articleCache, loggerV1)
+	seriesDAO := dao2.NewGORMSeriesDAO(gormDB)
+	seriesCache := cache2.NewSeriesCache(cmdable)
+	seriesRepository := article2.NewCachedSeriesRepository(seriesDAO, seriesCache, loggerV1)
+	articleService := service2.NewArticleService(articleRepository, articleRevisionRepository, articleTagRepository, seriesRepository, producer, loggerV1)
+	jobDAO := dao.NewGORMJobDAO(gormDB)
+	jobRepository := repository.NewPreemptCronJobRepository(jobDAO)
+	jobService := service.NewCronJobService(jobRepository, loggerV1)
+	articleHandler := web.NewArticleHandler(articleService, loggerV1, jobService)
+	searchServiceClient := ioc.InitSearchGRPCClient()
+	searchHandler := web.NewSearchHandler(searchServiceClient, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, searchHandler)
 	return engine
 }
 
@@ -61,4 +73,6 @@ var thirdPartySet = wire.NewSet(ioc.InitRedis, InitDB, InitLogger, ioc.NewSyncPr
 
 var userSvcProvider = wire.NewSet(dao.NewUserDAO, cache.NewRedisUserCache, repository.NewUserRepository, service.NewUserService)
 
-var articlSvcProvider = wire.NewSet(article2.NewCachedArticleRepository, dao2.NewGORMArticleDAO, service2.NewArticleService)
+var articlSvcProvider = wire.NewSet(article2.NewCachedArticleRepository, article2.NewArticleRevisionRepository, article2.NewCachedArticleTagRepository, article2.NewCachedSeriesRepository, dao2.NewGORMArticleDAO, dao2.NewGORMArticleRevisionDAO, dao2.NewGORMTagDAO, dao2.NewGORMSeriesDAO, service2.NewArticleService)
+
+var jobSvcProvider = wire.NewSet(dao.NewGORMJobDAO, repository.NewPreemptCronJobRepository, service.NewCronJobService)
@@ -0,0 +1,105 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/internal/domain"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+)
+
+// ArticlePublishExecutor 执行定时发表文章的任务
+type ArticlePublishExecutor struct {
+	svc artv1.ArticleServiceClient
+	l   logger.LoggerV1
+}
+
+func NewArticlePublishExecutor(svc artv1.ArticleServiceClient, l logger.LoggerV1) *ArticlePublishExecutor {
+	return &ArticlePublishExecutor{svc: svc, l: l}
+}
+
+type articlePublishCfg struct {
+	Aid int64 `json:"aid"`
+	Uid int64 `json:"uid"`
+}
+
+// NewArticlePublishJob 构造定时发表的任务，同一篇文章只会有一个任务
+// 没有 cron 表达式，执行一次之后就会停止，失败了会退避重试
+func NewArticlePublishJob(aid int64, uid int64) domain.Job {
+	cfg, _ := json.Marshal(articlePublishCfg{Aid: aid, Uid: uid})
+	return domain.Job{
+		Name:     ArticlePublishJobName(aid),
+		Executor: (&ArticlePublishExecutor{}).Name(),
+		Cfg:      string(cfg),
+	}
+}
+
+func ArticlePublishJobName(aid int64) string {
+	return fmt.Sprintf("article_publish_%d", aid)
+}
+
+func (a *ArticlePublishExecutor) Name() string {
+	return "article_publish"
+}
+
+func (a *ArticlePublishExecutor) Exec(ctx context.Context, j domain.Job) error {
+	var cfg articlePublishCfg
+	err := json.Unmarshal([]byte(j.Cfg), &cfg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	resp, err := a.svc.GetById(ctx, &artv1.GetByIdRequest{Id: cfg.Aid})
+	if err != nil {
+		return err
+	}
+	art := resp.GetArt()
+	// 作者可能已经取消了定时，或者提前手动发表了，或者改了时间但是任务还没更新
+	// 这些情况都直接跳过
+	if art.GetStatus() != uint32(artv1.ArticleStatus_ARTICLE_STATUS_SCHEDULED) ||
+		art.GetAuthor().GetId() != cfg.Uid ||
+		time.UnixMilli(art.GetPublishAt()).After(time.Now()) {
+		a.l.Info("跳过定时发表",
+			logger.Int64("aid", cfg.Aid),
+			logger.Int64("status", int64(art.GetStatus())))
+		return nil
+	}
+	_, err = a.svc.Publish(ctx, &artv1.PublishRequest{
+		Art: &artv1.Article{
+			Id:        art.GetId(),
+			Title:     art.GetTitle(),
+			Content:   art.GetContent(),
+			PublishAt: art.GetPublishAt(),
+			// 发表会整体覆盖标签，所以要带上原来的
+			Tags: art.GetTags(),
+			Author: &artv1.Author{
+				Id: cfg.Uid,
+			},
+		},
+	})
+	return err
+}
+
+// GiveUp 重试次数用完还是发表不了，就取消定时，文章回到草稿，作者能看到并且自己处理
+// 不然文章会一直停在定时发表的状态，但是再也不会被发表
+func (a *ArticlePublishExecutor) GiveUp(ctx context.Context, j domain.Job, cause error) {
+	var cfg articlePublishCfg
+	err := json.Unmarshal([]byte(j.Cfg), &cfg)
+	if err != nil {
+		a.l.Error("定时发表任务配置错误", logger.Error(err), logger.String("cfg", j.Cfg))
+		return
+	}
+	_, err = a.svc.CancelSchedule(ctx, &artv1.CancelScheduleRequest{Id: cfg.Aid, Uid: cfg.Uid})
+	if err != nil {
+		a.l.Error("定时发表失败，退回草稿也失败了", logger.Error(err),
+			logger.Int64("aid", cfg.Aid))
+		return
+	}
+	a.l.Warn("定时发表失败，文章退回草稿", logger.Error(cause),
+		logger.Int64("aid", cfg.Aid),
+		logger.Int64("uid", cfg.Uid))
+}
@@ -0,0 +1,178 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	artv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1/mocks"
+	"github.com/TengFeiyang01/webook/webook/internal/domain"
+	"github.com/TengFeiyang01/webook/webook/internal/service"
+	svcmocks "github.com/TengFeiyang01/webook/webook/internal/service/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArticlePublishExecutor_Exec(t *testing.T) {
+	past := time.Now().Add(-time.Minute).UnixMilli()
+	scheduled := uint32(artv1.ArticleStatus_ARTICLE_STATUS_SCHEDULED)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) artv1.ArticleServiceClient
+
+		wantErr error
+	}{
+		{
+			name: "到时间了，带上原来的标签发表",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), &artv1.GetByIdRequest{Id: 1}).
+					Return(&artv1.GetByIdResponse{Art: &artv1.Article{
+						Id: 1, Title: "标题", Content: "内容", Status: scheduled, PublishAt: past,
+						Tags: []string{"Go"}, Author: &artv1.Author{Id: 123},
+					}}, nil)
+				svc.EXPECT().Publish(gomock.Any(), &artv1.PublishRequest{Art: &artv1.Article{
+					Id: 1, Title: "标题", Content: "内容", PublishAt: past,
+					Tags: []string{"Go"}, Author: &artv1.Author{Id: 123},
+				}}).Return(&artv1.PublishResponse{Id: 1}, nil)
+				return svc
+			},
+		},
+		{
+			name: "已经取消了定时，跳过",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(&artv1.GetByIdResponse{Art: &artv1.Article{
+						Id: 1, Status: uint32(artv1.ArticleStatus_ARTICLE_STATUS_UNPUBLISHED),
+						Author: &artv1.Author{Id: 123},
+					}}, nil)
+				return svc
+			},
+		},
+		{
+			name: "不是同一个作者，跳过",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(&artv1.GetByIdResponse{Art: &artv1.Article{
+						Id: 1, Status: scheduled, PublishAt: past, Author: &artv1.Author{Id: 456},
+					}}, nil)
+				return svc
+			},
+		},
+		{
+			name: "作者把时间改晚了，跳过",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(&artv1.GetByIdResponse{Art: &artv1.Article{
+						Id: 1, Status: scheduled, PublishAt: time.Now().Add(time.Hour).UnixMilli(),
+						Author: &artv1.Author{Id: 123},
+					}}, nil)
+				return svc
+			},
+		},
+		{
+			name: "查询文章失败",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return svc
+			},
+			wantErr: errors.New("mock error"),
+		},
+		{
+			name: "发表失败",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(&artv1.GetByIdResponse{Art: &artv1.Article{
+						Id: 1, Status: scheduled, PublishAt: past, Author: &artv1.Author{Id: 123},
+					}}, nil)
+				svc.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return svc
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			exec := NewArticlePublishExecutor(tc.mock(ctrl), logger.NewNopLogger())
+			err := exec.Exec(context.Background(), NewArticlePublishJob(1, 123))
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+// TestSchedule_Retry 只有重试次数用完才交给执行器兜底，任务被重新安排了就什么也不做
+func TestSchedule_Retry(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (service.JobService, artv1.ArticleServiceClient)
+	}{
+		{
+			name: "还能重试",
+			mock: func(ctrl *gomock.Controller) (service.JobService, artv1.ArticleServiceClient) {
+				jobSvc := svcmocks.NewMockJobService(ctrl)
+				jobSvc.EXPECT().Retry(gomock.Any(), gomock.Any()).Return(nil)
+				return jobSvc, artv1mocks.NewMockArticleServiceClient(ctrl)
+			},
+		},
+		{
+			name: "重试次数用完，文章退回草稿",
+			mock: func(ctrl *gomock.Controller) (service.JobService, artv1.ArticleServiceClient) {
+				jobSvc := svcmocks.NewMockJobService(ctrl)
+				jobSvc.EXPECT().Retry(gomock.Any(), gomock.Any()).Return(service.ErrJobRetriesExhausted)
+				artSvc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				artSvc.EXPECT().CancelSchedule(gomock.Any(), &artv1.CancelScheduleRequest{Id: 1, Uid: 123}).
+					Return(&artv1.CancelScheduleResponse{}, nil)
+				return jobSvc, artSvc
+			},
+		},
+		{
+			name: "任务已经被重新安排，不能退回草稿",
+			mock: func(ctrl *gomock.Controller) (service.JobService, artv1.ArticleServiceClient) {
+				jobSvc := svcmocks.NewMockJobService(ctrl)
+				jobSvc.EXPECT().Retry(gomock.Any(), gomock.Any()).Return(service.ErrJobRescheduled)
+				return jobSvc, artv1mocks.NewMockArticleServiceClient(ctrl)
+			},
+		},
+		{
+			name: "安排重试失败",
+			mock: func(ctrl *gomock.Controller) (service.JobService, artv1.ArticleServiceClient) {
+				jobSvc := svcmocks.NewMockJobService(ctrl)
+				jobSvc.EXPECT().Retry(gomock.Any(), gomock.Any()).Return(errors.New("mock error"))
+				return jobSvc, artv1mocks.NewMockArticleServiceClient(ctrl)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			jobSvc, artSvc := tc.mock(ctrl)
+			s := NewSchedule(jobSvc, logger.NewNopLogger())
+			exec := NewArticlePublishExecutor(artSvc, logger.NewNopLogger())
+			j := NewArticlePublishJob(1, 123)
+			j.Retries = 5
+			s.retry(exec, j, errors.New("发表失败"))
+		})
+	}
+}
+
+func TestArticlePublishExecutor_GiveUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	artSvc := artv1mocks.NewMockArticleServiceClient(ctrl)
+	// 配置错了没法知道是哪篇文章，什么也不做
+	exec := NewArticlePublishExecutor(artSvc, logger.NewNopLogger())
+	exec.GiveUp(context.Background(), domain.Job{Cfg: "{"}, errors.New("mock error"))
+}
@@ -0,0 +1,92 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
+	"github.com/TengFeiyang01/webook/webook/internal/domain"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+)
+
+// ArticlePurgeExecutor 彻底删除在回收站里面放了太久的文章，连同它们的互动数据
+type ArticlePurgeExecutor struct {
+	artSvc  artv1.ArticleServiceClient
+	intrSvc intrv1.InteractiveServiceClient
+	l       logger.LoggerV1
+	// batchSize 每一批删除的数量
+	batchSize int
+}
+
+func NewArticlePurgeExecutor(artSvc artv1.ArticleServiceClient,
+	intrSvc intrv1.InteractiveServiceClient, l logger.LoggerV1) *ArticlePurgeExecutor {
+	return &ArticlePurgeExecutor{artSvc: artSvc, intrSvc: intrSvc, l: l, batchSize: 100}
+}
+
+type articlePurgeCfg struct {
+	// Retention 在回收站里面保留多久，单位是毫秒
+	Retention int64 `json:"retention"`
+}
+
+// NewArticlePurgeJob 构造清理回收站的任务，全局只有一个，按照 cron 周期执行
+func NewArticlePurgeJob(cron string, retention time.Duration) domain.Job {
+	cfg, _ := json.Marshal(articlePurgeCfg{Retention: retention.Milliseconds()})
+	return domain.Job{
+		Name:     "article_purge",
+		Executor: (&ArticlePurgeExecutor{}).Name(),
+		Cfg:      string(cfg),
+		Cron:     cron,
+	}
+}
+
+func (a *ArticlePurgeExecutor) Name() string {
+	return "article_purge"
+}
+
+func (a *ArticlePurgeExecutor) Exec(ctx context.Context, j domain.Job) error {
+	var cfg articlePurgeCfg
+	err := json.Unmarshal([]byte(j.Cfg), &cfg)
+	if err != nil {
+		return err
+	}
+	before := time.Now().Add(-time.Duration(cfg.Retention) * time.Millisecond)
+	for {
+		cnt, err := a.purge(ctx, before)
+		if err != nil {
+			return err
+		}
+		// 最后一批了，或者有删除失败的，剩下的等下一次调度
+		if cnt < a.batchSize {
+			return nil
+		}
+	}
+}
+
+func (a *ArticlePurgeExecutor) purge(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	resp, err := a.artSvc.PurgeExpired(ctx, &artv1.PurgeExpiredRequest{
+		Before: before.UnixMilli(),
+		Limit:  int32(a.batchSize),
+	})
+	if err != nil {
+		return 0, err
+	}
+	ids := resp.GetIds()
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	// 文章已经删掉了，互动数据删除失败只会留下一些没人访问的数据
+	_, err = a.intrSvc.Delete(ctx, &intrv1.DeleteRequest{
+		Biz:    "art",
+		BizIds: ids,
+	})
+	if err != nil {
+		a.l.Error("删除文章的互动数据失败",
+			logger.Int64("cnt", int64(len(ids))),
+			logger.Error(err))
+	}
+	return len(ids), nil
+}
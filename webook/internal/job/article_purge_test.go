@@ -0,0 +1,84 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	artv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1/mocks"
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
+	intrv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArticlePurgeExecutor_Exec(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient)
+
+		wantErr error
+	}{
+		{
+			name: "删满一批就接着删",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient) {
+				artSvc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artSvc.EXPECT().PurgeExpired(gomock.Any(), gomock.Any()).
+					Return(&artv1.PurgeExpiredResponse{Ids: []int64{1, 2}}, nil)
+				intrSvc.EXPECT().Delete(gomock.Any(), &intrv1.DeleteRequest{Biz: "art", BizIds: []int64{1, 2}}).
+					Return(&intrv1.DeleteResponse{}, nil)
+				artSvc.EXPECT().PurgeExpired(gomock.Any(), gomock.Any()).
+					Return(&artv1.PurgeExpiredResponse{Ids: []int64{3}}, nil)
+				intrSvc.EXPECT().Delete(gomock.Any(), &intrv1.DeleteRequest{Biz: "art", BizIds: []int64{3}}).
+					Return(&intrv1.DeleteResponse{}, nil)
+				return artSvc, intrSvc
+			},
+		},
+		{
+			name: "没有过期的文章",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient) {
+				artSvc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				artSvc.EXPECT().PurgeExpired(gomock.Any(), gomock.Any()).
+					Return(&artv1.PurgeExpiredResponse{}, nil)
+				return artSvc, intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+			},
+		},
+		{
+			name: "互动数据删除失败不影响",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient) {
+				artSvc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artSvc.EXPECT().PurgeExpired(gomock.Any(), gomock.Any()).
+					Return(&artv1.PurgeExpiredResponse{Ids: []int64{1}}, nil)
+				intrSvc.EXPECT().Delete(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return artSvc, intrSvc
+			},
+		},
+		{
+			name: "删除文章失败",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient) {
+				artSvc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				artSvc.EXPECT().PurgeExpired(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return artSvc, intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artSvc, intrSvc := tc.mock(ctrl)
+			exec := NewArticlePurgeExecutor(artSvc, intrSvc, logger.NewNopLogger())
+			exec.batchSize = 2
+			err := exec.Exec(context.Background(), NewArticlePurgeJob("0 * * * *", time.Hour))
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/internal/domain"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+)
+
+// AttachmentGCExecutor 清理没有被任何历史版本引用的附件
+type AttachmentGCExecutor struct {
+	artSvc artv1.ArticleServiceClient
+	l      logger.LoggerV1
+	// batchSize 每一批检查的数量
+	batchSize int
+}
+
+func NewAttachmentGCExecutor(artSvc artv1.ArticleServiceClient, l logger.LoggerV1) *AttachmentGCExecutor {
+	return &AttachmentGCExecutor{artSvc: artSvc, l: l, batchSize: 100}
+}
+
+type attachmentGCCfg struct {
+	// Grace 上传之后多久还没有被引用才清理，单位是毫秒
+	// 作者上传了图片之后可能过一会儿才保存文章
+	Grace int64 `json:"grace"`
+}
+
+// NewAttachmentGCJob 构造清理附件的任务，全局只有一个，按照 cron 周期执行
+func NewAttachmentGCJob(cron string, grace time.Duration) domain.Job {
+	cfg, _ := json.Marshal(attachmentGCCfg{Grace: grace.Milliseconds()})
+	return domain.Job{
+		Name:     "attachment_gc",
+		Executor: (&AttachmentGCExecutor{}).Name(),
+		Cfg:      string(cfg),
+		Cron:     cron,
+	}
+}
+
+func (a *AttachmentGCExecutor) Name() string {
+	return "attachment_gc"
+}
+
+func (a *AttachmentGCExecutor) Exec(ctx context.Context, j domain.Job) error {
+	var cfg attachmentGCCfg
+	err := json.Unmarshal([]byte(j.Cfg), &cfg)
+	if err != nil {
+		return err
+	}
+	before := time.Now().Add(-time.Duration(cfg.Grace) * time.Millisecond)
+	var startId int64
+	deleted := 0
+	for {
+		resp, err := a.gc(ctx, before, startId)
+		if err != nil {
+			return err
+		}
+		deleted += int(resp.GetDeleted())
+		startId = resp.GetNextId()
+		if startId == 0 {
+			a.l.Info("清理附件完成", logger.Int64("deleted", int64(deleted)))
+			return nil
+		}
+	}
+}
+
+func (a *AttachmentGCExecutor) gc(ctx context.Context, before time.Time, startId int64) (*artv1.GCAttachmentsResponse, error) {
+	// 每一批都要扫描历史版本，给长一点的超时时间
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	return a.artSvc.GCAttachments(ctx, &artv1.GCAttachmentsRequest{
+		Before:  before.UnixMilli(),
+		StartId: startId,
+		Limit:   int32(a.batchSize),
+	})
+}
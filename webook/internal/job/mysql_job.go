@@ -22,6 +22,12 @@ type Executor interface {
 	Exec(ctx context.Context, j domain.Job) error
 }
 
+// GiveUpHandler 执行器可以选择实现这个接口
+// 一次性任务重试次数用完之后会回调 GiveUp，执行器在这里做兜底，比如把业务状态改回去
+type GiveUpHandler interface {
+	GiveUp(ctx context.Context, j domain.Job, cause error)
+}
+
 type HttpExecutor struct {
 }
 
@@ -80,12 +86,15 @@ type Schedule struct {
 	svc     service.JobService
 	l       logger.LoggerV1
 	limiter *semaphore.Weighted
+	// interval 没有抢到任务的时候，下一次抢占之前等待的时间
+	interval time.Duration
 }
 
 func NewSchedule(svc service.JobService, l logger.LoggerV1) *Schedule {
 	return &Schedule{svc: svc, l: l,
-		limiter: semaphore.NewWeighted(200),
-		execs:   make(map[string]Executor)}
+		limiter:  semaphore.NewWeighted(200),
+		execs:    make(map[string]Executor),
+		interval: time.Second}
 }
 
 func (s *Schedule) RegisterExecutor(exec Executor) {
@@ -108,13 +117,24 @@ func (s *Schedule) Schedule(ctx context.Context) error {
 		if err != nil {
 			// 你不能 return
 			// 你要继续下一轮
-			s.l.Error("抢占任务失败", logger.Error(err))
+			// 大多数时候是没有可以执行的任务，歇一会儿再抢
+			s.limiter.Release(1)
+			if !errors.Is(err, service.ErrNoPreemptableJob) {
+				s.l.Error("抢占任务失败", logger.Error(err))
+			}
+			time.Sleep(s.interval)
+			continue
 		}
 
 		exec, ok := s.execs[j.Executor]
 		if !ok {
 			// DEBUG 的时候 最后中断
 			s.l.Error("未找到对应的执行器", logger.String("executor", j.Executor))
+			s.limiter.Release(1)
+			if err1 := j.CancelFunc(); err1 != nil {
+				s.l.Error("释放任务失败", logger.Error(err1),
+					logger.Int64("id", j.Id))
+			}
 			continue
 		}
 
@@ -133,16 +153,49 @@ func (s *Schedule) Schedule(ctx context.Context) error {
 			// 这边要考虑超时控制
 			err1 := exec.Exec(ctx, j)
 			if err1 != nil {
-				// 考虑在这里重试
-				s.l.Error("任务执行失败", logger.Error(err1))
+				s.l.Error("任务执行失败", logger.Error(err1),
+					logger.Int64("id", j.Id),
+					logger.Int64("retries", int64(j.Retries)))
+				s.retry(exec, j, err1)
+				return
 			}
 			// 你要不要考虑下一次调度?
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 			err1 = s.svc.ResetNextTime(ctx, j)
-			if err1 != nil {
+			switch {
+			case err1 == nil:
+			case errors.Is(err1, service.ErrJobRescheduled):
+				// 执行期间作者改了定时，新安排的任务会按照新的时间执行
+				s.l.Info("任务已经被重新安排", logger.Int64("id", j.Id))
+			default:
 				s.l.Error("设置下一次执行时间失败", logger.Error(err1))
 			}
 		}()
 	}
 }
+
+// retry 执行失败之后重新安排任务，重试次数用完了就交给执行器兜底
+func (s *Schedule) retry(exec Executor, j domain.Job, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	err := s.svc.Retry(ctx, j)
+	if err == nil {
+		return
+	}
+	if errors.Is(err, service.ErrJobRescheduled) {
+		s.l.Info("任务已经被重新安排，不再重试", logger.Int64("id", j.Id))
+		return
+	}
+	if !errors.Is(err, service.ErrJobRetriesExhausted) {
+		s.l.Error("安排任务重试失败", logger.Error(err), logger.Int64("id", j.Id))
+		return
+	}
+	// 这里需要告警，任务不会再被调度了
+	s.l.Error("任务重试次数用完，放弃执行", logger.Error(cause),
+		logger.Int64("id", j.Id),
+		logger.String("name", j.Name))
+	if h, ok := exec.(GiveUpHandler); ok {
+		h.GiveUp(ctx, j, cause)
+	}
+}
@@ -10,7 +10,13 @@ func InitTables(db *gorm.DB) error {
 	return db.AutoMigrate(&User{},
 		&dao.Article{},
 		&dao.PublishedArticleV1{},
+		&dao.ArticleRevision{},
+		&dao.Tag{},
+		&dao.ArticleTag{},
+		&dao.Series{},
+		&dao.SeriesArticle{},
 		&AsyncSms{},
+		&Job{},
 		&dao2.Interactive{},
 		&dao2.UserLikeBiz{},
 		&dao2.UserCollectionBiz{},
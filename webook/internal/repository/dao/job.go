@@ -2,57 +2,145 @@ package dao
 
 import (
 	"context"
+	"errors"
+	"github.com/TengFeiyang01/webook/webook/pkg/gormx"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
+var (
+	// ErrNoPreemptableJob 当前没有可以抢占的任务
+	ErrNoPreemptableJob = gorm.ErrRecordNotFound
+	// ErrJobRescheduled 执行期间任务被重新安排了，或者续约失败被别人抢走了，版本号对不上
+	// 这一轮执行的结果不能再修改任务，不然会把新安排的任务停掉或者改掉时间
+	ErrJobRescheduled = errors.New("任务已经被重新安排")
+)
+
+// JobDAO 抢占之后的操作都要带上抢占时候的版本号，版本号对不上说明任务已经不归自己管了
 type JobDAO interface {
+	// Preempt 返回的 Job.Version 是抢占之后的版本号
 	Preempt(ctx context.Context) (Job, error)
-	Release(ctx context.Context, id int64) error
-	UpdateUtime(ctx context.Context, id int64) error
-	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
-	Stop(ctx context.Context, id int64) error
+	Release(ctx context.Context, id int64, version int) error
+	UpdateUtime(ctx context.Context, id int64, version int) error
+	// UpdateNextTime 版本号对不上的时候返回 ErrJobRescheduled，Stop 和 Retry 也一样
+	UpdateNextTime(ctx context.Context, id int64, version int, next time.Time) error
+	Stop(ctx context.Context, id int64, version int) error
+	// Retry 执行失败之后，在 next 时间点重试，同时累加失败次数
+	Retry(ctx context.Context, id int64, version int, next time.Time) error
+	// Upsert 按照 name 插入或者更新任务，更新之后任务会重新进入等待状态
+	// 版本号也会加一，正在执行的那一轮就改不了这个任务了
+	Upsert(ctx context.Context, j Job) error
+	// InsertIfAbsent 同名的任务已经存在就什么也不做，不会改变它的状态和下一次执行的时间
+	InsertIfAbsent(ctx context.Context, j Job) error
+	StopByName(ctx context.Context, name string) error
 }
 
 type GORMJobDAO struct {
 	db *gorm.DB
 }
 
-func (g *GORMJobDAO) UpdateNextTime(ctx context.Context, id int64, next time.Time) error {
+func NewGORMJobDAO(db *gorm.DB) JobDAO {
+	return &GORMJobDAO{db: db}
+}
+
+func (g *GORMJobDAO) Upsert(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.Ctime = now
+	j.Utime = now
+	j.Status = jobStatusWaiting
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"cfg":        j.Cfg,
+			"executor":   j.Executor,
+			"expression": j.Expression,
+			"next_time":  j.NextTime,
+			"status":     jobStatusWaiting,
+			"utime":      now,
+			// 重新安排的任务，失败次数从头算
+			"retries": 0,
+			"version": gorm.Expr("`version` + 1"),
+		}),
+	}).Create(&j).Error
+}
+
+func (g *GORMJobDAO) InsertIfAbsent(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.Ctime = now
+	j.Utime = now
+	j.Status = jobStatusWaiting
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&j).Error
+}
+
+func (g *GORMJobDAO) StopByName(ctx context.Context, name string) error {
 	return g.db.WithContext(ctx).Model(&Job{}).
-		Where("id = ?", id).Updates(map[string]interface{}{
+		Where("name = ?", name).Updates(map[string]interface{}{
+		"status": jobStatusPaused,
+		"utime":  time.Now().UnixMilli(),
+	}).Error
+}
+
+func (g *GORMJobDAO) UpdateNextTime(ctx context.Context, id int64, version int, next time.Time) error {
+	return g.updateByVersion(ctx, id, version, map[string]interface{}{
 		"utime":     time.Now().UnixMilli(),
 		"next_time": next.UnixMilli(),
-	}).Error
+	})
+}
+
+func (g *GORMJobDAO) Retry(ctx context.Context, id int64, version int, next time.Time) error {
+	return g.updateByVersion(ctx, id, version, map[string]interface{}{
+		"utime":     time.Now().UnixMilli(),
+		"next_time": next.UnixMilli(),
+		"retries":   gorm.Expr("`retries` + 1"),
+	})
 }
 
-func (g *GORMJobDAO) UpdateUtime(ctx context.Context, id int64) error {
+func (g *GORMJobDAO) UpdateUtime(ctx context.Context, id int64, version int) error {
 	return g.db.WithContext(ctx).Model(&Job{}).
-		Where("id = ?", id).Updates(map[string]interface{}{
+		Where("id = ? AND version = ?", id, version).Updates(map[string]interface{}{
 		"utime": time.Now().UnixMilli(),
 	}).Error
 }
 
-func (g *GORMJobDAO) Stop(ctx context.Context, id int64) error {
-	return g.db.WithContext(ctx).Model(&Job{}).
-		Where("id = ?", id).Updates(map[string]interface{}{
+func (g *GORMJobDAO) Stop(ctx context.Context, id int64, version int) error {
+	return g.updateByVersion(ctx, id, version, map[string]interface{}{
 		"status": jobStatusPaused,
 		"utime":  time.Now().UnixMilli(),
-	}).Error
+	})
+}
+
+// updateByVersion 一定会更新 utime，所以没有更新到说明版本号对不上
+func (g *GORMJobDAO) updateByVersion(ctx context.Context, id int64, version int, updates map[string]interface{}) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? AND version = ?", id, version).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobRescheduled
+	}
+	return nil
 }
 
-func (g *GORMJobDAO) Release(ctx context.Context, id int64) error {
+func (g *GORMJobDAO) Release(ctx context.Context, id int64, version int) error {
 	// 要不要检测 status 或者 version
-	// 要的
+	// 要的, 只释放运行中的任务, 不然已经被停止的一次性任务又会被重新调度
+	// 版本号对不上说明任务被重新安排或者被别人抢走了，也不能释放
 	now := time.Now().UnixMilli()
 	return g.db.WithContext(ctx).Model(&Job{}).
-		Where("id = ?", id).Updates(map[string]interface{}{
+		Where("id = ? AND version = ? AND status = ?", id, version, jobStatusRunning).Updates(map[string]interface{}{
 		"status": jobStatusWaiting,
 		"utime":  now,
 	}).Error
 }
 
 func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
+	// 抢占靠版本号做 CAS，从库上读到的版本号可能是旧的，会一直抢占失败，所以读主库
+	ctx = gormx.WithPrimary(ctx)
 	// 高并发情况, 大部分都是陪太子读书
 	for {
 		now := time.Now().UnixMilli()
@@ -86,6 +174,7 @@ func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 			// 抢占失败, 你只能说, 我要继续下一轮
 			continue
 		}
+		j.Version++
 		return j, nil
 	}
 }
@@ -93,7 +182,7 @@ func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 type Job struct {
 	Id       int64 `gorm:"primary_key,AUTO_INCREMENT"`
 	Cfg      string
-	Name     string `gorm:"unique"`
+	Name     string `gorm:"type:varchar(256);unique"`
 	Executor string
 
 	// 哪些任务可以抢占, 哪些任务已经被人占着, 哪些任务永远不会执行
@@ -108,6 +197,8 @@ type Job struct {
 	// next_time <= now && status = 0
 	// next_time 和 status 联合索引
 	NextTime int64 `gorm:"index"`
+	// Retries 连续执行失败的次数，一次性任务靠它来决定要不要继续重试
+	Retries int
 
 	Ctime int64
 	Utime int64
@@ -0,0 +1,222 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMJobDAO_Preempt(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+
+		wantJob Job
+		wantErr error
+	}{
+		{
+			name: "抢占成功，返回抢占之后的版本号",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `jobs` WHERE .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "job", 3))
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\?").
+					WithArgs(jobStatusRunning, sqlmock.AnyArg(), 4, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDb
+			},
+			wantJob: Job{Id: 1, Name: "job", Version: 4},
+		},
+		{
+			name: "版本号变了，抢下一个",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `jobs` WHERE .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "job", 3))
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT \\* FROM `jobs` WHERE .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(2, "job2", 7))
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\?").
+					WithArgs(jobStatusRunning, sqlmock.AnyArg(), 8, 2, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDb
+			},
+			wantJob: Job{Id: 2, Name: "job2", Version: 8},
+		},
+		{
+			name: "没有可以抢占的任务",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `jobs` WHERE .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				return mockDb
+			},
+			wantErr: ErrNoPreemptableJob,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewGORMJobDAO(initMockDB(t, tc.mock(t)))
+			j, err := d.Preempt(context.Background())
+			assert.True(t, errors.Is(err, tc.wantErr))
+			assert.Equal(t, tc.wantJob, j)
+		})
+	}
+}
+
+// TestGORMJobDAO_Version 执行完之后修改任务都要带上抢占时候的版本号
+func TestGORMJobDAO_Version(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+		exec func(d JobDAO) error
+
+		wantErr error
+	}{
+		{
+			name: "停止任务",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\?").
+					WithArgs(jobStatusPaused, sqlmock.AnyArg(), 1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.Stop(context.Background(), 1, 4)
+			},
+		},
+		{
+			name: "执行期间被重新安排了，停止不了",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.Stop(context.Background(), 1, 4)
+			},
+			wantErr: ErrJobRescheduled,
+		},
+		{
+			name: "安排重试，累加失败次数",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `jobs` SET `next_time`=\\?,`retries`=`retries` \\+ 1,`utime`=\\? WHERE id = \\? AND version = \\?").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.Retry(context.Background(), 1, 4, time.Now().Add(time.Minute))
+			},
+		},
+		{
+			name: "执行期间被重新安排了，不重试",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.Retry(context.Background(), 1, 4, time.Now().Add(time.Minute))
+			},
+			wantErr: ErrJobRescheduled,
+		},
+		{
+			name: "执行期间被重新安排了，下一次执行的时间也不改",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.UpdateNextTime(context.Background(), 1, 4, time.Now().Add(time.Hour))
+			},
+			wantErr: ErrJobRescheduled,
+		},
+		{
+			name: "释放只释放自己抢占的",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `jobs` SET .* WHERE id = \\? AND version = \\? AND status = \\?").
+					WithArgs(jobStatusWaiting, sqlmock.AnyArg(), 1, 4, jobStatusRunning).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.Release(context.Background(), 1, 4)
+			},
+		},
+		{
+			name: "重新安排的时候版本号加一",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("INSERT INTO `jobs` .* ON DUPLICATE KEY UPDATE .*`version`=`version` \\+ 1").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.Upsert(context.Background(), Job{Name: "job"})
+			},
+		},
+		{
+			name: "已经存在的任务不覆盖",
+			mock: func(t *testing.T) *sql.DB {
+				mockDb, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("INSERT INTO `jobs` .* ON DUPLICATE KEY UPDATE `id`=`id`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDb
+			},
+			exec: func(d JobDAO) error {
+				return d.InsertIfAbsent(context.Background(), Job{Name: "job"})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewGORMJobDAO(initMockDB(t, tc.mock(t)))
+			err := tc.exec(d)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func initMockDB(t *testing.T, mockDb *sql.DB) *gorm.DB {
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
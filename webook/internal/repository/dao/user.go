@@ -14,6 +14,8 @@ type UserDAO interface {
 	FindByPhone(ctx context.Context, phone string) (User, error)
 	Insert(ctx context.Context, u User) error
 	FindById(ctx context.Context, id int64) (User, error)
+	// FindByIds 不存在的 id 直接忽略
+	FindByIds(ctx context.Context, ids []int64) ([]User, error)
 	UpdateById(ctx context.Context, entity User) error
 	FindByWechat(ctx context.Context, openID string) (User, error)
 }
@@ -65,6 +67,12 @@ func (dao *GORMUserDAO) FindById(ctx context.Context, id int64) (User, error) {
 	return u, err
 }
 
+func (dao *GORMUserDAO) FindByIds(ctx context.Context, ids []int64) ([]User, error) {
+	var res []User
+	err := dao.db.WithContext(ctx).Where("id IN ?", ids).Find(&res).Error
+	return res, err
+}
+
 func (dao *GORMUserDAO) FindByWechat(ctx context.Context, openID string) (User, error) {
 	var u User
 	err := dao.db.WithContext(ctx).Where("wechat_open_id = ?", openID).First(&u).Error
@@ -7,32 +7,62 @@ import (
 	"github.com/TengFeiyang01/webook/webook/internal/repository/dao"
 )
 
+var (
+	ErrNoPreemptableJob = dao.ErrNoPreemptableJob
+	ErrJobRescheduled   = dao.ErrJobRescheduled
+)
+
 type JobRepository interface {
 	Preempt(ctx context.Context) (domain.Job, error)
-	Release(ctx context.Context, id int64) error
-	UpdateUtime(ctx context.Context, id int64) error
-	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
-	Stop(ctx context.Context, id int64) error
+	// 下面这几个方法都要带上抢占时候的版本号，也就是 domain.Job.Version
+	Release(ctx context.Context, id int64, version int) error
+	UpdateUtime(ctx context.Context, id int64, version int) error
+	UpdateNextTime(ctx context.Context, id int64, version int, next time.Time) error
+	Stop(ctx context.Context, id int64, version int) error
+	Retry(ctx context.Context, id int64, version int, next time.Time) error
+	Upsert(ctx context.Context, j domain.Job, next time.Time) error
+	InsertIfAbsent(ctx context.Context, j domain.Job, next time.Time) error
+	StopByName(ctx context.Context, name string) error
 }
 
 type PreemptCronJobRepository struct {
 	dao dao.JobDAO
 }
 
-func (p *PreemptCronJobRepository) UpdateUtime(ctx context.Context, id int64) error {
-	return p.dao.UpdateUtime(ctx, id)
+func NewPreemptCronJobRepository(dao dao.JobDAO) JobRepository {
+	return &PreemptCronJobRepository{dao: dao}
+}
+
+func (p *PreemptCronJobRepository) Upsert(ctx context.Context, j domain.Job, next time.Time) error {
+	return p.dao.Upsert(ctx, p.toEntity(j, next))
+}
+
+func (p *PreemptCronJobRepository) InsertIfAbsent(ctx context.Context, j domain.Job, next time.Time) error {
+	return p.dao.InsertIfAbsent(ctx, p.toEntity(j, next))
+}
+
+func (p *PreemptCronJobRepository) StopByName(ctx context.Context, name string) error {
+	return p.dao.StopByName(ctx, name)
 }
 
-func (p *PreemptCronJobRepository) UpdateNextTime(ctx context.Context, id int64, next time.Time) error {
-	return p.dao.UpdateNextTime(ctx, id, next)
+func (p *PreemptCronJobRepository) UpdateUtime(ctx context.Context, id int64, version int) error {
+	return p.dao.UpdateUtime(ctx, id, version)
 }
 
-func (p *PreemptCronJobRepository) Stop(ctx context.Context, id int64) error {
-	return p.dao.Stop(ctx, id)
+func (p *PreemptCronJobRepository) UpdateNextTime(ctx context.Context, id int64, version int, next time.Time) error {
+	return p.dao.UpdateNextTime(ctx, id, version, next)
 }
 
-func (p *PreemptCronJobRepository) Release(ctx context.Context, id int64) error {
-	return p.dao.Release(ctx, id)
+func (p *PreemptCronJobRepository) Stop(ctx context.Context, id int64, version int) error {
+	return p.dao.Stop(ctx, id, version)
+}
+
+func (p *PreemptCronJobRepository) Retry(ctx context.Context, id int64, version int, next time.Time) error {
+	return p.dao.Retry(ctx, id, version, next)
+}
+
+func (p *PreemptCronJobRepository) Release(ctx context.Context, id int64, version int) error {
+	return p.dao.Release(ctx, id, version)
 }
 
 func (p *PreemptCronJobRepository) Preempt(ctx context.Context) (domain.Job, error) {
@@ -46,5 +76,17 @@ func (p *PreemptCronJobRepository) Preempt(ctx context.Context) (domain.Job, err
 		Cron:     j.Expression,
 		Name:     j.Name,
 		Executor: j.Executor,
+		Retries:  j.Retries,
+		Version:  j.Version,
 	}, nil
 }
+
+func (p *PreemptCronJobRepository) toEntity(j domain.Job, next time.Time) dao.Job {
+	return dao.Job{
+		Name:       j.Name,
+		Executor:   j.Executor,
+		Cfg:        j.Cfg,
+		Expression: j.Cron,
+		NextTime:   next.UnixMilli(),
+	}
+}
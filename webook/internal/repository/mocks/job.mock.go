@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webook/internal/repository/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webook/internal/repository/job.go -package=repomocks -destination=webook/internal/repository/mocks/job.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/TengFeiyang01/webook/webook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// InsertIfAbsent mocks base method.
+func (m *MockJobRepository) InsertIfAbsent(ctx context.Context, j domain.Job, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertIfAbsent", ctx, j, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertIfAbsent indicates an expected call of InsertIfAbsent.
+func (mr *MockJobRepositoryMockRecorder) InsertIfAbsent(ctx, j, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIfAbsent", reflect.TypeOf((*MockJobRepository)(nil).InsertIfAbsent), ctx, j, next)
+}
+
+// Preempt mocks base method.
+func (m *MockJobRepository) Preempt(ctx context.Context) (domain.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preempt", ctx)
+	ret0, _ := ret[0].(domain.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preempt indicates an expected call of Preempt.
+func (mr *MockJobRepositoryMockRecorder) Preempt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preempt", reflect.TypeOf((*MockJobRepository)(nil).Preempt), ctx)
+}
+
+// Release mocks base method.
+func (m *MockJobRepository) Release(ctx context.Context, id int64, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockJobRepositoryMockRecorder) Release(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockJobRepository)(nil).Release), ctx, id, version)
+}
+
+// Retry mocks base method.
+func (m *MockJobRepository) Retry(ctx context.Context, id int64, version int, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id, version, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockJobRepositoryMockRecorder) Retry(ctx, id, version, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockJobRepository)(nil).Retry), ctx, id, version, next)
+}
+
+// Stop mocks base method.
+func (m *MockJobRepository) Stop(ctx context.Context, id int64, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockJobRepositoryMockRecorder) Stop(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockJobRepository)(nil).Stop), ctx, id, version)
+}
+
+// StopByName mocks base method.
+func (m *MockJobRepository) StopByName(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopByName", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopByName indicates an expected call of StopByName.
+func (mr *MockJobRepositoryMockRecorder) StopByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopByName", reflect.TypeOf((*MockJobRepository)(nil).StopByName), ctx, name)
+}
+
+// UpdateNextTime mocks base method.
+func (m *MockJobRepository) UpdateNextTime(ctx context.Context, id int64, version int, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNextTime", ctx, id, version, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNextTime indicates an expected call of UpdateNextTime.
+func (mr *MockJobRepositoryMockRecorder) UpdateNextTime(ctx, id, version, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNextTime", reflect.TypeOf((*MockJobRepository)(nil).UpdateNextTime), ctx, id, version, next)
+}
+
+// UpdateUtime mocks base method.
+func (m *MockJobRepository) UpdateUtime(ctx context.Context, id int64, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUtime", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUtime indicates an expected call of UpdateUtime.
+func (mr *MockJobRepositoryMockRecorder) UpdateUtime(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUtime", reflect.TypeOf((*MockJobRepository)(nil).UpdateUtime), ctx, id, version)
+}
+
+// Upsert mocks base method.
+func (m *MockJobRepository) Upsert(ctx context.Context, j domain.Job, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, j, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockJobRepositoryMockRecorder) Upsert(ctx, j, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockJobRepository)(nil).Upsert), ctx, j, next)
+}
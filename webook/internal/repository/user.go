@@ -9,6 +9,7 @@ import (
 	"github.com/TengFeiyang01/webook/webook/internal/domain"
 	"github.com/TengFeiyang01/webook/webook/internal/repository/cache"
 	"github.com/TengFeiyang01/webook/webook/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
 )
 
 // UserRepository 所有的 User 相关的功能
@@ -17,6 +18,8 @@ type UserRepository interface {
 	FindByPhone(ctx context.Context, phone string) (domain.User, error)
 	Create(ctx context.Context, u domain.User) error
 	FindById(ctx context.Context, id int64) (domain.User, error)
+	// FindByIds 批量查询直接查数据库，不存在的用户不会出现在结果里
+	FindByIds(ctx context.Context, ids []int64) ([]domain.User, error)
 	UpdateById(ctx context.Context, u domain.User) error
 	FindByWechat(ctx context.Context, openID string) (domain.User, error)
 }
@@ -100,6 +103,19 @@ func (r *CachedUserRepository) FindById(ctx context.Context, id int64) (domain.U
 	return u, nil
 }
 
+func (r *CachedUserRepository) FindByIds(ctx context.Context, ids []int64) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	us, err := r.dao.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(us, func(idx int, src dao.User) domain.User {
+		return r.toDomain(src)
+	}), nil
+}
+
 func (r *CachedUserRepository) FindByWechat(ctx context.Context, openID string) (domain.User, error) {
 	// 先从cache找
 	// 再从dao里面找
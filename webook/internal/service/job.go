@@ -2,62 +2,143 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/TengFeiyang01/webook/webook/internal/domain"
 	"github.com/TengFeiyang01/webook/webook/internal/repository"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
 	"time"
 )
 
+var (
+	ErrNoPreemptableJob = repository.ErrNoPreemptableJob
+	// ErrJobRescheduled 执行期间任务被重新安排了，这一轮执行的结果直接丢掉
+	ErrJobRescheduled = repository.ErrJobRescheduled
+	// ErrJobRetriesExhausted 一次性任务重试次数用完了，任务已经停止
+	ErrJobRetriesExhausted = errors.New("任务重试次数已用完")
+)
+
 //go:generate mockgen -source=./job.go -package=svcmocks -destination=./mocks/job.mock.go JobService
 type JobService interface {
 	// Preempt 抢占
 	Preempt(ctx context.Context) (domain.Job, error)
+	// ResetNextTime 执行期间任务被重新安排了，返回 ErrJobRescheduled，Retry 也一样
 	ResetNextTime(ctx context.Context, j domain.Job) error
+	// Retry 任务执行失败之后调用
+	// 有 cron 的任务等下一轮调度就是重试，一次性任务按照退避策略重新安排，
+	// 次数用完了就停止任务，返回 ErrJobRetriesExhausted
+	Retry(ctx context.Context, j domain.Job) error
 	//PreemptV1(ctx context.Context) (domain.Job, func() error, error)
+
+	// Schedule 新建或者重新安排一个任务，在 next 时间点被调度
+	// 同名的任务会被覆盖，所以重新安排时间也是调用这个方法
+	Schedule(ctx context.Context, j domain.Job, next time.Time) error
+	// ScheduleIfAbsent 同名的任务不存在才新建，已经存在的任务保持原样
+	// 启动的时候初始化周期任务用这个，不然每次启动都会把正在执行或者已经停止的任务改回等待
+	ScheduleIfAbsent(ctx context.Context, j domain.Job, next time.Time) error
+	// Cancel 取消任务，任务记录还在，只是不会再被调度
+	Cancel(ctx context.Context, name string) error
 }
 
 type cronJobService struct {
 	repo            repository.JobRepository
 	refreshInterval time.Duration
-	l               logger.LoggerV1
+	// 一次性任务失败之后的重试间隔，第 n 次重试等待 retryInterval * 2^n，最多 maxRetryInterval
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+	maxRetries       int
+	l                logger.LoggerV1
+}
+
+func NewCronJobService(repo repository.JobRepository, l logger.LoggerV1) JobService {
+	return &cronJobService{
+		repo:             repo,
+		refreshInterval:  time.Minute,
+		retryInterval:    time.Minute,
+		maxRetryInterval: time.Hour,
+		maxRetries:       5,
+		l:                l,
+	}
+}
+
+func (p *cronJobService) Schedule(ctx context.Context, j domain.Job, next time.Time) error {
+	return p.repo.Upsert(ctx, j, next)
+}
+
+func (p *cronJobService) ScheduleIfAbsent(ctx context.Context, j domain.Job, next time.Time) error {
+	return p.repo.InsertIfAbsent(ctx, j, next)
+}
+
+func (p *cronJobService) Cancel(ctx context.Context, name string) error {
+	return p.repo.StopByName(ctx, name)
 }
 
 func (p *cronJobService) ResetNextTime(ctx context.Context, j domain.Job) error {
 	next := j.NextTime()
 	if next.IsZero() {
-		return p.repo.Stop(ctx, j.Id)
+		return p.repo.Stop(ctx, j.Id, j.Version)
+	}
+	return p.repo.UpdateNextTime(ctx, j.Id, j.Version, next)
+}
+
+func (p *cronJobService) Retry(ctx context.Context, j domain.Job) error {
+	if j.Cron != "" {
+		return p.ResetNextTime(ctx, j)
 	}
-	return p.repo.UpdateNextTime(ctx, j.Id, next)
+	if j.Retries >= p.maxRetries {
+		// 任务被重新安排了的话这里会失败，不会走到放弃的逻辑
+		err := p.repo.Stop(ctx, j.Id, j.Version)
+		if err != nil {
+			return err
+		}
+		return ErrJobRetriesExhausted
+	}
+	interval := p.retryInterval << j.Retries
+	if interval > p.maxRetryInterval || interval <= 0 {
+		interval = p.maxRetryInterval
+	}
+	return p.repo.Retry(ctx, j.Id, j.Version, time.Now().Add(interval))
 }
 
 func (p *cronJobService) Preempt(ctx context.Context) (domain.Job, error) {
 	j, err := p.repo.Preempt(ctx)
+	if err != nil {
+		return domain.Job{}, err
+	}
 
 	ticker := time.NewTicker(p.refreshInterval)
+	done := make(chan struct{})
 	go func() {
-		for range ticker.C {
-			p.refresh(j.Id)
+		for {
+			select {
+			case <-ticker.C:
+				p.refresh(j)
+			case <-done:
+				return
+			}
 		}
 	}()
 
 	// 你抢占之后，你一直抢占吗？
 	j.CancelFunc = func() error {
+		// 释放之后就不需要续约了
+		ticker.Stop()
+		close(done)
 		// 自己在这里释放掉
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		return p.repo.Release(ctx, j.Id)
+		return p.repo.Release(ctx, j.Id, j.Version)
 	}
-	return j, err
+	return j, nil
 }
 
-func (p *cronJobService) refresh(id int64) {
+func (p *cronJobService) refresh(j domain.Job) {
 	// 如何续约？
 	// 更新一下更新时间即可
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	err := p.repo.UpdateUtime(ctx, id)
+	err := p.repo.UpdateUtime(ctx, j.Id, j.Version)
 	if err != nil {
 		// 可以考虑重试
-		p.l.Error("续约失败", logger.Error(err), logger.Int64("jid", id))
+		p.l.Error("续约失败", logger.Error(err), logger.Int64("jid", j.Id))
 	}
 }
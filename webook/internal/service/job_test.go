@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/internal/domain"
+	"github.com/TengFeiyang01/webook/webook/internal/repository"
+	repomocks "github.com/TengFeiyang01/webook/webook/internal/repository/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCronJobService_Retry(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.JobRepository
+
+		job     domain.Job
+		wantErr error
+	}{
+		{
+			name: "有 cron 的任务等下一轮调度",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().UpdateNextTime(gomock.Any(), int64(1), 3, gomock.Any()).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Version: 3, Cron: "0 * * * *", Retries: 10},
+		},
+		{
+			name: "第一次失败",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Retry(gomock.Any(), int64(1), 3, retryAfter(time.Minute)).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Version: 3},
+		},
+		{
+			name: "指数退避",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Retry(gomock.Any(), int64(1), 3, retryAfter(time.Minute*4)).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Version: 3, Retries: 2},
+		},
+		{
+			name: "退避时间有上限",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Retry(gomock.Any(), int64(1), 3, retryAfter(time.Minute*10)).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Version: 3, Retries: 4},
+		},
+		{
+			name: "重试次数用完，停止任务",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Stop(gomock.Any(), int64(1), 3).Return(nil)
+				return repo
+			},
+			job:     domain.Job{Id: 1, Version: 3, Retries: 5},
+			wantErr: ErrJobRetriesExhausted,
+		},
+		{
+			name: "重试次数用完，但是任务已经被重新安排",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Stop(gomock.Any(), int64(1), 3).Return(repository.ErrJobRescheduled)
+				return repo
+			},
+			job: domain.Job{Id: 1, Version: 3, Retries: 5},
+			// 不能返回 ErrJobRetriesExhausted，不然执行器会把新安排的定时取消掉
+			wantErr: ErrJobRescheduled,
+		},
+		{
+			name: "安排重试的时候任务已经被重新安排",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Retry(gomock.Any(), int64(1), 3, retryAfter(time.Minute)).Return(repository.ErrJobRescheduled)
+				return repo
+			},
+			job:     domain.Job{Id: 1, Version: 3},
+			wantErr: ErrJobRescheduled,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := &cronJobService{
+				repo:             tc.mock(ctrl),
+				retryInterval:    time.Minute,
+				maxRetryInterval: time.Minute * 10,
+				maxRetries:       5,
+				l:                logger.NewNopLogger(),
+			}
+			err := svc.Retry(context.Background(), tc.job)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+// retryAfter 重试的时间是 d 之后，允许一秒的误差
+func retryAfter(d time.Duration) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		next, ok := x.(time.Time)
+		if !ok {
+			return false
+		}
+		diff := time.Until(next) - d
+		return diff > -time.Second && diff < time.Second
+	})
+}
+
+func TestCronJobService_ResetNextTime(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.JobRepository
+
+		job     domain.Job
+		wantErr error
+	}{
+		{
+			name: "一次性任务执行成功之后停止",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Stop(gomock.Any(), int64(1), 3).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Version: 3},
+		},
+		{
+			name: "执行期间被重新安排了，不会把新的任务停掉",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Stop(gomock.Any(), int64(1), 3).Return(repository.ErrJobRescheduled)
+				return repo
+			},
+			job:     domain.Job{Id: 1, Version: 3},
+			wantErr: ErrJobRescheduled,
+		},
+		{
+			name: "有 cron 的任务更新下一次执行的时间",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().UpdateNextTime(gomock.Any(), int64(1), 3, gomock.Any()).Return(nil)
+				return repo
+			},
+			job: domain.Job{Id: 1, Version: 3, Cron: "0 * * * *"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCronJobService(tc.mock(ctrl), logger.NewNopLogger())
+			err := svc.ResetNextTime(context.Background(), tc.job)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCronJobService_Preempt(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.JobRepository
+
+		wantJob domain.Job
+		wantErr error
+	}{
+		{
+			name: "释放的时候带上抢占的版本号",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Preempt(gomock.Any()).Return(domain.Job{Id: 1, Version: 3}, nil)
+				repo.EXPECT().Release(gomock.Any(), int64(1), 3).Return(nil)
+				return repo
+			},
+			wantJob: domain.Job{Id: 1, Version: 3},
+		},
+		{
+			name: "没有可以抢占的任务",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Preempt(gomock.Any()).Return(domain.Job{}, repository.ErrNoPreemptableJob)
+				return repo
+			},
+			wantErr: ErrNoPreemptableJob,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCronJobService(tc.mock(ctrl), logger.NewNopLogger())
+			j, err := svc.Preempt(context.Background())
+			assert.True(t, errors.Is(err, tc.wantErr))
+			if err != nil {
+				return
+			}
+			require.NotNil(t, j.CancelFunc)
+			assert.NoError(t, j.CancelFunc())
+			j.CancelFunc = nil
+			assert.Equal(t, tc.wantJob, j)
+		})
+	}
+}
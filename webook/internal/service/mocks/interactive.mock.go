@@ -11,9 +11,11 @@ package svcmocks
 
 import (
 	context "context"
-	gomock "go.uber.org/mock/gomock"
 	reflect "reflect"
-	"github.com/TengFeiyang01/webook/webook/interactive/domain"
+	time "time"
+
+	domain "github.com/TengFeiyang01/webook/webook/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockInteractiveService is a mock of InteractiveService interface.
@@ -67,6 +69,20 @@ func (mr *MockInteractiveServiceMockRecorder) Collect(ctx, biz, bizId, cid, uid
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockInteractiveService)(nil).Collect), ctx, biz, bizId, cid, uid)
 }
 
+// Delete mocks base method.
+func (m *MockInteractiveService) Delete(ctx context.Context, biz string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, biz, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractiveServiceMockRecorder) Delete(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractiveService)(nil).Delete), ctx, biz, bizIds)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveService) Get(ctx context.Context, biz string, id, uid int64) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +113,21 @@ func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, biz, bizIds any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, biz, bizIds)
 }
 
+// GetStats mocks base method.
+func (m *MockInteractiveService) GetStats(ctx context.Context, biz string, bizIds []int64, granularity domain.StatsGranularity, start, end time.Time) ([]domain.StatsPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, biz, bizIds, granularity, start, end)
+	ret0, _ := ret[0].([]domain.StatsPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockInteractiveServiceMockRecorder) GetStats(ctx, biz, bizIds, granularity, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockInteractiveService)(nil).GetStats), ctx, biz, bizIds, granularity, start, end)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
@@ -124,3 +155,33 @@ func (mr *MockInteractiveServiceMockRecorder) Like(c, biz, id, uid any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), c, biz, id, uid)
 }
+
+// ListStats mocks base method.
+func (m *MockInteractiveService) ListStats(ctx context.Context, biz string, bizIds []int64, granularity domain.StatsGranularity, start, end time.Time) ([]domain.StatsPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStats", ctx, biz, bizIds, granularity, start, end)
+	ret0, _ := ret[0].([]domain.StatsPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStats indicates an expected call of ListStats.
+func (mr *MockInteractiveServiceMockRecorder) ListStats(ctx, biz, bizIds, granularity, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStats", reflect.TypeOf((*MockInteractiveService)(nil).ListStats), ctx, biz, bizIds, granularity, start, end)
+}
+
+// TopStats mocks base method.
+func (m *MockInteractiveService) TopStats(ctx context.Context, biz string, bizIds []int64, granularity domain.StatsGranularity, start, end time.Time, order domain.StatsOrder, limit int) ([]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopStats", ctx, biz, bizIds, granularity, start, end, order, limit)
+	ret0, _ := ret[0].([]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopStats indicates an expected call of TopStats.
+func (mr *MockInteractiveServiceMockRecorder) TopStats(ctx, biz, bizIds, granularity, start, end, order, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopStats", reflect.TypeOf((*MockInteractiveService)(nil).TopStats), ctx, biz, bizIds, granularity, start, end, order, limit)
+}
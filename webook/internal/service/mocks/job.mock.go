@@ -12,8 +12,9 @@ package svcmocks
 import (
 	context "context"
 	reflect "reflect"
-	domain "github.com/TengFeiyang01/webook/webook/internal/domain"
+	time "time"
 
+	domain "github.com/TengFeiyang01/webook/webook/internal/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +41,20 @@ func (m *MockJobService) EXPECT() *MockJobServiceMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockJobService) Cancel(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockJobServiceMockRecorder) Cancel(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockJobService)(nil).Cancel), ctx, name)
+}
+
 // Preempt mocks base method.
 func (m *MockJobService) Preempt(ctx context.Context) (domain.Job, error) {
 	m.ctrl.T.Helper()
@@ -68,3 +83,45 @@ func (mr *MockJobServiceMockRecorder) ResetNextTime(ctx, j any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetNextTime", reflect.TypeOf((*MockJobService)(nil).ResetNextTime), ctx, j)
 }
+
+// Retry mocks base method.
+func (m *MockJobService) Retry(ctx context.Context, j domain.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, j)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockJobServiceMockRecorder) Retry(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockJobService)(nil).Retry), ctx, j)
+}
+
+// Schedule mocks base method.
+func (m *MockJobService) Schedule(ctx context.Context, j domain.Job, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, j, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockJobServiceMockRecorder) Schedule(ctx, j, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockJobService)(nil).Schedule), ctx, j, next)
+}
+
+// ScheduleIfAbsent mocks base method.
+func (m *MockJobService) ScheduleIfAbsent(ctx context.Context, j domain.Job, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleIfAbsent", ctx, j, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleIfAbsent indicates an expected call of ScheduleIfAbsent.
+func (mr *MockJobServiceMockRecorder) ScheduleIfAbsent(ctx, j, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleIfAbsent", reflect.TypeOf((*MockJobService)(nil).ScheduleIfAbsent), ctx, j, next)
+}
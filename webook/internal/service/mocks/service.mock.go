@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./art.go
+// Source: ./article.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./art.go -destination=./mocks/service.mock.go -package=svcmocks ArticleService
+//	mockgen -source=./article.go -destination=./mocks/service.mock.go -package=svcmocks ArticleService
 //
 
 // Package svcmocks is a generated GoMock package.
@@ -11,10 +11,11 @@ package svcmocks
 
 import (
 	context "context"
-	gomock "go.uber.org/mock/gomock"
 	reflect "reflect"
 	time "time"
-	"github.com/TengFeiyang01/webook/webook/article/domain"
+
+	domain "github.com/TengFeiyang01/webook/webook/article/domain"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockArticleService is a mock of ArticleService interface.
@@ -40,6 +41,154 @@ func (m *MockArticleService) EXPECT() *MockArticleServiceMockRecorder {
 	return m.recorder
 }
 
+// Approve mocks base method.
+func (m *MockArticleService) Approve(ctx context.Context, reviewer, id, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, reviewer, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockArticleServiceMockRecorder) Approve(ctx, reviewer, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockArticleService)(nil).Approve), ctx, reviewer, id, version)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleService) CancelSchedule(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleServiceMockRecorder) CancelSchedule(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleService)(nil).CancelSchedule), ctx, uid, id)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockArticleService) CompleteUpload(ctx context.Context, uid, id int64) (domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, uid, id)
+	ret0, _ := ret[0].(domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockArticleServiceMockRecorder) CompleteUpload(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockArticleService)(nil).CompleteUpload), ctx, uid, id)
+}
+
+// CreateSeries mocks base method.
+func (m *MockArticleService) CreateSeries(ctx context.Context, s domain.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockArticleServiceMockRecorder) CreateSeries(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockArticleService)(nil).CreateSeries), ctx, s)
+}
+
+// CreateUpload mocks base method.
+func (m *MockArticleService) CreateUpload(ctx context.Context, a domain.Attachment) (domain.Attachment, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, a)
+	ret0, _ := ret[0].(domain.Attachment)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockArticleServiceMockRecorder) CreateUpload(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockArticleService)(nil).CreateUpload), ctx, a)
+}
+
+// Delete mocks base method.
+func (m *MockArticleService) Delete(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleServiceMockRecorder) Delete(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleService)(nil).Delete), ctx, uid, id)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockArticleService) DeleteAttachment(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockArticleServiceMockRecorder) DeleteAttachment(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockArticleService)(nil).DeleteAttachment), ctx, uid, id)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleService) DiffRevisions(ctx context.Context, uid, from, to int64) (domain.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, uid, from, to)
+	ret0, _ := ret[0].(domain.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceMockRecorder) DiffRevisions(ctx, uid, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleService)(nil).DiffRevisions), ctx, uid, from, to)
+}
+
+// FindSimilar mocks base method.
+func (m *MockArticleService) FindSimilar(ctx context.Context, id int64, limit int) ([]domain.SimilarArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilar", ctx, id, limit)
+	ret0, _ := ret[0].([]domain.SimilarArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilar indicates an expected call of FindSimilar.
+func (mr *MockArticleServiceMockRecorder) FindSimilar(ctx, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilar", reflect.TypeOf((*MockArticleService)(nil).FindSimilar), ctx, id, limit)
+}
+
+// GCAttachments mocks base method.
+func (m *MockArticleService) GCAttachments(ctx context.Context, before time.Time, startId int64, limit int) (int64, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GCAttachments", ctx, before, startId, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GCAttachments indicates an expected call of GCAttachments.
+func (mr *MockArticleServiceMockRecorder) GCAttachments(ctx, before, startId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GCAttachments", reflect.TypeOf((*MockArticleService)(nil).GCAttachments), ctx, before, startId, limit)
+}
+
 // GetById mocks base method.
 func (m *MockArticleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +204,36 @@ func (mr *MockArticleServiceMockRecorder) GetById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleService)(nil).GetById), ctx, id)
 }
 
+// GetExport mocks base method.
+func (m *MockArticleService) GetExport(ctx context.Context, uid, id int64) (domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, uid, id)
+	ret0, _ := ret[0].(domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockArticleServiceMockRecorder) GetExport(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockArticleService)(nil).GetExport), ctx, uid, id)
+}
+
+// GetImport mocks base method.
+func (m *MockArticleService) GetImport(ctx context.Context, uid, id int64) (domain.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", ctx, uid, id)
+	ret0, _ := ret[0].(domain.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockArticleServiceMockRecorder) GetImport(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockArticleService)(nil).GetImport), ctx, uid, id)
+}
+
 // GetPublishedById mocks base method.
 func (m *MockArticleService) GetPublishedById(ctx context.Context, id, uid int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +249,36 @@ func (mr *MockArticleServiceMockRecorder) GetPublishedById(ctx, id, uid any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockArticleService)(nil).GetPublishedById), ctx, id, uid)
 }
 
+// GetRevision mocks base method.
+func (m *MockArticleService) GetRevision(ctx context.Context, uid, id int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, uid, id)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleServiceMockRecorder) GetRevision(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleService)(nil).GetRevision), ctx, uid, id)
+}
+
+// GetSeriesNav mocks base method.
+func (m *MockArticleService) GetSeriesNav(ctx context.Context, artId int64) (domain.SeriesNav, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeriesNav", ctx, artId)
+	ret0, _ := ret[0].(domain.SeriesNav)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeriesNav indicates an expected call of GetSeriesNav.
+func (mr *MockArticleServiceMockRecorder) GetSeriesNav(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeriesNav", reflect.TypeOf((*MockArticleService)(nil).GetSeriesNav), ctx, artId)
+}
+
 // List mocks base method.
 func (m *MockArticleService) List(ctx context.Context, id int64, offset, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +294,112 @@ func (mr *MockArticleServiceMockRecorder) List(ctx, id, offset, limit any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleService)(nil).List), ctx, id, offset, limit)
 }
 
+// ListAttachments mocks base method.
+func (m *MockArticleService) ListAttachments(ctx context.Context, uid int64, offset, limit int) ([]domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockArticleServiceMockRecorder) ListAttachments(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockArticleService)(nil).ListAttachments), ctx, uid, offset, limit)
+}
+
+// ListByCursor mocks base method.
+func (m *MockArticleService) ListByCursor(ctx context.Context, uid int64, cursor string, limit int) ([]domain.Article, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockArticleServiceMockRecorder) ListByCursor(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockArticleService)(nil).ListByCursor), ctx, uid, cursor, limit)
+}
+
+// ListExports mocks base method.
+func (m *MockArticleService) ListExports(ctx context.Context, uid int64, offset, limit int) ([]domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExports", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockArticleServiceMockRecorder) ListExports(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockArticleService)(nil).ListExports), ctx, uid, offset, limit)
+}
+
+// ListFlaggedDuplicates mocks base method.
+func (m *MockArticleService) ListFlaggedDuplicates(ctx context.Context, offset, limit int) ([]domain.ModerationLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlaggedDuplicates", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.ModerationLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlaggedDuplicates indicates an expected call of ListFlaggedDuplicates.
+func (mr *MockArticleServiceMockRecorder) ListFlaggedDuplicates(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlaggedDuplicates", reflect.TypeOf((*MockArticleService)(nil).ListFlaggedDuplicates), ctx, offset, limit)
+}
+
+// ListImports mocks base method.
+func (m *MockArticleService) ListImports(ctx context.Context, uid int64, offset, limit int) ([]domain.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImports", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImports indicates an expected call of ListImports.
+func (mr *MockArticleServiceMockRecorder) ListImports(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImports", reflect.TypeOf((*MockArticleService)(nil).ListImports), ctx, uid, offset, limit)
+}
+
+// ListModerationLogs mocks base method.
+func (m *MockArticleService) ListModerationLogs(ctx context.Context, artId int64, offset, limit int) ([]domain.ModerationLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationLogs", ctx, artId, offset, limit)
+	ret0, _ := ret[0].([]domain.ModerationLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationLogs indicates an expected call of ListModerationLogs.
+func (mr *MockArticleServiceMockRecorder) ListModerationLogs(ctx, artId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationLogs", reflect.TypeOf((*MockArticleService)(nil).ListModerationLogs), ctx, artId, offset, limit)
+}
+
+// ListPendingReview mocks base method.
+func (m *MockArticleService) ListPendingReview(ctx context.Context, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingReview", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingReview indicates an expected call of ListPendingReview.
+func (mr *MockArticleServiceMockRecorder) ListPendingReview(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingReview", reflect.TypeOf((*MockArticleService)(nil).ListPendingReview), ctx, offset, limit)
+}
+
 // ListPub mocks base method.
 func (m *MockArticleService) ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +415,112 @@ func (mr *MockArticleServiceMockRecorder) ListPub(ctx, start, offset, limit any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), ctx, start, offset, limit)
 }
 
+// ListPubByAuthor mocks base method.
+func (m *MockArticleService) ListPubByAuthor(ctx context.Context, author int64, start time.Time, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, author, start, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleServiceMockRecorder) ListPubByAuthor(ctx, author, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleService)(nil).ListPubByAuthor), ctx, author, start, offset, limit)
+}
+
+// ListPubByCursor mocks base method.
+func (m *MockArticleService) ListPubByCursor(ctx context.Context, start time.Time, cursor string, limit int) ([]domain.Article, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByCursor", ctx, start, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPubByCursor indicates an expected call of ListPubByCursor.
+func (mr *MockArticleServiceMockRecorder) ListPubByCursor(ctx, start, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByCursor", reflect.TypeOf((*MockArticleService)(nil).ListPubByCursor), ctx, start, cursor, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleService) ListPubByTag(ctx context.Context, tag string, start time.Time, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, start, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceMockRecorder) ListPubByTag(ctx, tag, start, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleService)(nil).ListPubByTag), ctx, tag, start, offset, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleService) ListRevisions(ctx context.Context, uid, artId int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, uid, artId, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceMockRecorder) ListRevisions(ctx, uid, artId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleService)(nil).ListRevisions), ctx, uid, artId, offset, limit)
+}
+
+// ListSeries mocks base method.
+func (m *MockArticleService) ListSeries(ctx context.Context, uid int64, offset, limit int) ([]domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockArticleServiceMockRecorder) ListSeries(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockArticleService)(nil).ListSeries), ctx, uid, offset, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleService) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleServiceMockRecorder) ListTrash(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleService)(nil).ListTrash), ctx, uid, offset, limit)
+}
+
+// LookupPublished mocks base method.
+func (m *MockArticleService) LookupPublished(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupPublished", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupPublished indicates an expected call of LookupPublished.
+func (mr *MockArticleServiceMockRecorder) LookupPublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupPublished", reflect.TypeOf((*MockArticleService)(nil).LookupPublished), ctx, id)
+}
+
 // Publish mocks base method.
 func (m *MockArticleService) Publish(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +551,92 @@ func (mr *MockArticleServiceMockRecorder) PublishV1(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishV1", reflect.TypeOf((*MockArticleService)(nil).PublishV1), ctx, art)
 }
 
+// Purge mocks base method.
+func (m *MockArticleService) Purge(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleServiceMockRecorder) Purge(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleService)(nil).Purge), ctx, uid, id)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockArticleService) PurgeExpired(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx, before, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockArticleServiceMockRecorder) PurgeExpired(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockArticleService)(nil).PurgeExpired), ctx, before, limit)
+}
+
+// Reject mocks base method.
+func (m *MockArticleService) Reject(ctx context.Context, reviewer, id, version int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, reviewer, id, version, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockArticleServiceMockRecorder) Reject(ctx, reviewer, id, version, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockArticleService)(nil).Reject), ctx, reviewer, id, version, reason)
+}
+
+// ReorderSeries mocks base method.
+func (m *MockArticleService) ReorderSeries(ctx context.Context, uid, id int64, artIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderSeries", ctx, uid, id, artIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderSeries indicates an expected call of ReorderSeries.
+func (mr *MockArticleServiceMockRecorder) ReorderSeries(ctx, uid, id, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSeries", reflect.TypeOf((*MockArticleService)(nil).ReorderSeries), ctx, uid, id, artIds)
+}
+
+// Restore mocks base method.
+func (m *MockArticleService) Restore(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleServiceMockRecorder) Restore(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleService)(nil).Restore), ctx, uid, id)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticleService) RestoreRevision(ctx context.Context, uid, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, uid, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceMockRecorder) RestoreRevision(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleService)(nil).RestoreRevision), ctx, uid, id)
+}
+
 // Save mocks base method.
 func (m *MockArticleService) Save(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +652,51 @@ func (mr *MockArticleServiceMockRecorder) Save(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleService)(nil).Save), ctx, art)
 }
 
+// SchedulePublish mocks base method.
+func (m *MockArticleService) SchedulePublish(ctx context.Context, art domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePublish", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePublish indicates an expected call of SchedulePublish.
+func (mr *MockArticleServiceMockRecorder) SchedulePublish(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePublish", reflect.TypeOf((*MockArticleService)(nil).SchedulePublish), ctx, art)
+}
+
+// StartExport mocks base method.
+func (m *MockArticleService) StartExport(ctx context.Context, uid int64) (domain.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartExport", ctx, uid)
+	ret0, _ := ret[0].(domain.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartExport indicates an expected call of StartExport.
+func (mr *MockArticleServiceMockRecorder) StartExport(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExport", reflect.TypeOf((*MockArticleService)(nil).StartExport), ctx, uid)
+}
+
+// StartImport mocks base method.
+func (m *MockArticleService) StartImport(ctx context.Context, uid int64, data []byte) (domain.Import, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", ctx, uid, data)
+	ret0, _ := ret[0].(domain.Import)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockArticleServiceMockRecorder) StartImport(ctx, uid, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockArticleService)(nil).StartImport), ctx, uid, data)
+}
+
 // WithDraw mocks base method.
 func (m *MockArticleService) WithDraw(ctx context.Context, art domain.Article) error {
 	m.ctrl.T.Helper()
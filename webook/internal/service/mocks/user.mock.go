@@ -12,8 +12,8 @@ package svcmocks
 import (
 	context "context"
 	reflect "reflect"
-	domain "github.com/TengFeiyang01/webook/webook/internal/domain"
 
+	domain "github.com/TengFeiyang01/webook/webook/internal/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -100,6 +100,21 @@ func (mr *MockUserServiceMockRecorder) Profile(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockUserService)(nil).Profile), ctx, id)
 }
 
+// Profiles mocks base method.
+func (m *MockUserService) Profiles(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profiles", ctx, ids)
+	ret0, _ := ret[0].(map[int64]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profiles indicates an expected call of Profiles.
+func (mr *MockUserServiceMockRecorder) Profiles(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profiles", reflect.TypeOf((*MockUserService)(nil).Profiles), ctx, ids)
+}
+
 // SignUp mocks base method.
 func (m *MockUserService) SignUp(ctx context.Context, u domain.User) error {
 	m.ctrl.T.Helper()
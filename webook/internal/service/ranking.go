@@ -51,7 +51,8 @@ func (svc *BatchRankingService) TopN(ctx context.Context) error {
 }
 
 func (svc *BatchRankingService) topN(ctx context.Context) ([]domain.Article, error) {
-	offset := 0
+	// 用游标而不是 offset，计算过程中有文章更新也不会跳过或者重复
+	cursor := ""
 	now := time.Now()
 	ddl := now.Add(-time.Hour * 24 * 7)
 	type Score struct {
@@ -69,10 +70,13 @@ func (svc *BatchRankingService) topN(ctx context.Context) ([]domain.Article, err
 	})
 
 	for {
-		arts, err := svc.artSvc.ListPub(ctx, now, offset, svc.batchSize)
+		arts, next, err := svc.artSvc.ListPubByCursor(ctx, now, cursor, svc.batchSize)
 		if err != nil {
 			return nil, err
 		}
+		if len(arts) == 0 {
+			break
+		}
 		ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
 			return src.Id
 		})
@@ -116,11 +120,10 @@ func (svc *BatchRankingService) topN(ctx context.Context) ([]domain.Article, err
 		}
 
 		// 一批已经处理完了, 问题来了, 我要不要进入下一批？我怎么知道还有没有
-		if len(arts) == 0 || len(arts) < svc.batchSize || arts[len(arts)-1].Utime.Before(ddl) {
+		if next == "" || arts[len(arts)-1].Utime.Before(ddl) {
 			break
 		}
-		// 更新 offset
-		offset = offset + len(arts)
+		cursor = next
 	}
 	// 最后得出结果
 	ql := q.Len()
@@ -28,14 +28,14 @@ func TestRankingTopN(t *testing.T) {
 			mock: func(ctrl *gomock.Controller) (service2.ArticleService, intrv1.InteractiveServiceClient) {
 				artSvc := svcmocks.NewMockArticleService(ctrl)
 				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 0, 3).
+				artSvc.EXPECT().ListPubByCursor(gomock.Any(), gomock.Any(), "", 3).
 					Return([]domain.Article{
 						{Id: 1, Utime: now, Ctime: now},
 						{Id: 2, Utime: now, Ctime: now},
 						{Id: 3, Utime: now, Ctime: now},
-					}, nil)
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 3, 3).
-					Return([]domain.Article{}, nil)
+					}, "next", nil)
+				artSvc.EXPECT().ListPubByCursor(gomock.Any(), gomock.Any(), "next", 3).
+					Return([]domain.Article{}, "", nil)
 
 				interSvc.EXPECT().GetByIds(gomock.Any(), &intrv1.GetByIdsRequest{
 					Biz:    "art",
@@ -46,11 +46,6 @@ func TestRankingTopN(t *testing.T) {
 						2: {BizId: 2, LikeCnt: 2},
 						3: {BizId: 3, LikeCnt: 3},
 					}}, nil)
-				interSvc.EXPECT().GetByIds(gomock.Any(), &intrv1.GetByIdsRequest{
-					Biz:    "art",
-					BizIds: []int64{},
-				}).
-					Return(&intrv1.GetByIdsResponse{Intrs: map[int64]*intrv1.Interactive{}}, nil)
 				return artSvc, interSvc
 			},
 
@@ -16,12 +16,15 @@ type UserService interface {
 	FindOrCreate(ctx context.Context, phone string) (domain.User, error)
 	FindOrCreateByWechat(ctx context.Context, wechatInfo domain.WechatInfo) (domain.User, error)
 	Profile(ctx context.Context, id int64) (domain.User, error)
+	// Profiles 批量查询，key 是用户 ID，不存在的用户不会出现在结果里
+	Profiles(ctx context.Context, ids []int64) (map[int64]domain.User, error)
 	UpdateById(ctx context.Context, u domain.User) error
 	UpdateNonSensitiveInfo(ctx context.Context, u domain.User) error
 }
 
 var (
 	ErrUserDuplicate         = repository.ErrUserDuplicate
+	ErrUserNotFound          = repository.ErrUserNotFound
 	ErrInvalidUserOrPassword = errors.New("邮箱或密码不对")
 )
 
@@ -125,6 +128,18 @@ func (svc *userService) Profile(ctx context.Context, id int64) (domain.User, err
 	return svc.repo.FindById(ctx, id)
 }
 
+func (svc *userService) Profiles(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	us, err := svc.repo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.User, len(us))
+	for _, u := range us {
+		res[u.ID] = u
+	}
+	return res, nil
+}
+
 func (svc *userService) UpdateById(ctx context.Context, u domain.User) error {
 	return svc.repo.UpdateById(ctx, u)
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 	"net/http"
 	"strconv"
@@ -13,8 +15,13 @@ import (
 	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
 	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/internal/errs"
+	"github.com/TengFeiyang01/webook/webook/internal/job"
+	"github.com/TengFeiyang01/webook/webook/internal/service"
 	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	"github.com/TengFeiyang01/webook/webook/pkg/diff"
 	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
 )
 
@@ -23,14 +30,17 @@ var _ handler = (*ArticleHandler)(nil)
 type ArticleHandler struct {
 	svc      artv1.ArticleServiceClient
 	interSvc intrv1.InteractiveServiceClient
+	jobSvc   service.JobService
 	biz      string
 	l        logger.LoggerV1
 }
 
-func NewArticleHandler(svc artv1.ArticleServiceClient, l logger.LoggerV1, intrSvc intrv1.InteractiveServiceClient) *ArticleHandler {
+func NewArticleHandler(svc artv1.ArticleServiceClient, l logger.LoggerV1, intrSvc intrv1.InteractiveServiceClient,
+	jobSvc service.JobService) *ArticleHandler {
 	return &ArticleHandler{
 		svc:      svc,
 		interSvc: intrSvc,
+		jobSvc:   jobSvc,
 		l:        l,
 		biz:      "art",
 	}
@@ -44,9 +54,32 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	// 这个是获取数据的接口，理论上来说（遵循 RESTful 规范），应该是 GET 方法
 	g.POST("/list", ginx.WrapBodyAndToken[ListReq, ijwt.UserClaims](h.List))
 	g.POST("/detail/:id", ginx.WrapToken[ijwt.UserClaims](h.Detail))
+	// 定时发表，重新提交就是修改时间
+	g.POST("/schedule", ginx.WrapBodyAndToken[ScheduleReq, ijwt.UserClaims](h.Schedule))
+	g.POST("/schedule/cancel", ginx.WrapBodyAndToken[CancelScheduleReq, ijwt.UserClaims](h.CancelSchedule))
+
+	// 回收站
+	g.POST("/delete", ginx.WrapBodyAndToken[TrashReq, ijwt.UserClaims](h.Delete))
+	g.POST("/trash", ginx.WrapBodyAndToken[ListTrashReq, ijwt.UserClaims](h.ListTrash))
+	g.POST("/restore", ginx.WrapBodyAndToken[TrashReq, ijwt.UserClaims](h.Restore))
+	g.POST("/purge", ginx.WrapBodyAndToken[TrashReq, ijwt.UserClaims](h.Purge))
+
+	// 系列
+	series := g.Group("/series")
+	series.POST("/create", ginx.WrapBodyAndToken[SeriesReq, ijwt.UserClaims](h.CreateSeries))
+	series.POST("/reorder", ginx.WrapBodyAndToken[ReorderSeriesReq, ijwt.UserClaims](h.ReorderSeries))
+	series.POST("/list", ginx.WrapBodyAndToken[ListSeriesReq, ijwt.UserClaims](h.ListSeries))
+
+	// 历史版本
+	rev := g.Group("/revisions")
+	rev.POST("/list", ginx.WrapBodyAndToken[RevisionListReq, ijwt.UserClaims](h.ListRevisions))
+	rev.POST("/detail/:id", ginx.WrapToken[ijwt.UserClaims](h.RevisionDetail))
+	rev.POST("/diff", ginx.WrapBodyAndToken[RevisionDiffReq, ijwt.UserClaims](h.DiffRevisions))
+	rev.POST("/restore", ginx.WrapBodyAndToken[RevisionRestoreReq, ijwt.UserClaims](h.RestoreRevision))
 
 	pub := g.Group("/pub")
 	pub.GET("/:id", ginx.WrapToken[ijwt.UserClaims](h.PubDetail))
+	pub.POST("/tag", ginx.WrapBodyAndToken[ListPubByTagReq, ijwt.UserClaims](h.ListPubByTag))
 	// 点赞和取消点赞都复用这个接口
 	pub.POST("/like", ginx.WrapBodyAndToken[LikeReq, ijwt.UserClaims](h.Like))
 	pub.POST("/collect", ginx.WrapBodyAndToken[CollectReq, ijwt.UserClaims](h.Collect))
@@ -155,14 +188,25 @@ func (h *ArticleHandler) Edit(ctx *gin.Context) {
 	}
 	resp, err := h.svc.Save(ctx, &artv1.SaveRequest{
 		Art: &artv1.Article{
-			Id:      req.Id,
-			Title:   req.Title,
-			Content: req.Content,
+			Id:       req.Id,
+			Title:    req.Title,
+			Content:  req.Content,
+			Tags:     req.tags(),
+			KeepTags: req.keepTags(),
+			Version:  req.version(),
 			Author: &artv1.Author{
 				Id: claims.Uid,
 			},
 		},
 	})
+	if status.Code(err) == codes.Aborted {
+		ctx.JSON(http.StatusOK, h.versionConflict(ctx, req.Id))
+		return
+	}
+	if status.Code(err) == codes.InvalidArgument {
+		ctx.JSON(http.StatusOK, invalidArticleResult(err))
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: 5,
@@ -171,12 +215,67 @@ func (h *ArticleHandler) Edit(ctx *gin.Context) {
 		h.l.Info("保存帖子失败", logger.Error(err))
 		return
 	}
+	if req.Version == nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Msg:  "OK",
+			Data: resp.GetId(),
+		})
+		return
+	}
 	ctx.JSON(http.StatusOK, ginx.Result{
-		Msg:  "OK",
-		Data: resp.GetId(),
+		Msg: "OK",
+		Data: ArticleSaveVO{
+			Id:      resp.GetId(),
+			Version: resp.GetVersion(),
+		},
 	})
 }
 
+// sensitiveArticleResult 保存、发表的时候标题或者内容里面有违禁词
+var sensitiveArticleResult = ginx.Result{
+	Code: errs.ArticleSensitiveContent,
+	Msg:  "标题或者内容包含违禁词",
+}
+
+// invalidArticleResult 文章服务返回了 InvalidArgument，按照错误详情里面的原因区分提示
+func invalidArticleResult(err error) ginx.Result {
+	switch grpcx.Reason(err) {
+	case domain.ReasonSensitiveContent:
+		return sensitiveArticleResult
+	case domain.ReasonTooManyTags:
+		return ginx.Result{
+			Code: errs.ArticleTooManyTags,
+			Msg:  fmt.Sprintf("最多只能有 %d 个标签", domain.MaxTagsPerArticle),
+		}
+	default:
+		return ginx.Result{
+			Code: errs.ArticleInvalidInput,
+			Msg:  "参数错误",
+		}
+	}
+}
+
+// versionConflict 把服务端当前的版本带回去，查询失败也要返回冲突的错误码
+func (h *ArticleHandler) versionConflict(ctx *gin.Context, id int64) ginx.Result {
+	res := ginx.Result{
+		Code: errs.ArticleVersionConflict,
+		Msg:  "文章已经在别处被修改",
+	}
+	resp, err := h.svc.GetById(ctx, &artv1.GetByIdRequest{Id: id})
+	if err != nil {
+		h.l.Error("查询文章最新版本失败", logger.Int64("aid", id), logger.Error(err))
+		return res
+	}
+	art := resp.GetArt()
+	res.Data = ArticleConflictVO{
+		Version: art.GetVersion(),
+		Title:   art.GetTitle(),
+		Content: art.GetContent(),
+		Utime:   time.UnixMilli(art.GetUtime()).Format(time.DateTime),
+	}
+	return res
+}
+
 func (h *ArticleHandler) Publish(ctx *gin.Context) {
 	var req ArticleReq
 	if err := ctx.Bind(&req); err != nil {
@@ -195,14 +294,25 @@ func (h *ArticleHandler) Publish(ctx *gin.Context) {
 	}
 	resp, err := h.svc.Publish(ctx, &artv1.PublishRequest{
 		Art: &artv1.Article{
-			Id:      req.Id,
-			Title:   req.Title,
-			Content: req.Content,
+			Id:       req.Id,
+			Title:    req.Title,
+			Content:  req.Content,
+			Tags:     req.tags(),
+			KeepTags: req.keepTags(),
+			Version:  req.version(),
 			Author: &artv1.Author{
 				Id: claims.Uid,
 			},
 		},
 	})
+	if status.Code(err) == codes.Aborted {
+		ctx.JSON(http.StatusOK, h.versionConflict(ctx, req.Id))
+		return
+	}
+	if status.Code(err) == codes.InvalidArgument {
+		ctx.JSON(http.StatusOK, invalidArticleResult(err))
+		return
+	}
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		ctx.JSON(http.StatusOK, ginx.Result{
 			Code: http.StatusUnauthorized,
@@ -225,20 +335,36 @@ func (h *ArticleHandler) Publish(ctx *gin.Context) {
 	})
 }
 
-func (h *ArticleHandler) Abstract(content string) string {
-	cs := []rune(content)
-	if len(cs) < 100 {
-		return content
+// Abstract 优先使用渲染出来的摘要，老版本的服务端没有这个字段，就退化成截取原文
+func (h *ArticleHandler) Abstract(art *artv1.Article) string {
+	if art.GetAbstract() != "" {
+		return art.GetAbstract()
 	}
-	return content[:100]
+	cs := []rune(art.GetContent())
+	if len(cs) <= domain.MaxAbstractLength {
+		return art.GetContent()
+	}
+	return string(cs[:domain.MaxAbstractLength])
 }
 
 func (h *ArticleHandler) List(ctx *gin.Context, req ListReq, uc ijwt.UserClaims) (ginx.Result, error) {
-	resp, err := h.svc.List(ctx, &artv1.ListRequest{
+	listReq := &artv1.ListRequest{
 		Id:     uc.Uid,
 		Offset: int32(req.Offset),
 		Limit:  int32(req.Limit),
-	})
+	}
+	if req.Cursor != nil {
+		// 游标模式下忽略 offset
+		listReq.Offset = 0
+		listReq.Cursor = *req.Cursor
+	}
+	resp, err := h.svc.List(ctx, listReq)
+	if status.Code(err) == codes.InvalidArgument {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
@@ -246,15 +372,61 @@ func (h *ArticleHandler) List(ctx *gin.Context, req ListReq, uc ijwt.UserClaims)
 		}, nil
 	}
 	// 列表页不显示全文，只显示“摘要”
+	arts := slice.Map[*artv1.Article, ArticleVO](resp.Arts,
+		func(idx int, src *artv1.Article) ArticleVO {
+			return ArticleVO{
+				Id:             src.Id,
+				Title:          src.Title,
+				Content:        src.Content,
+				Abstract:       h.Abstract(src),
+				ReadingMinutes: src.ReadingMinutes,
+				Author:         src.Author.Name,
+				Status:         uint8(src.Status),
+				PublishAt:      h.formatPublishAt(src.PublishAt),
+				Tags:           src.Tags,
+				Ctime:          time.UnixMilli(src.Ctime).Format(time.DateTime),
+				Utime:          time.UnixMilli(src.Utime).Format(time.DateTime),
+			}
+		})
+	if req.Cursor == nil {
+		return ginx.Result{Data: arts}, nil
+	}
+	return ginx.Result{
+		Data: ArticleListVO{
+			Arts:       arts,
+			NextCursor: resp.GetNextCursor(),
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) ListPubByTag(ctx *gin.Context, req ListPubByTagReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if req.Tag == "" {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	resp, err := h.svc.ListPubByTag(ctx, &artv1.ListPubByTagRequest{
+		Tag:       req.Tag,
+		Timestamp: time.Now().UnixMilli(),
+		Offset:    int32(req.Offset),
+		Limit:     int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	// 列表页不显示全文，只显示“摘要”
 	return ginx.Result{
 		Data: slice.Map[*artv1.Article, ArticleVO](resp.Arts,
 			func(idx int, src *artv1.Article) ArticleVO {
 				return ArticleVO{
 					Id:       src.Id,
 					Title:    src.Title,
-					Content:  src.Content,
-					Abstract: h.Abstract(src.Content),
-					Author:   src.Author.Name,
+					Abstract: h.Abstract(src),
+					Tags:     src.Tags,
 					Ctime:    time.UnixMilli(src.Ctime).Format(time.DateTime),
 					Utime:    time.UnixMilli(src.Utime).Format(time.DateTime),
 				}
@@ -288,13 +460,18 @@ func (h *ArticleHandler) Detail(ctx *gin.Context, usr ijwt.UserClaims) (ginx.Res
 	}
 	return ginx.Result{
 		Data: ArticleVO{
-			Id:       resp.Art.Id,
-			Title:    resp.Art.Title,
-			Abstract: h.Abstract(resp.Art.Content),
-			Content:  resp.Art.Content,
-			Status:   domain.ArticleStatus(resp.Art.Status).ToUint8(),
-			Ctime:    time.UnixMilli(resp.Art.Ctime).Format(time.DateTime),
-			Utime:    time.UnixMilli(resp.Art.Utime).Format(time.DateTime),
+			Id:             resp.Art.Id,
+			Title:          resp.Art.Title,
+			Abstract:       h.Abstract(resp.Art),
+			Content:        resp.Art.Content,
+			Html:           resp.Art.Html,
+			ReadingMinutes: resp.Art.ReadingMinutes,
+			Version:        resp.Art.Version,
+			Status:         domain.ArticleStatus(resp.Art.Status).ToUint8(),
+			PublishAt:      h.formatPublishAt(resp.Art.PublishAt),
+			Tags:           resp.Art.Tags,
+			Ctime:          time.UnixMilli(resp.Art.Ctime).Format(time.DateTime),
+			Utime:          time.UnixMilli(resp.Art.Utime).Format(time.DateTime),
 		},
 	}, nil
 }
@@ -315,15 +492,28 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, usr ijwt.UserClaims) (ginx.
 	// 读文章本体
 	eg.Go(func() error {
 		resp, err := h.svc.GetPubById(ctx, &artv1.GetPubByIdRequest{Id: id, Uid: usr.Uid})
+		if err != nil {
+			return err
+		}
 		art = domain.Article{
 			Id:      resp.Art.Id,
 			Title:   resp.Art.Title,
 			Content: resp.Art.Content,
 			Status:  domain.ArticleStatus(resp.Art.Status),
-			Ctime:   time.UnixMilli(resp.Art.Ctime),
-			Utime:   time.UnixMilli(resp.Art.Utime),
+			Tags:    resp.Art.Tags,
+			Author: domain.Author{
+				Id:   resp.Art.GetAuthor().GetId(),
+				Name: resp.Art.GetAuthor().GetName(),
+			},
+			Rendered: domain.RenderedContent{
+				HTML:        resp.Art.Html,
+				Abstract:    resp.Art.Abstract,
+				ReadingTime: time.Duration(resp.Art.ReadingMinutes) * time.Minute,
+			},
+			Ctime: time.UnixMilli(resp.Art.Ctime),
+			Utime: time.UnixMilli(resp.Art.Utime),
 		}
-		return err
+		return nil
 	})
 	if err := eg.Wait(); err != nil {
 		h.l.Error("failed to get published art", logger.Error(err))
@@ -333,14 +523,6 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, usr ijwt.UserClaims) (ginx.
 		}, err
 	}
 
-	// 不借助数据库查询来判断
-	if art.Author.Id != usr.Uid {
-		return ginx.Result{
-			Code: http.StatusBadRequest,
-			Msg:  "input error",
-		}, fmt.Errorf("非法访问文章，创作者 ID 不匹配 %d", usr.Uid)
-	}
-
 	// 要在这里获得文章的计数
 
 	var resp *intrv1.GetResponse
@@ -352,6 +534,17 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, usr ijwt.UserClaims) (ginx.
 		})
 		return err
 	})
+	var series *SeriesNavVO
+	eg.Go(func() error {
+		// 系列导航不是必须的，查不到也不影响读者看文章
+		navResp, err := h.svc.GetSeriesNav(ctx, &artv1.GetSeriesNavRequest{ArtId: id})
+		if err != nil {
+			h.l.Error("failed to get series nav", logger.Int64("aid", id), logger.Error(err))
+			return nil
+		}
+		series = h.toSeriesNavVO(navResp)
+		return nil
+	})
 	if err := eg.Wait(); err != nil {
 		h.l.Error("failed to get interactive art", logger.Error(err))
 		return ginx.Result{
@@ -372,18 +565,351 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, usr ijwt.UserClaims) (ginx.
 
 	return ginx.Result{
 		Data: ArticleVO{
-			Id:         art.Id,
-			Title:      art.Title,
-			Status:     art.Status.ToUint8(),
-			Content:    art.Content,
-			Author:     art.Author.Name,
-			Ctime:      art.Ctime.Format(time.DateTime),
-			Utime:      art.Utime.Format(time.DateTime),
-			LikeCnt:    resp.Intr.LikeCnt,
-			ReadCnt:    resp.Intr.ReadCnt,
-			CollectCnt: resp.Intr.CollectCnt,
-			Liked:      resp.Intr.Liked,
-			Collected:  resp.Intr.Collected,
+			Id:             art.Id,
+			Title:          art.Title,
+			Status:         art.Status.ToUint8(),
+			Content:        art.Content,
+			Html:           art.Rendered.HTML,
+			Abstract:       art.Abstract(),
+			ReadingMinutes: int32(art.Rendered.ReadingTime / time.Minute),
+			Tags:           art.Tags,
+			Author:         art.Author.Name,
+			Ctime:          art.Ctime.Format(time.DateTime),
+			Utime:          art.Utime.Format(time.DateTime),
+			LikeCnt:        resp.Intr.LikeCnt,
+			ReadCnt:        resp.Intr.ReadCnt,
+			CollectCnt:     resp.Intr.CollectCnt,
+			Liked:          resp.Intr.Liked,
+			Collected:      resp.Intr.Collected,
+			Series:         series,
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) ListRevisions(ctx *gin.Context, req RevisionListReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.ListRevisions(ctx, &artv1.ListRevisionsRequest{
+		Uid:       uc.Uid,
+		ArticleId: req.Id,
+		Offset:    int32(req.Offset),
+		Limit:     int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetRevisions(), func(idx int, src *artv1.ArticleRevision) RevisionVO {
+			vo := h.toRevisionVO(src)
+			vo.Content = ""
+			return vo
+		}),
+	}, nil
+}
+
+func (h *ArticleHandler) RevisionDetail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	resp, err := h.svc.GetRevision(ctx, &artv1.GetRevisionRequest{Uid: uc.Uid, Id: id})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Data: h.toRevisionVO(resp.GetRevision())}, nil
+}
+
+func (h *ArticleHandler) DiffRevisions(ctx *gin.Context, req RevisionDiffReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.DiffRevisions(ctx, &artv1.DiffRevisionsRequest{
+		Uid:  uc.Uid,
+		From: req.From,
+		To:   req.To,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	from, to := h.toRevisionVO(resp.GetFrom()), h.toRevisionVO(resp.GetTo())
+	from.Content, to.Content = "", ""
+	return ginx.Result{
+		Data: RevisionDiffVO{
+			From: from,
+			To:   to,
+			Lines: slice.Map(resp.GetLines(), func(idx int, src *artv1.DiffLine) DiffLineVO {
+				return DiffLineVO{
+					Op:   diff.Op(src.GetOp()).String(),
+					Text: src.GetText(),
+				}
+			}),
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) RestoreRevision(ctx *gin.Context, req RevisionRestoreReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.RestoreRevision(ctx, &artv1.RestoreRevisionRequest{Uid: uc.Uid, Id: req.Id})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Msg:  "OK",
+		Data: resp.GetId(),
+	}, nil
+}
+
+func (h *ArticleHandler) toRevisionVO(rev *artv1.ArticleRevision) RevisionVO {
+	return RevisionVO{
+		Id:        rev.GetId(),
+		ArticleId: rev.GetArticleId(),
+		Version:   rev.GetVersion(),
+		Title:     rev.GetTitle(),
+		Content:   rev.GetContent(),
+		Kind:      domain.RevisionKind(rev.GetKind()).String(),
+		Ctime:     time.UnixMilli(rev.GetCtime()).Format(time.DateTime),
+	}
+}
+
+func (h *ArticleHandler) Schedule(ctx *gin.Context, req ScheduleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	publishAt := time.UnixMilli(req.PublishAt)
+	if req.PublishAt <= 0 || !publishAt.After(time.Now()) {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	resp, err := h.svc.SchedulePublish(ctx, &artv1.SchedulePublishRequest{
+		Art: &artv1.Article{
+			Id:        req.Id,
+			Title:     req.Title,
+			Content:   req.Content,
+			PublishAt: req.PublishAt,
+			Tags:      req.Tags,
+			Author: &artv1.Author{
+				Id: uc.Uid,
+			},
 		},
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return invalidArticleResult(err), nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	// 发表的任务由 ArticleScheduledConsumer 安排，事件和定时状态在文章服务里面一起提交
+	return ginx.Result{
+		Msg:  "OK",
+		Data: resp.GetId(),
 	}, nil
 }
+
+func (h *ArticleHandler) CancelSchedule(ctx *gin.Context, req CancelScheduleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.CancelSchedule(ctx, &artv1.CancelScheduleRequest{Id: req.Id, Uid: uc.Uid})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	// 就算这里失败了，任务执行的时候发现文章不是定时状态也会跳过
+	err = h.jobSvc.Cancel(ctx, job.ArticlePublishJobName(req.Id))
+	if err != nil {
+		h.l.Error("取消定时发表任务失败",
+			logger.Int64("aid", req.Id),
+			logger.Error(err))
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) Delete(ctx *gin.Context, req TrashReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.Delete(ctx, &artv1.DeleteRequest{Id: req.Id, Uid: uc.Uid})
+	if err != nil {
+		return h.trashErr(err)
+	}
+	// 定时发表的文章删除之后就不要再发表了，恢复的时候会重新安排
+	err = h.jobSvc.Cancel(ctx, job.ArticlePublishJobName(req.Id))
+	if err != nil {
+		h.l.Error("取消定时发表任务失败",
+			logger.Int64("aid", req.Id),
+			logger.Error(err))
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) ListTrash(ctx *gin.Context, req ListTrashReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.ListTrash(ctx, &artv1.ListTrashRequest{
+		Uid:    uc.Uid,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*artv1.Article, ArticleVO](resp.GetArts(),
+			func(idx int, src *artv1.Article) ArticleVO {
+				return ArticleVO{
+					Id:        src.Id,
+					Title:     src.Title,
+					Abstract:  h.Abstract(src),
+					Status:    uint8(src.Status),
+					Tags:      src.Tags,
+					Ctime:     time.UnixMilli(src.Ctime).Format(time.DateTime),
+					Utime:     time.UnixMilli(src.Utime).Format(time.DateTime),
+					DeletedAt: time.UnixMilli(src.DeletedAt).Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
+
+func (h *ArticleHandler) Restore(ctx *gin.Context, req TrashReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	// 定时发表的文章恢复之后，文章服务会再发一次定时发表的事件，重新安排任务
+	_, err := h.svc.Restore(ctx, &artv1.RestoreRequest{Id: req.Id, Uid: uc.Uid})
+	if err != nil {
+		return h.trashErr(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) Purge(ctx *gin.Context, req TrashReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.Purge(ctx, &artv1.PurgeRequest{Id: req.Id, Uid: uc.Uid})
+	if err != nil {
+		return h.trashErr(err)
+	}
+	// 文章已经没了，互动数据删除失败也不影响读者
+	_, err = h.interSvc.Delete(ctx, &intrv1.DeleteRequest{
+		Biz:    h.biz,
+		BizIds: []int64{req.Id},
+	})
+	if err != nil {
+		h.l.Error("删除文章的互动数据失败",
+			logger.Int64("aid", req.Id),
+			logger.Error(err))
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) CreateSeries(ctx *gin.Context, req SeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.CreateSeries(ctx, &artv1.CreateSeriesRequest{
+		Series: &artv1.Series{
+			Title:       req.Title,
+			Description: req.Description,
+			AuthorId:    uc.Uid,
+			ArticleIds:  req.ArticleIds,
+		},
+	})
+	if err != nil {
+		return h.seriesErr(err)
+	}
+	return ginx.Result{
+		Msg:  "OK",
+		Data: resp.GetId(),
+	}, nil
+}
+
+func (h *ArticleHandler) ReorderSeries(ctx *gin.Context, req ReorderSeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.ReorderSeries(ctx, &artv1.ReorderSeriesRequest{
+		Id:         req.Id,
+		Uid:        uc.Uid,
+		ArticleIds: req.ArticleIds,
+	})
+	if err != nil {
+		return h.seriesErr(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) ListSeries(ctx *gin.Context, req ListSeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.ListSeries(ctx, &artv1.ListSeriesRequest{
+		Uid:    uc.Uid,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*artv1.Series, SeriesVO](resp.GetSeries(),
+			func(idx int, src *artv1.Series) SeriesVO {
+				return SeriesVO{
+					Id:          src.Id,
+					Title:       src.Title,
+					Description: src.Description,
+					Ctime:       time.UnixMilli(src.Ctime).Format(time.DateTime),
+					Utime:       time.UnixMilli(src.Utime).Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
+
+// seriesErr 系列不存在、文章不对都算输入错误
+func (h *ArticleHandler) seriesErr(err error) (ginx.Result, error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	return ginx.Result{
+		Code: 5,
+		Msg:  "system error",
+	}, err
+}
+
+func (h *ArticleHandler) toSeriesNavVO(resp *artv1.GetSeriesNavResponse) *SeriesNavVO {
+	if resp.GetSeries() == nil {
+		return nil
+	}
+	res := &SeriesNavVO{
+		Id:    resp.GetSeries().GetId(),
+		Title: resp.GetSeries().GetTitle(),
+	}
+	if prev := resp.GetPrev(); prev != nil {
+		res.Prev = &SeriesItemVO{Id: prev.GetId(), Title: prev.GetTitle()}
+	}
+	if next := resp.GetNext(); next != nil {
+		res.Next = &SeriesItemVO{Id: next.GetId(), Title: next.GetTitle()}
+	}
+	return res
+}
+
+// trashErr 文章不存在、不是自己的，或者不在回收站里，都按照输入错误处理
+func (h *ArticleHandler) trashErr(err error) (ginx.Result, error) {
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, nil
+	}
+	return ginx.Result{
+		Code: 5,
+		Msg:  "system error",
+	}, err
+}
+
+func (h *ArticleHandler) formatPublishAt(ms int64) string {
+	if ms <= 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).Format(time.DateTime)
+}
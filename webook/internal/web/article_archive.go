@@ -0,0 +1,257 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/mdarchive"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*ArchiveHandler)(nil)
+
+// ArchiveHandler 把文章导出成 Markdown 压缩包，或者从压缩包导入
+// 导出和导入都在文章服务的后台执行，前端轮询进度
+type ArchiveHandler struct {
+	svc artv1.ArticleServiceClient
+	l   logger.LoggerV1
+}
+
+func NewArchiveHandler(svc artv1.ArticleServiceClient, l logger.LoggerV1) *ArchiveHandler {
+	return &ArchiveHandler{svc: svc, l: l}
+}
+
+func (h *ArchiveHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles")
+	g.POST("/export", ginx.WrapToken[ijwt.UserClaims](h.StartExport))
+	g.POST("/export/list", ginx.WrapBodyAndToken[ListExportsReq, ijwt.UserClaims](h.ListExports))
+	g.POST("/export/detail", ginx.WrapBodyAndToken[ExportReq, ijwt.UserClaims](h.GetExport))
+	// 表单上传，字段名是 file
+	g.POST("/import", ginx.WrapToken[ijwt.UserClaims](h.Import))
+	g.POST("/import/list", ginx.WrapBodyAndToken[ListImportsReq, ijwt.UserClaims](h.ListImports))
+	g.POST("/import/detail", ginx.WrapBodyAndToken[ImportReq, ijwt.UserClaims](h.GetImport))
+}
+
+func (h *ArchiveHandler) StartExport(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.StartExport(ctx, &artv1.StartExportRequest{Uid: uc.Uid})
+	if status.Code(err) == codes.FailedPrecondition {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "上一次导出还没有完成",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Data: h.toVO(resp.GetExport())}, nil
+}
+
+func (h *ArchiveHandler) ListExports(ctx *gin.Context, req ListExportsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.ListExports(ctx, &artv1.ListExportsRequest{
+		Uid:    uc.Uid,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetExports(), func(idx int, src *artv1.Export) ExportVO {
+			return h.toVO(src)
+		}),
+	}, nil
+}
+
+// GetExport 下载地址有有效期，过期了再调用一次拿新的
+func (h *ArchiveHandler) GetExport(ctx *gin.Context, req ExportReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.GetExport(ctx, &artv1.GetExportRequest{
+		Uid: uc.Uid,
+		Id:  req.Id,
+	})
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "导出任务不存在",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Data: h.toVO(resp.GetExport())}, nil
+}
+
+// Import 先在这里检查一遍压缩包，有问题直接告诉作者，然后交给文章服务在后台导入
+// 前端通过 /import/detail 轮询进度和每个文件的结果
+func (h *ArchiveHandler) Import(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	// 解析表单之前就限制住，留一点给表单本身
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, domain.MaxImportSize+1<<20)
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "请上传压缩包",
+		}, nil
+	}
+	if fh.Size > domain.MaxImportSize {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "压缩包太大",
+		}, nil
+	}
+	data, err := h.readFile(fh)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	_, err = mdarchive.Read(bytes.NewReader(data), int64(len(data)), mdarchive.Limits{
+		MaxFiles:    domain.MaxImportFiles,
+		MaxFileSize: domain.MaxImportFileSize,
+	})
+	switch {
+	case errors.Is(err, mdarchive.ErrTooManyFiles):
+		return ginx.Result{
+			Code: 4,
+			Msg:  fmt.Sprintf("一次最多导入 %d 篇", domain.MaxImportFiles),
+		}, nil
+	case errors.Is(err, mdarchive.ErrFileTooLarge):
+		return ginx.Result{
+			Code: 4,
+			Msg:  "单篇文章不能超过 1MB",
+		}, nil
+	case err != nil:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "压缩包格式不对",
+		}, nil
+	}
+	resp, err := h.svc.StartImport(ctx, &artv1.StartImportRequest{
+		Uid:  uc.Uid,
+		Data: data,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Data: h.toImportVO(resp.GetImport())}, nil
+	case codes.FailedPrecondition:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "上一次导入还没有完成",
+		}, nil
+	case codes.InvalidArgument:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "压缩包格式不对",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+}
+
+func (h *ArchiveHandler) readFile(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func (h *ArchiveHandler) ListImports(ctx *gin.Context, req ListImportsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.ListImports(ctx, &artv1.ListImportsRequest{
+		Uid:    uc.Uid,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetImports(), func(idx int, src *artv1.Import) ImportVO {
+			return h.toImportVO(src)
+		}),
+	}, nil
+}
+
+// GetImport 带着每个文件的结果
+func (h *ArchiveHandler) GetImport(ctx *gin.Context, req ImportReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.GetImport(ctx, &artv1.GetImportRequest{
+		Uid: uc.Uid,
+		Id:  req.Id,
+	})
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "导入任务不存在",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Data: h.toImportVO(resp.GetImport())}, nil
+}
+
+func (h *ArchiveHandler) toImportVO(i *artv1.Import) ImportVO {
+	return ImportVO{
+		Id:       i.GetId(),
+		Status:   uint8(i.GetStatus()),
+		Total:    int(i.GetTotal()),
+		Imported: int(i.GetImported()),
+		Results: slice.Map(i.GetResults(), func(idx int, src *artv1.ImportResult) ImportResultVO {
+			return ImportResultVO{
+				Filename: src.GetFilename(),
+				Title:    src.GetTitle(),
+				Id:       src.GetId(),
+				Error:    src.GetError(),
+			}
+		}),
+		Error: i.GetError(),
+		Ctime: time.UnixMilli(i.GetCtime()).Format(time.DateTime),
+		Utime: time.UnixMilli(i.GetUtime()).Format(time.DateTime),
+	}
+}
+
+func (h *ArchiveHandler) toVO(e *artv1.Export) ExportVO {
+	return ExportVO{
+		Id:          e.GetId(),
+		Status:      uint8(e.GetStatus()),
+		Total:       int(e.GetTotal()),
+		Exported:    int(e.GetExported()),
+		Size:        e.GetSize(),
+		DownloadUrl: e.GetDownloadUrl(),
+		Error:       e.GetError(),
+		Ctime:       time.UnixMilli(e.GetCtime()).Format(time.DateTime),
+		Utime:       time.UnixMilli(e.GetUtime()).Format(time.DateTime),
+	}
+}
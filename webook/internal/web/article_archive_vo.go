@@ -0,0 +1,55 @@
+package web
+
+type ExportReq struct {
+	Id int64 `json:"id"`
+}
+
+type ListExportsReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type ExportVO struct {
+	Id int64 `json:"id"`
+	// Status 1 等待执行，2 执行中，3 成功，4 失败
+	Status   uint8 `json:"status"`
+	Total    int   `json:"total"`
+	Exported int   `json:"exported"`
+	Size     int64 `json:"size"`
+	// DownloadUrl 只有详情接口并且导出成功了才有，一个小时之后过期
+	DownloadUrl string `json:"download_url,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Ctime       string `json:"ctime"`
+	Utime       string `json:"utime"`
+}
+
+type ImportReq struct {
+	Id int64 `json:"id"`
+}
+
+type ListImportsReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type ImportVO struct {
+	Id int64 `json:"id"`
+	// Status 1 等待执行，2 执行中，3 成功，4 失败
+	Status   uint8 `json:"status"`
+	Total    int   `json:"total"`
+	Imported int   `json:"imported"`
+	// Results 只有详情接口才有
+	Results []ImportResultVO `json:"results,omitempty"`
+	Error   string           `json:"error,omitempty"`
+	Ctime   string           `json:"ctime"`
+	Utime   string           `json:"utime"`
+}
+
+// ImportResultVO 一个文件的导入结果，Id 和 Error 只会有一个
+type ImportResultVO struct {
+	Filename string `json:"filename"`
+	Title    string `json:"title"`
+	// Id 新建的草稿
+	Id    int64  `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
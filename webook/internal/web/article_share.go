@@ -0,0 +1,213 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*ArticleShareHandler)(nil)
+
+// ArticleShareHandler 分享链接，只读地访问草稿和私密的文章
+type ArticleShareHandler struct {
+	svc      artv1.ArticleServiceClient
+	shareHdl ijwt.ShareHandler
+	l        logger.LoggerV1
+}
+
+func NewArticleShareHandler(svc artv1.ArticleServiceClient, shareHdl ijwt.ShareHandler,
+	l logger.LoggerV1) *ArticleShareHandler {
+	return &ArticleShareHandler{
+		svc:      svc,
+		shareHdl: shareHdl,
+		l:        l,
+	}
+}
+
+func (h *ArticleShareHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/share")
+	// 不需要登录，要在登录校验里面忽略这个路径
+	g.GET("", ginx.WrapBodyV1(h.Detail))
+	g.POST("/create", ginx.WrapBodyAndToken[ShareCreateReq, ijwt.UserClaims](h.Create))
+	g.POST("/revoke", ginx.WrapBodyAndToken[ShareRevokeReq, ijwt.UserClaims](h.Revoke))
+	g.POST("/revoke_all", ginx.WrapBodyAndToken[ShareRevokeAllReq, ijwt.UserClaims](h.RevokeAll))
+	g.POST("/views", ginx.WrapBodyAndToken[ShareViewsReq, ijwt.UserClaims](h.Views))
+}
+
+func (h *ArticleShareHandler) Create(ctx *gin.Context, req ShareCreateReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	art, res, err := h.authorArticle(ctx, req.Id, uc.Uid)
+	if err != nil {
+		return res, err
+	}
+	if domain.ArticleStatus(art.GetStatus()) == domain.ArticleStatusPublished {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "已经发表的文章不需要分享链接",
+		}, nil
+	}
+	token, claims, err := h.shareHdl.NewShareToken(req.Id, uc.Uid, time.Duration(req.ExpireHours)*time.Hour)
+	if err != nil {
+		return ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: ShareLinkVO{
+			Id:     claims.ID,
+			Token:  token,
+			Expire: claims.ExpiresAt.Format(time.DateTime),
+		},
+	}, nil
+}
+
+// Revoke 作者手里不一定还有链接，按照链接的 ID 撤销
+func (h *ArticleShareHandler) Revoke(ctx *gin.Context, req ShareRevokeReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if req.LinkId == "" {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	_, res, err := h.authorArticle(ctx, req.Id, uc.Uid)
+	if err != nil {
+		return res, err
+	}
+	if err = h.shareHdl.Revoke(ctx, req.Id, req.LinkId); err != nil {
+		return ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+// RevokeAll 撤销文章已经创建的所有分享链接，之后新建的不受影响
+func (h *ArticleShareHandler) RevokeAll(ctx *gin.Context, req ShareRevokeAllReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, res, err := h.authorArticle(ctx, req.Id, uc.Uid)
+	if err != nil {
+		return res, err
+	}
+	if err = h.shareHdl.RevokeAll(ctx, req.Id); err != nil {
+		return ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleShareHandler) Views(ctx *gin.Context, req ShareViewsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, res, err := h.authorArticle(ctx, req.Id, uc.Uid)
+	if err != nil {
+		return res, err
+	}
+	views, err := h.shareHdl.Views(ctx, req.Id)
+	if err != nil {
+		return ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	vos := make([]ShareViewsVO, 0, len(views))
+	for id, cnt := range views {
+		vos = append(vos, ShareViewsVO{Id: id, Views: cnt})
+	}
+	sort.Slice(vos, func(i, j int) bool {
+		return vos[i].Views > vos[j].Views
+	})
+	return ginx.Result{Data: vos}, nil
+}
+
+// Detail 拿着分享链接的人看文章，只能看，不能编辑
+func (h *ArticleShareHandler) Detail(ctx *gin.Context, req ShareDetailReq) (ginx.Result, error) {
+	claims, err := h.shareHdl.ParseShareToken(ctx, req.Token)
+	switch {
+	case errors.Is(err, ijwt.ErrShareTokenInvalid):
+		return ginx.Result{
+			Code: 4,
+			Msg:  "分享链接无效或者已经过期",
+		}, nil
+	case errors.Is(err, ijwt.ErrShareTokenRevoked):
+		return ginx.Result{
+			Code: 4,
+			Msg:  "分享链接已经撤销",
+		}, nil
+	case err != nil:
+		return ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	resp, err := h.svc.GetById(ctx, &artv1.GetByIdRequest{Id: claims.Aid})
+	// 放进回收站的文章查不到，链接也就失效了
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	art := resp.GetArt()
+	// 文章被删掉了，或者换了作者，链接就失效了
+	if art.GetAuthor().GetId() != claims.Uid || art.GetDeletedAt() > 0 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, nil
+	}
+	if err = h.shareHdl.IncrViews(ctx, claims); err != nil {
+		// 浏览次数不准确没关系
+		h.l.Error("更新分享链接浏览次数失败",
+			logger.Int64("aid", claims.Aid),
+			logger.String("link", claims.ID),
+			logger.Error(err))
+	}
+	return ginx.Result{
+		Data: ArticleVO{
+			Id:             art.GetId(),
+			Title:          art.GetTitle(),
+			Content:        art.GetContent(),
+			Html:           art.GetHtml(),
+			ReadingMinutes: art.GetReadingMinutes(),
+			Status:         domain.ArticleStatus(art.GetStatus()).ToUint8(),
+			Tags:           art.GetTags(),
+			Ctime:          time.UnixMilli(art.GetCtime()).Format(time.DateTime),
+			Utime:          time.UnixMilli(art.GetUtime()).Format(time.DateTime),
+		},
+	}, nil
+}
+
+// authorArticle 只有作者自己才能管理分享链接
+func (h *ArticleShareHandler) authorArticle(ctx *gin.Context, id, uid int64) (*artv1.Article, ginx.Result, error) {
+	resp, err := h.svc.GetById(ctx, &artv1.GetByIdRequest{Id: id})
+	if err != nil {
+		return nil, ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	if resp.GetArt().GetAuthor().GetId() != uid {
+		return nil, ginx.Result{
+			Code: http.StatusBadRequest,
+			Msg:  "input error",
+		}, fmt.Errorf("非法访问文章，创作者 ID 不匹配 %d", uid)
+	}
+	return resp.GetArt(), ginx.Result{}, nil
+}
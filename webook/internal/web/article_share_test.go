@@ -0,0 +1,124 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	artv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1/mocks"
+	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	jwtmocks "github.com/TengFeiyang01/webook/webook/internal/web/jwt/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestArticleShareHandler_Detail(t *testing.T) {
+	claims := ijwt.ShareClaims{Aid: 10, Uid: 123}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, ijwt.ShareHandler)
+
+		wantCode int
+		wantRes  ginx.Result
+	}{
+		{
+			name: "查看成功",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, ijwt.ShareHandler) {
+				shareHdl := jwtmocks.NewMockShareHandler(ctrl)
+				shareHdl.EXPECT().ParseShareToken(gomock.Any(), "token").Return(claims, nil)
+				shareHdl.EXPECT().IncrViews(gomock.Any(), claims).Return(nil)
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), &artv1.GetByIdRequest{Id: 10}).
+					Return(&artv1.GetByIdResponse{Art: &artv1.Article{
+						Id: 10, Title: "标题", Author: &artv1.Author{Id: 123},
+					}}, nil)
+				return svc, shareHdl
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "文章放进了回收站",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, ijwt.ShareHandler) {
+				shareHdl := jwtmocks.NewMockShareHandler(ctrl)
+				shareHdl.EXPECT().ParseShareToken(gomock.Any(), "token").Return(claims, nil)
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "文章不存在"))
+				return svc, shareHdl
+			},
+			wantCode: http.StatusOK,
+			wantRes:  ginx.Result{Code: 4, Msg: "文章不存在"},
+		},
+		{
+			name: "文章换了作者",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, ijwt.ShareHandler) {
+				shareHdl := jwtmocks.NewMockShareHandler(ctrl)
+				shareHdl.EXPECT().ParseShareToken(gomock.Any(), "token").Return(claims, nil)
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(&artv1.GetByIdResponse{Art: &artv1.Article{
+						Id: 10, Author: &artv1.Author{Id: 456},
+					}}, nil)
+				return svc, shareHdl
+			},
+			wantCode: http.StatusOK,
+			wantRes:  ginx.Result{Code: 4, Msg: "文章不存在"},
+		},
+		{
+			name: "链接已经撤销",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, ijwt.ShareHandler) {
+				shareHdl := jwtmocks.NewMockShareHandler(ctrl)
+				shareHdl.EXPECT().ParseShareToken(gomock.Any(), "token").
+					Return(ijwt.ShareClaims{}, ijwt.ErrShareTokenRevoked)
+				return artv1mocks.NewMockArticleServiceClient(ctrl), shareHdl
+			},
+			wantCode: http.StatusOK,
+			wantRes:  ginx.Result{Code: 4, Msg: "分享链接已经撤销"},
+		},
+		{
+			name: "查询文章失败",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, ijwt.ShareHandler) {
+				shareHdl := jwtmocks.NewMockShareHandler(ctrl)
+				shareHdl.EXPECT().ParseShareToken(gomock.Any(), "token").Return(claims, nil)
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetById(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return svc, shareHdl
+			},
+			// 出错的时候 ginx 只记录日志，不写响应
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server := gin.Default()
+			svc, shareHdl := tc.mock(ctrl)
+			h := NewArticleShareHandler(svc, shareHdl, logger.NewNopLogger())
+			h.RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/articles/share?token=token", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+			assert.Equal(t, tc.wantCode, resp.Code)
+			if tc.wantRes.Msg == "" {
+				return
+			}
+			var res ginx.Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
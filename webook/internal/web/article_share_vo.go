@@ -0,0 +1,39 @@
+package web
+
+type ShareCreateReq struct {
+	// Id 要分享的文章
+	Id int64 `json:"id"`
+	// ExpireHours 多少个小时之后过期，不传就是最长的有效期
+	ExpireHours int64 `json:"expire_hours"`
+}
+
+type ShareRevokeReq struct {
+	// Id 文章的 ID
+	Id int64 `json:"id"`
+	// LinkId 分享链接的 ID，创建链接和查看浏览次数的时候都会返回
+	LinkId string `json:"link_id"`
+}
+
+type ShareRevokeAllReq struct {
+	Id int64 `json:"id"`
+}
+
+type ShareViewsReq struct {
+	Id int64 `json:"id"`
+}
+
+type ShareDetailReq struct {
+	Token string `form:"token"`
+}
+
+type ShareLinkVO struct {
+	Id     string `json:"id"`
+	Token  string `json:"token"`
+	Expire string `json:"expire"`
+}
+
+type ShareViewsVO struct {
+	// Id 分享链接的 ID
+	Id    string `json:"id"`
+	Views int64  `json:"views"`
+}
@@ -0,0 +1,320 @@
+package web
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
+	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*ArticleStatsHandler)(nil)
+
+const (
+	// maxStatsArts 作者的文章太多的话，只统计最新的这么多篇
+	maxStatsArts = 1000
+	// statsArtsPage 分页查作者的文章，每页多少篇
+	statsArtsPage = 100
+)
+
+var (
+	statsGranularities = map[string]uint32{"": 2, "day": 2, "hour": 1}
+	statsOrders        = map[string]uint32{"": 0, "read": 0, "like": 1, "collect": 2}
+)
+
+// ArticleStatsHandler 创作者看自己文章的阅读、点赞和收藏趋势
+// 计数在互动服务里面按照小时和天汇总好了，这里负责找出作者的文章
+type ArticleStatsHandler struct {
+	svc     artv1.ArticleServiceClient
+	intrSvc intrv1.InteractiveServiceClient
+	biz     string
+	l       logger.LoggerV1
+}
+
+func NewArticleStatsHandler(svc artv1.ArticleServiceClient, intrSvc intrv1.InteractiveServiceClient,
+	l logger.LoggerV1) *ArticleStatsHandler {
+	return &ArticleStatsHandler{svc: svc, intrSvc: intrSvc, biz: "art", l: l}
+}
+
+func (h *ArticleStatsHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/stats")
+	g.POST("/article", ginx.WrapBodyAndToken[ArticleStatsReq, ijwt.UserClaims](h.Article))
+	g.POST("/author", ginx.WrapBodyAndToken[StatsReq, ijwt.UserClaims](h.Author))
+	g.POST("/top", ginx.WrapBodyAndToken[TopStatsReq, ijwt.UserClaims](h.Top))
+	// 参数放在 query 里面，浏览器可以直接下载
+	g.GET("/export", h.Export)
+}
+
+// Article 单篇文章的趋势，只能看自己的
+func (h *ArticleStatsHandler) Article(ctx *gin.Context, req ArticleStatsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	q, ok := h.toQuery(req.StatsReq)
+	if !ok {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	resp, err := h.svc.GetById(ctx, &artv1.GetByIdRequest{Id: req.Id})
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	if resp.GetArt().GetAuthor().GetId() != uc.Uid {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, fmt.Errorf("非法查看文章统计 %d, %d", uc.Uid, req.Id)
+	}
+	q.BizIds = []int64{req.Id}
+	return h.series(ctx, q)
+}
+
+// Author 作者所有文章加在一起的趋势
+func (h *ArticleStatsHandler) Author(ctx *gin.Context, req StatsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	q, ok := h.toQuery(req)
+	if !ok {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	arts, err := h.authorArts(ctx, uc.Uid)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	q.BizIds = slice.Map(arts, func(idx int, src *artv1.Article) int64 {
+		return src.GetId()
+	})
+	return h.series(ctx, q)
+}
+
+// Top 这段时间内表现最好的文章
+func (h *ArticleStatsHandler) Top(ctx *gin.Context, req TopStatsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	q, ok := h.toQuery(req.StatsReq)
+	order, orderOk := statsOrders[req.OrderBy]
+	if !ok || !orderOk {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	arts, err := h.authorArts(ctx, uc.Uid)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	titles := make(map[int64]string, len(arts))
+	for _, art := range arts {
+		titles[art.GetId()] = art.GetTitle()
+	}
+	q.BizIds = slice.Map(arts, func(idx int, src *artv1.Article) int64 {
+		return src.GetId()
+	})
+	resp, err := h.intrSvc.TopStats(ctx, &intrv1.TopStatsRequest{
+		Query:   q,
+		OrderBy: order,
+		Limit:   int32(req.Limit),
+	})
+	if err != nil {
+		return h.statsErr(err)
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetIntrs(), func(idx int, src *intrv1.Interactive) TopArticleVO {
+			return TopArticleVO{
+				Id:         src.GetBizId(),
+				Title:      titles[src.GetBizId()],
+				ReadCnt:    src.GetReadCnt(),
+				LikeCnt:    src.GetLikeCnt(),
+				CollectCnt: src.GetCollectCnt(),
+			}
+		}),
+	}, nil
+}
+
+// Export 导出成 CSV，每篇文章每个时间段一行，只有有数据的时间段
+func (h *ArticleStatsHandler) Export(ctx *gin.Context) {
+	uc, ok := ctx.MustGet("user").(ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("failed to find user's session message")
+		return
+	}
+	var req StatsReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	q, ok := h.toQuery(req)
+	if !ok {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	arts, err := h.authorArts(ctx, uc.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("导出统计的时候查询文章失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	titles := make(map[int64]string, len(arts))
+	for _, art := range arts {
+		titles[art.GetId()] = art.GetTitle()
+	}
+	q.BizIds = slice.Map(arts, func(idx int, src *artv1.Article) int64 {
+		return src.GetId()
+	})
+	resp, err := h.intrSvc.ListStats(ctx, &intrv1.ListStatsRequest{Query: q})
+	if err != nil {
+		res, err := h.statsErr(err)
+		ctx.JSON(http.StatusOK, res)
+		if err != nil {
+			h.l.Error("导出统计失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		}
+		return
+	}
+	filename := fmt.Sprintf("stats-%s.csv", time.UnixMilli(q.GetStart()).Format("20060102"))
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	ctx.Status(http.StatusOK)
+	// 带上 BOM，不然 Excel 打开中文标题是乱码
+	_, _ = ctx.Writer.WriteString("\ufeff")
+	w := csv.NewWriter(ctx.Writer)
+	_ = w.Write([]string{"时间", "文章ID", "标题", "阅读", "点赞", "收藏"})
+	for _, p := range resp.GetPoints() {
+		_ = w.Write([]string{
+			time.UnixMilli(p.GetTime()).Format(time.DateTime),
+			strconv.FormatInt(p.GetBizId(), 10),
+			titles[p.GetBizId()],
+			strconv.FormatInt(p.GetReadCnt(), 10),
+			strconv.FormatInt(p.GetLikeCnt(), 10),
+			strconv.FormatInt(p.GetCollectCnt(), 10),
+		})
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		h.l.Error("写入统计 CSV 失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+	}
+}
+
+func (h *ArticleStatsHandler) series(ctx context.Context, q *intrv1.StatsQuery) (ginx.Result, error) {
+	resp, err := h.intrSvc.GetStats(ctx, &intrv1.GetStatsRequest{Query: q})
+	if err != nil {
+		return h.statsErr(err)
+	}
+	var total StatsPointVO
+	points := slice.Map(resp.GetPoints(), func(idx int, src *intrv1.StatsPoint) StatsPointVO {
+		total.ReadCnt += src.GetReadCnt()
+		total.LikeCnt += src.GetLikeCnt()
+		total.CollectCnt += src.GetCollectCnt()
+		return StatsPointVO{
+			Time:       time.UnixMilli(src.GetTime()).Format(time.DateTime),
+			ReadCnt:    src.GetReadCnt(),
+			LikeCnt:    src.GetLikeCnt(),
+			CollectCnt: src.GetCollectCnt(),
+		}
+	})
+	return ginx.Result{
+		Data: StatsVO{Total: total, Points: points},
+	}, nil
+}
+
+// authorArts 作者最新的 maxStatsArts 篇文章，草稿没有计数，不过查了也没关系
+func (h *ArticleStatsHandler) authorArts(ctx context.Context, uid int64) ([]*artv1.Article, error) {
+	var (
+		res    []*artv1.Article
+		cursor string
+	)
+	for len(res) < maxStatsArts {
+		resp, err := h.svc.List(ctx, &artv1.ListRequest{
+			Id:     uid,
+			Limit:  statsArtsPage,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, resp.GetArts()...)
+		cursor = resp.GetNextCursor()
+		if cursor == "" {
+			break
+		}
+	}
+	if len(res) > maxStatsArts {
+		res = res[:maxStatsArts]
+	}
+	return res, nil
+}
+
+// toQuery 没有传时间的话默认看最近的，BizIds 由调用方填
+func (h *ArticleStatsHandler) toQuery(req StatsReq) (*intrv1.StatsQuery, bool) {
+	granularity, ok := statsGranularities[req.Granularity]
+	if !ok {
+		return nil, false
+	}
+	end := req.End
+	if end <= 0 {
+		end = time.Now().UnixMilli()
+	}
+	start := req.Start
+	if start <= 0 {
+		span := 7 * 24 * time.Hour
+		if granularity == 1 {
+			span = 24 * time.Hour
+		}
+		start = time.UnixMilli(end).Add(-span).UnixMilli()
+	}
+	return &intrv1.StatsQuery{
+		Biz:         h.biz,
+		Granularity: granularity,
+		Start:       start,
+		End:         end,
+	}, true
+}
+
+func (h *ArticleStatsHandler) statsErr(err error) (ginx.Result, error) {
+	if status.Code(err) == codes.InvalidArgument {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "时间范围不对，按小时最多查 31 天，按天最多查 366 天",
+		}, nil
+	}
+	return ginx.Result{
+		Code: 5,
+		Msg:  "system error",
+	}, err
+}
@@ -0,0 +1,44 @@
+package web
+
+type StatsReq struct {
+	// Granularity hour 或者 day，默认 day
+	Granularity string `json:"granularity" form:"granularity"`
+	// Start End 毫秒时间戳，不传的话 End 是现在，Start 往前推 7 天，按小时的话推 24 小时
+	Start int64 `json:"start" form:"start"`
+	End   int64 `json:"end" form:"end"`
+}
+
+type ArticleStatsReq struct {
+	Id int64 `json:"id"`
+	StatsReq
+}
+
+type TopStatsReq struct {
+	StatsReq
+	// OrderBy read、like 或者 collect，默认 read
+	OrderBy string `json:"order_by"`
+	Limit   int    `json:"limit"`
+}
+
+type StatsVO struct {
+	// Total 这段时间内的总和
+	Total  StatsPointVO   `json:"total"`
+	Points []StatsPointVO `json:"points"`
+}
+
+type StatsPointVO struct {
+	// Time 时间段的开始，汇总的时候为空
+	Time       string `json:"time,omitempty"`
+	ReadCnt    int64  `json:"read_cnt"`
+	LikeCnt    int64  `json:"like_cnt"`
+	CollectCnt int64  `json:"collect_cnt"`
+}
+
+type TopArticleVO struct {
+	Id    int64  `json:"id"`
+	Title string `json:"title"`
+	// 计数是这段时间内的，不是总数
+	ReadCnt    int64 `json:"read_cnt"`
+	LikeCnt    int64 `json:"like_cnt"`
+	CollectCnt int64 `json:"collect_cnt"`
+}
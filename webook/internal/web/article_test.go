@@ -4,30 +4,49 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	artv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1/mocks"
+	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
+	intrv1mocks "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1/mocks"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
-	"github.com/TengFeiyang01/webook/webook/article/service"
-	service2 "github.com/TengFeiyang01/webook/webook/interactive/service"
-	svcmocks "github.com/TengFeiyang01/webook/webook/internal/service/mocks"
+	"github.com/TengFeiyang01/webook/webook/internal/errs"
 	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
 	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
 	loggermocks "github.com/TengFeiyang01/webook/webook/pkg/logger/mocks"
 )
 
+// TestMain ginx 统计业务错误码要先初始化，不然单独跑某个测试会 panic
+func TestMain(m *testing.M) {
+	ginx.InitCounter(prometheus.CounterOpts{
+		Namespace: "webook",
+		Subsystem: "web_test",
+		Name:      "biz_code",
+	})
+	os.Exit(m.Run())
+}
+
 func TestArticleHandler_Publish(t *testing.T) {
 
 	testCases := []struct {
 		name string
 
-		mock func(ctrl *gomock.Controller) (service.ArticleService, logger.LoggerV1, service2.InteractiveService)
+		mock func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient)
 
 		reqBody string
 
@@ -36,17 +55,18 @@ func TestArticleHandler_Publish(t *testing.T) {
 	}{
 		{
 			name: "新建并发表",
-			mock: func(ctrl *gomock.Controller) (service.ArticleService, logger.LoggerV1, service2.InteractiveService) {
-				svc := svcmocks.NewMockArticleService(ctrl)
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
 				l := loggermocks.NewMockLoggerV1(ctrl)
-				interSvc := svcmocks.NewMockInteractiveService(ctrl)
-				svc.EXPECT().Publish(gomock.Any(), domain.Article{
-					Title:   "my title",
-					Content: "my content",
-					Author: domain.Author{
-						Id: 123,
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), &artv1.PublishRequest{
+					Art: &artv1.Article{
+						Title:    "my title",
+						Content:  "my content",
+						Author:   &artv1.Author{Id: 123},
+						KeepTags: true,
 					},
-				}).Return(int64(1), nil)
+				}).Return(&artv1.PublishResponse{Id: 1}, nil)
 				return svc, l, interSvc
 			},
 			reqBody: `
@@ -63,18 +83,19 @@ func TestArticleHandler_Publish(t *testing.T) {
 		},
 		{
 			name: "已有帖子且发表成功",
-			mock: func(ctrl *gomock.Controller) (service.ArticleService, logger.LoggerV1, service2.InteractiveService) {
-				svc := svcmocks.NewMockArticleService(ctrl)
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
 				l := loggermocks.NewMockLoggerV1(ctrl)
-				interSvc := svcmocks.NewMockInteractiveService(ctrl)
-				svc.EXPECT().Publish(gomock.Any(), domain.Article{
-					Id:      1,
-					Title:   "new title",
-					Content: "new content",
-					Author: domain.Author{
-						Id: 123,
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), &artv1.PublishRequest{
+					Art: &artv1.Article{
+						Id:       1,
+						Title:    "new title",
+						Content:  "new content",
+						Author:   &artv1.Author{Id: 123},
+						KeepTags: true,
 					},
-				}).Return(int64(1), nil)
+				}).Return(&artv1.PublishResponse{Id: 1}, nil)
 				return svc, l, interSvc
 			},
 			reqBody: `
@@ -83,6 +104,68 @@ func TestArticleHandler_Publish(t *testing.T) {
 	"title":"new title",
 	"content":"new content"
 }
+`,
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Data: float64(1),
+				Msg:  "OK",
+			},
+		},
+		{
+			name: "带上标签发表",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				l := loggermocks.NewMockLoggerV1(ctrl)
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), &artv1.PublishRequest{
+					Art: &artv1.Article{
+						Id:      1,
+						Title:   "new title",
+						Content: "new content",
+						Tags:    []string{"Go"},
+						Author:  &artv1.Author{Id: 123},
+					},
+				}).Return(&artv1.PublishResponse{Id: 1}, nil)
+				return svc, l, interSvc
+			},
+			reqBody: `
+{
+	"id":1,
+	"title":"new title",
+	"content":"new content",
+	"tags":["Go"]
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Data: float64(1),
+				Msg:  "OK",
+			},
+		},
+		{
+			name: "传了空的标签就是清空",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				l := loggermocks.NewMockLoggerV1(ctrl)
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), &artv1.PublishRequest{
+					Art: &artv1.Article{
+						Id:      1,
+						Title:   "new title",
+						Content: "new content",
+						Tags:    []string{},
+						Author:  &artv1.Author{Id: 123},
+					},
+				}).Return(&artv1.PublishResponse{Id: 1}, nil)
+				return svc, l, interSvc
+			},
+			reqBody: `
+{
+	"id":1,
+	"title":"new title",
+	"content":"new content",
+	"tags":[]
+}
 `,
 			wantCode: http.StatusOK,
 			wantRes: ginx.Result{
@@ -92,17 +175,19 @@ func TestArticleHandler_Publish(t *testing.T) {
 		},
 		{
 			name: "publish失败",
-			mock: func(ctrl *gomock.Controller) (service.ArticleService, logger.LoggerV1, service2.InteractiveService) {
-				svc := svcmocks.NewMockArticleService(ctrl)
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
 				l := loggermocks.NewMockLoggerV1(ctrl)
-				interSvc := svcmocks.NewMockInteractiveService(ctrl)
-				svc.EXPECT().Publish(gomock.Any(), domain.Article{
-					Title:   "my title",
-					Content: "my content",
-					Author: domain.Author{
-						Id: 123,
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), &artv1.PublishRequest{
+					Art: &artv1.Article{
+						Title:    "my title",
+						Content:  "my content",
+						Author:   &artv1.Author{Id: 123},
+						KeepTags: true,
 					},
-				}).Return(int64(0), errors.New("publish failed"))
+				}).Return(nil, errors.New("publish failed"))
+				l.EXPECT().Info(gomock.Any(), gomock.Any())
 				return svc, l, interSvc
 			},
 			reqBody: `
@@ -117,12 +202,78 @@ func TestArticleHandler_Publish(t *testing.T) {
 				Msg:  "系统错误",
 			},
 		},
+		{
+			name: "包含违禁词",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				l := loggermocks.NewMockLoggerV1(ctrl)
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					Return(nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonSensitiveContent, "内容包含违禁词"))
+				return svc, l, interSvc
+			},
+			reqBody: `
+{
+	"title":"my title",
+	"content":"my content"
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Code: errs.ArticleSensitiveContent,
+				Msg:  "标题或者内容包含违禁词",
+			},
+		},
+		{
+			name: "标签太多",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				l := loggermocks.NewMockLoggerV1(ctrl)
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					Return(nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonTooManyTags, "标签太多"))
+				return svc, l, interSvc
+			},
+			reqBody: `
+{
+	"title":"my title",
+	"content":"my content"
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Code: errs.ArticleTooManyTags,
+				Msg:  fmt.Sprintf("最多只能有 %d 个标签", domain.MaxTagsPerArticle),
+			},
+		},
+		{
+			name: "其他的参数错误不能当成违禁词",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				l := loggermocks.NewMockLoggerV1(ctrl)
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "参数错误"))
+				return svc, l, interSvc
+			},
+			reqBody: `
+{
+	"title":"my title",
+	"content":"my content"
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Code: errs.ArticleInvalidInput,
+				Msg:  "参数错误",
+			},
+		},
 		{
 			name: "输入有误、Bind返回错误",
-			mock: func(ctrl *gomock.Controller) (service.ArticleService, logger.LoggerV1, service2.InteractiveService) {
-				svc := svcmocks.NewMockArticleService(ctrl)
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
 				l := loggermocks.NewMockLoggerV1(ctrl)
-				interSvc := svcmocks.NewMockInteractiveService(ctrl)
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
 				return svc, l, interSvc
 			},
 			reqBody: `
@@ -135,17 +286,19 @@ func TestArticleHandler_Publish(t *testing.T) {
 		},
 		{
 			name: "找不到User",
-			mock: func(ctrl *gomock.Controller) (service.ArticleService, logger.LoggerV1, service2.InteractiveService) {
-				svc := svcmocks.NewMockArticleService(ctrl)
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, logger.LoggerV1, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
 				l := loggermocks.NewMockLoggerV1(ctrl)
-				interSvc := svcmocks.NewMockInteractiveService(ctrl)
-				svc.EXPECT().Publish(gomock.Any(), domain.Article{
-					Title:   "my title",
-					Content: "my content",
-					Author: domain.Author{
-						Id: 123,
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), &artv1.PublishRequest{
+					Art: &artv1.Article{
+						Title:    "my title",
+						Content:  "my content",
+						Author:   &artv1.Author{Id: 123},
+						KeepTags: true,
 					},
-				}).Return(int64(0), gorm.ErrRecordNotFound)
+				}).Return(nil, gorm.ErrRecordNotFound)
+				l.EXPECT().Error(gomock.Any())
 				return svc, l, interSvc
 			},
 			reqBody: `
@@ -168,11 +321,12 @@ func TestArticleHandler_Publish(t *testing.T) {
 			defer ctrl.Finish()
 			server := gin.Default()
 			server.Use(func(ctx *gin.Context) {
-				ctx.Set("claims", &ijwt.UserClaims{
+				ctx.Set("user", ijwt.UserClaims{
 					Uid: 123,
 				})
 			})
-			h := NewArticleHandler(tc.mock(ctrl))
+			svc, l, interSvc := tc.mock(ctrl)
+			h := NewArticleHandler(svc, l, interSvc, nil)
 			h.RegisterRoutes(server)
 
 			req, err := http.NewRequest(http.MethodPost, "/articles/publish", bytes.NewBuffer([]byte(tc.reqBody)))
@@ -193,3 +347,178 @@ func TestArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_DiffRevisions(t *testing.T) {
+	ctime := time.Now().Truncate(time.Second)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) artv1.ArticleServiceClient
+
+		wantCode int
+		wantRes  ginx.Result
+	}{
+		{
+			name: "比较成功",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().DiffRevisions(gomock.Any(), &artv1.DiffRevisionsRequest{Uid: 123, From: 1, To: 2}).
+					Return(&artv1.DiffRevisionsResponse{
+						From: &artv1.ArticleRevision{Id: 1, ArticleId: 10, Version: 1, Title: "标题",
+							Content: "旧的内容", Kind: artv1.RevisionKind_REVISION_KIND_SAVE, Ctime: ctime.UnixMilli()},
+						To: &artv1.ArticleRevision{Id: 2, ArticleId: 10, Version: 2, Title: "标题",
+							Content: "新的内容", Kind: artv1.RevisionKind_REVISION_KIND_PUBLISH, Ctime: ctime.UnixMilli()},
+						Lines: []*artv1.DiffLine{
+							{Op: artv1.DiffOp_DIFF_OP_EQUAL, Text: "标题"},
+							{Op: artv1.DiffOp_DIFF_OP_DELETE, Text: "旧的内容"},
+							{Op: artv1.DiffOp_DIFF_OP_INSERT, Text: "新的内容"},
+						},
+					}, nil)
+				return svc
+			},
+			wantCode: http.StatusOK,
+			wantRes: ginx.Result{
+				Data: map[string]any{
+					// 比较的时候不返回完整的内容
+					"from": map[string]any{"id": float64(1), "article_id": float64(10), "version": float64(1),
+						"title": "标题", "kind": "Save", "ctime": ctime.Format(time.DateTime)},
+					"to": map[string]any{"id": float64(2), "article_id": float64(10), "version": float64(2),
+						"title": "标题", "kind": "Publish", "ctime": ctime.Format(time.DateTime)},
+					"lines": []any{
+						map[string]any{"op": " ", "text": "标题"},
+						map[string]any{"op": "-", "text": "旧的内容"},
+						map[string]any{"op": "+", "text": "新的内容"},
+					},
+				},
+			},
+		},
+		{
+			name: "比较失败",
+			mock: func(ctrl *gomock.Controller) artv1.ArticleServiceClient {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().DiffRevisions(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return svc
+			},
+			// 出错的时候 ginx 只记录日志，不写响应
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", ijwt.UserClaims{
+					Uid: 123,
+				})
+			})
+			h := NewArticleHandler(tc.mock(ctrl), logger.NewNopLogger(), nil, nil)
+			h.RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/articles/revisions/diff",
+				bytes.NewBuffer([]byte(`{"from":1,"to":2}`)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+			assert.Equal(t, tc.wantCode, resp.Code)
+			if resp.Body.Len() == 0 {
+				return
+			}
+			var webRes ginx.Result
+			err = json.NewDecoder(resp.Body).Decode(&webRes)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, webRes)
+		})
+	}
+}
+
+func TestArticleHandler_PubDetail(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient)
+
+		wantCode int
+		wantArt  *ArticleVO
+	}{
+		{
+			name: "读者不是作者也能看到内容和系列导航",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetPubById(gomock.Any(), &artv1.GetPubByIdRequest{Id: 10, Uid: 123}).
+					Return(&artv1.GetPubByIdResponse{Art: &artv1.Article{
+						Id: 10, Title: "标题", Content: "内容", Html: "<p>内容</p>",
+						Author: &artv1.Author{Id: 456, Name: "作者"},
+					}}, nil)
+				svc.EXPECT().GetSeriesNav(gomock.Any(), &artv1.GetSeriesNavRequest{ArtId: 10}).
+					Return(&artv1.GetSeriesNavResponse{
+						Series: &artv1.Series{Id: 1, Title: "系列"},
+						Next:   &artv1.Article{Id: 11, Title: "下一篇"},
+					}, nil)
+				interSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				interSvc.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(&intrv1.GetResponse{Intr: &intrv1.Interactive{ReadCnt: 3}}, nil)
+				interSvc.EXPECT().IncrReadCnt(gomock.Any(), gomock.Any()).
+					Return(&intrv1.IncrReadCntResponse{}, nil).AnyTimes()
+				return svc, interSvc
+			},
+			wantCode: http.StatusOK,
+			wantArt: &ArticleVO{
+				Id:       10,
+				Title:    "标题",
+				Abstract: "内容",
+				Content:  "内容",
+				Html:     "<p>内容</p>",
+				Author:   "作者",
+				ReadCnt:  3,
+				Series: &SeriesNavVO{Id: 1, Title: "系列",
+					Next: &SeriesItemVO{Id: 11, Title: "下一篇"}},
+			},
+		},
+		{
+			name: "查询文章失败",
+			mock: func(ctrl *gomock.Controller) (artv1.ArticleServiceClient, intrv1.InteractiveServiceClient) {
+				svc := artv1mocks.NewMockArticleServiceClient(ctrl)
+				svc.EXPECT().GetPubById(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return svc, intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+			},
+			// 出错的时候 ginx 只记录日志，不写响应
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", ijwt.UserClaims{
+					Uid: 123,
+				})
+			})
+			svc, interSvc := tc.mock(ctrl)
+			h := NewArticleHandler(svc, logger.NewNopLogger(), interSvc, nil)
+			h.RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/articles/pub/10", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+			assert.Equal(t, tc.wantCode, resp.Code)
+			if tc.wantArt == nil {
+				return
+			}
+			var webRes struct {
+				Data ArticleVO `json:"data"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&webRes)
+			require.NoError(t, err)
+			art := webRes.Data
+			// 时间由服务端格式化，这里不比较
+			art.Ctime, art.Utime = "", ""
+			assert.Equal(t, *tc.wantArt, art)
+		})
+	}
+}
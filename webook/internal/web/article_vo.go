@@ -22,8 +22,12 @@ type ArticleVO struct {
 	Title string `json:"title"`
 	// 摘要
 	Abstract string `json:"abstract"`
-	// 内容
+	// 内容，作者写的原始 Markdown
 	Content string `json:"content"`
+	// Html 渲染并清洗过的内容，只有详情页才有
+	Html string `json:"html,omitempty"`
+	// 预估的阅读时间，单位是分钟
+	ReadingMinutes int32 `json:"reading_minutes,omitempty"`
 	// 状态这个东西，可以是前端来处理，也可以是后端来处理
 	// 0 -> unknown -> 未知状态
 	// 1 -> 未发表
@@ -38,19 +42,187 @@ type ArticleVO struct {
 	Liked     bool `json:"liked"`
 	Collected bool `json:"collected"`
 
+	// 制作库的版本号，编辑的时候带上
+	Version int64 `json:"version,omitempty"`
+	// 定时发表的时间，没有设置就是空
+	PublishAt string   `json:"publish_at,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+
 	Ctime string `json:"ctime"`
 	Utime string `json:"utime"`
+	// DeletedAt 移进回收站的时间，只有回收站列表才有
+	DeletedAt string `json:"deleted_at,omitempty"`
+	// Series 文章所在的系列，只有读者的详情页才有
+	Series *SeriesNavVO `json:"series,omitempty"`
 }
 
 type ListReq struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
+	// Cursor 不传的话按照 offset 翻页，返回的 data 是文章数组，这是为了兼容老的前端
+	// 传了（第一页传空字符串）就按照游标翻页，返回的 data 是 ArticleListVO
+	Cursor *string `json:"cursor"`
+}
+
+type ArticleListVO struct {
+	Arts []ArticleVO `json:"arts"`
+	// NextCursor 为空说明没有下一页了
+	NextCursor string `json:"next_cursor"`
+}
+
+type RevisionListReq struct {
+	// Id 文章 ID
+	Id     int64 `json:"id"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type RevisionDiffReq struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+type RevisionRestoreReq struct {
+	// Id 版本 ID
+	Id int64 `json:"id"`
+}
+
+// ScheduleReq 定时发表，已经在定时中的文章再次提交就是修改时间
+type ScheduleReq struct {
+	Id      int64  `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// PublishAt 毫秒时间戳
+	PublishAt int64    `json:"publish_at"`
+	Tags      []string `json:"tags"`
+}
+
+type ListPubByTagReq struct {
+	Tag    string `json:"tag"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+type CancelScheduleReq struct {
+	Id int64 `json:"id"`
+}
+
+// TrashReq 删除、恢复和彻底删除共用
+type TrashReq struct {
+	Id int64 `json:"id"`
+}
+
+type ListTrashReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type SeriesReq struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// ArticleIds 按照阅读顺序排列
+	ArticleIds []int64 `json:"article_ids"`
+}
+
+// ReorderSeriesReq 整体覆盖，添加和移除文章也用这个
+type ReorderSeriesReq struct {
+	Id         int64   `json:"id"`
+	ArticleIds []int64 `json:"article_ids"`
+}
+
+type ListSeriesReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type SeriesVO struct {
+	Id          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Ctime       string `json:"ctime"`
+	Utime       string `json:"utime"`
+}
+
+// SeriesNavVO 详情页上的系列导航，没有上一篇或者下一篇的话就是空
+type SeriesNavVO struct {
+	Id    int64         `json:"id"`
+	Title string        `json:"title"`
+	Prev  *SeriesItemVO `json:"prev,omitempty"`
+	Next  *SeriesItemVO `json:"next,omitempty"`
+}
+
+type SeriesItemVO struct {
+	Id    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type RevisionVO struct {
+	Id        int64  `json:"id"`
+	ArticleId int64  `json:"article_id"`
+	Version   int64  `json:"version"`
+	Title     string `json:"title"`
+	// 列表页不返回内容
+	Content string `json:"content,omitempty"`
+	// Kind Save, Publish 或者 Restore
+	Kind  string `json:"kind"`
+	Ctime string `json:"ctime"`
+}
+
+type DiffLineVO struct {
+	// Op 和 unified diff 保持一致，" " 不变，"+" 新增，"-" 删除
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type RevisionDiffVO struct {
+	From  RevisionVO   `json:"from"`
+	To    RevisionVO   `json:"to"`
+	Lines []DiffLineVO `json:"lines"`
 }
 
 type ArticleReq struct {
 	Id      int64  `json:"id"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
+	// Tags 整体覆盖，传空数组就是清空，不传就不修改
+	Tags *[]string `json:"tags"`
+	// Version 编辑之前读到的版本号，版本号对不上会返回 errs.ArticleVersionConflict
+	// 不传就不检查，返回的 data 是文章 ID，这是为了兼容老的前端
+	// 传了（新建的文章传 0）返回的 data 是 ArticleSaveVO
+	Version *int64 `json:"version"`
+}
+
+func (req ArticleReq) version() int64 {
+	if req.Version == nil {
+		return 0
+	}
+	return *req.Version
+}
+
+// tags 没传标签的时候返回 nil，要和 keepTags 一起用
+func (req ArticleReq) tags() []string {
+	if req.Tags == nil {
+		return nil
+	}
+	return *req.Tags
+}
+
+func (req ArticleReq) keepTags() bool {
+	return req.Tags == nil
+}
+
+type ArticleSaveVO struct {
+	Id int64 `json:"id"`
+	// Version 保存之后的版本号，下次保存的时候带上
+	Version int64 `json:"version"`
+}
+
+// ArticleConflictVO 版本冲突的时候返回服务端当前的文章，前端用来提示作者合并
+type ArticleConflictVO struct {
+	Version int64  `json:"version"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Utime   string `json:"utime"`
 }
 
 func (req ArticleReq) toDomain(uid int64) domain.Article {
@@ -58,6 +230,7 @@ func (req ArticleReq) toDomain(uid int64) domain.Article {
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
+		Tags:    req.tags(),
 		Author: domain.Author{
 			Id: uid,
 		},
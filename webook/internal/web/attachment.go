@@ -0,0 +1,128 @@
+package web
+
+import (
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*AttachmentHandler)(nil)
+
+// AttachmentHandler 编辑器里面插入图片
+// 先申请上传 URL，前端直接上传到对象存储，然后再确认上传完成
+type AttachmentHandler struct {
+	svc artv1.ArticleServiceClient
+}
+
+func NewAttachmentHandler(svc artv1.ArticleServiceClient) *AttachmentHandler {
+	return &AttachmentHandler{svc: svc}
+}
+
+func (h *AttachmentHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/attachments")
+	g.POST("/upload", ginx.WrapBodyAndToken[CreateUploadReq, ijwt.UserClaims](h.CreateUpload))
+	g.POST("/complete", ginx.WrapBodyAndToken[AttachmentReq, ijwt.UserClaims](h.CompleteUpload))
+	g.POST("/list", ginx.WrapBodyAndToken[ListAttachmentsReq, ijwt.UserClaims](h.List))
+	g.POST("/delete", ginx.WrapBodyAndToken[AttachmentReq, ijwt.UserClaims](h.Delete))
+}
+
+func (h *AttachmentHandler) CreateUpload(ctx *gin.Context, req CreateUploadReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.CreateUpload(ctx, &artv1.CreateUploadRequest{
+		Uid:         uc.Uid,
+		Filename:    req.Filename,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+	})
+	if err != nil {
+		return h.attachmentErr(err)
+	}
+	return ginx.Result{
+		Data: UploadVO{
+			Attachment: h.toVO(resp.GetAttachment()),
+			UploadUrl:  resp.GetUploadUrl(),
+		},
+	}, nil
+}
+
+func (h *AttachmentHandler) CompleteUpload(ctx *gin.Context, req AttachmentReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.CompleteUpload(ctx, &artv1.CompleteUploadRequest{
+		Uid: uc.Uid,
+		Id:  req.Id,
+	})
+	if err != nil {
+		return h.attachmentErr(err)
+	}
+	return ginx.Result{Data: h.toVO(resp.GetAttachment())}, nil
+}
+
+func (h *AttachmentHandler) List(ctx *gin.Context, req ListAttachmentsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.ListAttachments(ctx, &artv1.ListAttachmentsRequest{
+		Uid:    uc.Uid,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetAttachments(), func(idx int, src *artv1.Attachment) AttachmentVO {
+			return h.toVO(src)
+		}),
+	}, nil
+}
+
+func (h *AttachmentHandler) Delete(ctx *gin.Context, req AttachmentReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.DeleteAttachment(ctx, &artv1.DeleteAttachmentRequest{
+		Uid: uc.Uid,
+		Id:  req.Id,
+	})
+	if err != nil {
+		return h.attachmentErr(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *AttachmentHandler) attachmentErr(err error) (ginx.Result, error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	case codes.ResourceExhausted:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "附件空间不足",
+		}, nil
+	case codes.FailedPrecondition:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "附件没有上传成功",
+		}, nil
+	}
+	return ginx.Result{
+		Code: 5,
+		Msg:  "system error",
+	}, err
+}
+
+func (h *AttachmentHandler) toVO(a *artv1.Attachment) AttachmentVO {
+	return AttachmentVO{
+		Id:          a.GetId(),
+		Filename:    a.GetFilename(),
+		ContentType: a.GetContentType(),
+		Size:        a.GetSize(),
+		Status:      uint8(a.GetStatus()),
+		Url:         a.GetUrl(),
+		Ctime:       time.UnixMilli(a.GetCtime()).Format(time.DateTime),
+	}
+}
@@ -0,0 +1,34 @@
+package web
+
+type CreateUploadReq struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type AttachmentReq struct {
+	Id int64 `json:"id"`
+}
+
+type ListAttachmentsReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type AttachmentVO struct {
+	Id          int64  `json:"id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Status 1 等待上传，2 已经上传
+	Status uint8 `json:"status"`
+	// Url 插入到文章里面的地址
+	Url   string `json:"url"`
+	Ctime string `json:"ctime"`
+}
+
+type UploadVO struct {
+	Attachment AttachmentVO `json:"attachment"`
+	// UploadUrl 用 PUT 上传，Content-Type 和大小要和申请的一致
+	UploadUrl string `json:"upload_url"`
+}
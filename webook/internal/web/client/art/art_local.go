@@ -1,13 +1,17 @@
 package art
 
 import (
+	"errors"
 	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"time"
 	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
 	"github.com/TengFeiyang01/webook/webook/article/domain"
 	"github.com/TengFeiyang01/webook/webook/article/service"
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
 )
 
 type ArticleServiceAdapter struct {
@@ -19,8 +23,18 @@ func NewArticleServiceAdapter(svc service.ArticleService) *ArticleServiceAdapter
 }
 
 func (a *ArticleServiceAdapter) Save(ctx context.Context, in *artv1.SaveRequest, opts ...grpc.CallOption) (*artv1.SaveResponse, error) {
-	id, err := a.svc.Save(ctx, a.toPB(in.GetArt()))
-	return &artv1.SaveResponse{Id: id}, err
+	art := a.toPB(in.GetArt())
+	id, err := a.svc.Save(ctx, art)
+	if errors.Is(err, service.ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, service.ErrSensitiveContent) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonSensitiveContent, err.Error())
+	}
+	if errors.Is(err, service.ErrTooManyTags) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonTooManyTags, err.Error())
+	}
+	return &artv1.SaveResponse{Id: id, Version: art.NextVersion()}, err
 }
 
 func (a *ArticleServiceAdapter) WithDraw(ctx context.Context, in *artv1.WithDrawRequest, opts ...grpc.CallOption) (*artv1.WithDrawResponse, error) {
@@ -30,35 +44,249 @@ func (a *ArticleServiceAdapter) WithDraw(ctx context.Context, in *artv1.WithDraw
 
 func (a *ArticleServiceAdapter) Publish(ctx context.Context, in *artv1.PublishRequest, opts ...grpc.CallOption) (*artv1.PublishResponse, error) {
 	id, err := a.svc.Publish(ctx, a.toPB(in.GetArt()))
+	if errors.Is(err, service.ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if errors.Is(err, service.ErrSensitiveContent) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonSensitiveContent, err.Error())
+	}
+	if errors.Is(err, service.ErrTooManyTags) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonTooManyTags, err.Error())
+	}
 	return &artv1.PublishResponse{Id: id}, err
 }
 
 func (a *ArticleServiceAdapter) List(ctx context.Context, in *artv1.ListRequest, opts ...grpc.CallOption) (*artv1.ListResponse, error) {
-	arts, err := a.svc.List(ctx, in.GetId(), int(in.GetOffset()), int(in.GetLimit()))
+	var (
+		arts []domain.Article
+		next string
+		err  error
+	)
+	if in.GetCursor() == "" && in.GetOffset() > 0 {
+		arts, err = a.svc.List(ctx, in.GetId(), int(in.GetOffset()), int(in.GetLimit()))
+	} else {
+		arts, next, err = a.svc.ListByCursor(ctx, in.GetId(), in.GetCursor(), int(in.GetLimit()))
+	}
+	// 和 grpc 的实现保持一致，调用方不需要区分本地调用还是远程调用
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	return &artv1.ListResponse{Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
 		return a.toDTO(arts[idx])
-	})}, err
+	}), NextCursor: next}, err
 }
 
 func (a *ArticleServiceAdapter) ListPub(ctx context.Context, in *artv1.ListPubRequest, opts ...grpc.CallOption) (*artv1.ListPubResponse, error) {
-	arts, err := a.svc.ListPub(ctx, time.UnixMilli(in.GetTimestamp()), int(in.GetOffset()), int(in.GetLimit()))
+	var (
+		arts  []domain.Article
+		next  string
+		err   error
+		start = time.UnixMilli(in.GetTimestamp())
+	)
+	if in.GetCursor() == "" && in.GetOffset() > 0 {
+		arts, err = a.svc.ListPub(ctx, start, int(in.GetOffset()), int(in.GetLimit()))
+	} else {
+		arts, next, err = a.svc.ListPubByCursor(ctx, start, in.GetCursor(), int(in.GetLimit()))
+	}
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	return &artv1.ListPubResponse{Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
 		return a.toDTO(arts[idx])
+	}), NextCursor: next}, err
+}
+
+func (a *ArticleServiceAdapter) ListPubByTag(ctx context.Context, in *artv1.ListPubByTagRequest, opts ...grpc.CallOption) (*artv1.ListPubByTagResponse, error) {
+	arts, err := a.svc.ListPubByTag(ctx, in.GetTag(), time.UnixMilli(in.GetTimestamp()), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListPubByTagResponse{Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+		return a.toDTO(src)
+	})}, err
+}
+
+func (a *ArticleServiceAdapter) ListPubByAuthor(ctx context.Context, in *artv1.ListPubByAuthorRequest, opts ...grpc.CallOption) (*artv1.ListPubByAuthorResponse, error) {
+	arts, err := a.svc.ListPubByAuthor(ctx, in.GetAuthorId(), time.UnixMilli(in.GetTimestamp()), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListPubByAuthorResponse{Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+		return a.toDTO(src)
 	})}, err
 }
 
 func (a *ArticleServiceAdapter) GetById(ctx context.Context, in *artv1.GetByIdRequest, opts ...grpc.CallOption) (*artv1.GetByIdResponse, error) {
 	art, err := a.svc.GetById(ctx, in.GetId())
-	return &artv1.GetByIdResponse{Art: a.toDTO(art)}, err
+	// 文章不存在的时候返回 NOT_FOUND，评论服务要靠它区分文章不存在和系统错误
+	return &artv1.GetByIdResponse{Art: a.toDTO(art)}, a.trashErr(err)
 }
 
 func (a *ArticleServiceAdapter) GetPubById(ctx context.Context, in *artv1.GetPubByIdRequest, opts ...grpc.CallOption) (*artv1.GetPubByIdResponse, error) {
+	if in.GetSkipRead() {
+		art, err := a.svc.LookupPublished(ctx, in.GetId())
+		return &artv1.GetPubByIdResponse{Art: a.toDTO(art)}, a.trashErr(err)
+	}
 	art, err := a.svc.GetById(ctx, in.GetId())
 	return &artv1.GetPubByIdResponse{Art: a.toDTO(art)}, err
 }
 
+func (a *ArticleServiceAdapter) ListRevisions(ctx context.Context, in *artv1.ListRevisionsRequest, opts ...grpc.CallOption) (*artv1.ListRevisionsResponse, error) {
+	revs, err := a.svc.ListRevisions(ctx, in.GetUid(), in.GetArticleId(), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListRevisionsResponse{Revisions: slice.Map(revs, func(idx int, src domain.ArticleRevision) *artv1.ArticleRevision {
+		return a.toRevisionDTO(src)
+	})}, err
+}
+
+func (a *ArticleServiceAdapter) GetRevision(ctx context.Context, in *artv1.GetRevisionRequest, opts ...grpc.CallOption) (*artv1.GetRevisionResponse, error) {
+	rev, err := a.svc.GetRevision(ctx, in.GetUid(), in.GetId())
+	return &artv1.GetRevisionResponse{Revision: a.toRevisionDTO(rev)}, err
+}
+
+func (a *ArticleServiceAdapter) DiffRevisions(ctx context.Context, in *artv1.DiffRevisionsRequest, opts ...grpc.CallOption) (*artv1.DiffRevisionsResponse, error) {
+	res, err := a.svc.DiffRevisions(ctx, in.GetUid(), in.GetFrom(), in.GetTo())
+	return &artv1.DiffRevisionsResponse{
+		From: a.toRevisionDTO(res.From),
+		To:   a.toRevisionDTO(res.To),
+		Lines: slice.Map(res.Lines, func(idx int, src domain.DiffLine) *artv1.DiffLine {
+			return &artv1.DiffLine{Op: artv1.DiffOp(src.Op), Text: src.Text}
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) RestoreRevision(ctx context.Context, in *artv1.RestoreRevisionRequest, opts ...grpc.CallOption) (*artv1.RestoreRevisionResponse, error) {
+	id, err := a.svc.RestoreRevision(ctx, in.GetUid(), in.GetId())
+	return &artv1.RestoreRevisionResponse{Id: id}, err
+}
+
+func (a *ArticleServiceAdapter) SchedulePublish(ctx context.Context, in *artv1.SchedulePublishRequest, opts ...grpc.CallOption) (*artv1.SchedulePublishResponse, error) {
+	id, err := a.svc.SchedulePublish(ctx, a.toPB(in.GetArt()))
+	if errors.Is(err, service.ErrSensitiveContent) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonSensitiveContent, err.Error())
+	}
+	if errors.Is(err, service.ErrTooManyTags) {
+		return nil, grpcx.ErrorWithReason(codes.InvalidArgument, domain.ReasonTooManyTags, err.Error())
+	}
+	return &artv1.SchedulePublishResponse{Id: id}, err
+}
+
+func (a *ArticleServiceAdapter) CancelSchedule(ctx context.Context, in *artv1.CancelScheduleRequest, opts ...grpc.CallOption) (*artv1.CancelScheduleResponse, error) {
+	err := a.svc.CancelSchedule(ctx, in.GetUid(), in.GetId())
+	return &artv1.CancelScheduleResponse{}, err
+}
+
+func (a *ArticleServiceAdapter) Delete(ctx context.Context, in *artv1.DeleteRequest, opts ...grpc.CallOption) (*artv1.DeleteResponse, error) {
+	err := a.svc.Delete(ctx, in.GetUid(), in.GetId())
+	return &artv1.DeleteResponse{}, a.trashErr(err)
+}
+
+func (a *ArticleServiceAdapter) ListTrash(ctx context.Context, in *artv1.ListTrashRequest, opts ...grpc.CallOption) (*artv1.ListTrashResponse, error) {
+	arts, err := a.svc.ListTrash(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListTrashResponse{
+		Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+			return a.toDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) Restore(ctx context.Context, in *artv1.RestoreRequest, opts ...grpc.CallOption) (*artv1.RestoreResponse, error) {
+	err := a.svc.Restore(ctx, in.GetUid(), in.GetId())
+	return &artv1.RestoreResponse{}, a.trashErr(err)
+}
+
+func (a *ArticleServiceAdapter) Purge(ctx context.Context, in *artv1.PurgeRequest, opts ...grpc.CallOption) (*artv1.PurgeResponse, error) {
+	err := a.svc.Purge(ctx, in.GetUid(), in.GetId())
+	return &artv1.PurgeResponse{}, a.trashErr(err)
+}
+
+func (a *ArticleServiceAdapter) PurgeExpired(ctx context.Context, in *artv1.PurgeExpiredRequest, opts ...grpc.CallOption) (*artv1.PurgeExpiredResponse, error) {
+	ids, err := a.svc.PurgeExpired(ctx, time.UnixMilli(in.GetBefore()), int(in.GetLimit()))
+	return &artv1.PurgeExpiredResponse{Ids: ids}, err
+}
+
+func (a *ArticleServiceAdapter) trashErr(err error) error {
+	if errors.Is(err, service.ErrArticleNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceAdapter) CreateSeries(ctx context.Context, in *artv1.CreateSeriesRequest, opts ...grpc.CallOption) (*artv1.CreateSeriesResponse, error) {
+	s := in.GetSeries()
+	id, err := a.svc.CreateSeries(ctx, domain.Series{
+		Title:       s.GetTitle(),
+		Description: s.GetDescription(),
+		Author:      domain.Author{Id: s.GetAuthorId()},
+		ArticleIds:  s.GetArticleIds(),
+	})
+	return &artv1.CreateSeriesResponse{Id: id}, a.seriesErr(err)
+}
+
+func (a *ArticleServiceAdapter) ReorderSeries(ctx context.Context, in *artv1.ReorderSeriesRequest, opts ...grpc.CallOption) (*artv1.ReorderSeriesResponse, error) {
+	err := a.svc.ReorderSeries(ctx, in.GetUid(), in.GetId(), in.GetArticleIds())
+	return &artv1.ReorderSeriesResponse{}, a.seriesErr(err)
+}
+
+func (a *ArticleServiceAdapter) ListSeries(ctx context.Context, in *artv1.ListSeriesRequest, opts ...grpc.CallOption) (*artv1.ListSeriesResponse, error) {
+	res, err := a.svc.ListSeries(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListSeriesResponse{
+		Series: slice.Map(res, func(idx int, src domain.Series) *artv1.Series {
+			return a.toSeriesDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) GetSeriesNav(ctx context.Context, in *artv1.GetSeriesNavRequest, opts ...grpc.CallOption) (*artv1.GetSeriesNavResponse, error) {
+	nav, err := a.svc.GetSeriesNav(ctx, in.GetArtId())
+	if errors.Is(err, service.ErrSeriesNotFound) {
+		return &artv1.GetSeriesNavResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &artv1.GetSeriesNavResponse{Series: a.toSeriesDTO(nav.Series)}
+	if nav.Prev.Id > 0 {
+		res.Prev = &artv1.Article{Id: nav.Prev.Id, Title: nav.Prev.Title}
+	}
+	if nav.Next.Id > 0 {
+		res.Next = &artv1.Article{Id: nav.Next.Id, Title: nav.Next.Title}
+	}
+	return res, nil
+}
+
+func (a *ArticleServiceAdapter) seriesErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrSeriesNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidSeries),
+		errors.Is(err, service.ErrInvalidSeriesArticles),
+		errors.Is(err, service.ErrArticleInOtherSeries):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceAdapter) toSeriesDTO(s domain.Series) *artv1.Series {
+	return &artv1.Series{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		AuthorId:    s.Author.Id,
+		ArticleIds:  s.ArticleIds,
+		Ctime:       s.Ctime.UnixMilli(),
+		Utime:       s.Utime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceAdapter) toRevisionDTO(rev domain.ArticleRevision) *artv1.ArticleRevision {
+	return &artv1.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		AuthorId:  rev.AuthorId,
+		Version:   rev.Version,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Kind:      artv1.RevisionKind(rev.Kind),
+		Ctime:     rev.Ctime.UnixMilli(),
+	}
+}
+
 func (a *ArticleServiceAdapter) toDTO(art domain.Article) *artv1.Article {
-	return &artv1.Article{
+	res := &artv1.Article{
 		Id:      art.Id,
 		Title:   art.Title,
 		Content: art.Content,
@@ -67,13 +295,25 @@ func (a *ArticleServiceAdapter) toDTO(art domain.Article) *artv1.Article {
 			Id:   art.Author.Id,
 			Name: art.Author.Name,
 		},
-		Ctime: art.Ctime.UnixMilli(),
-		Utime: art.Utime.UnixMilli(),
+		Tags:           art.Tags,
+		Html:           art.Rendered.HTML,
+		Abstract:       art.Abstract(),
+		ReadingMinutes: int32(art.Rendered.ReadingTime / time.Minute),
+		Version:        art.Version,
+		Ctime:          art.Ctime.UnixMilli(),
+		Utime:          art.Utime.UnixMilli(),
+	}
+	if !art.PublishAt.IsZero() {
+		res.PublishAt = art.PublishAt.UnixMilli()
 	}
+	if !art.DeletedAt.IsZero() {
+		res.DeletedAt = art.DeletedAt.UnixMilli()
+	}
+	return res
 }
 
 func (a *ArticleServiceAdapter) toPB(art *artv1.Article) domain.Article {
-	return domain.Article{
+	res := domain.Article{
 		Id:      art.Id,
 		Title:   art.Title,
 		Content: art.Content,
@@ -81,8 +321,278 @@ func (a *ArticleServiceAdapter) toPB(art *artv1.Article) domain.Article {
 			Id:   art.Author.Id,
 			Name: art.Author.Name,
 		},
-		Status: domain.ArticleStatus(uint8(art.Status)),
-		Ctime:  time.UnixMilli(art.Ctime),
-		Utime:  time.UnixMilli(art.Utime),
+		Status:  domain.ArticleStatus(uint8(art.Status)),
+		Tags:    art.Tags,
+		Version: art.Version,
+		Ctime:   time.UnixMilli(art.Ctime),
+		Utime:   time.UnixMilli(art.Utime),
+	}
+	if art.PublishAt > 0 {
+		res.PublishAt = time.UnixMilli(art.PublishAt)
+	}
+	return res
+}
+
+func (a *ArticleServiceAdapter) CreateUpload(ctx context.Context, in *artv1.CreateUploadRequest, opts ...grpc.CallOption) (*artv1.CreateUploadResponse, error) {
+	att, url, err := a.svc.CreateUpload(ctx, domain.Attachment{
+		Author:      domain.Author{Id: in.GetUid()},
+		Filename:    in.GetFilename(),
+		ContentType: in.GetContentType(),
+		Size:        in.GetSize(),
+	})
+	if err != nil {
+		return nil, a.attachmentErr(err)
+	}
+	return &artv1.CreateUploadResponse{
+		Attachment: a.toAttachmentDTO(att),
+		UploadUrl:  url,
+	}, nil
+}
+
+func (a *ArticleServiceAdapter) CompleteUpload(ctx context.Context, in *artv1.CompleteUploadRequest, opts ...grpc.CallOption) (*artv1.CompleteUploadResponse, error) {
+	att, err := a.svc.CompleteUpload(ctx, in.GetUid(), in.GetId())
+	if err != nil {
+		return nil, a.attachmentErr(err)
+	}
+	return &artv1.CompleteUploadResponse{Attachment: a.toAttachmentDTO(att)}, nil
+}
+
+func (a *ArticleServiceAdapter) ListAttachments(ctx context.Context, in *artv1.ListAttachmentsRequest, opts ...grpc.CallOption) (*artv1.ListAttachmentsResponse, error) {
+	res, err := a.svc.ListAttachments(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListAttachmentsResponse{
+		Attachments: slice.Map(res, func(idx int, src domain.Attachment) *artv1.Attachment {
+			return a.toAttachmentDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) DeleteAttachment(ctx context.Context, in *artv1.DeleteAttachmentRequest, opts ...grpc.CallOption) (*artv1.DeleteAttachmentResponse, error) {
+	err := a.svc.DeleteAttachment(ctx, in.GetUid(), in.GetId())
+	return &artv1.DeleteAttachmentResponse{}, a.attachmentErr(err)
+}
+
+func (a *ArticleServiceAdapter) GCAttachments(ctx context.Context, in *artv1.GCAttachmentsRequest, opts ...grpc.CallOption) (*artv1.GCAttachmentsResponse, error) {
+	next, deleted, err := a.svc.GCAttachments(ctx, time.UnixMilli(in.GetBefore()), in.GetStartId(), int(in.GetLimit()))
+	return &artv1.GCAttachmentsResponse{NextId: next, Deleted: int32(deleted)}, err
+}
+
+func (a *ArticleServiceAdapter) attachmentErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed),
+		errors.Is(err, service.ErrAttachmentTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrAttachmentQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrAttachmentNotUploaded):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceAdapter) toAttachmentDTO(att domain.Attachment) *artv1.Attachment {
+	return &artv1.Attachment{
+		Id:          att.Id,
+		AuthorId:    att.Author.Id,
+		Filename:    att.Filename,
+		ContentType: att.ContentType,
+		Size:        att.Size,
+		Status:      uint32(att.Status),
+		Url:         att.URL,
+		Ctime:       att.Ctime.UnixMilli(),
+		Utime:       att.Utime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceAdapter) ListPendingReview(ctx context.Context, in *artv1.ListPendingReviewRequest, opts ...grpc.CallOption) (*artv1.ListPendingReviewResponse, error) {
+	arts, err := a.svc.ListPendingReview(ctx, int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListPendingReviewResponse{
+		Arts: slice.Map(arts, func(idx int, src domain.Article) *artv1.Article {
+			return a.toDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) Approve(ctx context.Context, in *artv1.ApproveRequest, opts ...grpc.CallOption) (*artv1.ApproveResponse, error) {
+	err := a.svc.Approve(ctx, in.GetReviewer(), in.GetId(), in.GetVersion())
+	return &artv1.ApproveResponse{}, a.moderationErr(err)
+}
+
+func (a *ArticleServiceAdapter) Reject(ctx context.Context, in *artv1.RejectRequest, opts ...grpc.CallOption) (*artv1.RejectResponse, error) {
+	err := a.svc.Reject(ctx, in.GetReviewer(), in.GetId(), in.GetVersion(), in.GetReason())
+	return &artv1.RejectResponse{}, a.moderationErr(err)
+}
+
+func (a *ArticleServiceAdapter) ListModerationLogs(ctx context.Context, in *artv1.ListModerationLogsRequest, opts ...grpc.CallOption) (*artv1.ListModerationLogsResponse, error) {
+	logs, err := a.svc.ListModerationLogs(ctx, in.GetArtId(), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListModerationLogsResponse{
+		Logs: slice.Map(logs, func(idx int, src domain.ModerationLog) *artv1.ModerationLog {
+			return a.toModerationLogDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) moderationErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrArticleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotPendingReview):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrEmptyRejectReason):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceAdapter) toModerationLogDTO(log domain.ModerationLog) *artv1.ModerationLog {
+	return &artv1.ModerationLog{
+		Id:       log.Id,
+		ArtId:    log.ArticleId,
+		AuthorId: log.AuthorId,
+		Reviewer: log.Reviewer,
+		Action:   uint32(log.Action),
+		Status:   uint32(log.Status),
+		Reason:   log.Reason,
+		Ctime:    log.Ctime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceAdapter) FindSimilar(ctx context.Context, in *artv1.FindSimilarRequest, opts ...grpc.CallOption) (*artv1.FindSimilarResponse, error) {
+	arts, err := a.svc.FindSimilar(ctx, in.GetId(), int(in.GetLimit()))
+	if err != nil {
+		if errors.Is(err, service.ErrFingerprintNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &artv1.FindSimilarResponse{
+		Arts: slice.Map(arts, func(idx int, src domain.SimilarArticle) *artv1.SimilarArticle {
+			return &artv1.SimilarArticle{
+				Id:       src.Id,
+				AuthorId: src.AuthorId,
+				Distance: int32(src.Distance),
+				Ctime:    src.Ctime.UnixMilli(),
+			}
+		}),
+	}, nil
+}
+
+func (a *ArticleServiceAdapter) ListFlaggedDuplicates(ctx context.Context, in *artv1.ListFlaggedDuplicatesRequest, opts ...grpc.CallOption) (*artv1.ListFlaggedDuplicatesResponse, error) {
+	logs, err := a.svc.ListFlaggedDuplicates(ctx, int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListFlaggedDuplicatesResponse{
+		Logs: slice.Map(logs, func(idx int, src domain.ModerationLog) *artv1.ModerationLog {
+			return a.toModerationLogDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) StartExport(ctx context.Context, in *artv1.StartExportRequest, opts ...grpc.CallOption) (*artv1.StartExportResponse, error) {
+	e, err := a.svc.StartExport(ctx, in.GetUid())
+	if err != nil {
+		return nil, a.exportErr(err)
+	}
+	return &artv1.StartExportResponse{Export: a.toExportDTO(e)}, nil
+}
+
+func (a *ArticleServiceAdapter) GetExport(ctx context.Context, in *artv1.GetExportRequest, opts ...grpc.CallOption) (*artv1.GetExportResponse, error) {
+	e, err := a.svc.GetExport(ctx, in.GetUid(), in.GetId())
+	if err != nil {
+		return nil, a.exportErr(err)
+	}
+	return &artv1.GetExportResponse{Export: a.toExportDTO(e)}, nil
+}
+
+func (a *ArticleServiceAdapter) ListExports(ctx context.Context, in *artv1.ListExportsRequest, opts ...grpc.CallOption) (*artv1.ListExportsResponse, error) {
+	res, err := a.svc.ListExports(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListExportsResponse{
+		Exports: slice.Map(res, func(idx int, src domain.Export) *artv1.Export {
+			return a.toExportDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) exportErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrExportNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrExportInProgress):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceAdapter) toExportDTO(e domain.Export) *artv1.Export {
+	return &artv1.Export{
+		Id:          e.Id,
+		AuthorId:    e.Author.Id,
+		Status:      uint32(e.Status),
+		Total:       int32(e.Total),
+		Exported:    int32(e.Exported),
+		Size:        e.Size,
+		DownloadUrl: e.URL,
+		Error:       e.Error,
+		Ctime:       e.Ctime.UnixMilli(),
+		Utime:       e.Utime.UnixMilli(),
+	}
+}
+
+func (a *ArticleServiceAdapter) StartImport(ctx context.Context, in *artv1.StartImportRequest, opts ...grpc.CallOption) (*artv1.StartImportResponse, error) {
+	i, err := a.svc.StartImport(ctx, in.GetUid(), in.GetData())
+	if err != nil {
+		return nil, a.importErr(err)
+	}
+	return &artv1.StartImportResponse{Import: a.toImportDTO(i)}, nil
+}
+
+func (a *ArticleServiceAdapter) GetImport(ctx context.Context, in *artv1.GetImportRequest, opts ...grpc.CallOption) (*artv1.GetImportResponse, error) {
+	i, err := a.svc.GetImport(ctx, in.GetUid(), in.GetId())
+	if err != nil {
+		return nil, a.importErr(err)
+	}
+	return &artv1.GetImportResponse{Import: a.toImportDTO(i)}, nil
+}
+
+func (a *ArticleServiceAdapter) ListImports(ctx context.Context, in *artv1.ListImportsRequest, opts ...grpc.CallOption) (*artv1.ListImportsResponse, error) {
+	res, err := a.svc.ListImports(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	return &artv1.ListImportsResponse{
+		Imports: slice.Map(res, func(idx int, src domain.Import) *artv1.Import {
+			return a.toImportDTO(src)
+		}),
+	}, err
+}
+
+func (a *ArticleServiceAdapter) importErr(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidArchive):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrImportNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrImportInProgress):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func (a *ArticleServiceAdapter) toImportDTO(i domain.Import) *artv1.Import {
+	return &artv1.Import{
+		Id:       i.Id,
+		AuthorId: i.Author.Id,
+		Status:   uint32(i.Status),
+		Total:    int32(i.Total),
+		Imported: int32(i.Imported),
+		Results: slice.Map(i.Results, func(idx int, src domain.ImportResult) *artv1.ImportResult {
+			return &artv1.ImportResult{
+				Filename: src.Filename,
+				Title:    src.Title,
+				Id:       src.Id,
+				Error:    src.Error,
+			}
+		}),
+		Error: i.Error,
+		Ctime: i.Ctime.UnixMilli(),
+		Utime: i.Utime.UnixMilli(),
 	}
 }
@@ -50,6 +50,14 @@ func (g *GrayScaleArticleServiceClient) ListPub(ctx context.Context, in *artv1.L
 	return g.client().ListPub(ctx, in)
 }
 
+func (g *GrayScaleArticleServiceClient) ListPubByTag(ctx context.Context, in *artv1.ListPubByTagRequest, opts ...grpc.CallOption) (*artv1.ListPubByTagResponse, error) {
+	return g.client().ListPubByTag(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListPubByAuthor(ctx context.Context, in *artv1.ListPubByAuthorRequest, opts ...grpc.CallOption) (*artv1.ListPubByAuthorResponse, error) {
+	return g.client().ListPubByAuthor(ctx, in)
+}
+
 func (g *GrayScaleArticleServiceClient) GetById(ctx context.Context, in *artv1.GetByIdRequest, opts ...grpc.CallOption) (*artv1.GetByIdResponse, error) {
 	return g.client().GetById(ctx, in)
 }
@@ -58,3 +66,132 @@ func (g *GrayScaleArticleServiceClient) GetPubById(ctx context.Context, in *artv
 	//TODO implement me
 	panic("implement me")
 }
+
+func (g *GrayScaleArticleServiceClient) ListRevisions(ctx context.Context, in *artv1.ListRevisionsRequest, opts ...grpc.CallOption) (*artv1.ListRevisionsResponse, error) {
+	return g.client().ListRevisions(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) GetRevision(ctx context.Context, in *artv1.GetRevisionRequest, opts ...grpc.CallOption) (*artv1.GetRevisionResponse, error) {
+	return g.client().GetRevision(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) DiffRevisions(ctx context.Context, in *artv1.DiffRevisionsRequest, opts ...grpc.CallOption) (*artv1.DiffRevisionsResponse, error) {
+	return g.client().DiffRevisions(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) RestoreRevision(ctx context.Context, in *artv1.RestoreRevisionRequest, opts ...grpc.CallOption) (*artv1.RestoreRevisionResponse, error) {
+	return g.client().RestoreRevision(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) SchedulePublish(ctx context.Context, in *artv1.SchedulePublishRequest, opts ...grpc.CallOption) (*artv1.SchedulePublishResponse, error) {
+	return g.client().SchedulePublish(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) CancelSchedule(ctx context.Context, in *artv1.CancelScheduleRequest, opts ...grpc.CallOption) (*artv1.CancelScheduleResponse, error) {
+	return g.client().CancelSchedule(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) Delete(ctx context.Context, in *artv1.DeleteRequest, opts ...grpc.CallOption) (*artv1.DeleteResponse, error) {
+	return g.client().Delete(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListTrash(ctx context.Context, in *artv1.ListTrashRequest, opts ...grpc.CallOption) (*artv1.ListTrashResponse, error) {
+	return g.client().ListTrash(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) Restore(ctx context.Context, in *artv1.RestoreRequest, opts ...grpc.CallOption) (*artv1.RestoreResponse, error) {
+	return g.client().Restore(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) Purge(ctx context.Context, in *artv1.PurgeRequest, opts ...grpc.CallOption) (*artv1.PurgeResponse, error) {
+	return g.client().Purge(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) PurgeExpired(ctx context.Context, in *artv1.PurgeExpiredRequest, opts ...grpc.CallOption) (*artv1.PurgeExpiredResponse, error) {
+	return g.client().PurgeExpired(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) CreateSeries(ctx context.Context, in *artv1.CreateSeriesRequest, opts ...grpc.CallOption) (*artv1.CreateSeriesResponse, error) {
+	return g.client().CreateSeries(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ReorderSeries(ctx context.Context, in *artv1.ReorderSeriesRequest, opts ...grpc.CallOption) (*artv1.ReorderSeriesResponse, error) {
+	return g.client().ReorderSeries(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListSeries(ctx context.Context, in *artv1.ListSeriesRequest, opts ...grpc.CallOption) (*artv1.ListSeriesResponse, error) {
+	return g.client().ListSeries(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) GetSeriesNav(ctx context.Context, in *artv1.GetSeriesNavRequest, opts ...grpc.CallOption) (*artv1.GetSeriesNavResponse, error) {
+	return g.client().GetSeriesNav(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) CreateUpload(ctx context.Context, in *artv1.CreateUploadRequest, opts ...grpc.CallOption) (*artv1.CreateUploadResponse, error) {
+	return g.client().CreateUpload(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) CompleteUpload(ctx context.Context, in *artv1.CompleteUploadRequest, opts ...grpc.CallOption) (*artv1.CompleteUploadResponse, error) {
+	return g.client().CompleteUpload(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListAttachments(ctx context.Context, in *artv1.ListAttachmentsRequest, opts ...grpc.CallOption) (*artv1.ListAttachmentsResponse, error) {
+	return g.client().ListAttachments(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) DeleteAttachment(ctx context.Context, in *artv1.DeleteAttachmentRequest, opts ...grpc.CallOption) (*artv1.DeleteAttachmentResponse, error) {
+	return g.client().DeleteAttachment(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) GCAttachments(ctx context.Context, in *artv1.GCAttachmentsRequest, opts ...grpc.CallOption) (*artv1.GCAttachmentsResponse, error) {
+	return g.client().GCAttachments(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListPendingReview(ctx context.Context, in *artv1.ListPendingReviewRequest, opts ...grpc.CallOption) (*artv1.ListPendingReviewResponse, error) {
+	return g.client().ListPendingReview(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) Approve(ctx context.Context, in *artv1.ApproveRequest, opts ...grpc.CallOption) (*artv1.ApproveResponse, error) {
+	return g.client().Approve(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) Reject(ctx context.Context, in *artv1.RejectRequest, opts ...grpc.CallOption) (*artv1.RejectResponse, error) {
+	return g.client().Reject(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListModerationLogs(ctx context.Context, in *artv1.ListModerationLogsRequest, opts ...grpc.CallOption) (*artv1.ListModerationLogsResponse, error) {
+	return g.client().ListModerationLogs(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) FindSimilar(ctx context.Context, in *artv1.FindSimilarRequest, opts ...grpc.CallOption) (*artv1.FindSimilarResponse, error) {
+	return g.client().FindSimilar(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListFlaggedDuplicates(ctx context.Context, in *artv1.ListFlaggedDuplicatesRequest, opts ...grpc.CallOption) (*artv1.ListFlaggedDuplicatesResponse, error) {
+	return g.client().ListFlaggedDuplicates(ctx, in)
+}
+
+// StartExport 导出和导入的压缩包只在文章服务的存储里面，后台任务也只在文章服务里面跑，所以不参与灰度
+func (g *GrayScaleArticleServiceClient) StartExport(ctx context.Context, in *artv1.StartExportRequest, opts ...grpc.CallOption) (*artv1.StartExportResponse, error) {
+	return g.remote.StartExport(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) GetExport(ctx context.Context, in *artv1.GetExportRequest, opts ...grpc.CallOption) (*artv1.GetExportResponse, error) {
+	return g.remote.GetExport(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListExports(ctx context.Context, in *artv1.ListExportsRequest, opts ...grpc.CallOption) (*artv1.ListExportsResponse, error) {
+	return g.remote.ListExports(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) StartImport(ctx context.Context, in *artv1.StartImportRequest, opts ...grpc.CallOption) (*artv1.StartImportResponse, error) {
+	return g.remote.StartImport(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) GetImport(ctx context.Context, in *artv1.GetImportRequest, opts ...grpc.CallOption) (*artv1.GetImportResponse, error) {
+	return g.remote.GetImport(ctx, in)
+}
+
+func (g *GrayScaleArticleServiceClient) ListImports(ctx context.Context, in *artv1.ListImportsRequest, opts ...grpc.CallOption) (*artv1.ListImportsResponse, error) {
+	return g.remote.ListImports(ctx, in)
+}
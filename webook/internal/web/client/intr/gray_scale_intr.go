@@ -45,6 +45,22 @@ func (g *GrayScaleInteractiveServiceClient) GetByIds(ctx context.Context, in *in
 	return g.client().GetByIds(ctx, in, opts...)
 }
 
+func (g *GrayScaleInteractiveServiceClient) Delete(ctx context.Context, in *intrv1.DeleteRequest, opts ...grpc.CallOption) (*intrv1.DeleteResponse, error) {
+	return g.client().Delete(ctx, in)
+}
+
+func (g *GrayScaleInteractiveServiceClient) GetStats(ctx context.Context, in *intrv1.GetStatsRequest, opts ...grpc.CallOption) (*intrv1.GetStatsResponse, error) {
+	return g.client().GetStats(ctx, in)
+}
+
+func (g *GrayScaleInteractiveServiceClient) ListStats(ctx context.Context, in *intrv1.ListStatsRequest, opts ...grpc.CallOption) (*intrv1.ListStatsResponse, error) {
+	return g.client().ListStats(ctx, in)
+}
+
+func (g *GrayScaleInteractiveServiceClient) TopStats(ctx context.Context, in *intrv1.TopStatsRequest, opts ...grpc.CallOption) (*intrv1.TopStatsResponse, error) {
+	return g.client().TopStats(ctx, in)
+}
+
 func (g *GrayScaleInteractiveServiceClient) UpdateThreshold(newThreshold int32) {
 	g.threshold.Store(newThreshold)
 }
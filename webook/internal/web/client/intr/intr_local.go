@@ -1,11 +1,15 @@
 package intr
 
 import (
+	"errors"
+	"time"
 	intrv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/intr/v1"
 	"github.com/TengFeiyang01/webook/webook/interactive/domain"
 	"github.com/TengFeiyang01/webook/webook/interactive/service"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // InteractiveServiceAdapter 将一个本地实现伪装成一个 gRPC 客户端
@@ -62,6 +66,67 @@ func (i *InteractiveServiceAdapter) GetByIds(ctx context.Context, in *intrv1.Get
 	}, nil
 }
 
+func (i *InteractiveServiceAdapter) Delete(ctx context.Context, in *intrv1.DeleteRequest, opts ...grpc.CallOption) (*intrv1.DeleteResponse, error) {
+	err := i.svc.Delete(ctx, in.GetBiz(), in.GetBizIds())
+	return &intrv1.DeleteResponse{}, err
+}
+
+func (i *InteractiveServiceAdapter) GetStats(ctx context.Context, in *intrv1.GetStatsRequest, opts ...grpc.CallOption) (*intrv1.GetStatsResponse, error) {
+	q := in.GetQuery()
+	points, err := i.svc.GetStats(ctx, q.GetBiz(), q.GetBizIds(), domain.StatsGranularity(q.GetGranularity()),
+		time.UnixMilli(q.GetStart()), time.UnixMilli(q.GetEnd()))
+	if err != nil {
+		return nil, i.statsErr(err)
+	}
+	return &intrv1.GetStatsResponse{Points: i.toPointDTOs(points)}, nil
+}
+
+func (i *InteractiveServiceAdapter) ListStats(ctx context.Context, in *intrv1.ListStatsRequest, opts ...grpc.CallOption) (*intrv1.ListStatsResponse, error) {
+	q := in.GetQuery()
+	points, err := i.svc.ListStats(ctx, q.GetBiz(), q.GetBizIds(), domain.StatsGranularity(q.GetGranularity()),
+		time.UnixMilli(q.GetStart()), time.UnixMilli(q.GetEnd()))
+	if err != nil {
+		return nil, i.statsErr(err)
+	}
+	return &intrv1.ListStatsResponse{Points: i.toPointDTOs(points)}, nil
+}
+
+func (i *InteractiveServiceAdapter) TopStats(ctx context.Context, in *intrv1.TopStatsRequest, opts ...grpc.CallOption) (*intrv1.TopStatsResponse, error) {
+	q := in.GetQuery()
+	intrs, err := i.svc.TopStats(ctx, q.GetBiz(), q.GetBizIds(), domain.StatsGranularity(q.GetGranularity()),
+		time.UnixMilli(q.GetStart()), time.UnixMilli(q.GetEnd()),
+		domain.StatsOrder(in.GetOrderBy()), int(in.GetLimit()))
+	if err != nil {
+		return nil, i.statsErr(err)
+	}
+	res := make([]*intrv1.Interactive, 0, len(intrs))
+	for _, intr := range intrs {
+		res = append(res, i.toDTO(intr))
+	}
+	return &intrv1.TopStatsResponse{Intrs: res}, nil
+}
+
+func (i *InteractiveServiceAdapter) statsErr(err error) error {
+	if errors.Is(err, service.ErrInvalidStatsQuery) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (i *InteractiveServiceAdapter) toPointDTOs(points []domain.StatsPoint) []*intrv1.StatsPoint {
+	res := make([]*intrv1.StatsPoint, 0, len(points))
+	for _, p := range points {
+		res = append(res, &intrv1.StatsPoint{
+			BizId:      p.BizId,
+			Time:       p.Time.UnixMilli(),
+			ReadCnt:    p.ReadCnt,
+			LikeCnt:    p.LikeCnt,
+			CollectCnt: p.CollectCnt,
+		})
+	}
+	return res
+}
+
 // DTO data transfer object
 func (i *InteractiveServiceAdapter) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
@@ -0,0 +1,176 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	commentv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/comment/v1"
+	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*CommentHandler)(nil)
+
+// CommentHandler 评论都在评论服务里面，这里只做参数转换
+type CommentHandler struct {
+	svc commentv1.CommentServiceClient
+	biz string
+	l   logger.LoggerV1
+}
+
+func NewCommentHandler(svc commentv1.CommentServiceClient, l logger.LoggerV1) *CommentHandler {
+	return &CommentHandler{svc: svc, biz: "art", l: l}
+}
+
+func (h *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/comments")
+	g.POST("/create", ginx.WrapBodyAndToken[CommentCreateReq, ijwt.UserClaims](h.Create))
+	g.POST("/delete", ginx.WrapBodyAndToken[CommentDeleteReq, ijwt.UserClaims](h.Delete))
+	g.POST("/list", ginx.WrapBodyAndToken[CommentListReq, ijwt.UserClaims](h.List))
+	g.POST("/replies", ginx.WrapBodyAndToken[CommentRepliesReq, ijwt.UserClaims](h.Replies))
+	g.POST("/like", ginx.WrapBodyAndToken[LikeReq, ijwt.UserClaims](h.Like))
+}
+
+func (h *CommentHandler) Create(ctx *gin.Context, req CommentCreateReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.CreateComment(ctx, &commentv1.CreateCommentRequest{
+		Uid:      uc.Uid,
+		Biz:      h.bizOrDefault(req.Biz, req.ParentId),
+		BizId:    req.BizId,
+		ParentId: req.ParentId,
+		Content:  req.Content,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Data: h.toVO(resp.GetComment())}, nil
+	case codes.InvalidArgument:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "评论不能为空，也不能太长",
+		}, nil
+	case codes.NotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章或者评论不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+}
+
+func (h *CommentHandler) Delete(ctx *gin.Context, req CommentDeleteReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.svc.DeleteComment(ctx, &commentv1.DeleteCommentRequest{Id: req.Id, Uid: uc.Uid})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "评论不存在",
+		}, nil
+	case codes.PermissionDenied:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "只有评论的作者和文章的作者可以删除",
+		}, fmt.Errorf("非法删除评论 %d, %d", uc.Uid, req.Id)
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+}
+
+func (h *CommentHandler) List(ctx *gin.Context, req CommentListReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.GetCommentList(ctx, &commentv1.CommentListRequest{
+		Biz:        h.bizOrDefault(req.Biz, 0),
+		BizId:      req.BizId,
+		MinId:      req.MinId,
+		Limit:      int32(req.Limit),
+		ReplyLimit: int32(req.ReplyLimit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Data: h.toVOs(resp.GetComments())}, nil
+}
+
+func (h *CommentHandler) Replies(ctx *gin.Context, req CommentRepliesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.svc.GetMoreReplies(ctx, &commentv1.GetMoreRepliesRequest{
+		RootId: req.RootId,
+		MaxId:  req.MaxId,
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Data: h.toVOs(resp.GetReplies())}, nil
+}
+
+// Like 点赞数记在互动服务里面，评论服务负责检查评论是不是存在
+func (h *CommentHandler) Like(ctx *gin.Context, req LikeReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	var err error
+	if req.Like {
+		_, err = h.svc.Like(ctx, &commentv1.LikeRequest{Id: req.Id, Uid: uc.Uid})
+	} else {
+		_, err = h.svc.CancelLike(ctx, &commentv1.CancelLikeRequest{Id: req.Id, Uid: uc.Uid})
+	}
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "评论不存在",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+// bizOrDefault 回复的时候资源以被回复的评论为准，不用填
+func (h *CommentHandler) bizOrDefault(biz string, parentId int64) string {
+	if biz != "" || parentId > 0 {
+		return biz
+	}
+	return h.biz
+}
+
+func (h *CommentHandler) toVOs(cs []*commentv1.Comment) []CommentVO {
+	return slice.Map(cs, func(idx int, src *commentv1.Comment) CommentVO {
+		return h.toVO(src)
+	})
+}
+
+func (h *CommentHandler) toVO(c *commentv1.Comment) CommentVO {
+	res := CommentVO{
+		Id:         c.GetId(),
+		Uid:        c.GetUid(),
+		Content:    c.GetContent(),
+		RootId:     c.GetRootId(),
+		ParentId:   c.GetParentId(),
+		ReplyToUid: c.GetReplyToUid(),
+		ReplyCnt:   c.GetReplyCnt(),
+		LikeCnt:    c.GetLikeCnt(),
+		Ctime:      time.UnixMilli(c.GetCtime()).Format(time.DateTime),
+	}
+	if len(c.GetReplies()) > 0 {
+		res.Replies = h.toVOs(c.GetReplies())
+	}
+	return res
+}
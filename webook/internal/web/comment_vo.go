@@ -0,0 +1,46 @@
+package web
+
+type CommentCreateReq struct {
+	// Biz 不传默认是文章
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// ParentId 回复哪条评论，根评论不传
+	ParentId int64  `json:"parent_id"`
+	Content  string `json:"content"`
+}
+
+type CommentDeleteReq struct {
+	Id int64 `json:"id"`
+}
+
+type CommentListReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// MinId 上一页最后一条根评论的 id，第一页不传
+	MinId int64 `json:"min_id"`
+	Limit int   `json:"limit"`
+	// ReplyLimit 每条根评论带多少条回复，不传默认 3 条
+	ReplyLimit int `json:"reply_limit"`
+}
+
+type CommentRepliesReq struct {
+	RootId int64 `json:"root_id"`
+	// MaxId 上一页最后一条回复的 id，第一页不传
+	MaxId int64 `json:"max_id"`
+	Limit int   `json:"limit"`
+}
+
+type CommentVO struct {
+	Id       int64  `json:"id"`
+	Uid      int64  `json:"uid"`
+	Content  string `json:"content"`
+	RootId   int64  `json:"root_id"`
+	ParentId int64  `json:"parent_id"`
+	// ReplyToUid 回复的是谁，根评论是 0
+	ReplyToUid int64 `json:"reply_to_uid"`
+	// ReplyCnt 只有根评论有
+	ReplyCnt int64       `json:"reply_cnt"`
+	LikeCnt  int64       `json:"like_cnt"`
+	Ctime    string      `json:"ctime"`
+	Replies  []CommentVO `json:"replies,omitempty"`
+}
@@ -0,0 +1,238 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/article/domain"
+	"github.com/TengFeiyang01/webook/webook/internal/service"
+	"github.com/TengFeiyang01/webook/webook/pkg/feed"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+var _ handler = (*FeedHandler)(nil)
+
+const (
+	// feedMaxAge 阅读器拉取得很频繁，让中间的缓存挡掉一部分
+	feedMaxAge = 5 * time.Minute
+)
+
+type FeedConfig struct {
+	// Title 全站订阅源的标题
+	Title string `yaml:"title"`
+	// SiteURL 网站首页
+	SiteURL string `yaml:"siteURL"`
+	// FeedURL 订阅源自己的地址前缀，例如 https://api.webook.com/feeds
+	FeedURL string `yaml:"feedURL"`
+	// ArticleURL 文章页面的地址，%d 是文章 id
+	ArticleURL string `yaml:"articleURL"`
+	// AuthorURL 作者主页的地址，%d 是作者 id
+	AuthorURL string `yaml:"authorURL"`
+	// Size 每个订阅源最多多少篇文章
+	Size int `yaml:"size"`
+}
+
+// FeedHandler 给 RSS 阅读器用的订阅源，不需要登录
+// 默认输出 RSS 2.0，带上 format=atom 输出 Atom 1.0
+type FeedHandler struct {
+	svc     artv1.ArticleServiceClient
+	userSvc service.UserService
+	cfg     FeedConfig
+	l       logger.LoggerV1
+}
+
+func NewFeedHandler(svc artv1.ArticleServiceClient, userSvc service.UserService,
+	cfg FeedConfig, l logger.LoggerV1) *FeedHandler {
+	if cfg.Size <= 0 {
+		cfg.Size = 20
+	}
+	return &FeedHandler{svc: svc, userSvc: userSvc, cfg: cfg, l: l}
+}
+
+func (h *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	// 不需要登录，要在登录校验里面忽略 /feeds/ 前缀
+	g := server.Group("/feeds")
+	g.GET("/latest.xml", h.Latest)
+	// gin 的路径参数不能带后缀，:file 是 123.xml 这种
+	g.GET("/authors/:file", h.Author)
+}
+
+// Latest 全站最新发表的文章
+func (h *FeedHandler) Latest(ctx *gin.Context) {
+	resp, err := h.svc.ListPub(ctx, &artv1.ListPubRequest{
+		Timestamp: time.Now().UnixMilli(),
+		Limit:     int32(h.cfg.Size),
+	})
+	if err != nil {
+		h.l.Error("订阅源查询最新文章失败", logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	arts := h.published(resp.GetArts())
+	names := h.authorNames(ctx, arts)
+	h.write(ctx, feed.Feed{
+		Title:       h.cfg.Title,
+		Link:        h.cfg.SiteURL,
+		Self:        h.cfg.FeedURL + "/latest.xml",
+		Description: h.cfg.Title + " 最新发表的文章",
+		Items:       h.toItems(arts, names),
+	})
+}
+
+// Author 某个作者最新发表的文章
+func (h *FeedHandler) Author(ctx *gin.Context) {
+	file := ctx.Param("file")
+	if !strings.HasSuffix(file, ".xml") {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	uid, err := strconv.ParseInt(strings.TrimSuffix(file, ".xml"), 10, 64)
+	if err != nil || uid <= 0 {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	u, err := h.userSvc.Profile(ctx, uid)
+	if errors.Is(err, service.ErrUserNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.l.Error("订阅源查询作者失败", logger.Int64("uid", uid), logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	arts, err := h.authorArts(ctx, uid)
+	if err != nil {
+		h.l.Error("订阅源查询作者的文章失败", logger.Int64("uid", uid), logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	name := h.displayName(uid, u.NickName)
+	h.write(ctx, feed.Feed{
+		Title:       name + " - " + h.cfg.Title,
+		Link:        fmt.Sprintf(h.cfg.AuthorURL, uid),
+		Self:        fmt.Sprintf("%s/authors/%d.xml", h.cfg.FeedURL, uid),
+		Description: name + " 最新发表的文章",
+		Items:       h.toItems(arts, map[int64]string{uid: name}),
+	})
+}
+
+// authorArts 直接查线上库，撤回了的文章不会返回
+func (h *FeedHandler) authorArts(ctx context.Context, uid int64) ([]*artv1.Article, error) {
+	resp, err := h.svc.ListPubByAuthor(ctx, &artv1.ListPubByAuthorRequest{
+		AuthorId:  uid,
+		Timestamp: time.Now().UnixMilli(),
+		Limit:     int32(h.cfg.Size),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetArts(), nil
+}
+
+// published 线上库里面撤回的文章还在，状态是仅自己可见，要过滤掉
+func (h *FeedHandler) published(arts []*artv1.Article) []*artv1.Article {
+	res := make([]*artv1.Article, 0, len(arts))
+	for _, art := range arts {
+		if domain.ArticleStatus(art.GetStatus()) == domain.ArticleStatusPublished {
+			res = append(res, art)
+		}
+	}
+	return res
+}
+
+// authorNames 列表里面只有作者 id，一次查出来，查不到的作者不影响输出
+func (h *FeedHandler) authorNames(ctx context.Context, arts []*artv1.Article) map[int64]string {
+	uids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		uids = append(uids, art.GetAuthor().GetId())
+	}
+	users, err := h.userSvc.Profiles(ctx, uids)
+	if err != nil {
+		h.l.Warn("订阅源查询作者失败", logger.Error(err))
+	}
+	res := make(map[int64]string, len(uids))
+	for _, uid := range uids {
+		res[uid] = h.displayName(uid, users[uid].NickName)
+	}
+	return res
+}
+
+// displayName 没有设置昵称的作者也要有个名字，Atom 要求作者必须有 name
+func (h *FeedHandler) displayName(uid int64, nickname string) string {
+	if nickname != "" {
+		return nickname
+	}
+	return fmt.Sprintf("用户%d", uid)
+}
+
+func (h *FeedHandler) toItems(arts []*artv1.Article, names map[int64]string) []feed.Item {
+	res := make([]feed.Item, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, feed.Item{
+			Title:      art.GetTitle(),
+			Link:       fmt.Sprintf(h.cfg.ArticleURL, art.GetId()),
+			Summary:    h.abstract(art),
+			Author:     names[art.GetAuthor().GetId()],
+			Categories: art.GetTags(),
+			Published:  time.UnixMilli(art.GetCtime()),
+			Updated:    time.UnixMilli(art.GetUtime()),
+		})
+	}
+	return res
+}
+
+func (h *FeedHandler) abstract(art *artv1.Article) string {
+	if art.GetAbstract() != "" {
+		return art.GetAbstract()
+	}
+	cs := []rune(art.GetContent())
+	if len(cs) <= domain.MaxAbstractLength {
+		return art.GetContent()
+	}
+	return string(cs[:domain.MaxAbstractLength])
+}
+
+// write 内容没有变化的时候返回 304，阅读器不用重新下载
+func (h *FeedHandler) write(ctx *gin.Context, f feed.Feed) {
+	var (
+		data        []byte
+		err         error
+		contentType = feed.ContentTypeRSS
+	)
+	switch ctx.Query("format") {
+	case "", "rss":
+		data, err = f.RSS()
+	case "atom":
+		contentType = feed.ContentTypeAtom
+		f.Self += "?format=atom"
+		data, err = f.Atom()
+	default:
+		ctx.Status(http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		h.l.Error("生成订阅源失败", logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	etag := feed.ETag(data)
+	lastModified := f.LastModified()
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(feedMaxAge.Seconds())))
+	if !lastModified.IsZero() {
+		ctx.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if feed.NotModified(ctx.Request, etag, lastModified) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, contentType, data)
+}
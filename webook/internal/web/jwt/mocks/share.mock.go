@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./share.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./share.go -package=jwtmocks -destination=./mocks/share.mock.go ShareHandler
+//
+
+// Package jwtmocks is a generated GoMock package.
+package jwtmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	jwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShareHandler is a mock of ShareHandler interface.
+type MockShareHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareHandlerMockRecorder
+}
+
+// MockShareHandlerMockRecorder is the mock recorder for MockShareHandler.
+type MockShareHandlerMockRecorder struct {
+	mock *MockShareHandler
+}
+
+// NewMockShareHandler creates a new mock instance.
+func NewMockShareHandler(ctrl *gomock.Controller) *MockShareHandler {
+	mock := &MockShareHandler{ctrl: ctrl}
+	mock.recorder = &MockShareHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareHandler) EXPECT() *MockShareHandlerMockRecorder {
+	return m.recorder
+}
+
+// IncrViews mocks base method.
+func (m *MockShareHandler) IncrViews(ctx context.Context, claims jwt.ShareClaims) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrViews", ctx, claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrViews indicates an expected call of IncrViews.
+func (mr *MockShareHandlerMockRecorder) IncrViews(ctx, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrViews", reflect.TypeOf((*MockShareHandler)(nil).IncrViews), ctx, claims)
+}
+
+// NewShareToken mocks base method.
+func (m *MockShareHandler) NewShareToken(aid, uid int64, expiration time.Duration) (string, jwt.ShareClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewShareToken", aid, uid, expiration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(jwt.ShareClaims)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewShareToken indicates an expected call of NewShareToken.
+func (mr *MockShareHandlerMockRecorder) NewShareToken(aid, uid, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewShareToken", reflect.TypeOf((*MockShareHandler)(nil).NewShareToken), aid, uid, expiration)
+}
+
+// ParseShareToken mocks base method.
+func (m *MockShareHandler) ParseShareToken(ctx context.Context, tokenStr string) (jwt.ShareClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseShareToken", ctx, tokenStr)
+	ret0, _ := ret[0].(jwt.ShareClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseShareToken indicates an expected call of ParseShareToken.
+func (mr *MockShareHandlerMockRecorder) ParseShareToken(ctx, tokenStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseShareToken", reflect.TypeOf((*MockShareHandler)(nil).ParseShareToken), ctx, tokenStr)
+}
+
+// Revoke mocks base method.
+func (m *MockShareHandler) Revoke(ctx context.Context, aid int64, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, aid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareHandlerMockRecorder) Revoke(ctx, aid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareHandler)(nil).Revoke), ctx, aid, id)
+}
+
+// RevokeAll mocks base method.
+func (m *MockShareHandler) RevokeAll(ctx context.Context, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockShareHandlerMockRecorder) RevokeAll(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockShareHandler)(nil).RevokeAll), ctx, aid)
+}
+
+// Views mocks base method.
+func (m *MockShareHandler) Views(ctx context.Context, aid int64) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Views", ctx, aid)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Views indicates an expected call of Views.
+func (mr *MockShareHandlerMockRecorder) Views(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Views", reflect.TypeOf((*MockShareHandler)(nil).Views), ctx, aid)
+}
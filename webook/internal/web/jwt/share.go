@@ -0,0 +1,156 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrShareTokenInvalid = errors.New("分享链接无效")
+	ErrShareTokenRevoked = errors.New("分享链接已经撤销")
+)
+
+// MaxShareExpiration 分享链接最长的有效期，撤销记录和浏览次数也最多保留这么久
+const MaxShareExpiration = time.Hour * 24 * 30
+
+// ShareClaims 分享链接，ID 是链接的 ID，Uid 是创建链接的作者
+type ShareClaims struct {
+	jwt.RegisteredClaims
+
+	Aid int64
+	Uid int64
+	// Ctime 创建链接的毫秒时间戳，JWT 的 iat 只精确到秒，判断不了是不是在撤销全部之前创建的
+	Ctime int64
+}
+
+// ShareHandler 作者把草稿或者私密的文章分享出去，拿到链接的人不用登录也能看
+//
+//go:generate mockgen -source=./share.go -package=jwtmocks -destination=./mocks/share.mock.go ShareHandler
+type ShareHandler interface {
+	NewShareToken(aid, uid int64, expiration time.Duration) (string, ShareClaims, error)
+	// ParseShareToken 校验签名和过期时间，已经撤销的返回 ErrShareTokenRevoked
+	ParseShareToken(ctx context.Context, tokenStr string) (ShareClaims, error)
+	// Revoke 撤销文章的某个分享链接，id 是链接的 ID，调用方要先确认文章属于当前作者
+	Revoke(ctx context.Context, aid int64, id string) error
+	// RevokeAll 撤销文章在这之前创建的所有分享链接
+	RevokeAll(ctx context.Context, aid int64) error
+	IncrViews(ctx context.Context, claims ShareClaims) error
+	// Views 文章每个分享链接的浏览次数，key 是链接的 ID
+	Views(ctx context.Context, aid int64) (map[string]int64, error)
+}
+
+type RedisShareHandler struct {
+	cmd redis.Cmdable
+	// key 分享链接的签名密钥，和登录用的分开，换了之后所有的分享链接都会失效
+	key []byte
+}
+
+func NewRedisShareHandler(cmd redis.Cmdable, key []byte) ShareHandler {
+	return &RedisShareHandler{
+		cmd: cmd,
+		key: key,
+	}
+}
+
+func (h *RedisShareHandler) NewShareToken(aid, uid int64, expiration time.Duration) (string, ShareClaims, error) {
+	if expiration <= 0 || expiration > MaxShareExpiration {
+		expiration = MaxShareExpiration
+	}
+	claims := ShareClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
+		},
+		Aid:   aid,
+		Uid:   uid,
+		Ctime: time.Now().UnixMilli(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
+	tokenStr, err := token.SignedString(h.key)
+	return tokenStr, claims, err
+}
+
+func (h *RedisShareHandler) ParseShareToken(ctx context.Context, tokenStr string) (ShareClaims, error) {
+	var claims ShareClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
+		return h.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS512.Alg()}))
+	if err != nil || !token.Valid || claims.ID == "" || claims.Aid == 0 {
+		return ShareClaims{}, ErrShareTokenInvalid
+	}
+	// 单个链接的撤销记录和整篇文章的撤销时间一次查出来
+	vals, err := h.cmd.MGet(ctx, h.revokedKey(claims.Aid, claims.ID), h.revokedBeforeKey(claims.Aid)).Result()
+	if err != nil {
+		return ShareClaims{}, err
+	}
+	if vals[0] != nil {
+		return ShareClaims{}, ErrShareTokenRevoked
+	}
+	if before, ok := vals[1].(string); ok {
+		ms, err := strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			return ShareClaims{}, err
+		}
+		if claims.Ctime <= ms {
+			return ShareClaims{}, ErrShareTokenRevoked
+		}
+	}
+	return claims, nil
+}
+
+// Revoke 不知道链接什么时候过期，撤销记录保留最长的有效期
+// key 里面带着文章 ID，作者只能撤销自己文章的链接
+func (h *RedisShareHandler) Revoke(ctx context.Context, aid int64, id string) error {
+	return h.cmd.Set(ctx, h.revokedKey(aid, id), "", MaxShareExpiration).Err()
+}
+
+// RevokeAll 记下撤销的时间，在这之前创建的链接都失效
+// 过了最长的有效期，这之前创建的链接自己也过期了
+func (h *RedisShareHandler) RevokeAll(ctx context.Context, aid int64) error {
+	return h.cmd.Set(ctx, h.revokedBeforeKey(aid), time.Now().UnixMilli(), MaxShareExpiration).Err()
+}
+
+func (h *RedisShareHandler) IncrViews(ctx context.Context, claims ShareClaims) error {
+	key := h.viewsKey(claims.Aid)
+	pipe := h.cmd.TxPipeline()
+	pipe.HIncrBy(ctx, key, claims.ID, 1)
+	// 最新的链接过期之后，浏览次数也没用了
+	pipe.Expire(ctx, key, MaxShareExpiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (h *RedisShareHandler) Views(ctx context.Context, aid int64) (map[string]int64, error) {
+	vals, err := h.cmd.HGetAll(ctx, h.viewsKey(aid)).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]int64, len(vals))
+	for id, val := range vals {
+		cnt, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		res[id] = cnt
+	}
+	return res, nil
+}
+
+func (h *RedisShareHandler) revokedKey(aid int64, id string) string {
+	return fmt.Sprintf("article:share:revoked:%d:%s", aid, id)
+}
+
+func (h *RedisShareHandler) revokedBeforeKey(aid int64) string {
+	return fmt.Sprintf("article:share:revoked_before:%d", aid)
+}
+
+func (h *RedisShareHandler) viewsKey(aid int64) string {
+	return fmt.Sprintf("article:share:views:%d", aid)
+}
@@ -0,0 +1,172 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/internal/repository/cache/redismocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var shareKey = []byte("share-key-for-test")
+
+func TestRedisShareHandler_ParseShareToken(t *testing.T) {
+	h := &RedisShareHandler{key: shareKey}
+	token, claims, err := h.NewShareToken(12, 123, time.Hour)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(time.Hour).Unix(), claims.ExpiresAt.Unix(), 2)
+
+	// 超过最长的有效期
+	_, longClaims, err := h.NewShareToken(12, 123, time.Hour*24*365)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(MaxShareExpiration).Unix(), longClaims.ExpiresAt.Unix(), 2)
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS512, ShareClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "expired",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+		Aid: 12,
+		Uid: 123,
+	}).SignedString(shareKey)
+	require.NoError(t, err)
+	// 用登录的 key 签名的 token 不能拿来当分享链接
+	wrongKey, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString(AtKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		mock  func(ctrl *gomock.Controller) redis.Cmdable
+		token string
+
+		wantAid int64
+		wantErr error
+	}{
+		{
+			name: "有效",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewSliceCmd(context.Background())
+				res.SetVal([]any{nil, nil})
+				cmd.EXPECT().MGet(gomock.Any(), "article:share:revoked:12:"+claims.ID, "article:share:revoked_before:12").Return(res)
+				return cmd
+			},
+			token:   token,
+			wantAid: 12,
+		},
+		{
+			name: "已经撤销",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewSliceCmd(context.Background())
+				res.SetVal([]any{"", nil})
+				cmd.EXPECT().MGet(gomock.Any(), "article:share:revoked:12:"+claims.ID, "article:share:revoked_before:12").Return(res)
+				return cmd
+			},
+			token:   token,
+			wantErr: ErrShareTokenRevoked,
+		},
+		{
+			name: "撤销全部之前创建的",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewSliceCmd(context.Background())
+				res.SetVal([]any{nil, strconv.FormatInt(claims.Ctime, 10)})
+				cmd.EXPECT().MGet(gomock.Any(), "article:share:revoked:12:"+claims.ID, "article:share:revoked_before:12").Return(res)
+				return cmd
+			},
+			token:   token,
+			wantErr: ErrShareTokenRevoked,
+		},
+		{
+			name: "撤销全部之后创建的",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewSliceCmd(context.Background())
+				res.SetVal([]any{nil, strconv.FormatInt(claims.Ctime-1, 10)})
+				cmd.EXPECT().MGet(gomock.Any(), "article:share:revoked:12:"+claims.ID, "article:share:revoked_before:12").Return(res)
+				return cmd
+			},
+			token:   token,
+			wantAid: 12,
+		},
+		{
+			name: "redis 错误",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewSliceCmd(context.Background())
+				res.SetErr(errors.New("redis 错误"))
+				cmd.EXPECT().MGet(gomock.Any(), gomock.Any()).Return(res)
+				return cmd
+			},
+			token:   token,
+			wantErr: errors.New("redis 错误"),
+		},
+		{
+			name: "过期",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				return redismocks.NewMockCmdable(ctrl)
+			},
+			token:   expired,
+			wantErr: ErrShareTokenInvalid,
+		},
+		{
+			name: "签名不对",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				return redismocks.NewMockCmdable(ctrl)
+			},
+			token:   wrongKey,
+			wantErr: ErrShareTokenInvalid,
+		},
+		{
+			name: "格式不对",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				return redismocks.NewMockCmdable(ctrl)
+			},
+			token:   "abc",
+			wantErr: ErrShareTokenInvalid,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := NewRedisShareHandler(tc.mock(ctrl), shareKey)
+			res, err := h.ParseShareToken(context.Background(), tc.token)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantAid, res.Aid)
+		})
+	}
+}
+
+func TestRedisShareHandler_Views(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	res := redis.NewMapStringStringCmd(context.Background())
+	res.SetVal(map[string]string{"a": "3", "b": "1"})
+	cmd.EXPECT().HGetAll(gomock.Any(), "article:share:views:12").Return(res)
+	views, err := NewRedisShareHandler(cmd, shareKey).Views(context.Background(), 12)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a": 3, "b": 1}, views)
+}
+
+func TestRedisShareHandler_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	res := redis.NewStatusCmd(context.Background())
+	res.SetVal("OK")
+	cmd.EXPECT().Set(gomock.Any(), "article:share:revoked:12:abc", "", MaxShareExpiration).Return(res)
+	cmd.EXPECT().Set(gomock.Any(), "article:share:revoked_before:12", gomock.Any(), MaxShareExpiration).Return(res)
+	h := NewRedisShareHandler(cmd, shareKey)
+	require.NoError(t, h.Revoke(context.Background(), 12, "abc"))
+	require.NoError(t, h.RevokeAll(context.Background(), 12))
+}
@@ -4,12 +4,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"net/http"
+	"strings"
 	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
 )
 
 // LoginJWTMiddlewareBuilder JWT 登录校验
 type LoginJWTMiddlewareBuilder struct {
 	paths []string
+	// prefixes 路径里面带参数的，按照前缀忽略
+	prefixes []string
 	ijwt.Handler
 }
 
@@ -24,6 +27,11 @@ func (l *LoginJWTMiddlewareBuilder) IgnorePaths(path string) *LoginJWTMiddleware
 	return l
 }
 
+func (l *LoginJWTMiddlewareBuilder) IgnorePrefix(prefix string) *LoginJWTMiddlewareBuilder {
+	l.prefixes = append(l.prefixes, prefix)
+	return l
+}
+
 func (l *LoginJWTMiddlewareBuilder) Build() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// 不需要登录校验
@@ -32,6 +40,11 @@ func (l *LoginJWTMiddlewareBuilder) Build() gin.HandlerFunc {
 				return
 			}
 		}
+		for _, prefix := range l.prefixes {
+			if strings.HasPrefix(ctx.Request.URL.Path, prefix) {
+				return
+			}
+		}
 
 		tokenStr := l.ExtractToken(ctx)
 		claims := ijwt.UserClaims{}
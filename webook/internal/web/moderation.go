@@ -0,0 +1,229 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*ModerationHandler)(nil)
+
+// ModerationHandler 审核队列，只有配置里面的审核人可以处理
+// 作者可以看自己文章的审核记录
+type ModerationHandler struct {
+	svc       artv1.ArticleServiceClient
+	reviewers map[int64]struct{}
+}
+
+func NewModerationHandler(svc artv1.ArticleServiceClient, reviewers []int64) *ModerationHandler {
+	m := make(map[int64]struct{}, len(reviewers))
+	for _, uid := range reviewers {
+		m[uid] = struct{}{}
+	}
+	return &ModerationHandler{svc: svc, reviewers: m}
+}
+
+func (h *ModerationHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/moderation")
+	g.POST("/pending", ginx.WrapBodyAndToken[ListPendingReviewReq, ijwt.UserClaims](h.ListPending))
+	g.POST("/approve", ginx.WrapBodyAndToken[ReviewReq, ijwt.UserClaims](h.Approve))
+	g.POST("/reject", ginx.WrapBodyAndToken[ReviewReq, ijwt.UserClaims](h.Reject))
+	g.POST("/logs", ginx.WrapBodyAndToken[ListModerationLogsReq, ijwt.UserClaims](h.Logs))
+	g.POST("/similar", ginx.WrapBodyAndToken[FindSimilarReq, ijwt.UserClaims](h.Similar))
+	g.POST("/flagged", ginx.WrapBodyAndToken[ListFlaggedDuplicatesReq, ijwt.UserClaims](h.Flagged))
+}
+
+func (h *ModerationHandler) ListPending(ctx *gin.Context, req ListPendingReviewReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !h.isReviewer(uc.Uid) {
+		return h.forbidden(uc.Uid)
+	}
+	resp, err := h.svc.ListPendingReview(ctx, &artv1.ListPendingReviewRequest{
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetArts(), func(idx int, src *artv1.Article) PendingArticleVO {
+			return PendingArticleVO{
+				Id:       src.GetId(),
+				Title:    src.GetTitle(),
+				Abstract: src.GetAbstract(),
+				Content:  src.GetContent(),
+				AuthorId: src.GetAuthor().GetId(),
+				Tags:     src.GetTags(),
+				Version:  src.GetVersion(),
+				Utime:    time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *ModerationHandler) Approve(ctx *gin.Context, req ReviewReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !h.isReviewer(uc.Uid) {
+		return h.forbidden(uc.Uid)
+	}
+	_, err := h.svc.Approve(ctx, &artv1.ApproveRequest{
+		Reviewer: uc.Uid,
+		Id:       req.Id,
+		Version:  req.Version,
+	})
+	if err != nil {
+		return h.moderationErr(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ModerationHandler) Reject(ctx *gin.Context, req ReviewReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !h.isReviewer(uc.Uid) {
+		return h.forbidden(uc.Uid)
+	}
+	_, err := h.svc.Reject(ctx, &artv1.RejectRequest{
+		Reviewer: uc.Uid,
+		Id:       req.Id,
+		Version:  req.Version,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		return h.moderationErr(err)
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+// Logs 审核人可以看所有文章的，作者只能看自己的
+func (h *ModerationHandler) Logs(ctx *gin.Context, req ListModerationLogsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !h.isReviewer(uc.Uid) {
+		resp, err := h.svc.GetById(ctx, &artv1.GetByIdRequest{Id: req.Id})
+		if err != nil {
+			return ginx.Result{
+				Code: 5,
+				Msg:  "system error",
+			}, err
+		}
+		if resp.GetArt().GetAuthor().GetId() != uc.Uid {
+			return h.forbidden(uc.Uid)
+		}
+	}
+	resp, err := h.svc.ListModerationLogs(ctx, &artv1.ListModerationLogsRequest{
+		ArtId:  req.Id,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetLogs(), func(idx int, src *artv1.ModerationLog) ModerationLogVO {
+			return ModerationLogVO{
+				Id:       src.GetId(),
+				Reviewer: src.GetReviewer(),
+				Action:   src.GetAction(),
+				Status:   src.GetStatus(),
+				Reason:   src.GetReason(),
+				Ctime:    time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+// Similar 和某篇已发表文章几乎一样的文章，审核人用来判断是不是抄袭
+func (h *ModerationHandler) Similar(ctx *gin.Context, req FindSimilarReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !h.isReviewer(uc.Uid) {
+		return h.forbidden(uc.Uid)
+	}
+	resp, err := h.svc.FindSimilar(ctx, &artv1.FindSimilarRequest{
+		Id:    req.Id,
+		Limit: int32(req.Limit),
+	})
+	if err != nil {
+		return h.moderationErr(err)
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetArts(), func(idx int, src *artv1.SimilarArticle) SimilarArticleVO {
+			return SimilarArticleVO{
+				Id:       src.GetId(),
+				AuthorId: src.GetAuthorId(),
+				Distance: int(src.GetDistance()),
+				Ctime:    time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+// Flagged 发表的时候被标记为疑似重复的文章，最新的在前面
+func (h *ModerationHandler) Flagged(ctx *gin.Context, req ListFlaggedDuplicatesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if !h.isReviewer(uc.Uid) {
+		return h.forbidden(uc.Uid)
+	}
+	resp, err := h.svc.ListFlaggedDuplicates(ctx, &artv1.ListFlaggedDuplicatesRequest{
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetLogs(), func(idx int, src *artv1.ModerationLog) FlaggedDuplicateVO {
+			return FlaggedDuplicateVO{
+				ArtId:    src.GetArtId(),
+				AuthorId: src.GetAuthorId(),
+				Reason:   src.GetReason(),
+				Ctime:    time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *ModerationHandler) isReviewer(uid int64) bool {
+	_, ok := h.reviewers[uid]
+	return ok
+}
+
+func (h *ModerationHandler) forbidden(uid int64) (ginx.Result, error) {
+	return ginx.Result{
+		Code: http.StatusForbidden,
+		Msg:  "没有权限",
+	}, fmt.Errorf("非法访问审核接口 %d", uid)
+}
+
+func (h *ModerationHandler) moderationErr(err error) (ginx.Result, error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	case codes.FailedPrecondition:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章已经不在审核队列里面了",
+		}, nil
+	case codes.Aborted:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "作者在审核期间修改了文章，请重新审核",
+		}, nil
+	}
+	return ginx.Result{
+		Code: 5,
+		Msg:  "system error",
+	}, err
+}
@@ -0,0 +1,70 @@
+package web
+
+type ListPendingReviewReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type ReviewReq struct {
+	Id int64 `json:"id"`
+	// Version 审核人看到的版本号，作者在审核期间修改过的话会审核失败
+	Version int64 `json:"version"`
+	// Reason 拒绝的时候必须填，会通知作者
+	Reason string `json:"reason"`
+}
+
+type ListModerationLogsReq struct {
+	Id     int64 `json:"id"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type FindSimilarReq struct {
+	Id    int64 `json:"id"`
+	Limit int   `json:"limit"`
+}
+
+type ListFlaggedDuplicatesReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type PendingArticleVO struct {
+	Id       int64    `json:"id"`
+	Title    string   `json:"title"`
+	Abstract string   `json:"abstract"`
+	Content  string   `json:"content"`
+	AuthorId int64    `json:"author_id"`
+	Tags     []string `json:"tags,omitempty"`
+	Version  int64    `json:"version"`
+	// Utime 提交审核的时间
+	Utime string `json:"utime"`
+}
+
+type ModerationLogVO struct {
+	Id int64 `json:"id"`
+	// Reviewer 0 表示系统自动处理
+	Reviewer int64 `json:"reviewer"`
+	// Action 1 提交，2 自动通过，3 自动拒绝，4 通过，5 拒绝，6 疑似重复
+	Action uint32 `json:"action"`
+	Status uint32 `json:"status"`
+	Reason string `json:"reason"`
+	Ctime  string `json:"ctime"`
+}
+
+type SimilarArticleVO struct {
+	Id       int64 `json:"id"`
+	AuthorId int64 `json:"author_id"`
+	// Distance SimHash 的海明距离，越小越像
+	Distance int `json:"distance"`
+	// Ctime 第一次发表的时间
+	Ctime string `json:"ctime"`
+}
+
+type FlaggedDuplicateVO struct {
+	ArtId    int64 `json:"art_id"`
+	AuthorId int64 `json:"author_id"`
+	// Reason 和哪些更早发表的文章相似
+	Reason string `json:"reason"`
+	Ctime  string `json:"ctime"`
+}
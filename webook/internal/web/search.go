@@ -0,0 +1,67 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	searchv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/search/v1"
+	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler = (*SearchHandler)(nil)
+
+type SearchHandler struct {
+	svc searchv1.SearchServiceClient
+	l   logger.LoggerV1
+}
+
+func NewSearchHandler(svc searchv1.SearchServiceClient, l logger.LoggerV1) *SearchHandler {
+	return &SearchHandler{svc: svc, l: l}
+}
+
+func (h *SearchHandler) RegisterRoutes(server *gin.Engine) {
+	server.POST("/search", ginx.WrapBody[SearchReq](h.l, h.Search))
+}
+
+func (h *SearchHandler) Search(ctx *gin.Context, req SearchReq) (ginx.Result, error) {
+	resp, err := h.svc.SearchArticle(ctx, &searchv1.SearchArticleRequest{
+		Keyword:   req.Keyword,
+		AuthorId:  req.AuthorId,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Offset:    int32(req.Offset),
+		Limit:     int32(req.Limit),
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: http.StatusInternalServerError,
+			Msg:  "system error",
+		}, err
+	}
+	return ginx.Result{
+		Data: SearchVO{
+			Total: resp.GetTotal(),
+			Arts: slice.Map(resp.GetHits(), func(idx int, src *searchv1.ArticleHit) SearchArticleVO {
+				return SearchArticleVO{
+					Id:       src.GetId(),
+					AuthorId: src.GetAuthorId(),
+					Title:    src.GetTitle(),
+					Snippet:  src.GetSnippet(),
+					Tags:     src.GetTags(),
+					Utime:    time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+				}
+			}),
+		},
+	}, nil
+}
@@ -0,0 +1,27 @@
+package web
+
+type SearchReq struct {
+	Keyword string `json:"keyword"`
+	// AuthorId 不传就是不按照作者过滤
+	AuthorId int64 `json:"author_id"`
+	// StartTime 和 EndTime 是毫秒时间戳，按照更新时间过滤，不传就是不限制
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+	Offset    int   `json:"offset"`
+	Limit     int   `json:"limit"`
+}
+
+type SearchVO struct {
+	Total int64             `json:"total"`
+	Arts  []SearchArticleVO `json:"arts"`
+}
+
+type SearchArticleVO struct {
+	Id       int64 `json:"id"`
+	AuthorId int64 `json:"author_id"`
+	// Title 和 Snippet 已经转义过了，命中的关键字用 <em> 包裹，前端可以直接渲染
+	Title   string   `json:"title"`
+	Snippet string   `json:"snippet"`
+	Tags    []string `json:"tags,omitempty"`
+	Utime   string   `json:"utime"`
+}
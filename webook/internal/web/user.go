@@ -17,6 +17,7 @@ import (
 	ijwt "github.com/TengFeiyang01/webook/webook/internal/web/jwt"
 	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/sensitive"
 )
 
 const biz = "login"
@@ -36,9 +37,11 @@ type UserHandler struct {
 	cmd            redis.Cmdable
 	ijwt.Handler
 	l logger.LoggerV1
+	// filter 昵称和关于我的敏感词
+	filter *sensitive.Filter
 }
 
-func NewUserHandler(svc service.UserService, codeSvc service.CodeService, cmd redis.Cmdable, jwtHdl ijwt.Handler, l logger.LoggerV1) *UserHandler {
+func NewUserHandler(svc service.UserService, codeSvc service.CodeService, cmd redis.Cmdable, jwtHdl ijwt.Handler, l logger.LoggerV1, filter *sensitive.Filter) *UserHandler {
 	const (
 		emailRegexPattern = "^\\w+([-+.]\\w+)*@\\w+([-.]\\w+)*\\.\\w+([-.]\\w+)*$"
 		// 和上面比起来，用 ` 看起来就比较清爽
@@ -54,6 +57,7 @@ func NewUserHandler(svc service.UserService, codeSvc service.CodeService, cmd re
 		cmd:            cmd,
 		Handler:        jwtHdl,
 		l:              l,
+		filter:         filter,
 	}
 }
 
@@ -269,12 +273,18 @@ func (u *UserHandler) Edit(ctx *gin.Context, req EditReq, uc ijwt.UserClaims) (g
 	if len(req.Nickname) > 1024 {
 		return ginx.Result{Code: 4, Msg: "关于我太长"}, fmt.Errorf("关于我太长")
 	}
+	nickname := u.filter.Check(req.Nickname)
+	aboutMe := u.filter.Check(req.AboutMe)
+	if nickname.Action == sensitive.ActionBlock || aboutMe.Action == sensitive.ActionBlock {
+		return ginx.Result{Code: errs.UserSensitiveContent, Msg: "昵称或者关于我包含违禁词"}, nil
+	}
 
 	err = u.svc.UpdateNonSensitiveInfo(ctx.Request.Context(), domain.User{
-		ID:       uc.Uid,
-		NickName: req.Nickname,
+		ID: uc.Uid,
+		// 个人资料没有审核流程，需要审核的词也直接打码
+		NickName: nickname.MaskAll(),
 		BirthDay: birthday,
-		AboutMe:  req.AboutMe,
+		AboutMe:  aboutMe.MaskAll(),
 	})
 	if err != nil {
 		return ginx.Result{Code: 5, Msg: "系统错误"}, err
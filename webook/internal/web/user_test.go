@@ -23,6 +23,7 @@ import (
 	"github.com/TengFeiyang01/webook/webook/pkg/ginx"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
 	loggermocks "github.com/TengFeiyang01/webook/webook/pkg/logger/mocks"
+	"github.com/TengFeiyang01/webook/webook/pkg/sensitive"
 )
 
 func TestEncrypt(t *testing.T) {
@@ -220,7 +221,8 @@ func TestUserHandler_SignUp(t *testing.T) {
 			defer ctrl.Finish()
 			server := gin.Default()
 
-			h := NewUserHandler(tc.mock(ctrl))
+			userSvc, codeSvc, cmd, jwtHdl, l := tc.mock(ctrl)
+			h := NewUserHandler(userSvc, codeSvc, cmd, jwtHdl, l, sensitive.NewFilter(nil, sensitive.Policy{}))
 			h.RegisterRoutes(server)
 
 			req, err := http.NewRequest(http.MethodPost, "/users/signup", bytes.NewBuffer([]byte(tc.reqBody)))
@@ -398,7 +400,8 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			server := gin.Default()
-			h := NewUserHandler(tc.mock(ctrl))
+			userSvc, codeSvc, cmd, jwtHdl, l := tc.mock(ctrl)
+			h := NewUserHandler(userSvc, codeSvc, cmd, jwtHdl, l, sensitive.NewFilter(nil, sensitive.Policy{}))
 			h.RegisterRoutes(server)
 
 			req, err := http.NewRequest(http.MethodPost, "/users/login_sms", bytes.NewBuffer([]byte(tc.reqBody)))
@@ -6,6 +6,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	artrepo "github.com/TengFeiyang01/webook/webook/article/repository"
+	artdao "github.com/TengFeiyang01/webook/webook/article/repository/dao"
 	"github.com/TengFeiyang01/webook/webook/article/service"
 	"github.com/TengFeiyang01/webook/webook/internal/web/client/art"
 )
@@ -41,3 +43,14 @@ func InitArtGRPCClient(svc service.ArticleService) artv1.ArticleServiceClient {
 	})
 	return res
 }
+
+// InitExportRepository BFF 里面的本地文章服务不处理导出，灰度的时候导出的请求都走文章服务
+// 压缩包的存储只有文章服务有，这里只是为了构造本地文章服务，不配置存储
+func InitExportRepository(d artdao.ExportDAO) artrepo.ExportRepository {
+	return artrepo.NewExportRepository(d, nil)
+}
+
+// InitImportRepository 和 InitExportRepository 一样，导入只在文章服务里面处理
+func InitImportRepository(d artdao.ImportDAO) artrepo.ImportRepository {
+	return artrepo.NewImportRepository(d, nil)
+}
@@ -0,0 +1,32 @@
+package ioc
+
+import (
+	commentv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/comment/v1"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// InitCommentGRPCClient 评论是新拆出来的服务，没有本地实现，直接走远程
+func InitCommentGRPCClient() commentv1.CommentServiceClient {
+	type Config struct {
+		Addr   string `yaml:"addr"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.comment", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	var opts []grpc.DialOption
+	if cfg.Secure {
+		// 加载你的证书之类的
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient(cfg.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return commentv1.NewCommentServiceClient(cc)
+}
@@ -3,7 +3,6 @@ package ioc
 import (
 	promsdk "github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	glogger "gorm.io/gorm/logger"
 	"gorm.io/plugin/opentelemetry/tracing"
@@ -15,10 +14,8 @@ import (
 )
 
 func InitDB(l logger.LoggerV1) *gorm.DB {
-	type Config struct {
-		DSN string `yaml:"dsn"`
-	}
-	var cfg = Config{
+	// 配置了 replicas 的话读写分离，查询走从库
+	var cfg = gormx.DBConfig{
 		DSN: "root:root@tcp(localhost:13316)/webook",
 	}
 
@@ -27,7 +24,11 @@ func InitDB(l logger.LoggerV1) *gorm.DB {
 		panic(err)
 	}
 	//dsn := viper.GetString("db.mysql.dsn")
-	db, err := gorm.Open(mysql.Open(cfg.DSN), &gorm.Config{
+	dialector, err := gormx.MySQLDialector(cfg, l)
+	if err != nil {
+		panic(err)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: glogger.New(gormLoggerFunc(l.Debug), glogger.Config{
 			// 慢查询阈值，超过这个阈值，才会使用
 			// 50ms 100ms
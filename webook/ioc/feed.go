@@ -0,0 +1,24 @@
+package ioc
+
+import (
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/internal/service"
+	"github.com/TengFeiyang01/webook/webook/internal/web"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/spf13/viper"
+)
+
+// InitFeedHandler 订阅源里面的链接都是绝对地址，要在配置里面写清楚网站的地址
+func InitFeedHandler(svc artv1.ArticleServiceClient, userSvc service.UserService, l logger.LoggerV1) *web.FeedHandler {
+	cfg := web.FeedConfig{
+		Title:      "webook",
+		SiteURL:    "http://localhost:3000",
+		FeedURL:    "http://localhost:8080/feeds",
+		ArticleURL: "http://localhost:3000/articles/view?id=%d",
+		AuthorURL:  "http://localhost:3000/users/profile?id=%d",
+	}
+	if err := viper.UnmarshalKey("feed", &cfg); err != nil {
+		panic(err)
+	}
+	return web.NewFeedHandler(svc, userSvc, cfg, l)
+}
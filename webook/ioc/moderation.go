@@ -0,0 +1,34 @@
+package ioc
+
+import (
+	"github.com/IBM/sarama"
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	artnotify "github.com/TengFeiyang01/webook/webook/internal/events/article"
+	"github.com/TengFeiyang01/webook/webook/internal/service"
+	"github.com/TengFeiyang01/webook/webook/internal/service/sms"
+	"github.com/TengFeiyang01/webook/webook/internal/web"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/spf13/viper"
+)
+
+// InitModerationHandler 审核人直接写在配置里面，没有配置的话审核接口谁都不能用
+func InitModerationHandler(svc artv1.ArticleServiceClient) *web.ModerationHandler {
+	type Config struct {
+		Reviewers []int64 `yaml:"reviewers"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("moderation", &cfg); err != nil {
+		panic(err)
+	}
+	return web.NewModerationHandler(svc, cfg.Reviewers)
+}
+
+// InitArticleRejectedConsumer 审核不通过的短信模板要先在短信服务商那里申请，没有配置就不能启动
+func InitArticleRejectedConsumer(client sarama.Client, userSvc service.UserService,
+	smsSvc sms.Service, l logger.LoggerV1) *artnotify.ArticleRejectedConsumer {
+	tplId := viper.GetString("moderation.rejectedTplId")
+	if tplId == "" {
+		panic("没有配置审核不通过的短信模板 moderation.rejectedTplId")
+	}
+	return artnotify.NewArticleRejectedConsumer(client, userSvc, smsSvc, tplId, l)
+}
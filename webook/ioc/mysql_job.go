@@ -3,18 +3,74 @@ package ioc
 import (
 	"context"
 	"time"
+	"github.com/spf13/viper"
 	"github.com/TengFeiyang01/webook/webook/internal/domain"
 	"github.com/TengFeiyang01/webook/webook/internal/job"
 	"github.com/TengFeiyang01/webook/webook/internal/service"
 	"github.com/TengFeiyang01/webook/webook/pkg/logger"
 )
 
-func InitScheduler(l logger.LoggerV1, svc service.JobService, local *job.LocalFuncExecutor) *job.Schedule {
+func InitScheduler(l logger.LoggerV1, svc service.JobService, local *job.LocalFuncExecutor,
+	artPublish *job.ArticlePublishExecutor, artPurge *job.ArticlePurgeExecutor,
+	attachGC *job.AttachmentGCExecutor) *job.Schedule {
 	res := job.NewSchedule(svc, l)
 	res.RegisterExecutor(local)
+	res.RegisterExecutor(artPublish)
+	res.RegisterExecutor(artPurge)
+	res.RegisterExecutor(attachGC)
+	initArticlePurgeJob(l, svc)
+	initAttachmentGCJob(l, svc)
 	return res
 }
 
+// initArticlePurgeJob 清理回收站的任务只在第一次启动的时候写进去
+// 每次启动都覆盖的话，正在执行的任务会被改回等待，别的实例会再执行一遍；
+// 修改了 cron 之后要手动更新数据库里面的任务
+func initArticlePurgeJob(l logger.LoggerV1, svc service.JobService) {
+	type Config struct {
+		Cron      string        `yaml:"cron"`
+		Retention time.Duration `yaml:"retention"`
+	}
+	cfg := Config{
+		Cron:      "0 * * * *",
+		Retention: time.Hour * 24 * 30,
+	}
+	err := viper.UnmarshalKey("job.articlePurge", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	j := job.NewArticlePurgeJob(cfg.Cron, cfg.Retention)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = svc.ScheduleIfAbsent(ctx, j, j.NextTime())
+	if err != nil {
+		l.Error("初始化清理回收站的任务失败", logger.Error(err))
+	}
+}
+
+// initAttachmentGCJob 和清理回收站的任务一样，只在第一次启动的时候写进去
+func initAttachmentGCJob(l logger.LoggerV1, svc service.JobService) {
+	type Config struct {
+		Cron  string        `yaml:"cron"`
+		Grace time.Duration `yaml:"grace"`
+	}
+	cfg := Config{
+		Cron:  "30 3 * * *",
+		Grace: time.Hour * 24,
+	}
+	err := viper.UnmarshalKey("job.attachmentGC", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	j := job.NewAttachmentGCJob(cfg.Cron, cfg.Grace)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = svc.ScheduleIfAbsent(ctx, j, j.NextTime())
+	if err != nil {
+		l.Error("初始化清理附件的任务失败", logger.Error(err))
+	}
+}
+
 func InitLocalFuncExecutor(svc service.RankingService) *job.LocalFuncExecutor {
 	res := job.NewLocalFuncExecutor()
 	// 要在数据库里面插入一条记录
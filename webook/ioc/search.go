@@ -0,0 +1,32 @@
+package ioc
+
+import (
+	searchv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/search/v1"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// InitSearchGRPCClient 搜索的索引在 search 服务的内存里，所以没有本地调用，直接走远程
+func InitSearchGRPCClient() searchv1.SearchServiceClient {
+	type Config struct {
+		Addr   string `yaml:"addr"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.search", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	var opts []grpc.DialOption
+	if cfg.Secure {
+		// 加载你的证书之类的
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient(cfg.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return searchv1.NewSearchServiceClient(cc)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"strings"
 	"time"
@@ -19,12 +20,24 @@ import (
 )
 
 func InitWebServer(middlewares []gin.HandlerFunc, userHandler *web.UserHandler,
-	oauth2WechatHdl *web.OAuth2WechatHandler, articleHdl *web.ArticleHandler) *gin.Engine {
+	oauth2WechatHdl *web.OAuth2WechatHandler, articleHdl *web.ArticleHandler,
+	searchHdl *web.SearchHandler, shareHdl *web.ArticleShareHandler,
+	attachHdl *web.AttachmentHandler, moderationHdl *web.ModerationHandler,
+	archiveHdl *web.ArchiveHandler, statsHdl *web.ArticleStatsHandler,
+	feedHdl *web.FeedHandler, commentHdl *web.CommentHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(middlewares...)
 	userHandler.RegisterRoutes(server)
 	oauth2WechatHdl.RegisterRoutes(server)
 	articleHdl.RegisterRoutes(server)
+	searchHdl.RegisterRoutes(server)
+	shareHdl.RegisterRoutes(server)
+	attachHdl.RegisterRoutes(server)
+	moderationHdl.RegisterRoutes(server)
+	archiveHdl.RegisterRoutes(server)
+	statsHdl.RegisterRoutes(server)
+	feedHdl.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
 	(&web.ObservabilityHandler{}).RegisterRoutes(server)
 	return server
 }
@@ -63,11 +76,22 @@ func InitGinMiddlewares(redisClient redis.Cmdable, jwtHdl ijwt.Handler, l myLogg
 			IgnorePaths("/users/login_sms/code/send").
 			IgnorePaths("/users/refresh_token").
 			IgnorePaths("/test/metric").
+			IgnorePaths("/articles/share").
+			IgnorePrefix("/feeds/").
 			Build(),
 		ratelimit.NewBuilder(NewRateLimiter(time.Second, 100)).Build(),
 	}
 }
 
+// InitShareHandler 分享链接的签名密钥从配置里面读，没有配置就不能启动
+func InitShareHandler(cmd redis.Cmdable) ijwt.ShareHandler {
+	key := viper.GetString("jwt.shareKey")
+	if key == "" {
+		panic("没有配置分享链接的签名密钥 jwt.shareKey")
+	}
+	return ijwt.NewRedisShareHandler(cmd, []byte(key))
+}
+
 func corsHandler() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		//AllowAllOrigins: true,
@@ -35,12 +35,17 @@ func main() {
 	}
 
 	app.cron.Start()
+	schedCtx, schedCancel := context.WithCancel(context.Background())
+	go func() {
+		_ = app.scheduler.Schedule(schedCtx)
+	}()
 
 	server := app.Server
 	server.GET("/hello", func(ctx *gin.Context) {
 		ctx.String(200, "hello world")
 	})
 	_ = server.Run(":8080")
+	schedCancel()
 	// 一分钟内你要关完
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
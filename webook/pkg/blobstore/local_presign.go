@@ -0,0 +1,202 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalPresigner 本地开发用的预签名，用 HMAC 代替云厂商的签名
+// 它本身就是一个 http.Handler，要挂在 baseURL 对应的路径上，PUT 上传，GET 下载
+type LocalPresigner struct {
+	*LocalStore
+	// baseURL 例如 http://localhost:8088/blob，对象的 URL 就是 baseURL/key
+	baseURL string
+	prefix  string
+	secret  []byte
+	// private 为 true 的时候下载也要签名，相当于私有读的 bucket
+	private bool
+}
+
+func NewLocalPresigner(store *LocalStore, baseURL string, secret []byte) (*LocalPresigner, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalPresigner{
+		LocalStore: store,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		prefix:     strings.TrimSuffix(u.Path, "/") + "/",
+		secret:     secret,
+	}, nil
+}
+
+// NewPrivateLocalPresigner 只能通过 PresignGet 生成的地址下载
+func NewPrivateLocalPresigner(store *LocalStore, baseURL string, secret []byte) (*LocalPresigner, error) {
+	p, err := NewLocalPresigner(store, baseURL, secret)
+	if err != nil {
+		return nil, err
+	}
+	p.private = true
+	return p, nil
+}
+
+func (p *LocalPresigner) PresignPut(ctx context.Context, key string, contentType string, size int64, expiration time.Duration) (string, error) {
+	if _, err := p.path(key); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(expiration).Unix()
+	q := url.Values{}
+	q.Set("content-type", contentType)
+	q.Set("size", strconv.FormatInt(size, 10))
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("signature", p.sign(key, contentType, size, expires))
+	return p.baseURL + "/" + key + "?" + q.Encode(), nil
+}
+
+func (p *LocalPresigner) PresignGet(ctx context.Context, key string, filename string, expiration time.Duration) (string, error) {
+	if _, err := p.path(key); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(expiration).Unix()
+	q := url.Values{}
+	q.Set("filename", filename)
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("signature", p.signGet(key, filename, expires))
+	return p.baseURL + "/" + key + "?" + q.Encode(), nil
+}
+
+// Stat 本地不保存 Content-Type，按照内容推断
+func (p *LocalPresigner) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	path, err := p.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ObjectInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	// DetectContentType 最多只看 512 个字节
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		Size:        info.Size(),
+		ContentType: http.DetectContentType(head[:n]),
+	}, nil
+}
+
+func (p *LocalPresigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, p.prefix) {
+		http.NotFound(w, r)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, p.prefix)
+	// 浏览器直接上传，和 S3 的 CORS 配置一样，只允许带 Content-Type
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "GET, PUT")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		p.get(w, r, key)
+	case http.MethodPut:
+		p.put(w, r, key)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (p *LocalPresigner) get(w http.ResponseWriter, r *http.Request, key string) {
+	q := r.URL.Query()
+	filename := q.Get("filename")
+	if p.private {
+		expires, err := strconv.ParseInt(q.Get("expires"), 10, 64)
+		if err != nil ||
+			!hmac.Equal([]byte(q.Get("signature")), []byte(p.signGet(key, filename, expires))) ||
+			time.Now().Unix() > expires {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}
+	data, err := p.Get(r.Context(), key)
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if p.private && filename != "" {
+		w.Header().Set("Content-Disposition", contentDisposition(filename))
+	}
+	_, _ = w.Write(data)
+}
+
+func (p *LocalPresigner) put(w http.ResponseWriter, r *http.Request, key string) {
+	q := r.URL.Query()
+	contentType := q.Get("content-type")
+	size, err1 := strconv.ParseInt(q.Get("size"), 10, 64)
+	expires, err2 := strconv.ParseInt(q.Get("expires"), 10, 64)
+	if err1 != nil || err2 != nil ||
+		!hmac.Equal([]byte(q.Get("signature")), []byte(p.sign(key, contentType, size, expires))) ||
+		time.Now().Unix() > expires {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	// 和 S3 一样，签名里面的类型和大小都要对得上
+	if r.Header.Get("Content-Type") != contentType || r.ContentLength != size {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, size+1))
+	if err != nil || int64(len(data)) != size {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err = p.Put(r.Context(), key, data, contentType); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (p *LocalPresigner) sign(key string, contentType string, size int64, expires int64) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(strings.Join([]string{http.MethodPut, key, contentType,
+		strconv.FormatInt(size, 10), strconv.FormatInt(expires, 10)}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *LocalPresigner) signGet(key string, filename string, expires int64) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(strings.Join([]string{http.MethodGet, key, filename,
+		strconv.FormatInt(expires, 10)}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ Presigner = (*LocalPresigner)(nil)
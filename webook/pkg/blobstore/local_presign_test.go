@@ -0,0 +1,108 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 最小的 GIF
+var gif = []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")
+
+func TestLocalPresigner(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	p, err := NewLocalPresigner(store, server.URL+"/blob", []byte("secret"))
+	require.NoError(t, err)
+	mux.Handle("/blob/", p)
+	ctx := context.Background()
+	size := int64(len(gif))
+
+	put := func(url string, contentType string, data []byte) int {
+		req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	url, err := p.PresignPut(ctx, "att/1.gif", "image/gif", size, time.Minute)
+	require.NoError(t, err)
+	// 类型和大小都要和签名一致
+	assert.Equal(t, http.StatusForbidden, put(url, "image/png", gif))
+	assert.Equal(t, http.StatusForbidden, put(url, "image/gif", append(gif, 'x')))
+	// 改了 key 签名就对不上了
+	assert.Equal(t, http.StatusForbidden, put(server.URL+"/blob/att/2.gif"+url[len(server.URL+"/blob/att/1.gif"):],
+		"image/gif", gif))
+	_, err = p.Stat(ctx, "att/1.gif")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.Equal(t, http.StatusOK, put(url, "image/gif", gif))
+	info, err := p.Stat(ctx, "att/1.gif")
+	require.NoError(t, err)
+	assert.Equal(t, ObjectInfo{Size: size, ContentType: "image/gif"}, info)
+
+	resp, err := http.Get(server.URL + "/blob/att/1.gif")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, gif, data)
+	assert.Equal(t, "image/gif", resp.Header.Get("Content-Type"))
+
+	// 过期了
+	url, err = p.PresignPut(ctx, "att/3.gif", "image/gif", size, -time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, put(url, "image/gif", gif))
+}
+
+func TestPrivateLocalPresigner(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	p, err := NewPrivateLocalPresigner(store, server.URL+"/blob", []byte("secret"))
+	require.NoError(t, err)
+	mux.Handle("/blob/", p)
+	ctx := context.Background()
+	require.NoError(t, p.Put(ctx, "exports/1.zip", gif, "application/zip"))
+
+	get := func(url string) *http.Response {
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+	// 没有签名不能下载
+	assert.Equal(t, http.StatusForbidden, get(server.URL+"/blob/exports/1.zip").StatusCode)
+
+	url, err := p.PresignGet(ctx, "exports/1.zip", "导出.zip", time.Minute)
+	require.NoError(t, err)
+	resp := get(url)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "attachment; filename*=utf-8''%E5%AF%BC%E5%87%BA.zip", resp.Header.Get("Content-Disposition"))
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, gif, data)
+
+	// 改了文件名签名就对不上了
+	assert.Equal(t, http.StatusForbidden, get(strings.Replace(url, "filename=", "filename=x", 1)).StatusCode)
+
+	url, err = p.PresignGet(ctx, "exports/1.zip", "导出.zip", -time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, get(url).StatusCode)
+}
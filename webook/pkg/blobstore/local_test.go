@@ -0,0 +1,42 @@
+package blobstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = s.Get(ctx, "art/1")
+	assert.Equal(t, ErrNotFound, err)
+
+	err = s.Put(ctx, "art/1", []byte("第一版"), "text/plain;charset=utf-8")
+	require.NoError(t, err)
+	err = s.Put(ctx, "art/1", []byte("第二版"), "text/plain;charset=utf-8")
+	require.NoError(t, err)
+	data, err := s.Get(ctx, "art/1")
+	require.NoError(t, err)
+	assert.Equal(t, "第二版", string(data))
+
+	require.NoError(t, s.Delete(ctx, "art/1"))
+	_, err = s.Get(ctx, "art/1")
+	assert.Equal(t, ErrNotFound, err)
+	// 删除不存在的对象不算错误
+	assert.NoError(t, s.Delete(ctx, "art/1"))
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	for _, key := range []string{"", "../escape", "a/../../escape", "a\\b"} {
+		err = s.Put(ctx, key, []byte("x"), "text/plain")
+		assert.Error(t, err, key)
+	}
+}
@@ -0,0 +1,29 @@
+package blobstore
+
+import (
+	"context"
+	"mime"
+	"time"
+)
+
+// ObjectInfo 对象的元数据
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+}
+
+// contentDisposition 让浏览器下载而不是打开，文件名可能有中文，按照 RFC 6266 编码
+func contentDisposition(filename string) string {
+	return mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+}
+
+// Presigner 生成预签名的上传和下载 URL，客户端直接访问对象存储，文件不经过我们的服务器
+type Presigner interface {
+	Store
+	// PresignPut 上传的时候 Content-Type 和 Content-Length 必须和签名的时候一致
+	PresignPut(ctx context.Context, key string, contentType string, size int64, expiration time.Duration) (string, error)
+	// PresignGet 私有对象的下载地址，filename 是浏览器保存的时候用的文件名
+	PresignGet(ctx context.Context, key string, filename string, expiration time.Duration) (string, error)
+	// Stat 用来确认客户端确实上传了，对象不存在返回 ErrNotFound
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+}
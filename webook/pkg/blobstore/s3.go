@@ -0,0 +1,101 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/ecodeclub/ekit"
+)
+
+// S3Store 兼容 S3 协议的对象存储，腾讯云的 COS、阿里云的 OSS 都可以用
+type S3Store struct {
+	client *s3.S3
+	bucket string
+}
+
+func NewS3Store(client *s3.S3, bucket string) *S3Store {
+	return &S3Store{client: client, bucket: bucket}
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      ekit.ToPtr[string](s.bucket),
+		Key:         ekit.ToPtr[string](key),
+		Body:        bytes.NewReader(data),
+		ContentType: ekit.ToPtr[string](contentType),
+	})
+	return err
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	res, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: ekit.ToPtr[string](s.bucket),
+		Key:    ekit.ToPtr[string](key),
+	})
+	if err != nil {
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	defer res.Body.Close()
+	return io.ReadAll(res.Body)
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: ekit.ToPtr[string](s.bucket),
+		Key:    ekit.ToPtr[string](key),
+	})
+	return err
+}
+
+func (s *S3Store) PresignPut(ctx context.Context, key string, contentType string, size int64, expiration time.Duration) (string, error) {
+	req, _ := s.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:        ekit.ToPtr[string](s.bucket),
+		Key:           ekit.ToPtr[string](key),
+		ContentType:   ekit.ToPtr[string](contentType),
+		ContentLength: ekit.ToPtr[int64](size),
+	})
+	req.SetContext(ctx)
+	return req.Presign(expiration)
+}
+
+func (s *S3Store) PresignGet(ctx context.Context, key string, filename string, expiration time.Duration) (string, error) {
+	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     ekit.ToPtr[string](s.bucket),
+		Key:                        ekit.ToPtr[string](key),
+		ResponseContentDisposition: ekit.ToPtr[string](contentDisposition(filename)),
+	})
+	req.SetContext(ctx)
+	return req.Presign(expiration)
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	res, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: ekit.ToPtr[string](s.bucket),
+		Key:    ekit.ToPtr[string](key),
+	})
+	if err != nil {
+		// HEAD 请求没有响应体，拿不到 NoSuchKey
+		var aerr awserr.RequestFailure
+		if errors.As(err, &aerr) && aerr.StatusCode() == http.StatusNotFound {
+			return ObjectInfo{}, ErrNotFound
+		}
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{
+		Size:        aws.Int64Value(res.ContentLength),
+		ContentType: aws.StringValue(res.ContentType),
+	}, nil
+}
+
+var _ Presigner = (*S3Store)(nil)
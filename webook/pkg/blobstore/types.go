@@ -0,0 +1,20 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("blobstore: 对象不存在")
+
+// Store 对象存储的抽象，key 由调用方决定，可以带 / 分层
+// 不同的实现对 key 的限制不一样，调用方最好只用字母、数字、-、_ 和 /
+type Store interface {
+	// Put 覆盖写入
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get 对象不存在返回 ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete 对象不存在不算错误
+	Delete(ctx context.Context, key string) error
+}
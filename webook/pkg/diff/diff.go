@@ -0,0 +1,145 @@
+package diff
+
+import "strings"
+
+type Op uint8
+
+const (
+	OpEqual Op = iota
+	OpInsert
+	OpDelete
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpInsert:
+		return "+"
+	case OpDelete:
+		return "-"
+	default:
+		return " "
+	}
+}
+
+// Line 是 diff 结果中的一行
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines 按行比较 a 和 b，返回把 a 变成 b 的编辑脚本
+func Lines(a, b string) []Line {
+	return Diff(split(a), split(b))
+}
+
+// MaxEdits 差异超过这么多行就不再找最短编辑脚本了，中间不同的部分整段删除再整段插入
+// 回溯要保存每一轮的 v，内存是 O(D²)，不加限制的话两个很长并且完全不同的版本会占用大量内存
+const MaxEdits = 500
+
+// Diff 使用 Myers 算法计算最短编辑脚本
+// 复杂度是 O((N+M)D)，D 是差异的行数，对于文章这种改动通常不大的场景足够了
+func Diff(a, b []string) []Line {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+	// 公共的前缀和后缀不参与计算
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	res := make([]Line, 0, len(a)+len(b))
+	res = appendLines(res, OpEqual, a[:pre])
+	res = append(res, myers(a[pre:len(a)-suf], b[pre:len(b)-suf])...)
+	return appendLines(res, OpEqual, a[len(a)-suf:])
+}
+
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	maxD := min(n+m, MaxEdits)
+	// 两边各多留一个位置，回溯的时候会读到 k-1 和 k+1
+	offset := maxD + 1
+	v := make([]int, 2*maxD+4)
+	// trace 记录每一轮开始之前 v 里面 [-d-1, d+1] 这一段，用来回溯
+	var trace [][]int
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+	res := make([]Line, 0, n+m)
+	res = appendLines(res, OpDelete, a)
+	return appendLines(res, OpInsert, b)
+}
+
+func backtrack(a, b []string, trace [][]int) []Line {
+	x, y := len(a), len(b)
+	res := make([]Line, 0, x+y)
+	for d := len(trace) - 1; d >= 0; d-- {
+		// snapshot 的下标 0 对应的是 k = -d-1
+		v := trace[d]
+		base := d + 1
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[base+k-1] < v[base+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[base+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			res = append(res, Line{Op: OpEqual, Text: a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				res = append(res, Line{Op: OpInsert, Text: b[y]})
+			} else {
+				x--
+				res = append(res, Line{Op: OpDelete, Text: a[x]})
+			}
+		}
+	}
+	// 回溯是倒着来的
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+func appendLines(res []Line, op Op, texts []string) []Line {
+	for _, text := range texts {
+		res = append(res, Line{Op: op, Text: text})
+	}
+	return res
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
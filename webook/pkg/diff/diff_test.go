@@ -0,0 +1,112 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    string
+		b    string
+		want []Line
+	}{
+		{
+			name: "都为空",
+		},
+		{
+			name: "完全相同",
+			a:    "a\nb",
+			b:    "a\nb",
+			want: []Line{{Op: OpEqual, Text: "a"}, {Op: OpEqual, Text: "b"}},
+		},
+		{
+			name: "新增一行",
+			a:    "a\nc",
+			b:    "a\nb\nc",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpInsert, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "删除一行",
+			a:    "a\nb\nc",
+			b:    "a\nc",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "修改一行",
+			a:    "标题\n旧的内容\n结尾",
+			b:    "标题\n新的内容\n结尾",
+			want: []Line{
+				{Op: OpEqual, Text: "标题"},
+				{Op: OpDelete, Text: "旧的内容"},
+				{Op: OpInsert, Text: "新的内容"},
+				{Op: OpEqual, Text: "结尾"},
+			},
+		},
+		{
+			name: "从空到有",
+			b:    "a\nb",
+			want: []Line{{Op: OpInsert, Text: "a"}, {Op: OpInsert, Text: "b"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Lines(tc.a, tc.b))
+		})
+	}
+}
+
+// TestLinesApply 把编辑脚本应用回去，必须能还原出 b
+func TestLinesApply(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7"
+	b := "0\n1\n3\n4\nx\n6\n7\n8"
+	assertApply(t, a, b, Lines(a, b))
+}
+
+// TestLinesMaxEdits 差异太大的时候中间整段替换，公共的前缀和后缀还是保留
+func TestLinesMaxEdits(t *testing.T) {
+	var a, b []string
+	for i := 0; i < MaxEdits; i++ {
+		a = append(a, "旧的第"+strconv.Itoa(i)+"行")
+		b = append(b, "新的第"+strconv.Itoa(i)+"行")
+	}
+	a = append(append([]string{"标题"}, a...), "结尾")
+	b = append(append([]string{"标题"}, b...), "结尾")
+	lines := Diff(a, b)
+	require.Len(t, lines, 2*MaxEdits+2)
+	assert.Equal(t, Line{Op: OpEqual, Text: "标题"}, lines[0])
+	assert.Equal(t, Line{Op: OpDelete, Text: "旧的第0行"}, lines[1])
+	assert.Equal(t, Line{Op: OpInsert, Text: "新的第0行"}, lines[MaxEdits+1])
+	assert.Equal(t, Line{Op: OpEqual, Text: "结尾"}, lines[2*MaxEdits+1])
+	assertApply(t, strings.Join(a, "\n"), strings.Join(b, "\n"), lines)
+}
+
+func assertApply(t *testing.T, a, b string, lines []Line) {
+	var left, right []string
+	for _, l := range lines {
+		switch l.Op {
+		case OpEqual:
+			left = append(left, l.Text)
+			right = append(right, l.Text)
+		case OpDelete:
+			left = append(left, l.Text)
+		case OpInsert:
+			right = append(right, l.Text)
+		}
+	}
+	assert.Equal(t, a, strings.Join(left, "\n"))
+	assert.Equal(t, b, strings.Join(right, "\n"))
+}
@@ -0,0 +1,42 @@
+package feed
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NotModified 按照 RFC 7232 判断条件请求是不是可以直接返回 304
+// 有 If-None-Match 的时候只看它，忽略 If-Modified-Since
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return matchETag(inm, etag)
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP 的时间只精确到秒
+	return !lastModified.Truncate(time.Second).After(t)
+}
+
+// matchETag If-None-Match 用的是弱比较，W/ 前缀不影响结果
+func matchETag(header string, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+// Package feed 输出 RSS 2.0 和 Atom 1.0 的订阅源，以及条件请求需要的 ETag 和 Last-Modified
+package feed
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"time"
+)
+
+const (
+	ContentTypeRSS  = "application/rss+xml; charset=utf-8"
+	ContentTypeAtom = "application/atom+xml; charset=utf-8"
+)
+
+// Feed 和具体格式无关的订阅源，链接都要求是绝对地址
+type Feed struct {
+	Title string
+	// Link 订阅源对应的网页
+	Link string
+	// Self 订阅源自己的地址，Atom 用它作为 id
+	Self        string
+	Description string
+	// Updated 为零值的时候取所有条目里面最晚的更新时间
+	Updated time.Time
+	Items   []Item
+}
+
+type Item struct {
+	Title string
+	// Link 文章的地址，同时用作条目的唯一标识
+	Link string
+	// Summary 纯文本的摘要，不带 HTML
+	Summary    string
+	Author     string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+}
+
+// LastModified 没有条目，也没有设置 Updated 的时候返回零值
+func (f Feed) LastModified() time.Time {
+	res := f.Updated
+	for _, item := range f.Items {
+		if item.Updated.After(res) {
+			res = item.Updated
+		}
+	}
+	return res
+}
+
+// RSS 作者没有邮箱，RSS 的 author 要求是邮箱，所以用 dc:creator
+func (f Feed) RSS() ([]byte, error) {
+	ch := rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		Language:    "zh-CN",
+		AtomLink:    &rssAtomLink{Href: f.Self, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]rssItem, 0, len(f.Items)),
+	}
+	if f.Self == "" {
+		ch.AtomLink = nil
+	}
+	if updated := f.LastModified(); !updated.IsZero() {
+		ch.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+	for _, item := range f.Items {
+		ch.Items = append(ch.Items, rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Guid:        rssGuid{IsPermaLink: "true", Value: item.Link},
+			Description: item.Summary,
+			Creator:     item.Author,
+			Categories:  item.Categories,
+			PubDate:     item.Published.Format(time.RFC1123Z),
+		})
+	}
+	return marshal(rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: ch,
+	})
+}
+
+// Atom 要求 updated 必须有，空的订阅源用 1970 年，保证同样的数据输出同样的内容
+func (f Feed) Atom() ([]byte, error) {
+	updated := f.LastModified()
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+	res := atomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Id:       f.Self,
+		Updated:  atomTime(updated),
+		Links:    []atomLink{{Href: f.Link, Rel: "alternate", Type: "text/html"}},
+		Entries:  make([]atomEntry, 0, len(f.Items)),
+	}
+	if f.Self != "" {
+		res.Links = append(res.Links, atomLink{Href: f.Self, Rel: "self", Type: "application/atom+xml"})
+	} else {
+		res.Id = f.Link
+	}
+	for _, item := range f.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			Id:        item.Link,
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published: atomTime(item.Published),
+			Updated:   atomTime(item.Updated),
+			Author:    atomPerson{Name: item.Author},
+			Summary:   atomText{Type: "text", Value: item.Summary},
+		}
+		for _, c := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: c})
+		}
+		res.Entries = append(res.Entries, entry)
+	}
+	return marshal(res)
+}
+
+// ETag 按照输出的内容计算，内容一样 ETag 就一样
+func ETag(body []byte) string {
+	sum := sha1.Sum(body)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Description   string       `xml:"description"`
+	Language      string       `xml:"language"`
+	LastBuildDate string       `xml:"lastBuildDate,omitempty"`
+	AtomLink      *rssAtomLink `xml:"atom:link"`
+	Items         []rssItem    `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Guid        rssGuid  `xml:"guid"`
+	Description string   `xml:"description"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type rssGuid struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Id       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	Id         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomPerson     `xml:"author"`
+	Summary    atomText       `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
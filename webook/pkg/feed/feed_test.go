@@ -0,0 +1,202 @@
+package feed
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFeed() Feed {
+	t1 := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	t2 := time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)
+	return Feed{
+		Title:       "Tom 的文章",
+		Link:        "https://webook.com/users/1",
+		Self:        "https://api.webook.com/feeds/authors/1.xml",
+		Description: "最新发表的文章",
+		Items: []Item{
+			{
+				Title:      "缓存 & 数据库",
+				Link:       "https://webook.com/articles/2",
+				Summary:    "一致性问题 <script>",
+				Author:     "Tom",
+				Categories: []string{"go", "redis"},
+				Published:  t1,
+				Updated:    t2,
+			},
+			{
+				Title:     "第一篇",
+				Link:      "https://webook.com/articles/1",
+				Summary:   "你好",
+				Author:    "Tom",
+				Published: t1,
+				Updated:   t1,
+			},
+		},
+	}
+}
+
+func TestFeed_RSS(t *testing.T) {
+	data, err := testFeed().RSS()
+	require.NoError(t, err)
+
+	var res struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			AtomLink      struct {
+				Href string `xml:"href,attr"`
+				Rel  string `xml:"rel,attr"`
+			} `xml:"http://www.w3.org/2005/Atom link"`
+			Items []struct {
+				Title       string   `xml:"title"`
+				Guid        string   `xml:"guid"`
+				Description string   `xml:"description"`
+				Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+				Categories  []string `xml:"category"`
+				PubDate     string   `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &res))
+	assert.Equal(t, "2.0", res.Version)
+	assert.Equal(t, "Tom 的文章", res.Channel.Title)
+	assert.Equal(t, "Thu, 02 May 2024 09:30:00 +0000", res.Channel.LastBuildDate)
+	assert.Equal(t, "https://api.webook.com/feeds/authors/1.xml", res.Channel.AtomLink.Href)
+	assert.Equal(t, "self", res.Channel.AtomLink.Rel)
+	require.Len(t, res.Channel.Items, 2)
+	item := res.Channel.Items[0]
+	assert.Equal(t, "缓存 & 数据库", item.Title)
+	assert.Equal(t, "https://webook.com/articles/2", item.Guid)
+	// 摘要里面的尖括号要转义，解析回来是原文
+	assert.Equal(t, "一致性问题 <script>", item.Description)
+	assert.Equal(t, "Tom", item.Creator)
+	assert.Equal(t, []string{"go", "redis"}, item.Categories)
+	assert.Equal(t, "Wed, 01 May 2024 08:00:00 +0000", item.PubDate)
+}
+
+func TestFeed_Atom(t *testing.T) {
+	data, err := testFeed().Atom()
+	require.NoError(t, err)
+
+	type link struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	}
+	var res struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Id      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Links   []link   `xml:"link"`
+		Entries []struct {
+			Id        string `xml:"id"`
+			Published string `xml:"published"`
+			Updated   string `xml:"updated"`
+			Author    string `xml:"author>name"`
+			Summary   struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"summary"`
+			Categories []struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &res))
+	assert.Equal(t, "https://api.webook.com/feeds/authors/1.xml", res.Id)
+	assert.Equal(t, "2024-05-02T09:30:00Z", res.Updated)
+	assert.Equal(t, []link{
+		{Href: "https://webook.com/users/1", Rel: "alternate"},
+		{Href: "https://api.webook.com/feeds/authors/1.xml", Rel: "self"},
+	}, res.Links)
+	require.Len(t, res.Entries, 2)
+	entry := res.Entries[0]
+	assert.Equal(t, "https://webook.com/articles/2", entry.Id)
+	assert.Equal(t, "2024-05-01T08:00:00Z", entry.Published)
+	assert.Equal(t, "2024-05-02T09:30:00Z", entry.Updated)
+	assert.Equal(t, "Tom", entry.Author)
+	assert.Equal(t, "text", entry.Summary.Type)
+	assert.Equal(t, "一致性问题 <script>", entry.Summary.Value)
+	assert.Len(t, entry.Categories, 2)
+}
+
+func TestFeed_Empty(t *testing.T) {
+	f := Feed{Title: "空的", Link: "https://webook.com"}
+	assert.True(t, f.LastModified().IsZero())
+	data1, err := f.Atom()
+	require.NoError(t, err)
+	data2, err := f.Atom()
+	require.NoError(t, err)
+	// 空的订阅源每次输出都一样，ETag 不会变
+	assert.Equal(t, ETag(data1), ETag(data2))
+	assert.Contains(t, string(data1), "<updated>1970-01-01T00:00:00Z</updated>")
+
+	data, err := f.RSS()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "lastBuildDate")
+	assert.NotContains(t, string(data), "atom:link")
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 5, 2, 9, 30, 0, 500, time.UTC)
+	etag := `"abc"`
+	testCases := []struct {
+		name   string
+		method string
+		header map[string]string
+		want   bool
+	}{
+		{name: "没有条件", want: false},
+		{name: "ETag 命中", header: map[string]string{"If-None-Match": `"abc"`}, want: true},
+		{name: "弱 ETag 命中", header: map[string]string{"If-None-Match": `"x", W/"abc"`}, want: true},
+		{name: "星号", header: map[string]string{"If-None-Match": "*"}, want: true},
+		{
+			name: "ETag 没命中，忽略 If-Modified-Since",
+			header: map[string]string{
+				"If-None-Match":     `"x"`,
+				"If-Modified-Since": lastModified.Add(time.Hour).Format(http.TimeFormat),
+			},
+			want: false,
+		},
+		{
+			name:   "没有修改过，毫秒部分不算",
+			header: map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			want:   true,
+		},
+		{
+			name:   "修改过",
+			header: map[string]string{"If-Modified-Since": lastModified.Add(-time.Second).Format(http.TimeFormat)},
+			want:   false,
+		},
+		{
+			name:   "时间格式不对",
+			header: map[string]string{"If-Modified-Since": "yesterday"},
+			want:   false,
+		},
+		{
+			name:   "POST 不处理",
+			method: http.MethodPost,
+			header: map[string]string{"If-None-Match": `"abc"`},
+			want:   false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/feeds/latest.xml", nil)
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+			assert.Equal(t, tc.want, NotModified(req, etag, lastModified))
+		})
+	}
+}
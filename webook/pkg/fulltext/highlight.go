@@ -0,0 +1,92 @@
+package fulltext
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	highlightPre  = "<em>"
+	highlightPost = "</em>"
+	ellipsis      = "..."
+)
+
+// Highlight 用 <em> 包裹命中的词，其余部分做 HTML 转义
+// 如果 maxRunes > 0，只截取第一个命中附近的 maxRunes 个字符作为摘要
+func Highlight(text string, terms []string, maxRunes int) string {
+	ranges := matchRanges(text, terms)
+	start, end := 0, len(text)
+	if maxRunes > 0 && utf8.RuneCountInString(text) > maxRunes {
+		start, end = window(text, ranges, maxRunes)
+	}
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString(ellipsis)
+	}
+	pos := start
+	for _, r := range ranges {
+		if r[1] <= start || r[0] >= end {
+			continue
+		}
+		s, e := max(r[0], start), min(r[1], end)
+		sb.WriteString(html.EscapeString(text[pos:s]))
+		sb.WriteString(highlightPre)
+		sb.WriteString(html.EscapeString(text[s:e]))
+		sb.WriteString(highlightPost)
+		pos = e
+	}
+	sb.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		sb.WriteString(ellipsis)
+	}
+	return sb.String()
+}
+
+// matchRanges 找出所有命中的字节区间，重叠或者相邻的会被合并
+func matchRanges(text string, terms []string) [][2]int {
+	if len(terms) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(terms))
+	for _, t := range terms {
+		set[t] = struct{}{}
+	}
+	var res [][2]int
+	for _, tk := range Tokenize(text) {
+		if _, ok := set[tk.Term]; ok {
+			res = append(res, [2]int{tk.Start, tk.End})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i][0] < res[j][0]
+	})
+	merged := res[:0]
+	for _, r := range res {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// window 以第一个命中为中心，往前留四分之一的长度作为上下文
+func window(text string, ranges [][2]int, maxRunes int) (int, int) {
+	offsets := runeOffsets(text, 0)
+	first := 0
+	if len(ranges) > 0 {
+		// 找到第一个命中所在的字符下标
+		first = sort.SearchInts(offsets, ranges[0][0])
+	}
+	startIdx := max(first-maxRunes/4, 0)
+	endIdx := startIdx + maxRunes
+	last := len(offsets) - 1
+	if endIdx > last {
+		endIdx = last
+		startIdx = max(endIdx-maxRunes, 0)
+	}
+	return offsets[startIdx], offsets[endIdx]
+}
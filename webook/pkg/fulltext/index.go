@@ -0,0 +1,216 @@
+package fulltext
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BM25 的参数，取的是常用的默认值
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// 不同字段的权重，标题命中比正文命中更重要
+const (
+	titleWeight   = 3
+	tagWeight     = 2
+	contentWeight = 1
+)
+
+type Document struct {
+	Id       int64
+	AuthorId int64
+	Title    string
+	Content  string
+	Tags     []string
+	Utime    time.Time
+}
+
+type Query struct {
+	Keyword string
+	// AuthorId 为 0 表示不过滤
+	AuthorId int64
+	// Start 和 End 过滤 Utime，零值表示不限制，区间是左闭右开的
+	Start  time.Time
+	End    time.Time
+	Offset int
+	Limit  int
+	// SnippetRunes 摘要的长度，按字符算
+	SnippetRunes int
+}
+
+type Hit struct {
+	Doc   Document
+	Score float64
+	// Title 和 Snippet 都已经做过 HTML 转义，命中的词用 <em> 包裹
+	Title   string
+	Snippet string
+}
+
+type Result struct {
+	Total int
+	Hits  []Hit
+}
+
+type entry struct {
+	doc    Document
+	length float64
+	terms  map[string]float64
+}
+
+// Index 是一个进程内的倒排索引，并发安全
+// 词频按照字段权重加权之后再参与 BM25 的计算，相当于一个简化的 BM25F
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[int64]*entry
+	postings map[string]map[int64]float64
+	totalLen float64
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[int64]*entry),
+		postings: make(map[string]map[int64]float64),
+	}
+}
+
+// Upsert 写入文档，已经存在的会被覆盖
+func (idx *Index) Upsert(doc Document) {
+	e := &entry{doc: doc, terms: make(map[string]float64)}
+	e.addField(doc.Title, titleWeight)
+	for _, t := range doc.Tags {
+		e.addField(t, tagWeight)
+	}
+	e.addField(doc.Content, contentWeight)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(doc.Id)
+	idx.docs[doc.Id] = e
+	idx.totalLen += e.length
+	for term, tf := range e.terms {
+		p, ok := idx.postings[term]
+		if !ok {
+			p = make(map[int64]float64)
+			idx.postings[term] = p
+		}
+		p[doc.Id] = tf
+	}
+}
+
+func (idx *Index) Delete(id int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(id)
+}
+
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+func (idx *Index) remove(id int64) {
+	e, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for term := range e.terms {
+		p := idx.postings[term]
+		delete(p, id)
+		if len(p) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLen -= e.length
+	delete(idx.docs, id)
+}
+
+// Search 任意一个词命中就会被召回，按照 BM25 得分降序，得分相同的按照更新时间降序
+func (idx *Index) Search(q Query) Result {
+	terms := QueryTerms(q.Keyword)
+	if len(terms) == 0 {
+		return Result{}
+	}
+	idx.mu.RLock()
+	hits := idx.score(terms, q)
+	idx.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].Doc.Utime.Equal(hits[j].Doc.Utime) {
+			return hits[i].Doc.Utime.After(hits[j].Doc.Utime)
+		}
+		return hits[i].Doc.Id > hits[j].Doc.Id
+	})
+	res := Result{Total: len(hits)}
+	if q.Offset >= len(hits) {
+		return res
+	}
+	end := len(hits)
+	if q.Limit > 0 && q.Offset+q.Limit < end {
+		end = q.Offset + q.Limit
+	}
+	res.Hits = hits[q.Offset:end]
+	// 只给当前页做高亮
+	for i := range res.Hits {
+		res.Hits[i].Title = Highlight(res.Hits[i].Doc.Title, terms, 0)
+		res.Hits[i].Snippet = Highlight(res.Hits[i].Doc.Content, terms, q.SnippetRunes)
+	}
+	return res
+}
+
+func (idx *Index) score(terms []string, q Query) []Hit {
+	n := float64(len(idx.docs))
+	if n == 0 {
+		return nil
+	}
+	avgLen := idx.totalLen / n
+	scores := make(map[int64]float64)
+	for _, term := range terms {
+		p := idx.postings[term]
+		if len(p) == 0 {
+			continue
+		}
+		df := float64(len(p))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range p {
+			e := idx.docs[id]
+			if !q.match(e.doc) {
+				continue
+			}
+			norm := 1 - bm25B + bm25B*e.length/avgLen
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+	res := make([]Hit, 0, len(scores))
+	for id, s := range scores {
+		res = append(res, Hit{Doc: idx.docs[id].doc, Score: s})
+	}
+	return res
+}
+
+func (q Query) match(doc Document) bool {
+	if q.AuthorId > 0 && doc.AuthorId != q.AuthorId {
+		return false
+	}
+	if !q.Start.IsZero() && doc.Utime.Before(q.Start) {
+		return false
+	}
+	if !q.End.IsZero() && !doc.Utime.Before(q.End) {
+		return false
+	}
+	return true
+}
+
+func (e *entry) addField(text string, weight float64) {
+	for _, tk := range Tokenize(text) {
+		e.terms[tk.Term] += weight
+		e.length += weight
+	}
+}
@@ -0,0 +1,121 @@
+package fulltext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_Search(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	idx := NewIndex()
+	idx.Upsert(Document{Id: 1, AuthorId: 10, Title: "Go 并发编程", Content: "goroutine 和 channel", Utime: now})
+	idx.Upsert(Document{Id: 2, AuthorId: 20, Title: "MySQL 索引", Content: "聊聊 Go 里面怎么用 MySQL", Utime: now.Add(time.Hour)})
+	idx.Upsert(Document{Id: 3, AuthorId: 10, Title: "Redis 缓存", Content: "缓存一致性", Tags: []string{"并发"}, Utime: now.Add(2 * time.Hour)})
+
+	ids := func(res Result) []int64 {
+		var res1 []int64
+		for _, h := range res.Hits {
+			res1 = append(res1, h.Doc.Id)
+		}
+		return res1
+	}
+	testCases := []struct {
+		name      string
+		q         Query
+		wantTotal int
+		wantIds   []int64
+	}{
+		{
+			name:      "标题命中比正文命中排得靠前",
+			q:         Query{Keyword: "go"},
+			wantTotal: 2,
+			wantIds:   []int64{1, 2},
+		},
+		{
+			name:      "标签也能命中",
+			q:         Query{Keyword: "并发"},
+			wantTotal: 2,
+			wantIds:   []int64{1, 3},
+		},
+		{
+			name:      "按照作者过滤",
+			q:         Query{Keyword: "go", AuthorId: 20},
+			wantTotal: 1,
+			wantIds:   []int64{2},
+		},
+		{
+			name:      "按照时间过滤，左闭右开",
+			q:         Query{Keyword: "并发", Start: now.Add(time.Hour), End: now.Add(3 * time.Hour)},
+			wantTotal: 1,
+			wantIds:   []int64{3},
+		},
+		{
+			name:      "分页",
+			q:         Query{Keyword: "go", Offset: 1, Limit: 1},
+			wantTotal: 2,
+			wantIds:   []int64{2},
+		},
+		{
+			name: "没有命中",
+			q:    Query{Keyword: "kafka"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := idx.Search(tc.q)
+			assert.Equal(t, tc.wantTotal, res.Total)
+			assert.Equal(t, tc.wantIds, ids(res))
+		})
+	}
+}
+
+func TestIndex_UpsertAndDelete(t *testing.T) {
+	idx := NewIndex()
+	idx.Upsert(Document{Id: 1, Title: "旧的标题"})
+	idx.Upsert(Document{Id: 1, Title: "新的标题"})
+	assert.Equal(t, 1, idx.Len())
+	assert.Equal(t, 0, idx.Search(Query{Keyword: "旧的"}).Total)
+	assert.Equal(t, 1, idx.Search(Query{Keyword: "新的"}).Total)
+
+	idx.Delete(1)
+	assert.Equal(t, 0, idx.Len())
+	assert.Equal(t, 0, idx.Search(Query{Keyword: "标题"}).Total)
+	assert.Empty(t, idx.postings)
+}
+
+func TestHighlight(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		terms    []string
+		maxRunes int
+		want     string
+	}{
+		{
+			name:  "英文不区分大小写并且转义",
+			text:  "<b>Go</b> is fun",
+			terms: []string{"go"},
+			want:  "&lt;b&gt;<em>Go</em>&lt;/b&gt; is fun",
+		},
+		{
+			name:  "相邻的二元组合并成一个高亮",
+			text:  "全文搜索引擎",
+			terms: []string{"搜索", "索引", "引擎"},
+			want:  "全文<em>搜索引擎</em>",
+		},
+		{
+			name:     "截取命中附近的内容",
+			text:     "零一二三四五六七八九搜索十一十二十三",
+			terms:    []string{"搜索"},
+			maxRunes: 8,
+			want:     "...八九<em>搜索</em>十一十二...",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Highlight(tc.text, tc.terms, tc.maxRunes))
+		})
+	}
+}
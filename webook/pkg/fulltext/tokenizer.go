@@ -0,0 +1,119 @@
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token 是分词的结果，Start 和 End 是在原文中的字节偏移，用来做高亮
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize 用于建索引
+// 英文和数字按照单词切分，统一转小写
+// 中日韩文字没有空格，这里不引入词典，而是同时输出单字和相邻两个字组成的二元组，
+// 单字保证单个字的查询也能命中，二元组保证多字查询的精度
+func Tokenize(text string) []Token {
+	var res []Token
+	for _, r := range runs(text) {
+		if !r.cjk {
+			res = append(res, Token{Term: strings.ToLower(text[r.start:r.end]), Start: r.start, End: r.end})
+			continue
+		}
+		offsets := runeOffsets(text[r.start:r.end], r.start)
+		for i := 0; i < len(offsets)-1; i++ {
+			res = append(res, Token{Term: text[offsets[i]:offsets[i+1]], Start: offsets[i], End: offsets[i+1]})
+			if i+2 < len(offsets) {
+				res = append(res, Token{Term: text[offsets[i]:offsets[i+2]], Start: offsets[i], End: offsets[i+2]})
+			}
+		}
+	}
+	return res
+}
+
+// QueryTerms 用于查询，去重之后返回
+// 中日韩文字只用二元组，只有一个字的时候才用单字
+func QueryTerms(text string) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	add := func(term string) {
+		if _, ok := seen[term]; ok {
+			return
+		}
+		seen[term] = struct{}{}
+		res = append(res, term)
+	}
+	for _, r := range runs(text) {
+		if !r.cjk {
+			add(strings.ToLower(text[r.start:r.end]))
+			continue
+		}
+		offsets := runeOffsets(text[r.start:r.end], r.start)
+		if len(offsets) == 2 {
+			add(text[offsets[0]:offsets[1]])
+			continue
+		}
+		for i := 0; i+2 < len(offsets); i++ {
+			add(text[offsets[i]:offsets[i+2]])
+		}
+	}
+	return res
+}
+
+type run struct {
+	start int
+	end   int
+	cjk   bool
+}
+
+// runs 把文本切成连续的英文数字片段或者中日韩文字片段，其余字符都是分隔符
+func runs(text string) []run {
+	var res []run
+	cur := run{start: -1}
+	flush := func(end int) {
+		if cur.start >= 0 {
+			cur.end = end
+			res = append(res, cur)
+		}
+		cur = run{start: -1}
+	}
+	for i, r := range text {
+		var cjk bool
+		switch {
+		case isCJK(r):
+			cjk = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+		default:
+			flush(i)
+			continue
+		}
+		if cur.start >= 0 && cur.cjk != cjk {
+			flush(i)
+		}
+		if cur.start < 0 {
+			cur = run{start: i, cjk: cjk}
+		}
+	}
+	flush(len(text))
+	return res
+}
+
+// runeOffsets 返回每个字符的起始偏移，最后再加上结束偏移
+func runeOffsets(s string, base int) []int {
+	res := make([]int, 0, utf8.RuneCountInString(s)+1)
+	for i := range s {
+		res = append(res, base+i)
+	}
+	return append(res, base+len(s))
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,87 @@
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []Token
+	}{
+		{
+			name: "空文本",
+		},
+		{
+			name: "英文转小写并且按照标点切分",
+			text: "Hello, Go!",
+			want: []Token{
+				{Term: "hello", Start: 0, End: 5},
+				{Term: "go", Start: 7, End: 9},
+			},
+		},
+		{
+			name: "中文输出单字和二元组",
+			text: "搜索引擎",
+			want: []Token{
+				{Term: "搜", Start: 0, End: 3},
+				{Term: "搜索", Start: 0, End: 6},
+				{Term: "索", Start: 3, End: 6},
+				{Term: "索引", Start: 3, End: 9},
+				{Term: "引", Start: 6, End: 9},
+				{Term: "引擎", Start: 6, End: 12},
+				{Term: "擎", Start: 9, End: 12},
+			},
+		},
+		{
+			name: "中英混排",
+			text: "用Go写",
+			want: []Token{
+				{Term: "用", Start: 0, End: 3},
+				{Term: "go", Start: 3, End: 5},
+				{Term: "写", Start: 5, End: 8},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Tokenize(tc.text))
+		})
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "只有标点",
+			text: "，。!",
+		},
+		{
+			name: "中文只用二元组",
+			text: "搜索引擎",
+			want: []string{"搜索", "索引", "引擎"},
+		},
+		{
+			name: "单个汉字",
+			text: "猫",
+			want: []string{"猫"},
+		},
+		{
+			name: "去重",
+			text: "Go go 并发 并发",
+			want: []string{"go", "并发"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, QueryTerms(tc.text))
+		})
+	}
+}
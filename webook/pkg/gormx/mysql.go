@@ -0,0 +1,68 @@
+package gormx
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// DBConfig 对应配置文件里面的 db，Replicas 为空的时候不做读写分离
+type DBConfig struct {
+	DSN      string          `yaml:"dsn"`
+	Replicas []ReplicaConfig `yaml:"replicas"`
+	// MaxLag 从库延迟超过这个值就不读它，默认 3 秒
+	MaxLag time.Duration `yaml:"maxLag"`
+	// CheckInterval 多久检查一次从库，默认 5 秒
+	CheckInterval time.Duration `yaml:"checkInterval"`
+}
+
+type ReplicaConfig struct {
+	// Name 为空的话用下标
+	Name   string `yaml:"name"`
+	DSN    string `yaml:"dsn"`
+	Weight int    `yaml:"weight"`
+}
+
+// MySQLDialector 配置了从库的时候，连接池换成 Resolver，并且在后台检查从库
+// 延迟和路由的指标注册到 prometheus 默认的 registry，一个进程只能调用一次
+func MySQLDialector(cfg DBConfig, l logger.LoggerV1) (gorm.Dialector, error) {
+	if len(cfg.Replicas) == 0 {
+		return mysql.Open(cfg.DSN), nil
+	}
+	if cfg.MaxLag <= 0 {
+		cfg.MaxLag = time.Second * 3
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = time.Second * 5
+	}
+	primary, err := sql.Open("mysql", cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	replicas := make([]Replica, 0, len(cfg.Replicas))
+	for i, rc := range cfg.Replicas {
+		db, err := sql.Open("mysql", rc.DSN)
+		if err != nil {
+			return nil, err
+		}
+		name := rc.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		replicas = append(replicas, Replica{Name: name, DB: db, Weight: rc.Weight})
+	}
+	r := NewResolver(primary, replicas, MySQLReplicaLag, cfg.MaxLag, l)
+	if err = prometheus.Register(r); err != nil {
+		return nil, err
+	}
+	// 先检查一次，启动之后马上就能读从库
+	r.Check(context.Background())
+	go r.Watch(context.Background(), cfg.CheckInterval)
+	return mysql.New(mysql.Config{Conn: r}), nil
+}
@@ -0,0 +1,291 @@
+package gormx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+var (
+	_ gorm.ConnPool        = (*Resolver)(nil)
+	_ gorm.TxBeginner      = (*Resolver)(nil)
+	_ gorm.GetDBConnector  = (*Resolver)(nil)
+	_ prometheus.Collector = (*Resolver)(nil)
+)
+
+var (
+	// ErrNotReplica 配置的从库没有在复制主库
+	ErrNotReplica = errors.New("不是从库")
+	// ErrReplicationStopped 复制线程停了，延迟是 NULL
+	ErrReplicationStopped = errors.New("从库复制已经停止")
+)
+
+type primaryKey struct{}
+
+// WithPrimary 返回的 ctx 上面的查询都走主库
+// 写完马上就要读的地方用，比如创建之后马上查询，从库可能还没有同步过来
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	val, _ := ctx.Value(primaryKey{}).(bool)
+	return val
+}
+
+// LagChecker 返回从库落后主库多久，返回错误说明从库不可用
+type LagChecker func(ctx context.Context, db *sql.DB) (time.Duration, error)
+
+type Replica struct {
+	// Name 用在监控和日志里面
+	Name string
+	DB   *sql.DB
+	// Weight 权重越大分到的查询越多，小于 1 的按照 1 处理
+	Weight int
+}
+
+type replica struct {
+	Replica
+	healthy bool
+	lag     time.Duration
+	// current 平滑加权轮询的当前权重
+	current int
+}
+
+// Resolver 作为 GORM 的连接池，把查询分到从库，写和事务都走主库
+// 从库按照权重平滑轮询，延迟太大或者检查失败的从库会被摘掉，
+// 一个健康的从库都没有的时候回退到主库
+// 事务里面的查询也走主库，GORM 开启事务之后用的是 *sql.Tx，不会再经过这里
+type Resolver struct {
+	primary  *sql.DB
+	replicas []*replica
+	checker  LagChecker
+	// maxLag 延迟超过这个值就不再读这个从库
+	maxLag time.Duration
+	l      logger.LoggerV1
+
+	mu sync.Mutex
+
+	primaryReads  atomic.Int64
+	replicaReads  atomic.Int64
+	fallbackReads atomic.Int64
+
+	lagDesc     *prometheus.Desc
+	healthyDesc *prometheus.Desc
+	readsDesc   *prometheus.Desc
+}
+
+// NewResolver 从库一开始都是不健康的，调用 Check 之后才会开始读从库
+func NewResolver(primary *sql.DB, replicas []Replica, checker LagChecker,
+	maxLag time.Duration, l logger.LoggerV1) *Resolver {
+	rs := make([]*replica, 0, len(replicas))
+	for _, r := range replicas {
+		r.Weight = max(r.Weight, 1)
+		rs = append(rs, &replica{Replica: r})
+	}
+	return &Resolver{
+		primary:  primary,
+		replicas: rs,
+		checker:  checker,
+		maxLag:   maxLag,
+		l:        l,
+		lagDesc: prometheus.NewDesc("ytf_webook_db_replica_lag_seconds",
+			"从库落后主库的秒数", []string{"replica"}, nil),
+		healthyDesc: prometheus.NewDesc("ytf_webook_db_replica_healthy",
+			"从库是否可以读，1 表示可以", []string{"replica"}, nil),
+		readsDesc: prometheus.NewDesc("ytf_webook_db_reads_total",
+			"查询分别落到了哪里，fallback 表示没有健康的从库回退到了主库", []string{"target"}, nil),
+	}
+}
+
+func (r *Resolver) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.primary.PrepareContext(ctx, query)
+}
+
+func (r *Resolver) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+func (r *Resolver) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.route(ctx, query).QueryContext(ctx, query, args...)
+}
+
+func (r *Resolver) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.route(ctx, query).QueryRowContext(ctx, query, args...)
+}
+
+func (r *Resolver) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.primary.BeginTx(ctx, opts)
+}
+
+// GetDBConn 给 gorm.DB.DB() 用，设置连接池参数、统计连接数都是针对主库
+func (r *Resolver) GetDBConn() (*sql.DB, error) {
+	return r.primary, nil
+}
+
+func (r *Resolver) route(ctx context.Context, query string) *sql.DB {
+	if usePrimary(ctx) || isLockingRead(query) {
+		r.primaryReads.Add(1)
+		return r.primary
+	}
+	db := r.pick()
+	if db == nil {
+		r.fallbackReads.Add(1)
+		return r.primary
+	}
+	r.replicaReads.Add(1)
+	return db
+}
+
+// isLockingRead 加锁读要在主库上执行
+func isLockingRead(query string) bool {
+	q := strings.ToUpper(query)
+	return strings.Contains(q, "FOR UPDATE") || strings.Contains(q, "LOCK IN SHARE MODE") ||
+		strings.Contains(q, "FOR SHARE")
+}
+
+// pick 在健康的从库里面平滑加权轮询，没有健康的从库返回 nil
+func (r *Resolver) pick() *sql.DB {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var (
+		best  *replica
+		total int
+	)
+	for _, rp := range r.replicas {
+		if !rp.healthy {
+			continue
+		}
+		rp.current += rp.Weight
+		total += rp.Weight
+		if best == nil || rp.current > best.current {
+			best = rp
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	best.current -= total
+	return best.DB
+}
+
+// Check 检查一遍所有的从库
+func (r *Resolver) Check(ctx context.Context) {
+	for _, rp := range r.replicas {
+		cctx, cancel := context.WithTimeout(ctx, time.Second)
+		lag, err := r.checker(cctx, rp.DB)
+		cancel()
+		healthy := err == nil && lag <= r.maxLag
+		r.mu.Lock()
+		changed := rp.healthy != healthy
+		rp.healthy = healthy
+		rp.lag = lag
+		if !healthy {
+			// 恢复的时候从零开始，不要一下子把积攒的权重都用掉
+			rp.current = 0
+		}
+		r.mu.Unlock()
+		if !changed {
+			continue
+		}
+		if healthy {
+			r.l.Info("从库恢复", logger.String("replica", rp.Name),
+				logger.Int64("lagMs", lag.Milliseconds()))
+		} else {
+			r.l.Warn("从库不可用，摘掉", logger.String("replica", rp.Name),
+				logger.Int64("lagMs", lag.Milliseconds()), logger.Error(err))
+		}
+	}
+}
+
+// Watch 每隔 interval 检查一次，一直运行到 ctx 被取消
+func (r *Resolver) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Check(ctx)
+		}
+	}
+}
+
+func (r *Resolver) Describe(ch chan<- *prometheus.Desc) {
+	ch <- r.lagDesc
+	ch <- r.healthyDesc
+	ch <- r.readsDesc
+}
+
+func (r *Resolver) Collect(ch chan<- prometheus.Metric) {
+	r.mu.Lock()
+	for _, rp := range r.replicas {
+		healthy := 0.0
+		if rp.healthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(r.lagDesc, prometheus.GaugeValue, rp.lag.Seconds(), rp.Name)
+		ch <- prometheus.MustNewConstMetric(r.healthyDesc, prometheus.GaugeValue, healthy, rp.Name)
+	}
+	r.mu.Unlock()
+	ch <- prometheus.MustNewConstMetric(r.readsDesc, prometheus.CounterValue,
+		float64(r.primaryReads.Load()), "primary")
+	ch <- prometheus.MustNewConstMetric(r.readsDesc, prometheus.CounterValue,
+		float64(r.replicaReads.Load()), "replica")
+	ch <- prometheus.MustNewConstMetric(r.readsDesc, prometheus.CounterValue,
+		float64(r.fallbackReads.Load()), "fallback")
+}
+
+// MySQLReplicaLag 用 SHOW REPLICA STATUS 查询延迟，老版本的 MySQL 用 SHOW SLAVE STATUS
+func MySQLReplicaLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	rows, err := db.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		rows, err = db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, ErrNotReplica
+	}
+	vals := make([]sql.RawBytes, len(cols))
+	dest := make([]any, len(cols))
+	for i := range vals {
+		dest[i] = &vals[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+	for i, col := range cols {
+		if col != "Seconds_Behind_Source" && col != "Seconds_Behind_Master" {
+			continue
+		}
+		if vals[i] == nil {
+			return 0, ErrReplicationStopped
+		}
+		sec, err := strconv.ParseInt(string(vals[i]), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(sec) * time.Second, nil
+	}
+	return 0, ErrNotReplica
+}
@@ -0,0 +1,104 @@
+package gormx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type Node struct {
+	Id   int64 `gorm:"primaryKey,autoIncrement"`
+	Name string
+}
+
+// openNode 每个库里面都有一条名字是 name 的记录，查出来是哪条就知道查的是哪个库
+func openNode(t *testing.T, name string) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), name+".db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	_, err = db.Exec("CREATE TABLE nodes (id integer PRIMARY KEY AUTOINCREMENT, name text)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO nodes (name) VALUES (?)", name)
+	require.NoError(t, err)
+	return db
+}
+
+func TestResolver(t *testing.T) {
+	primary := openNode(t, "primary")
+	replicas := []Replica{
+		{Name: "r1", DB: openNode(t, "r1"), Weight: 2},
+		{Name: "r2", DB: openNode(t, "r2"), Weight: 1},
+	}
+	lags := map[*sql.DB]time.Duration{}
+	var checkErr error
+	r := NewResolver(primary, replicas, func(ctx context.Context, db *sql.DB) (time.Duration, error) {
+		return lags[db], checkErr
+	}, time.Second, logger.NewNopLogger())
+	db, err := gorm.Open(sqlite.Dialector{Conn: r}, &gorm.Config{})
+	require.NoError(t, err)
+	ctx := context.Background()
+	read := func(ctx context.Context) string {
+		var n Node
+		require.NoError(t, db.WithContext(ctx).First(&n).Error)
+		return n.Name
+	}
+
+	// 还没有检查过，都走主库
+	assert.Equal(t, "primary", read(ctx))
+
+	r.Check(ctx)
+	// 按照 2:1 轮询
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, read(ctx))
+	}
+	assert.Equal(t, []string{"r1", "r2", "r1", "r1", "r2", "r1"}, got)
+	assert.Equal(t, "primary", read(WithPrimary(ctx)))
+
+	// 写和事务都走主库
+	require.NoError(t, db.WithContext(ctx).Create(&Node{Name: "new"}).Error)
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		err := tx.Model(&Node{}).Count(&cnt).Error
+		assert.Equal(t, int64(2), cnt)
+		return err
+	})
+	require.NoError(t, err)
+	var cnt int64
+	require.NoError(t, db.WithContext(ctx).Model(&Node{}).Count(&cnt).Error)
+	assert.Equal(t, int64(1), cnt)
+
+	// r1 延迟太大摘掉
+	lags[replicas[0].DB] = time.Second * 2
+	r.Check(ctx)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "r2", read(ctx))
+	}
+	// 每个从库两个指标，再加上三个路由的计数
+	assert.Equal(t, 7, testutil.CollectAndCount(r))
+
+	// 都不可用回退到主库
+	checkErr = errors.New("mock error")
+	r.Check(ctx)
+	fallback := r.fallbackReads.Load()
+	assert.Equal(t, "primary", read(ctx))
+	assert.Equal(t, fallback+1, r.fallbackReads.Load())
+}
+
+func TestIsLockingRead(t *testing.T) {
+	assert.True(t, isLockingRead("SELECT * FROM `nodes` WHERE id = ? FOR UPDATE"))
+	assert.True(t, isLockingRead("select * from nodes lock in share mode"))
+	assert.False(t, isLockingRead("SELECT * FROM `nodes` WHERE id = ?"))
+}
@@ -0,0 +1,31 @@
+package grpcx
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorWithReason 同一个错误码下面有好几种原因，调用方又要区分的时候，把原因放进 ErrorInfo 里面
+func ErrorWithReason(c codes.Code, reason string, msg string) error {
+	st, err := status.New(c, msg).WithDetails(&errdetails.ErrorInfo{Reason: reason})
+	if err != nil {
+		// 只有序列化失败才会走到这里，至少错误码还是对的
+		return status.Error(c, msg)
+	}
+	return st.Err()
+}
+
+// Reason 取出 ErrorWithReason 放进去的原因，没有的话返回空字符串
+func Reason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package idgen
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ID 的布局，从高位到低位：
+//
+//	1 位符号位 | 41 位毫秒时间戳 | 6 位节点 | 10 位序列号 | 6 位基因
+//
+// 基因是分片键（比如作者 ID）的低 6 位，所以 ID % N 和 分片键 % N 是一样的，
+// 只要 N 是 2 的幂并且不超过 64。这样按照 ID 查询的时候不用知道分片键也能路由到正确的分片
+const (
+	GeneBits = 6
+	seqBits  = 10
+	nodeBits = 6
+
+	MaxGene = 1<<GeneBits - 1
+	MaxNode = 1<<nodeBits - 1
+	maxSeq  = 1<<seqBits - 1
+
+	seqShift  = GeneBits
+	nodeShift = seqShift + seqBits
+	timeShift = nodeShift + nodeBits
+)
+
+// epoch 2024-01-01，41 位毫秒时间戳可以用到 2093 年
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+var ErrInvalidNode = errors.New("节点编号超出范围")
+
+// Generator 生成全局唯一、大体上按照时间递增的 ID
+// 多个实例的节点编号不能一样
+type Generator struct {
+	mu   sync.Mutex
+	node int64
+	last int64
+	seq  int64
+	now  func() int64
+}
+
+func NewGenerator(node int64) (*Generator, error) {
+	if node < 0 || node > MaxNode {
+		return nil, ErrInvalidNode
+	}
+	return &Generator{
+		node: node,
+		now: func() int64 {
+			return time.Now().UnixMilli()
+		},
+	}, nil
+}
+
+// Next key 是分片键，只用到它的低 6 位
+func (g *Generator) Next(key int64) int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+	if now < g.last {
+		// 时钟回拨了，继续用上一次的时间戳，序列号用完了会借用下一毫秒
+		now = g.last
+	}
+	if now == g.last {
+		g.seq++
+		if g.seq > maxSeq {
+			// 同一毫秒的序列号用完了，直接借用下一毫秒，不等待
+			now++
+			g.seq = 0
+		}
+	} else {
+		g.seq = 0
+	}
+	g.last = now
+	return (now-epoch)<<timeShift | g.node<<nodeShift | g.seq<<seqShift | Gene(key)
+}
+
+// Gene 分片键或者 ID 的基因
+func Gene(key int64) int64 {
+	return key & MaxGene
+}
+
+// Time ID 生成的时间，借用了后面的毫秒的话会稍微晚一点
+func Time(id int64) time.Time {
+	return time.UnixMilli(id>>timeShift + epoch)
+}
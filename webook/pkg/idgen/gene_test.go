@@ -0,0 +1,57 @@
+package idgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Next(t *testing.T) {
+	g, err := NewGenerator(3)
+	require.NoError(t, err)
+	seen := make(map[int64]struct{})
+	var last int64
+	for i := 0; i < 5000; i++ {
+		key := int64(i * 7)
+		id := g.Next(key)
+		_, ok := seen[id]
+		require.False(t, ok, "ID 重复了")
+		seen[id] = struct{}{}
+		assert.Greater(t, id, last)
+		last = id
+		// 按照 ID 和按照分片键路由到同一个分片
+		for _, n := range []int64{1, 2, 4, 16, 64} {
+			assert.Equal(t, key%n, id%n)
+		}
+	}
+	assert.WithinDuration(t, time.Now(), Time(last), time.Second*10)
+}
+
+func TestGenerator_ClockBackwards(t *testing.T) {
+	g, err := NewGenerator(0)
+	require.NoError(t, err)
+	now := epoch + 1000
+	g.now = func() int64 {
+		return now
+	}
+	first := g.Next(1)
+	now -= 10
+	second := g.Next(1)
+	assert.Greater(t, second, first)
+
+	// 序列号用完了借用下一毫秒
+	for i := 0; i < maxSeq; i++ {
+		g.Next(1)
+	}
+	id := g.Next(1)
+	assert.Equal(t, epoch+1001, Time(id).UnixMilli())
+}
+
+func TestNewGenerator(t *testing.T) {
+	_, err := NewGenerator(MaxNode + 1)
+	assert.ErrorIs(t, err, ErrInvalidNode)
+	_, err = NewGenerator(-1)
+	assert.ErrorIs(t, err, ErrInvalidNode)
+}
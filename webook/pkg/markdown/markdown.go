@@ -0,0 +1,134 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// 阅读速度，中日韩文字按字算，其余按单词算
+const (
+	cjkPerMinute  = 400
+	wordPerMinute = 200
+)
+
+// Document 是 Markdown 渲染之后的结果
+type Document struct {
+	// HTML 已经过白名单清洗，可以直接输出给前端
+	HTML string
+	// Text 去掉了所有标记的纯文本，代码块不算在内
+	Text string
+	// ReadingTime 预估的阅读时间，最少一分钟
+	ReadingTime time.Duration
+}
+
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+// NewRenderer 支持 GFM 的表格、删除线、任务列表和自动链接
+// goldmark 默认不输出原始 HTML，渲染的结果再经过一次 bluemonday 的 UGC 白名单兜底
+func NewRenderer() *Renderer {
+	policy := bluemonday.UGCPolicy()
+	policy.RequireNoReferrerOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	// 保留代码块的语言标记，前端用来做高亮
+	policy.AllowAttrs("class").Matching(bluemonday.SpaceSeparatedTokens).OnElements("code")
+	return &Renderer{
+		md:     goldmark.New(goldmark.WithExtensions(extension.GFM)),
+		policy: policy,
+	}
+}
+
+func (r *Renderer) Render(content string) (Document, error) {
+	src := []byte(content)
+	doc := r.md.Parser().Parse(text.NewReader(src))
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, src, doc); err != nil {
+		return Document{}, err
+	}
+	plain := plainText(doc, src)
+	return Document{
+		HTML:        r.policy.Sanitize(buf.String()),
+		Text:        plain,
+		ReadingTime: ReadingTime(plain),
+	}, nil
+}
+
+// plainText 每个块之间用空格隔开，连续的空白会被合并
+func plainText(doc ast.Node, src []byte) string {
+	var sb strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if n.Type() == ast.TypeBlock {
+				sb.WriteByte(' ')
+			}
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.CodeBlock, *ast.FencedCodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			sb.Write(node.Segment.Value(src))
+			if node.SoftLineBreak() || node.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(node.Value)
+		case *ast.AutoLink:
+			sb.Write(node.Label(src))
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// Abstract 按字符截取前 maxRunes 个字符，被截断的话末尾加上省略号
+func Abstract(plain string, maxRunes int) string {
+	if utf8.RuneCountInString(plain) <= maxRunes {
+		return plain
+	}
+	rs := []rune(plain)
+	return strings.TrimSpace(string(rs[:maxRunes])) + "..."
+}
+
+func ReadingTime(plain string) time.Duration {
+	var cjk, words int
+	inWord := false
+	for _, r := range plain {
+		switch {
+		case isCJK(r):
+			cjk++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+	}
+	minutes := float64(cjk)/cjkPerMinute + float64(words)/wordPerMinute
+	d := time.Duration(minutes * float64(time.Minute)).Round(time.Minute)
+	if d < time.Minute {
+		return time.Minute
+	}
+	return d
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,133 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+
+		wantHTML    []string
+		notWantHTML []string
+		wantText    string
+	}{
+		{
+			name:     "普通的 Markdown",
+			content:  "# 标题\n\n这是**加粗**的 [链接](https://example.com)。\n",
+			wantHTML: []string{"<h1>标题</h1>", "<strong>加粗</strong>", `href="https://example.com"`, `rel="nofollow noreferrer noopener"`},
+			wantText: "标题 这是加粗的 链接。",
+		},
+		{
+			name:        "原始 HTML 标签会被去掉，标签中间的文字保留",
+			content:     "hello <script>alert(1)</script>\n\n<img src=x onerror=alert(1)>",
+			notWantHTML: []string{"<script", "onerror"},
+			wantText:    "hello alert(1)",
+		},
+		{
+			name:        "javascript 链接会被去掉",
+			content:     "[点我](javascript:alert(1))",
+			notWantHTML: []string{"javascript:"},
+			wantText:    "点我",
+		},
+		{
+			name:     "代码块不算在纯文本里",
+			content:  "开始\n\n```go\nfmt.Println(1)\n```\n\n结束",
+			wantHTML: []string{`<code class="language-go">`},
+			wantText: "开始 结束",
+		},
+		{
+			name:     "表格",
+			content:  "| a | b |\n|---|---|\n| 1 | 2 |",
+			wantHTML: []string{"<table>", "<td>1</td>"},
+			wantText: "a b 1 2",
+		},
+	}
+	r := NewRenderer()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := r.Render(tc.content)
+			require.NoError(t, err)
+			for _, s := range tc.wantHTML {
+				assert.Contains(t, doc.HTML, s)
+			}
+			for _, s := range tc.notWantHTML {
+				assert.NotContains(t, doc.HTML, s)
+			}
+			assert.Equal(t, tc.wantText, doc.Text)
+			assert.Equal(t, time.Minute, doc.ReadingTime)
+		})
+	}
+}
+
+func TestAbstract(t *testing.T) {
+	testCases := []struct {
+		name     string
+		plain    string
+		maxRunes int
+		want     string
+	}{
+		{
+			name:     "不需要截断",
+			plain:    "你好",
+			maxRunes: 2,
+			want:     "你好",
+		},
+		{
+			name:     "按字符截断",
+			plain:    "你好世界",
+			maxRunes: 2,
+			want:     "你好...",
+		},
+		{
+			name:     "截断之后去掉末尾空白",
+			plain:    "ab cd",
+			maxRunes: 3,
+			want:     "ab...",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Abstract(tc.plain, tc.maxRunes))
+		})
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	testCases := []struct {
+		name  string
+		plain string
+		want  time.Duration
+	}{
+		{
+			name: "空文本也算一分钟",
+			want: time.Minute,
+		},
+		{
+			name:  "中文按字算",
+			plain: strings.Repeat("字", 1200),
+			want:  3 * time.Minute,
+		},
+		{
+			name:  "英文按单词算",
+			plain: strings.Repeat("word ", 600),
+			want:  3 * time.Minute,
+		},
+		{
+			name:  "混合",
+			plain: strings.Repeat("字", 400) + strings.Repeat(" word", 200),
+			want:  2 * time.Minute,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ReadingTime(tc.plain))
+		})
+	}
+}
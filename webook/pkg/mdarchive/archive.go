@@ -0,0 +1,175 @@
+package mdarchive
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	// ErrTooManyFiles 压缩包里面的 Markdown 文件超过了 Limits.MaxFiles
+	ErrTooManyFiles = errors.New("mdarchive: 文件太多")
+	// ErrFileTooLarge 解压之后的单个文件超过了 Limits.MaxFileSize
+	ErrFileTooLarge = errors.New("mdarchive: 文件太大")
+)
+
+// Writer 每篇文章写成压缩包根目录下的一个 .md 文件
+type Writer struct {
+	zw    *zip.Writer
+	names map[string]struct{}
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{zw: zip.NewWriter(w), names: make(map[string]struct{})}
+}
+
+// Add 文件名是 id-标题.md，标题里面不适合做文件名的字符都换成 -
+func (w *Writer) Add(doc Document) error {
+	data, err := Marshal(doc)
+	if err != nil {
+		return err
+	}
+	name := w.name(doc.Meta)
+	modified := doc.Meta.Utime
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+	f, err := w.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+func (w *Writer) Close() error {
+	return w.zw.Close()
+}
+
+// maxSlugLength 文件名里面最多保留标题的前多少个字符
+const maxSlugLength = 50
+
+func (w *Writer) name(meta Meta) string {
+	slug := Slug(meta.Title, maxSlugLength)
+	base := fmt.Sprintf("%d-%s", meta.Id, slug)
+	if slug == "" {
+		base = fmt.Sprintf("%d", meta.Id)
+	}
+	name := base + ".md"
+	// 没有 id 的时候可能重名
+	for i := 2; ; i++ {
+		if _, ok := w.names[name]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s-%d.md", base, i)
+	}
+	w.names[name] = struct{}{}
+	return name
+}
+
+// Slug 保留字母和数字，其它的连续字符合并成一个 -，最多 n 个字符
+func Slug(s string, n int) string {
+	var sb strings.Builder
+	cnt := 0
+	dash := false
+	for _, r := range s {
+		if cnt >= n {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+				cnt++
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			cnt++
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
+
+// Limits 导入的压缩包是用户上传的，要防止压缩炸弹
+type Limits struct {
+	MaxFiles    int
+	MaxFileSize int64
+}
+
+// File 压缩包里面的一个 Markdown 文件
+type File struct {
+	// Name 在压缩包里面的路径
+	Name string
+	Doc  Document
+}
+
+// Read 读取压缩包里面所有的 .md 和 .markdown 文件，按照在压缩包里面的顺序
+// 目录、隐藏文件和 macOS 生成的 __MACOSX 会被跳过，没有标题的用文件名
+func Read(r io.ReaderAt, size int64, limits Limits) ([]File, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	var res []File
+	for _, f := range zr.File {
+		if !isMarkdown(f.Name) {
+			continue
+		}
+		if len(res) >= limits.MaxFiles {
+			return nil, ErrTooManyFiles
+		}
+		data, err := readFile(f, limits.MaxFileSize)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		doc, err := Unmarshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		if strings.TrimSpace(doc.Meta.Title) == "" {
+			base := path.Base(f.Name)
+			doc.Meta.Title = strings.TrimSuffix(base, path.Ext(base))
+		}
+		res = append(res, File{Name: f.Name, Doc: doc})
+	}
+	return res, nil
+}
+
+func isMarkdown(name string) bool {
+	if strings.HasSuffix(name, "/") || strings.HasPrefix(name, "__MACOSX/") {
+		return false
+	}
+	base := path.Base(name)
+	if strings.HasPrefix(base, ".") {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(base))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// readFile 不相信头部里面声明的大小，按照实际解压出来的字节数判断
+func readFile(f *zip.File, maxSize int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrFileTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,154 @@
+package mdarchive
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterAndRead(t *testing.T) {
+	now := time.UnixMilli(1700000000000).UTC()
+	docs := []Document{
+		{
+			Meta: Meta{
+				Id:     1,
+				Title:  "Hello, 世界!",
+				Status: "published",
+				Tags:   []string{"go", "web"},
+				Ctime:  now,
+				Utime:  now,
+			},
+			Content: "# Hello\n\n正文",
+		},
+		{
+			Meta:    Meta{Id: 2, Title: "草稿", Status: "unpublished"},
+			Content: "",
+		},
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, doc := range docs {
+		require.NoError(t, w.Add(doc))
+	}
+	require.NoError(t, w.Close())
+
+	files, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()), Limits{MaxFiles: 10, MaxFileSize: 1024})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "1-hello-世界.md", files[0].Name)
+	assert.Equal(t, docs[0], files[0].Doc)
+	assert.Equal(t, "2-草稿.md", files[1].Name)
+	assert.Equal(t, docs[1], files[1].Doc)
+}
+
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		wantDoc Document
+		wantErr bool
+	}{
+		{
+			name:    "没有 front-matter，用一级标题",
+			data:    "```\n# 注释\n```\n\n# 标题\n正文",
+			wantDoc: Document{Meta: Meta{Title: "标题"}, Content: "```\n# 注释\n```\n\n# 标题\n正文"},
+		},
+		{
+			name:    "Windows 换行和 BOM",
+			data:    "\ufeff---\r\ntitle: 标题\r\ntags: [a]\r\n---\r\n\r\n正文\r\n",
+			wantDoc: Document{Meta: Meta{Title: "标题", Tags: []string{"a"}}, Content: "正文\n"},
+		},
+		{
+			name:    "只有 front-matter",
+			data:    "---\ntitle: 标题\n---",
+			wantDoc: Document{Meta: Meta{Title: "标题"}},
+		},
+		{
+			name:    "front-matter 格式不对",
+			data:    "---\ntitle: [\n---\n正文",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := Unmarshal([]byte(tc.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantDoc, doc)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	build := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			f, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+	limits := Limits{MaxFiles: 2, MaxFileSize: 16}
+	testCases := []struct {
+		name      string
+		files     map[string]string
+		wantTitle []string
+		wantErr   error
+	}{
+		{
+			name: "跳过不是 Markdown 的文件",
+			files: map[string]string{
+				"dir/a.MD":            "正文",
+				"__MACOSX/dir/._a.md": "x",
+				".hidden.md":          "x",
+				"img.png":             "x",
+			},
+			wantTitle: []string{"a"},
+		},
+		{
+			name: "文件太多",
+			files: map[string]string{
+				"a.md": "", "b.md": "", "c.markdown": "",
+			},
+			wantErr: ErrTooManyFiles,
+		},
+		{
+			name:    "文件太大",
+			files:   map[string]string{"a.md": strings.Repeat("a", 17)},
+			wantErr: ErrFileTooLarge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := build(tc.files)
+			files, err := Read(bytes.NewReader(data), int64(len(data)), limits)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if err != nil {
+				return
+			}
+			titles := make([]string, 0, len(files))
+			for _, f := range files {
+				titles = append(titles, f.Doc.Meta.Title)
+			}
+			assert.Equal(t, tc.wantTitle, titles)
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "go-1-22-的新特性", Slug("  Go 1.22 的新特性!! ", 50))
+	assert.Equal(t, "abc", Slug("abc-def", 3))
+	assert.Equal(t, "", Slug("???", 50))
+}
@@ -0,0 +1,86 @@
+package mdarchive
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Meta 写在 Markdown 开头的 front-matter，其它平台导出的文件可能一个字段都没有
+type Meta struct {
+	Id     int64    `yaml:"id,omitempty"`
+	Title  string   `yaml:"title"`
+	Status string   `yaml:"status,omitempty"`
+	Tags   []string `yaml:"tags,omitempty"`
+	// Series 所属的系列，只是导出给作者看的，导入的时候不会处理
+	Series    string    `yaml:"series,omitempty"`
+	Ctime     time.Time `yaml:"created,omitempty"`
+	Utime     time.Time `yaml:"updated,omitempty"`
+	PublishAt time.Time `yaml:"publish_at,omitempty"`
+}
+
+// Document 一篇文章对应一个 Markdown 文件
+type Document struct {
+	Meta    Meta
+	Content string
+}
+
+const fence = "---"
+
+// Marshal 有 front-matter 的时候，正文和它之间空一行
+func Marshal(doc Document) ([]byte, error) {
+	meta, err := yaml.Marshal(doc.Meta)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(meta) + len(doc.Content) + 16)
+	buf.WriteString(fence + "\n")
+	buf.Write(meta)
+	buf.WriteString(fence + "\n\n")
+	buf.WriteString(doc.Content)
+	return buf.Bytes(), nil
+}
+
+// Unmarshal 没有 front-matter 的话整个文件都是正文
+// 没有标题的时候用正文里面第一个一级标题
+func Unmarshal(data []byte) (Document, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var doc Document
+	if rest, ok := strings.CutPrefix(text, fence+"\n"); ok {
+		meta, content, found := strings.Cut(rest, "\n"+fence+"\n")
+		if !found {
+			// 只有 front-matter 没有正文
+			meta, found = strings.CutSuffix(rest, "\n"+fence)
+		}
+		if found {
+			if err := yaml.Unmarshal([]byte(meta), &doc.Meta); err != nil {
+				return Document{}, err
+			}
+			text = strings.TrimPrefix(content, "\n")
+		}
+	}
+	doc.Content = text
+	if strings.TrimSpace(doc.Meta.Title) == "" {
+		doc.Meta.Title = heading(text)
+	}
+	return doc, nil
+}
+
+// heading 第一个一级标题，代码块里面的不算
+func heading(text string) string {
+	inCode := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if title, ok := strings.CutPrefix(line, "# "); ok && !inCode {
+			return strings.TrimSpace(title)
+		}
+	}
+	return ""
+}
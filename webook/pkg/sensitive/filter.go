@@ -0,0 +1,171 @@
+package sensitive
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Action 命中之后怎么处理，按照严重程度排序
+type Action uint8
+
+const (
+	ActionPass Action = iota
+	// ActionMask 命中的词换成 *
+	ActionMask
+	// ActionReview 原样保留，交给人工审核
+	ActionReview
+	// ActionBlock 直接拒绝
+	ActionBlock
+)
+
+func ParseAction(s string) (Action, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "pass":
+		return ActionPass, nil
+	case "mask":
+		return ActionMask, nil
+	case "review":
+		return ActionReview, nil
+	case "block":
+		return ActionBlock, nil
+	}
+	return ActionPass, fmt.Errorf("sensitive: 未知的处理方式 %s", s)
+}
+
+func (a Action) String() string {
+	switch a {
+	case ActionMask:
+		return "mask"
+	case ActionReview:
+		return "review"
+	case ActionBlock:
+		return "block"
+	default:
+		return "pass"
+	}
+}
+
+// Policy 先看分类有没有单独配置，没有的话按照级别
+type Policy struct {
+	Levels     map[Level]Action
+	Categories map[string]Action
+}
+
+// DefaultPolicy 低级别打码，中级别人工审核，高级别直接拒绝
+func DefaultPolicy() Policy {
+	return Policy{
+		Levels: map[Level]Action{
+			LevelLow:    ActionMask,
+			LevelMedium: ActionReview,
+			LevelHigh:   ActionBlock,
+		},
+	}
+}
+
+func (p Policy) action(w Word) Action {
+	if a, ok := p.Categories[w.Category]; ok {
+		return a
+	}
+	if a, ok := p.Levels[w.Level]; ok {
+		return a
+	}
+	// 没有配置的级别宁可交给人工
+	return ActionReview
+}
+
+type Result struct {
+	// Action 所有命中的词里面最严重的处理方式
+	Action Action
+	// Hits 需要人工审核或者拒绝的词，打码了的不在里面
+	Hits []Hit
+	// Masked 处理方式是 ActionMask 的词换成了 * 之后的文本，其它的词保持原样
+	Masked string
+}
+
+// Words 需要人工审核或者拒绝的词，给审核人或者用户看的
+func (r Result) Words() []string {
+	res := make([]string, 0, len(r.Hits))
+	seen := make(map[string]struct{}, len(r.Hits))
+	for _, h := range r.Hits {
+		if _, ok := seen[h.Word.Text]; ok {
+			continue
+		}
+		seen[h.Word.Text] = struct{}{}
+		res = append(res, h.Word.Category+":"+h.Word.Text)
+	}
+	return res
+}
+
+// MaskAll 需要人工审核和拒绝的词也打码，给没有审核流程的场景用
+func (r Result) MaskAll() string {
+	if len(r.Hits) == 0 {
+		return r.Masked
+	}
+	rs := []rune(r.Masked)
+	for _, h := range r.Hits {
+		for i := h.Start; i < h.End; i++ {
+			rs[i] = '*'
+		}
+	}
+	return string(rs)
+}
+
+// Filter 词库和策略都可以在运行期间替换，正在进行的检查用的还是旧的
+type Filter struct {
+	matcher atomic.Pointer[Matcher]
+	policy  atomic.Pointer[Policy]
+}
+
+func NewFilter(words []Word, policy Policy) *Filter {
+	f := &Filter{}
+	f.Reload(words)
+	f.SetPolicy(policy)
+	return f
+}
+
+// Reload 整体替换词库
+func (f *Filter) Reload(words []Word) {
+	f.matcher.Store(NewMatcher(words))
+}
+
+func (f *Filter) SetPolicy(policy Policy) {
+	f.policy.Store(&policy)
+}
+
+func (f *Filter) Check(text string) Result {
+	res := Result{Masked: text}
+	hits := f.matcher.Load().Match(text)
+	if len(hits) == 0 {
+		return res
+	}
+	policy := f.policy.Load()
+	var (
+		rs     []rune
+		masked bool
+	)
+	for _, h := range hits {
+		a := policy.action(h.Word)
+		if a > res.Action {
+			res.Action = a
+		}
+		switch a {
+		case ActionPass:
+			continue
+		case ActionMask:
+			if rs == nil {
+				rs = []rune(text)
+			}
+			for i := h.Start; i < h.End; i++ {
+				rs[i] = '*'
+			}
+			masked = true
+			continue
+		}
+		res.Hits = append(res.Hits, h)
+	}
+	if masked {
+		res.Masked = string(rs)
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+package sensitive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Check(t *testing.T) {
+	words := []Word{
+		{Text: "傻瓜", Category: "abuse", Level: LevelLow},
+		{Text: "加微信", Category: "ad", Level: LevelMedium},
+		{Text: "赌博", Category: "gamble", Level: LevelHigh},
+	}
+	f := NewFilter(words, DefaultPolicy())
+	testCases := []struct {
+		name       string
+		text       string
+		wantAction Action
+		wantMasked string
+		wantWords  []string
+		// 连需要审核的也打码
+		wantMaskAll string
+	}{
+		{
+			name:        "没有命中",
+			text:        "今天天气不错",
+			wantAction:  ActionPass,
+			wantMasked:  "今天天气不错",
+			wantWords:   []string{},
+			wantMaskAll: "今天天气不错",
+		},
+		{
+			name:        "打码",
+			text:        "你这个傻瓜，傻瓜",
+			wantAction:  ActionMask,
+			wantMasked:  "你这个**，**",
+			wantWords:   []string{},
+			wantMaskAll: "你这个**，**",
+		},
+		{
+			name:        "取最严重的，打码照样打",
+			text:        "傻瓜才不加微信",
+			wantAction:  ActionReview,
+			wantMasked:  "**才不加微信",
+			wantWords:   []string{"ad:加微信"},
+			wantMaskAll: "**才不***",
+		},
+		{
+			name:        "拒绝",
+			text:        "加微信一起赌博",
+			wantAction:  ActionBlock,
+			wantMasked:  "加微信一起赌博",
+			wantWords:   []string{"ad:加微信", "gamble:赌博"},
+			wantMaskAll: "***一起**",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := f.Check(tc.text)
+			assert.Equal(t, tc.wantAction, res.Action)
+			assert.Equal(t, tc.wantMasked, res.Masked)
+			assert.Equal(t, tc.wantWords, res.Words())
+			assert.Equal(t, tc.wantMaskAll, res.MaskAll())
+		})
+	}
+}
+
+func TestFilter_Reload(t *testing.T) {
+	f := NewFilter(nil, DefaultPolicy())
+	assert.Equal(t, ActionPass, f.Check("赌博").Action)
+
+	words, err := LoadWords(strings.NewReader(`
+# 注释
+赌博,gamble,high
+加微信,ad
+傻瓜
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Word{
+		{Text: "赌博", Category: "gamble", Level: LevelHigh},
+		{Text: "加微信", Category: "ad", Level: LevelMedium},
+		{Text: "傻瓜", Category: DefaultCategory, Level: LevelMedium},
+	}, words)
+	f.Reload(words)
+	assert.Equal(t, ActionBlock, f.Check("赌博").Action)
+
+	// 分类单独配置的优先
+	f.SetPolicy(Policy{
+		Levels:     map[Level]Action{LevelHigh: ActionBlock},
+		Categories: map[string]Action{"ad": ActionMask},
+	})
+	assert.Equal(t, "***", f.Check("加微信").Masked)
+	// 没有配置的级别交给人工
+	assert.Equal(t, ActionReview, f.Check("傻瓜").Action)
+
+	_, err = LoadWords(strings.NewReader("赌博,gamble,critical"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package sensitive
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+)
+
+// DefaultCategory 词库里面没有写分类的时候用这个
+const DefaultCategory = "default"
+
+// LoadWords 每行一个词，格式是 词,分类,级别，分类和级别可以省略
+// 级别是 low、medium、high，省略的话是 medium。空行和 # 开头的行会被忽略
+func LoadWords(r io.Reader) ([]Word, error) {
+	var res []Word
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		segs := strings.Split(line, ",")
+		w := Word{
+			Text:     strings.TrimSpace(segs[0]),
+			Category: DefaultCategory,
+			Level:    LevelMedium,
+		}
+		if w.Text == "" {
+			return nil, fmt.Errorf("sensitive: 第 %d 行没有词", lineNo)
+		}
+		if len(segs) > 1 && strings.TrimSpace(segs[1]) != "" {
+			w.Category = strings.TrimSpace(segs[1])
+		}
+		if len(segs) > 2 {
+			lv, err := ParseLevel(segs[2])
+			if err != nil {
+				return nil, fmt.Errorf("sensitive: 第 %d 行: %w", lineNo, err)
+			}
+			w.Level = lv
+		}
+		res = append(res, w)
+	}
+	return res, sc.Err()
+}
+
+func LoadFile(path string) ([]Word, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadWords(f)
+}
+
+// WatchFile 每隔 interval 检查一下文件的修改时间，变了就重新加载
+// 加载失败的时候继续用旧的词库，一直运行到 ctx 被取消
+func WatchFile(ctx context.Context, f *Filter, path string, interval time.Duration, l logger.LoggerV1) {
+	var last time.Time
+	if fi, err := os.Stat(path); err == nil {
+		last = fi.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			l.Error("检查敏感词库失败", logger.String("path", path), logger.Error(err))
+			continue
+		}
+		if !fi.ModTime().After(last) {
+			continue
+		}
+		words, err := LoadFile(path)
+		if err != nil {
+			l.Error("重新加载敏感词库失败", logger.String("path", path), logger.Error(err))
+			continue
+		}
+		last = fi.ModTime()
+		f.Reload(words)
+		l.Info("重新加载敏感词库", logger.String("path", path), logger.Int64("words", int64(len(words))))
+	}
+}
@@ -0,0 +1,177 @@
+package sensitive
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Level 严重程度
+type Level uint8
+
+const (
+	LevelUnknown Level = iota
+	LevelLow
+	LevelMedium
+	LevelHigh
+)
+
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return LevelLow, nil
+	case "medium":
+		return LevelMedium, nil
+	case "high":
+		return LevelHigh, nil
+	}
+	return LevelUnknown, fmt.Errorf("sensitive: 未知的级别 %s", s)
+}
+
+func (l Level) String() string {
+	switch l {
+	case LevelLow:
+		return "low"
+	case LevelMedium:
+		return "medium"
+	case LevelHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+type Word struct {
+	Text     string
+	Category string
+	Level    Level
+}
+
+// Hit 命中的词，Start 和 End 是 rune 的下标，左闭右开
+type Hit struct {
+	Word  Word
+	Start int
+	End   int
+}
+
+// Matcher Aho-Corasick 自动机，构造之后只读，可以并发使用
+// 匹配的时候忽略大小写和全角半角
+type Matcher struct {
+	nodes []node
+	words []Word
+}
+
+type node struct {
+	children map[rune]int32
+	fail     int32
+	// out 在这个节点结束的词，包括沿着 fail 链能够到达的
+	out []int32
+}
+
+// NewMatcher 同一个词出现多次的时候保留级别最高的那个
+func NewMatcher(words []Word) *Matcher {
+	m := &Matcher{nodes: []node{{}}}
+	index := make(map[string]int32, len(words))
+	for _, w := range words {
+		text := normalize([]rune(w.Text))
+		if len(text) == 0 {
+			continue
+		}
+		key := string(text)
+		if i, ok := index[key]; ok {
+			if w.Level > m.words[i].Level {
+				m.words[i] = w
+			}
+			continue
+		}
+		idx := int32(len(m.words))
+		index[key] = idx
+		m.words = append(m.words, w)
+		cur := int32(0)
+		for _, r := range text {
+			next, ok := m.nodes[cur].children[r]
+			if !ok {
+				next = int32(len(m.nodes))
+				m.nodes = append(m.nodes, node{})
+				if m.nodes[cur].children == nil {
+					m.nodes[cur].children = make(map[rune]int32)
+				}
+				m.nodes[cur].children[r] = next
+			}
+			cur = next
+		}
+		m.nodes[cur].out = append(m.nodes[cur].out, idx)
+	}
+	m.buildFail()
+	return m
+}
+
+// buildFail 按层遍历，父节点的 fail 一定比子节点先算出来
+func (m *Matcher) buildFail() {
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].children {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].children {
+			m.nodes[child].fail = m.step(m.nodes[cur].fail, r)
+			fail := m.nodes[child].fail
+			m.nodes[child].out = append(m.nodes[child].out, m.nodes[fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// step 从 cur 读入 r 之后到达的节点
+func (m *Matcher) step(cur int32, r rune) int32 {
+	for {
+		if next, ok := m.nodes[cur].children[r]; ok {
+			return next
+		}
+		if cur == 0 {
+			return 0
+		}
+		cur = m.nodes[cur].fail
+	}
+}
+
+// Match 返回所有命中的词，包括互相重叠的，按照结束的位置排序
+func (m *Matcher) Match(text string) []Hit {
+	if len(m.words) == 0 {
+		return nil
+	}
+	rs := normalize([]rune(text))
+	var hits []Hit
+	cur := int32(0)
+	for i, r := range rs {
+		cur = m.step(cur, r)
+		for _, idx := range m.nodes[cur].out {
+			w := m.words[idx]
+			n := len([]rune(w.Text))
+			hits = append(hits, Hit{Word: w, Start: i + 1 - n, End: i + 1})
+		}
+	}
+	return hits
+}
+
+// Len 词库里面有多少个词
+func (m *Matcher) Len() int {
+	return len(m.words)
+}
+
+// normalize 转小写、全角转半角，一个 rune 还是对应一个 rune，下标不会变
+func normalize(rs []rune) []rune {
+	res := make([]rune, len(rs))
+	for i, r := range rs {
+		switch {
+		case r == '　':
+			r = ' '
+		case r >= '！' && r <= '～':
+			r -= 0xfee0
+		}
+		res[i] = unicode.ToLower(r)
+	}
+	return res
+}
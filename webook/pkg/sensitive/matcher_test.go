@@ -0,0 +1,71 @@
+package sensitive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	m := NewMatcher([]Word{
+		{Text: "he", Level: LevelLow},
+		{Text: "she", Level: LevelLow},
+		{Text: "his", Level: LevelLow},
+		{Text: "hers", Level: LevelLow},
+		{Text: "赌博", Category: "gamble", Level: LevelMedium},
+		{Text: "网络赌博", Category: "gamble", Level: LevelHigh},
+		// 重复的保留级别高的
+		{Text: "赌博", Category: "gamble", Level: LevelHigh},
+		{Text: ""},
+	})
+	assert.Equal(t, 6, m.Len())
+	testCases := []struct {
+		name string
+		text string
+		want []Hit
+	}{
+		{
+			name: "只命中前缀",
+			text: "hello world",
+			want: []Hit{{Word: Word{Text: "he", Level: LevelLow}, Start: 0, End: 2}},
+		},
+		{
+			name: "重叠",
+			text: "ushers",
+			want: []Hit{
+				{Word: Word{Text: "she", Level: LevelLow}, Start: 1, End: 4},
+				{Word: Word{Text: "he", Level: LevelLow}, Start: 2, End: 4},
+				{Word: Word{Text: "hers", Level: LevelLow}, Start: 2, End: 6},
+			},
+		},
+		{
+			name: "中文按照 rune 计算下标",
+			text: "禁止网络赌博",
+			want: []Hit{
+				{Word: Word{Text: "网络赌博", Category: "gamble", Level: LevelHigh}, Start: 2, End: 6},
+				{Word: Word{Text: "赌博", Category: "gamble", Level: LevelHigh}, Start: 4, End: 6},
+			},
+		},
+		{
+			name: "忽略大小写和全角",
+			text: "ＳＨＥ",
+			want: []Hit{
+				{Word: Word{Text: "she", Level: LevelLow}, Start: 0, End: 3},
+				{Word: Word{Text: "he", Level: LevelLow}, Start: 1, End: 3},
+			},
+		},
+		{
+			name: "空文本",
+			text: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tc.want, m.Match(tc.text))
+		})
+	}
+}
+
+func TestMatcher_Empty(t *testing.T) {
+	assert.Empty(t, NewMatcher(nil).Match("任何内容"))
+}
@@ -0,0 +1,75 @@
+// Package simhash 64 位的 SimHash，用来找内容几乎一样的文本
+// 相似的文本指纹只有少数几位不同，用海明距离衡量
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"unicode"
+)
+
+// shingleSize 按照连续的几个字切分，中文没有空格，按字切分比按词切分简单
+const shingleSize = 3
+
+// Fingerprint 只看字母和数字，大小写、空白和标点（包括 Markdown 的标记）都会被忽略
+// 没有任何字母和数字的时候返回 0
+func Fingerprint(text string) uint64 {
+	runes := normalize(text)
+	if len(runes) == 0 {
+		return 0
+	}
+	var weights [64]int
+	add := func(shingle []rune) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(string(shingle)))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+	if len(runes) < shingleSize {
+		add(runes)
+	}
+	// 出现多次的片段会被累加多次，相当于按照词频加权
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		add(runes[i : i+shingleSize])
+	}
+	var fp uint64
+	for i, w := range weights {
+		if w > 0 {
+			fp |= 1 << i
+		}
+	}
+	return fp
+}
+
+// Distance 海明距离，也就是有几位不一样
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Bands 把指纹切成 n 段，海明距离小于 n 的两个指纹至少有一段是完全一样的（抽屉原理）
+// 所以按段建索引，先找到至少一段相同的候选，再计算距离。n 必须能整除 64
+func Bands(fp uint64, n int) []uint64 {
+	width := 64 / n
+	mask := uint64(1)<<width - 1
+	res := make([]uint64, n)
+	for i := range res {
+		res[i] = fp >> (i * width) & mask
+	}
+	return res
+}
+
+func normalize(text string) []rune {
+	res := make([]rune, 0, len(text))
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			res = append(res, unicode.ToLower(r))
+		}
+	}
+	return res
+}
@@ -0,0 +1,55 @@
+package simhash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const original = `# 如何设计一个高可用的缓存
+
+缓存是提升系统性能最常用的手段之一。在读多写少的场景下，把热点数据放到 Redis 里面，
+可以极大地减轻数据库的压力。但是引入缓存之后，就要考虑缓存和数据库的一致性问题，
+还有缓存穿透、缓存击穿和缓存雪崩这三个经典的问题。
+
+缓存穿透是指查询一个根本不存在的数据，缓存和数据库都不会命中，请求每次都会打到数据库。
+常见的解决方案是缓存空值，或者使用布隆过滤器提前拦截。缓存击穿是指某个热点 key 过期的瞬间，
+大量请求同时打到数据库，可以用 singleflight 或者分布式锁来解决。缓存雪崩是指大量 key
+在同一时间过期，解决方案是在过期时间上加一个随机值，让过期时间分散开。`
+
+func TestFingerprint(t *testing.T) {
+	fp := Fingerprint(original)
+	assert.NotZero(t, fp)
+	// 空白、标点和大小写不影响
+	assert.Equal(t, fp, Fingerprint(strings.ToUpper(strings.ReplaceAll(original, "，", " "))))
+
+	// 改了几个字
+	repost := strings.Replace(original, "如何设计", "怎么设计", 1)
+	repost = strings.Replace(repost, "随机值", "随机数", 1)
+	assert.LessOrEqual(t, Distance(fp, Fingerprint(repost)), 3)
+
+	other := `Go 语言的调度器采用 GMP 模型。G 是 goroutine，M 是操作系统线程，P 是处理器，
+持有本地的运行队列。当一个 goroutine 发生系统调用阻塞的时候，M 会和 P 解绑，P 会去找
+另外一个空闲的 M 继续执行队列里面的 goroutine。工作窃取机制保证了负载均衡。`
+	assert.Greater(t, Distance(fp, Fingerprint(other)), 10)
+
+	assert.Zero(t, Fingerprint("，。！ \n"))
+	assert.NotZero(t, Fingerprint("Go"))
+}
+
+func TestBands(t *testing.T) {
+	fp := uint64(0x1234_5678_9abc_def0)
+	assert.Equal(t, []uint64{0xdef0, 0x9abc, 0x5678, 0x1234}, Bands(fp, 4))
+	assert.Equal(t, []uint64{fp}, Bands(fp, 1))
+
+	// 距离小于段数的时候至少有一段相同
+	other := fp ^ (1 << 3) ^ (1 << 20) ^ (1 << 40)
+	same := 0
+	for i, b := range Bands(other, 4) {
+		if b == Bands(fp, 4)[i] {
+			same++
+		}
+	}
+	assert.Equal(t, 1, same)
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
+	"github.com/TengFeiyang01/webook/webook/pkg/saramax"
+	"github.com/TengFeiyang01/webook/webook/search/service"
+)
+
+type App struct {
+	server    *grpcx.Server
+	consumers []saramax.Consumer
+	// syncer 把别的实例写进 MySQL 的索引数据同步到本地
+	syncer *service.IndexSyncer
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+type Article struct {
+	Id       int64
+	AuthorId int64
+	Title    string
+	Content  string
+	Tags     []string
+	Utime    time.Time
+}
+
+type SearchQuery struct {
+	Keyword string
+	// AuthorId 为 0 代表不按照作者过滤
+	AuthorId int64
+	// Start 和 End 按照更新时间过滤，左闭右开，零值代表不限制
+	Start  time.Time
+	End    time.Time
+	Offset int
+	Limit  int
+}
+
+type ArticleHit struct {
+	Article Article
+	Score   float64
+	// Title 和 Snippet 已经做过 HTML 转义，命中的词用 <em> 包裹
+	Title   string
+	Snippet string
+}
+
+type SearchResult struct {
+	Total int64
+	Hits  []ArticleHit
+}
+
+// SyncCursor 同步索引的位置，按照 (Mtime, Id) 升序
+type SyncCursor struct {
+	// Mtime MySQL 里面这一行最后修改的时间，毫秒数
+	Mtime int64
+	Id    int64
+}
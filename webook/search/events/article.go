@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/pkg/saramax"
+	"github.com/TengFeiyang01/webook/webook/search/domain"
+	"github.com/TengFeiyang01/webook/webook/search/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 和 article 模块 outbox 里面的 topic 保持一致
+const (
+	topicArticlePublished = "article_published"
+	topicArticleWithdrawn = "article_withdrawn"
+)
+
+// consumerGroup 所有实例共用一个消费者组，分区分给不同的实例
+// 索引的数据先写进 MySQL，每个实例再各自同步到内存里面
+const consumerGroup = "search_article"
+
+// ArticleEvent 和 article 模块写进 outbox 的事件保持一致
+// 只带 ID 和版本号，文章的数据要自己去查
+type ArticleEvent struct {
+	Id       int64 `json:"id"`
+	AuthorId int64 `json:"author_id"`
+	Status   uint8 `json:"status"`
+	Version  int64 `json:"version"`
+	Utime    int64 `json:"utime"`
+}
+
+type ArticleStatusConsumer struct {
+	client sarama.Client
+	artSvc artv1.ArticleServiceClient
+	svc    service.SearchService
+	l      logger.LoggerV1
+}
+
+func NewArticleStatusConsumer(client sarama.Client, artSvc artv1.ArticleServiceClient,
+	svc service.SearchService, l logger.LoggerV1) *ArticleStatusConsumer {
+	return &ArticleStatusConsumer{client: client, artSvc: artSvc, svc: svc, l: l}
+}
+
+func (c *ArticleStatusConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(consumerGroup, c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicArticlePublished, topicArticleWithdrawn},
+			saramax.NewHandler[ArticleEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("退出消费循环异常", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Consume 发表和撤回在两个 topic 上，互相之间没有顺序，
+// 所以不管是哪种事件，都按照线上库当前的状态来更新索引，这样也是幂等的
+func (c *ArticleStatusConsumer) Consume(msg *sarama.ConsumerMessage, evt ArticleEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	resp, err := c.artSvc.GetPubById(ctx, &artv1.GetPubByIdRequest{Id: evt.Id, SkipRead: true})
+	if status.Code(err) == codes.NotFound {
+		// 进了回收站或者被彻底删除了
+		return c.svc.DeleteArticle(ctx, evt.Id)
+	}
+	if err != nil {
+		return err
+	}
+	art := resp.GetArt()
+	if art.GetStatus() != uint32(artv1.ArticleStatus_ARTICLE_STATUS_PUBLISHED) {
+		return c.svc.DeleteArticle(ctx, evt.Id)
+	}
+	return c.svc.InputArticle(ctx, domain.Article{
+		Id:       art.GetId(),
+		AuthorId: art.GetAuthor().GetId(),
+		Title:    art.GetTitle(),
+		Content:  art.GetContent(),
+		Tags:     art.GetTags(),
+		Utime:    time.UnixMilli(art.GetUtime()),
+	})
+}
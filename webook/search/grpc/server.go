@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	searchv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/search/v1"
+	"github.com/TengFeiyang01/webook/webook/search/domain"
+	"github.com/TengFeiyang01/webook/webook/search/service"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SearchServiceServer struct {
+	searchv1.UnimplementedSearchServiceServer
+	svc service.SearchService
+}
+
+func NewSearchServiceServer(svc service.SearchService) *SearchServiceServer {
+	return &SearchServiceServer{svc: svc}
+}
+
+func (s *SearchServiceServer) Register(server *grpc.Server) {
+	searchv1.RegisterSearchServiceServer(server, s)
+}
+
+func (s *SearchServiceServer) SearchArticle(ctx context.Context, request *searchv1.SearchArticleRequest) (*searchv1.SearchArticleResponse, error) {
+	q := domain.SearchQuery{
+		Keyword:  request.GetKeyword(),
+		AuthorId: request.GetAuthorId(),
+		Offset:   int(request.GetOffset()),
+		Limit:    int(request.GetLimit()),
+	}
+	if request.GetStartTime() > 0 {
+		q.Start = time.UnixMilli(request.GetStartTime())
+	}
+	if request.GetEndTime() > 0 {
+		q.End = time.UnixMilli(request.GetEndTime())
+	}
+	res, err := s.svc.SearchArticle(ctx, q)
+	if errors.Is(err, service.ErrInvalidKeyword) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &searchv1.SearchArticleResponse{
+		Total: res.Total,
+		Hits: slice.Map(res.Hits, func(idx int, src domain.ArticleHit) *searchv1.ArticleHit {
+			return &searchv1.ArticleHit{
+				Id:       src.Article.Id,
+				AuthorId: src.Article.AuthorId,
+				Title:    src.Title,
+				Snippet:  src.Snippet,
+				Tags:     src.Article.Tags,
+				Utime:    src.Article.Utime.UnixMilli(),
+				Score:    src.Score,
+			}
+		}),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package ioc
+
+import (
+	artv1 "github.com/TengFeiyang01/webook/webook/api/proto/gen/article/v1"
+	"github.com/TengFeiyang01/webook/webook/pkg/grpcx"
+	grpc2 "github.com/TengFeiyang01/webook/webook/search/grpc"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func NewGRPCxServer(searchServer *grpc2.SearchServiceServer) *grpcx.Server {
+	type Config struct {
+		Addr string `yaml:"addr"`
+	}
+
+	var cfg Config
+	if err := viper.UnmarshalKey("grpc.server", &cfg); err != nil {
+		panic(err)
+	}
+
+	server := grpc.NewServer()
+	searchServer.Register(server)
+
+	return &grpcx.Server{
+		Server: server,
+		Addr:   cfg.Addr,
+	}
+}
+
+// InitArtGRPCClient 事件里面只有文章 ID，建索引的时候要去文章服务查内容
+func InitArtGRPCClient() artv1.ArticleServiceClient {
+	type Config struct {
+		Addr   string `yaml:"addr"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg Config
+	if err := viper.UnmarshalKey("grpc.client.art", &cfg); err != nil {
+		panic(err)
+	}
+	var opts []grpc.DialOption
+	if cfg.Secure {
+		// 加载你的证书之类的
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient(cfg.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return artv1.NewArticleServiceClient(cc)
+}
@@ -0,0 +1,31 @@
+package ioc
+
+import (
+	"github.com/IBM/sarama"
+	"github.com/TengFeiyang01/webook/webook/pkg/saramax"
+	"github.com/TengFeiyang01/webook/webook/search/events"
+	"github.com/spf13/viper"
+)
+
+func InitKafka() sarama.Client {
+	type Config struct {
+		Addrs []string `json:"addrs" yaml:"addrs"`
+	}
+	saramaCfg := sarama.NewConfig()
+	// 第一次上线的时候从头开始消费，把已经发表的文章都建进索引
+	saramaCfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	client, err := sarama.NewClient(cfg.Addrs, saramaCfg)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func NewConsumers(c1 *events.ArticleStatusConsumer) []saramax.Consumer {
+	return []saramax.Consumer{c1}
+}
@@ -0,0 +1,14 @@
+package ioc
+
+import (
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func InitLogger() logger.LoggerV1 {
+	l, err := zap.NewDevelopment()
+	if err != nil {
+		panic(err)
+	}
+	return logger.NewZapLogger(l)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"log"
+)
+
+func initViper() {
+	cfile := pflag.String("config", "config/dev.yaml", "指定配置文件路径")
+	pflag.Parse()
+	viper.SetConfigFile(*cfile)
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("Fatal error config file: %s \n", err))
+	}
+}
+
+func main() {
+	initViper()
+	app := InitAPP()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// 先从 MySQL 把索引建好，再开始消费和对外提供服务
+	if err := app.syncer.Load(ctx); err != nil {
+		panic(err)
+	}
+	go app.syncer.Start(ctx)
+	for _, c := range app.consumers {
+		err := c.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
+	err := app.server.Serve()
+	log.Println(err)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/fulltext"
+	"github.com/TengFeiyang01/webook/webook/search/domain"
+	"github.com/TengFeiyang01/webook/webook/search/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+)
+
+type ArticleRepository interface {
+	InputArticle(ctx context.Context, art domain.Article) error
+	DeleteArticle(ctx context.Context, id int64) error
+	SearchArticle(ctx context.Context, q domain.SearchQuery) (domain.SearchResult, error)
+	// SyncIndex 把 MySQL 里面排在 cursor 之后变更过的文章同步到本地索引
+	// 返回同步到的最后一条的位置，没有变更的话原样返回 cursor
+	SyncIndex(ctx context.Context, cursor domain.SyncCursor) (domain.SyncCursor, error)
+}
+
+// syncBatchSize 同步索引的时候一批查多少条
+const syncBatchSize = 500
+
+// articleRepository 写的时候先写 MySQL 再更新本地索引，
+// 本地索引马上可以查到，别的实例要等下一次同步
+type articleRepository struct {
+	dao   dao.ArticleDAO
+	store dao.ArticleStoreDAO
+}
+
+func NewArticleRepository(dao dao.ArticleDAO, store dao.ArticleStoreDAO) ArticleRepository {
+	return &articleRepository{dao: dao, store: store}
+}
+
+func (r *articleRepository) InputArticle(ctx context.Context, art domain.Article) error {
+	entity := dao.Article{
+		Id:       art.Id,
+		AuthorId: art.AuthorId,
+		Title:    art.Title,
+		Content:  art.Content,
+		Tags:     art.Tags,
+		Utime:    art.Utime.UnixMilli(),
+	}
+	if err := r.store.Upsert(ctx, entity); err != nil {
+		return err
+	}
+	return r.dao.Upsert(ctx, entity)
+}
+
+func (r *articleRepository) DeleteArticle(ctx context.Context, id int64) error {
+	if err := r.store.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.dao.Delete(ctx, id)
+}
+
+func (r *articleRepository) SyncIndex(ctx context.Context, cursor domain.SyncCursor) (domain.SyncCursor, error) {
+	for {
+		arts, err := r.store.ListChanged(ctx, cursor.Mtime, cursor.Id, syncBatchSize)
+		if err != nil {
+			return cursor, err
+		}
+		for _, art := range arts {
+			if art.Deleted {
+				err = r.dao.Delete(ctx, art.Id)
+			} else {
+				err = r.dao.Upsert(ctx, dao.Article{
+					Id:       art.Id,
+					AuthorId: art.AuthorId,
+					Title:    art.Title,
+					Content:  art.Content,
+					Tags:     art.Tags,
+					Utime:    art.Utime,
+				})
+			}
+			if err != nil {
+				return cursor, err
+			}
+			cursor = domain.SyncCursor{Mtime: art.Mtime, Id: art.Id}
+		}
+		if len(arts) < syncBatchSize {
+			return cursor, nil
+		}
+	}
+}
+
+func (r *articleRepository) SearchArticle(ctx context.Context, q domain.SearchQuery) (domain.SearchResult, error) {
+	dq := dao.Query{
+		Keyword:  q.Keyword,
+		AuthorId: q.AuthorId,
+		Offset:   q.Offset,
+		Limit:    q.Limit,
+	}
+	if !q.Start.IsZero() {
+		dq.Start = q.Start.UnixMilli()
+	}
+	if !q.End.IsZero() {
+		dq.End = q.End.UnixMilli()
+	}
+	res, err := r.dao.Search(ctx, dq)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+	return domain.SearchResult{
+		Total: int64(res.Total),
+		Hits: slice.Map(res.Hits, func(idx int, src fulltext.Hit) domain.ArticleHit {
+			return domain.ArticleHit{
+				Article: domain.Article{
+					Id:       src.Doc.Id,
+					AuthorId: src.Doc.AuthorId,
+					Title:    src.Doc.Title,
+					Content:  src.Doc.Content,
+					Tags:     src.Doc.Tags,
+					Utime:    src.Doc.Utime,
+				},
+				Score:   src.Score,
+				Title:   src.Title,
+				Snippet: src.Snippet,
+			}
+		}),
+	}, nil
+}
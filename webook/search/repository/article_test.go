@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/fulltext"
+	"github.com/TengFeiyang01/webook/webook/search/domain"
+	"github.com/TengFeiyang01/webook/webook/search/repository/dao"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestArticleRepository_SyncIndex(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// 内存数据库每个连接都是独立的
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+	require.NoError(t, dao.InitTables(db))
+	store := dao.NewGORMArticleStoreDAO(db)
+	// 两个实例共用 MySQL，各自有自己的索引
+	a := NewArticleRepository(dao.NewFullTextArticleDAO(fulltext.NewIndex()), store)
+	bIdx := fulltext.NewIndex()
+	b := NewArticleRepository(dao.NewFullTextArticleDAO(bIdx), store)
+	ctx := context.Background()
+	search := func(repo ArticleRepository, keyword string) []int64 {
+		res, err := repo.SearchArticle(ctx, domain.SearchQuery{Keyword: keyword, Limit: 10})
+		require.NoError(t, err)
+		var ids []int64
+		for _, hit := range res.Hits {
+			ids = append(ids, hit.Article.Id)
+		}
+		return ids
+	}
+
+	require.NoError(t, a.InputArticle(ctx, domain.Article{Id: 1, AuthorId: 123,
+		Title: "Go 并发", Content: "goroutine 和 channel", Tags: []string{"go"}, Utime: time.Now()}))
+	require.NoError(t, a.InputArticle(ctx, domain.Article{Id: 2, AuthorId: 123,
+		Title: "Go 泛型", Content: "类型参数", Utime: time.Now()}))
+	// 写入的实例马上可以查到，别的实例要同步之后才有
+	assert.Equal(t, []int64{1}, search(a, "goroutine"))
+	assert.Empty(t, search(b, "goroutine"))
+
+	cursor, err := b.SyncIndex(ctx, domain.SyncCursor{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cursor.Id)
+	assert.Equal(t, 2, bIdx.Len())
+	assert.Equal(t, []int64{1}, search(b, "goroutine"))
+
+	// 没有新的变更，cursor 不动
+	next, err := b.SyncIndex(ctx, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, next)
+
+	// 删除留下墓碑，别的实例同步之后也删掉
+	time.Sleep(time.Millisecond * 2)
+	require.NoError(t, a.DeleteArticle(ctx, 1))
+	assert.Empty(t, search(a, "goroutine"))
+	next, err = b.SyncIndex(ctx, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), next.Id)
+	assert.Equal(t, 1, bIdx.Len())
+	assert.Empty(t, search(b, "goroutine"))
+
+	// 重启之后从头同步，墓碑不会进索引
+	cIdx := fulltext.NewIndex()
+	_, err = NewArticleRepository(dao.NewFullTextArticleDAO(cIdx), store).SyncIndex(ctx, domain.SyncCursor{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, cIdx.Len())
+}
@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/fulltext"
+)
+
+// snippetRunes 摘要的长度
+const snippetRunes = 120
+
+type ArticleDAO interface {
+	Upsert(ctx context.Context, art Article) error
+	Delete(ctx context.Context, id int64) error
+	Search(ctx context.Context, q Query) (fulltext.Result, error)
+}
+
+// FullTextArticleDAO 索引在进程内存里面，数据从 ArticleStoreDAO 同步过来，重启之后从 MySQL 重建
+type FullTextArticleDAO struct {
+	idx *fulltext.Index
+}
+
+func NewFullTextArticleDAO(idx *fulltext.Index) ArticleDAO {
+	return &FullTextArticleDAO{idx: idx}
+}
+
+func (dao *FullTextArticleDAO) Upsert(ctx context.Context, art Article) error {
+	dao.idx.Upsert(fulltext.Document{
+		Id:       art.Id,
+		AuthorId: art.AuthorId,
+		Title:    art.Title,
+		Content:  art.Content,
+		Tags:     art.Tags,
+		Utime:    time.UnixMilli(art.Utime),
+	})
+	return nil
+}
+
+func (dao *FullTextArticleDAO) Delete(ctx context.Context, id int64) error {
+	dao.idx.Delete(id)
+	return nil
+}
+
+func (dao *FullTextArticleDAO) Search(ctx context.Context, q Query) (fulltext.Result, error) {
+	fq := fulltext.Query{
+		Keyword:      q.Keyword,
+		AuthorId:     q.AuthorId,
+		Offset:       q.Offset,
+		Limit:        q.Limit,
+		SnippetRunes: snippetRunes,
+	}
+	if q.Start > 0 {
+		fq.Start = time.UnixMilli(q.Start)
+	}
+	if q.End > 0 {
+		fq.End = time.UnixMilli(q.End)
+	}
+	return dao.idx.Search(fq), nil
+}
+
+type Article struct {
+	Id       int64
+	AuthorId int64
+	Title    string
+	Content  string
+	Tags     []string
+	Utime    int64
+}
+
+type Query struct {
+	Keyword  string
+	AuthorId int64
+	Start    int64
+	End      int64
+	Offset   int
+	Limit    int
+}
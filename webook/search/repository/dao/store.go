@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleStoreDAO 索引的数据落在 MySQL 里面，所有实例共用一份
+// 消费者组里面每个实例只消费一部分分区，写到这里之后，
+// 每个实例再从这里同步到自己内存里面的索引
+type ArticleStoreDAO interface {
+	Upsert(ctx context.Context, art Article) error
+	// Delete 不真的删除，留下一个墓碑，别的实例同步的时候才知道要从索引里面删掉
+	Delete(ctx context.Context, id int64) error
+	// ListChanged 按照 (mtime, id) 升序翻页，只取排在 (mtime, id) 之后的，包括已经删除的
+	ListChanged(ctx context.Context, mtime int64, id int64, limit int) ([]SearchArticle, error)
+}
+
+type GORMArticleStoreDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleStoreDAO(db *gorm.DB) ArticleStoreDAO {
+	return &GORMArticleStoreDAO{db: db}
+}
+
+func (dao *GORMArticleStoreDAO) Upsert(ctx context.Context, art Article) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"author_id", "title", "content", "tags", "utime", "deleted", "mtime"}),
+	}).Create(&SearchArticle{
+		Id:       art.Id,
+		AuthorId: art.AuthorId,
+		Title:    art.Title,
+		Content:  art.Content,
+		Tags:     art.Tags,
+		Utime:    art.Utime,
+		Mtime:    now,
+	}).Error
+}
+
+func (dao *GORMArticleStoreDAO) Delete(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&SearchArticle{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"title":   "",
+			"content": "",
+			"tags":    "null",
+			"deleted": true,
+			"mtime":   time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *GORMArticleStoreDAO) ListChanged(ctx context.Context, mtime int64, id int64, limit int) ([]SearchArticle, error) {
+	var res []SearchArticle
+	err := dao.db.WithContext(ctx).
+		Where("mtime > ? OR (mtime = ? AND id > ?)", mtime, mtime, id).
+		Order("mtime ASC, id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+type SearchArticle struct {
+	// Id 就是文章 ID，不自增
+	Id       int64 `gorm:"primaryKey;autoIncrement:false;index:idx_mtime_id,priority:2"`
+	AuthorId int64
+	Title    string   `gorm:"type:varchar(4096)"`
+	Content  string   `gorm:"type:BLOB"`
+	Tags     []string `gorm:"serializer:json"`
+	// Utime 文章的更新时间
+	Utime   int64
+	Deleted bool
+	// Mtime 这一行最后修改的时间，同步索引用
+	Mtime int64 `gorm:"index:idx_mtime_id,priority:1"`
+}
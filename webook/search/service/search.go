@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/TengFeiyang01/webook/webook/search/domain"
+	"github.com/TengFeiyang01/webook/webook/search/repository"
+)
+
+const (
+	// maxKeywordLength 关键字太长没有意义，还会放大查询的开销
+	maxKeywordLength = 64
+	defaultLimit     = 10
+	maxLimit         = 100
+)
+
+var ErrInvalidKeyword = errors.New("搜索关键字非法")
+
+type SearchService interface {
+	// InputArticle 新增或者更新索引里的文章
+	InputArticle(ctx context.Context, art domain.Article) error
+	DeleteArticle(ctx context.Context, id int64) error
+	SearchArticle(ctx context.Context, q domain.SearchQuery) (domain.SearchResult, error)
+}
+
+type searchService struct {
+	repo repository.ArticleRepository
+}
+
+func NewSearchService(repo repository.ArticleRepository) SearchService {
+	return &searchService{repo: repo}
+}
+
+func (s *searchService) InputArticle(ctx context.Context, art domain.Article) error {
+	return s.repo.InputArticle(ctx, art)
+}
+
+func (s *searchService) DeleteArticle(ctx context.Context, id int64) error {
+	return s.repo.DeleteArticle(ctx, id)
+}
+
+func (s *searchService) SearchArticle(ctx context.Context, q domain.SearchQuery) (domain.SearchResult, error) {
+	q.Keyword = strings.TrimSpace(q.Keyword)
+	if q.Keyword == "" || utf8.RuneCountInString(q.Keyword) > maxKeywordLength {
+		return domain.SearchResult{}, ErrInvalidKeyword
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	}
+	if q.Limit > maxLimit {
+		q.Limit = maxLimit
+	}
+	return s.repo.SearchArticle(ctx, q)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/TengFeiyang01/webook/webook/pkg/logger"
+	"github.com/TengFeiyang01/webook/webook/search/domain"
+	"github.com/TengFeiyang01/webook/webook/search/repository"
+)
+
+// IndexSyncer 把 MySQL 里面的文章同步到本地的索引
+// 每个实例只消费一部分分区，别的实例写进 MySQL 的变更要靠它同步过来
+type IndexSyncer struct {
+	repo repository.ArticleRepository
+	l    logger.LoggerV1
+
+	interval time.Duration
+	// lag 每次都往回多同步这么久，mtime 用的是写入那个实例的时钟，
+	// 实例之间的时钟有偏差，晚提交的事务也可能带着更早的 mtime
+	lag    time.Duration
+	cursor domain.SyncCursor
+}
+
+func NewIndexSyncer(repo repository.ArticleRepository, l logger.LoggerV1) *IndexSyncer {
+	return &IndexSyncer{
+		repo:     repo,
+		l:        l,
+		interval: time.Second * 3,
+		lag:      time.Second * 10,
+	}
+}
+
+// Load 全量加载，启动的时候加载完再对外提供服务
+func (s *IndexSyncer) Load(ctx context.Context) error {
+	cursor, err := s.repo.SyncIndex(ctx, domain.SyncCursor{})
+	s.cursor = cursor
+	return err
+}
+
+// Start 增量同步，一直运行到 ctx 被取消
+func (s *IndexSyncer) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.interval):
+		}
+		from := domain.SyncCursor{Mtime: s.cursor.Mtime - s.lag.Milliseconds()}
+		cursor, err := s.repo.SyncIndex(ctx, from)
+		if err != nil {
+			s.l.Error("同步搜索索引失败", logger.Error(err))
+		}
+		// 往回同步的那一段没有新的变更的话，cursor 会比原来的小
+		if cursor.Mtime > s.cursor.Mtime ||
+			(cursor.Mtime == s.cursor.Mtime && cursor.Id > s.cursor.Id) {
+			s.cursor = cursor
+		}
+	}
+}
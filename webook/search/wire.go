@@ -0,0 +1,41 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/TengFeiyang01/webook/webook/pkg/fulltext"
+	"github.com/TengFeiyang01/webook/webook/search/events"
+	"github.com/TengFeiyang01/webook/webook/search/grpc"
+	"github.com/TengFeiyang01/webook/webook/search/ioc"
+	"github.com/TengFeiyang01/webook/webook/search/repository"
+	"github.com/TengFeiyang01/webook/webook/search/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/search/service"
+	"github.com/google/wire"
+)
+
+var thirdPartySet = wire.NewSet(
+	ioc.InitLogger,
+	ioc.InitDB,
+	ioc.InitKafka,
+	ioc.InitArtGRPCClient,
+)
+
+var searchSvcSet = wire.NewSet(
+	fulltext.NewIndex,
+	dao.NewFullTextArticleDAO,
+	dao.NewGORMArticleStoreDAO,
+	repository.NewArticleRepository,
+	service.NewSearchService,
+	service.NewIndexSyncer,
+)
+
+func InitAPP() *App {
+	wire.Build(searchSvcSet,
+		thirdPartySet,
+		grpc.NewSearchServiceServer,
+		events.NewArticleStatusConsumer,
+		ioc.NewConsumers,
+		ioc.NewGRPCxServer,
+		wire.Struct(new(App), "*"))
+	return new(App)
+}
@@ -0,0 +1,49 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/TengFeiyang01/webook/webook/pkg/fulltext"
+	"github.com/TengFeiyang01/webook/webook/search/events"
+	"github.com/TengFeiyang01/webook/webook/search/grpc"
+	"github.com/TengFeiyang01/webook/webook/search/ioc"
+	"github.com/TengFeiyang01/webook/webook/search/repository"
+	"github.com/TengFeiyang01/webook/webook/search/repository/dao"
+	"github.com/TengFeiyang01/webook/webook/search/service"
+	"github.com/google/wire"
+)
+
+// Injectors from wire.go:
+
+func InitAPP() *App {
+	index := fulltext.NewIndex()
+	articleDAO := dao.NewFullTextArticleDAO(index)
+	loggerV1 := ioc.InitLogger()
+	db := ioc.InitDB(loggerV1)
+	articleStoreDAO := dao.NewGORMArticleStoreDAO(db)
+	articleRepository := repository.NewArticleRepository(articleDAO, articleStoreDAO)
+	searchService := service.NewSearchService(articleRepository)
+	searchServiceServer := grpc.NewSearchServiceServer(searchService)
+	server := ioc.NewGRPCxServer(searchServiceServer)
+	client := ioc.InitKafka()
+	articleServiceClient := ioc.InitArtGRPCClient()
+	articleStatusConsumer := events.NewArticleStatusConsumer(client, articleServiceClient, searchService, loggerV1)
+	v := ioc.NewConsumers(articleStatusConsumer)
+	indexSyncer := service.NewIndexSyncer(articleRepository, loggerV1)
+	app := &App{
+		server:    server,
+		consumers: v,
+		syncer:    indexSyncer,
+	}
+	return app
+}
+
+// wire.go:
+
+var thirdPartySet = wire.NewSet(ioc.InitLogger, ioc.InitDB, ioc.InitKafka, ioc.InitArtGRPCClient)
+
+var searchSvcSet = wire.NewSet(fulltext.NewIndex, dao.NewFullTextArticleDAO, dao.NewGORMArticleStoreDAO, repository.NewArticleRepository, service.NewSearchService, service.NewIndexSyncer)
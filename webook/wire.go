@@ -4,6 +4,7 @@ package main
 
 import (
 	artevents "github.com/TengFeiyang01/webook/webook/article/events"
+	artioc "github.com/TengFeiyang01/webook/webook/article/ioc"
 	artrepo "github.com/TengFeiyang01/webook/webook/article/repository"
 	artcache "github.com/TengFeiyang01/webook/webook/article/repository/cache"
 	artdao "github.com/TengFeiyang01/webook/webook/article/repository/dao"
@@ -13,6 +14,8 @@ import (
 	cache2 "github.com/TengFeiyang01/webook/webook/interactive/repository/cache"
 	dao2 "github.com/TengFeiyang01/webook/webook/interactive/repository/dao"
 	service2 "github.com/TengFeiyang01/webook/webook/interactive/service"
+	artnotify "github.com/TengFeiyang01/webook/webook/internal/events/article"
+	"github.com/TengFeiyang01/webook/webook/internal/job"
 	"github.com/TengFeiyang01/webook/webook/internal/repository"
 	"github.com/TengFeiyang01/webook/webook/internal/repository/cache"
 	"github.com/TengFeiyang01/webook/webook/internal/repository/dao"
@@ -28,13 +31,35 @@ var interactiveSvcSet = wire.NewSet(
 	cache2.NewInteractiveRedisCache,
 	repository2.NewCachedInteractiveRepository,
 	service2.NewInteractiveService,
+	dao2.NewGORMStatsDAO,
+	repository2.NewStatsRepository,
+	events2.NewKafkaProducer,
 )
 
 var articleSvcSet = wire.NewSet(
 	artcache.NewArticleCache,
+	artcache.NewSeriesCache,
 	artrepo.NewCachedArticleRepository,
+	artrepo.NewArticleRevisionRepository,
+	artrepo.NewCachedArticleTagRepository,
+	artrepo.NewCachedSeriesRepository,
 	artsvc.NewArticleService,
 	artdao.NewGORMArticleDAO,
+	artdao.NewGORMArticleRevisionDAO,
+	artdao.NewGORMTagDAO,
+	artdao.NewGORMSeriesDAO,
+	artdao.NewGORMAttachmentDAO,
+	artdao.NewGORMModerationDAO,
+	artdao.NewGORMExportDAO,
+	artdao.NewGORMImportDAO,
+	artdao.NewGORMFingerprintDAO,
+	artioc.InitAttachmentRepository,
+	ioc.InitExportRepository,
+	ioc.InitImportRepository,
+	artrepo.NewModerationRepository,
+	artrepo.NewFingerprintRepository,
+	artioc.InitModeration,
+	artioc.InitSensitiveFilter,
 )
 
 var rankingServiceSet = wire.NewSet(
@@ -43,6 +68,17 @@ var rankingServiceSet = wire.NewSet(
 	service.NewBatchRankingService,
 )
 
+var jobSchedulerSet = wire.NewSet(
+	dao.NewGORMJobDAO,
+	repository.NewPreemptCronJobRepository,
+	service.NewCronJobService,
+	job.NewArticlePublishExecutor,
+	job.NewArticlePurgeExecutor,
+	job.NewAttachmentGCExecutor,
+	ioc.InitLocalFuncExecutor,
+	ioc.InitScheduler,
+)
+
 func InitApp() *App {
 	wire.Build(
 		// 初始化 DB
@@ -61,6 +97,7 @@ func InitApp() *App {
 
 		articleSvcSet,
 		ioc.InitArtGRPCClient,
+		jobSchedulerSet,
 
 		// 初始化 DAO
 		dao.NewUserDAO,
@@ -76,7 +113,11 @@ func InitApp() *App {
 
 		// consumer
 		events2.NewInteractiveReadEventBatchConsumer,
-		artevents.NewKafkaProducer,
+		events2.NewInteractiveStatsConsumer,
+		ioc.InitArticleRejectedConsumer,
+		artnotify.NewArticleScheduledConsumer,
+		artdao.NewGORMOutboxDAO,
+		artevents.NewOutboxProducer,
 
 		// 初始化 service
 		service.NewUserService,
@@ -89,7 +130,18 @@ func InitApp() *App {
 		web.NewUserHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewArticleHandler,
+		web.NewSearchHandler,
+		web.NewArticleShareHandler,
+		web.NewAttachmentHandler,
+		web.NewArchiveHandler,
+		web.NewArticleStatsHandler,
+		ioc.InitModerationHandler,
+		ioc.InitFeedHandler,
+		ioc.InitSearchGRPCClient,
+		ioc.InitCommentGRPCClient,
+		web.NewCommentHandler,
 		ijwt.NewRedisJWT,
+		ioc.InitShareHandler,
 
 		ioc.InitGinMiddlewares,
 		ioc.InitWebServer,
@@ -8,6 +8,7 @@ package main
 
 import (
 	"github.com/TengFeiyang01/webook/webook/article/events"
+	ioc2 "github.com/TengFeiyang01/webook/webook/article/ioc"
 	repository2 "github.com/TengFeiyang01/webook/webook/article/repository"
 	cache2 "github.com/TengFeiyang01/webook/webook/article/repository/cache"
 	dao2 "github.com/TengFeiyang01/webook/webook/article/repository/dao"
@@ -17,6 +18,8 @@ import (
 	cache3 "github.com/TengFeiyang01/webook/webook/interactive/repository/cache"
 	dao3 "github.com/TengFeiyang01/webook/webook/interactive/repository/dao"
 	service3 "github.com/TengFeiyang01/webook/webook/interactive/service"
+	"github.com/TengFeiyang01/webook/webook/internal/events/article"
+	"github.com/TengFeiyang01/webook/webook/internal/job"
 	"github.com/TengFeiyang01/webook/webook/internal/repository"
 	"github.com/TengFeiyang01/webook/webook/internal/repository/cache"
 	"github.com/TengFeiyang01/webook/webook/internal/repository/dao"
@@ -47,43 +50,91 @@ func InitApp() *App {
 	codeRepository := repository.NewCodeRepository(codeCache)
 	smsService := ioc.InitSMSService(cmdable)
 	codeService := service.NewCodeService(codeRepository, smsService)
-	userHandler := web.NewUserHandler(userService, codeService, cmdable, handler, loggerV1)
+	filter := ioc2.InitSensitiveFilter(loggerV1)
+	userHandler := web.NewUserHandler(userService, codeService, cmdable, handler, loggerV1, filter)
 	wechatService := ioc.InitOAuth2WechatService(loggerV1)
 	wechatHandlerConfig := ioc.NewWechatHandlerConfig()
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, wechatHandlerConfig, handler)
 	articleDAO := dao2.NewGORMArticleDAO(db)
 	articleCache := cache2.NewArticleCache(cmdable)
 	articleRepository := repository2.NewCachedArticleRepository(articleDAO, loggerV1, userDAO, articleCache)
+	articleRevisionDAO := dao2.NewGORMArticleRevisionDAO(db)
+	articleRevisionRepository := repository2.NewArticleRevisionRepository(articleRevisionDAO)
+	tagDAO := dao2.NewGORMTagDAO(db)
+	articleTagRepository := repository2.NewCachedArticleTagRepository(tagDAO, articleCache, loggerV1)
+	seriesDAO := dao2.NewGORMSeriesDAO(db)
+	seriesCache := cache2.NewSeriesCache(cmdable)
+	seriesRepository := repository2.NewCachedSeriesRepository(seriesDAO, seriesCache, loggerV1)
+	attachmentDAO := dao2.NewGORMAttachmentDAO(db)
+	attachmentRepository := ioc2.InitAttachmentRepository(attachmentDAO)
+	moderationDAO := dao2.NewGORMModerationDAO(db)
+	moderationRepository := repository2.NewModerationRepository(moderationDAO, articleCache, loggerV1)
+	exportDAO := dao2.NewGORMExportDAO(db)
+	exportRepository := ioc.InitExportRepository(exportDAO)
+	importDAO := dao2.NewGORMImportDAO(db)
+	importRepository := ioc.InitImportRepository(importDAO)
+	fingerprintDAO := dao2.NewGORMFingerprintDAO(db)
+	fingerprintRepository := repository2.NewFingerprintRepository(fingerprintDAO)
+	moderation := ioc2.InitModeration()
 	client := ioc.InitKafka()
 	syncProducer := ioc.NewSyncProducer(client)
-	producer := events.NewKafkaProducer(syncProducer)
-	articleService := service2.NewArticleService(articleRepository, producer, loggerV1)
+	outboxDAO := dao2.NewGORMOutboxDAO(db)
+	producer := events.NewOutboxProducer(syncProducer, outboxDAO)
+	articleService := service2.NewArticleService(articleRepository, articleRevisionRepository, articleTagRepository, seriesRepository, attachmentRepository, moderationRepository, exportRepository, importRepository, fingerprintRepository, moderation, filter, producer, loggerV1)
 	articleServiceClient := ioc.InitArtGRPCClient(articleService)
 	interactiveDAO := dao3.NewGORMInteractiveDAO(db)
 	interactiveCache := cache3.NewInteractiveRedisCache(cmdable)
 	interactiveRepository := repository3.NewCachedInteractiveRepository(interactiveDAO, loggerV1, interactiveCache)
-	interactiveService := service3.NewInteractiveService(interactiveRepository)
+	statsDAO := dao3.NewGORMStatsDAO(db)
+	statsRepository := repository3.NewStatsRepository(statsDAO)
+	eventsProducer := events2.NewKafkaProducer(syncProducer)
+	interactiveService := service3.NewInteractiveService(interactiveRepository, statsRepository, eventsProducer, loggerV1)
 	interactiveServiceClient := ioc.InitIntrGRPCClient(interactiveService)
-	articleHandler := web.NewArticleHandler(articleServiceClient, loggerV1, interactiveServiceClient)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	jobDAO := dao.NewGORMJobDAO(db)
+	jobRepository := repository.NewPreemptCronJobRepository(jobDAO)
+	jobService := service.NewCronJobService(jobRepository, loggerV1)
+	articleHandler := web.NewArticleHandler(articleServiceClient, loggerV1, interactiveServiceClient, jobService)
+	searchServiceClient := ioc.InitSearchGRPCClient()
+	searchHandler := web.NewSearchHandler(searchServiceClient, loggerV1)
+	shareHandler := ioc.InitShareHandler(cmdable)
+	articleShareHandler := web.NewArticleShareHandler(articleServiceClient, shareHandler, loggerV1)
+	attachmentHandler := web.NewAttachmentHandler(articleServiceClient)
+	moderationHandler := ioc.InitModerationHandler(articleServiceClient)
+	archiveHandler := web.NewArchiveHandler(articleServiceClient, loggerV1)
+	articleStatsHandler := web.NewArticleStatsHandler(articleServiceClient, interactiveServiceClient, loggerV1)
+	feedHandler := ioc.InitFeedHandler(articleServiceClient, userService, loggerV1)
+	commentServiceClient := ioc.InitCommentGRPCClient()
+	commentHandler := web.NewCommentHandler(commentServiceClient, loggerV1)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, searchHandler, articleShareHandler, attachmentHandler, moderationHandler, archiveHandler, articleStatsHandler, feedHandler, commentHandler)
 	interactiveReadEventBatchConsumer := events2.NewInteractiveReadEventBatchConsumer(client, interactiveRepository, loggerV1)
-	v2 := ioc.NewConsumers(interactiveReadEventBatchConsumer)
+	interactiveStatsConsumer := events2.NewInteractiveStatsConsumer(client, statsRepository, loggerV1)
+	articleRejectedConsumer := ioc.InitArticleRejectedConsumer(client, userService, smsService, loggerV1)
+	articleScheduledConsumer := article.NewArticleScheduledConsumer(client, jobService, loggerV1)
+	v2 := ioc.NewConsumers(interactiveReadEventBatchConsumer, interactiveStatsConsumer, articleRejectedConsumer, articleScheduledConsumer)
 	rankingService := service.NewBatchRankingService(articleService, interactiveServiceClient)
 	rlockClient := ioc.InitRLockClient(cmdable)
 	rankingJob := ioc.InitRankingJob(rankingService, loggerV1, rlockClient)
 	cron := ioc.InitJobs(loggerV1, rankingJob)
+	localFuncExecutor := ioc.InitLocalFuncExecutor(rankingService)
+	articlePublishExecutor := job.NewArticlePublishExecutor(articleServiceClient, loggerV1)
+	articlePurgeExecutor := job.NewArticlePurgeExecutor(articleServiceClient, interactiveServiceClient, loggerV1)
+	attachmentGCExecutor := job.NewAttachmentGCExecutor(articleServiceClient, loggerV1)
+	schedule := ioc.InitScheduler(loggerV1, jobService, localFuncExecutor, articlePublishExecutor, articlePurgeExecutor, attachmentGCExecutor)
 	app := &App{
 		Server:    engine,
 		Consumers: v2,
 		cron:      cron,
+		scheduler: schedule,
 	}
 	return app
 }
 
 // wire.go:
 
-var interactiveSvcSet = wire.NewSet(dao3.NewGORMInteractiveDAO, cache3.NewInteractiveRedisCache, repository3.NewCachedInteractiveRepository, service3.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao3.NewGORMInteractiveDAO, cache3.NewInteractiveRedisCache, repository3.NewCachedInteractiveRepository, service3.NewInteractiveService, dao3.NewGORMStatsDAO, repository3.NewStatsRepository, events2.NewKafkaProducer)
 
-var articleSvcSet = wire.NewSet(cache2.NewArticleCache, repository2.NewCachedArticleRepository, service2.NewArticleService, dao2.NewGORMArticleDAO)
+var articleSvcSet = wire.NewSet(cache2.NewArticleCache, cache2.NewSeriesCache, repository2.NewCachedArticleRepository, repository2.NewArticleRevisionRepository, repository2.NewCachedArticleTagRepository, repository2.NewCachedSeriesRepository, service2.NewArticleService, dao2.NewGORMArticleDAO, dao2.NewGORMArticleRevisionDAO, dao2.NewGORMTagDAO, dao2.NewGORMSeriesDAO, dao2.NewGORMAttachmentDAO, dao2.NewGORMModerationDAO, dao2.NewGORMExportDAO, dao2.NewGORMImportDAO, dao2.NewGORMFingerprintDAO, ioc2.InitAttachmentRepository, ioc.InitExportRepository, ioc.InitImportRepository, repository2.NewModerationRepository, repository2.NewFingerprintRepository, ioc2.InitModeration, ioc2.InitSensitiveFilter)
 
 var rankingServiceSet = wire.NewSet(repository.NewCachedRankingRepository, cache.NewRankingRedisCache, service.NewBatchRankingService)
+
+var jobSchedulerSet = wire.NewSet(dao.NewGORMJobDAO, repository.NewPreemptCronJobRepository, service.NewCronJobService, job.NewArticlePublishExecutor, job.NewArticlePurgeExecutor, job.NewAttachmentGCExecutor, ioc.InitLocalFuncExecutor, ioc.InitScheduler)